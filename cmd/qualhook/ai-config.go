@@ -10,16 +10,20 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/bebsworthy/qualhook/internal/ai"
+	"github.com/bebsworthy/qualhook/internal/config"
+	"github.com/bebsworthy/qualhook/internal/debug"
 	"github.com/bebsworthy/qualhook/internal/executor"
 	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
 	"github.com/spf13/cobra"
 )
 
 var (
-	aiTool      string
-	aiTimeout   time.Duration
-	noTest      bool
-	aiForceFlag bool
+	aiTool       string
+	aiTimeout    time.Duration
+	noTest       bool
+	aiForceFlag  bool
+	aiNoCache    bool
+	aiClearCache bool
 )
 
 // aiConfigCmd represents the ai-config command
@@ -49,12 +53,18 @@ Examples:
   # Generate without testing commands
   qualhook ai-config --no-test
 
-  # Set timeout for AI analysis
-  qualhook ai-config --timeout 2m
+  # Set timeout for the AI tool (independent of command timeouts)
+  qualhook ai-config --ai-timeout 2m
 
   # Force overwrite existing configuration
   qualhook ai-config --force
 
+  # Ask the AI tool again instead of reusing a cached response
+  qualhook ai-config --no-cache
+
+  # Remove cached AI responses
+  qualhook ai-config --clear-cache
+
 REQUIREMENTS:
   You need either Claude CLI or Gemini CLI installed:
   
@@ -71,13 +81,33 @@ For more information about AI CLI tools, see:
 }
 
 func init() {
-	aiConfigCmd.Flags().StringVar(&aiTool, "tool", "", "AI tool to use (claude or gemini)")
-	aiConfigCmd.Flags().DurationVar(&aiTimeout, "timeout", 5*time.Minute, "Timeout for AI analysis")
+	aiConfigCmd.Flags().StringVar(&aiTool, "tool", "", "AI tool to use (claude, gemini or a tool from ai.tools)")
+	aiConfigCmd.Flags().DurationVar(&aiTimeout, "ai-timeout", ai.DefaultTimeout,
+		"Timeout for the AI tool (overrides ai.timeout in config)")
+	aiConfigCmd.Flags().DurationVar(&aiTimeout, "timeout", ai.DefaultTimeout, "Timeout for AI analysis")
+	_ = aiConfigCmd.Flags().MarkDeprecated("timeout", "use --ai-timeout instead") //nolint:errcheck // Flag is defined above
 	aiConfigCmd.Flags().BoolVar(&noTest, "no-test", false, "Skip testing generated commands")
 	aiConfigCmd.Flags().BoolVar(&aiForceFlag, "force", false, "Force overwrite existing configuration")
+	aiConfigCmd.Flags().BoolVar(&aiNoCache, "no-cache", false, "Invoke the AI tool even when a cached response exists")
+	aiConfigCmd.Flags().BoolVar(&aiClearCache, "clear-cache", false, "Remove cached AI responses and exit")
 }
 
 func runAIConfig(cmd *cobra.Command, args []string) error {
+	cacheDir, cacheErr := ai.DefaultCacheDir()
+	if aiClearCache {
+		if cacheErr != nil {
+			return cacheErr
+		}
+		if err := ai.ClearCache(cacheDir); err != nil {
+			return err
+		}
+		fmt.Println("✓ Cleared cached AI responses")
+		return nil
+	}
+	if cacheErr != nil {
+		debug.LogError(cacheErr, "locating AI response cache")
+	}
+
 	fmt.Println("🤖 Generating qualhook configuration with AI assistance...")
 
 	// Get working directory
@@ -87,8 +117,11 @@ func runAIConfig(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check for existing configuration
-	configPath := filepath.Join(workingDir, ".qualhook.json")
-	if _, err := os.Stat(configPath); err == nil && !aiForceFlag {
+	configPath, exists := config.FindConfigFile(workingDir)
+	if !exists {
+		configPath = filepath.Join(workingDir, config.ConfigFileName)
+	}
+	if exists && !aiForceFlag {
 		// Configuration exists, ask user what to do
 		action, err := promptForExistingConfig()
 		if err != nil {
@@ -116,7 +149,19 @@ func runAIConfig(cmd *cobra.Command, args []string) error {
 		WorkingDir:   workingDir,
 		Interactive:  true,
 		TestCommands: !noTest,
-		Timeout:      aiTimeout,
+		Timeout:      resolveAITimeout(cmd, workingDir),
+		CacheDir:     cacheDir,
+		NoCache:      aiNoCache,
+	}
+	if aiCfg := existingAIConfig(workingDir); aiCfg != nil {
+		options.MaxConcurrent = aiCfg.MaxConcurrent
+		options.MaxContextBytes = aiCfg.MaxContextBytes
+		options.Tools = ai.ConfiguredTools(aiCfg)
+		apiKey, err := ai.ResolveAPIKey(aiCfg, filepath.Dir(existingConfigPath(workingDir)))
+		if err != nil {
+			return err
+		}
+		options.APIKey = apiKey
 	}
 
 	// Generate configuration with AI
@@ -240,7 +285,7 @@ func displayConfigSummary(cfg *pkgconfig.Config) {
 // saveConfiguration saves the configuration to the specified path
 func saveConfiguration(cfg *pkgconfig.Config, configPath string) error {
 	// Serialize the configuration
-	data, err := pkgconfig.SaveConfig(cfg)
+	data, err := marshalConfigFile(cfg, configPath)
 	if err != nil {
 		return fmt.Errorf("failed to serialize configuration: %w", err)
 	}
@@ -289,3 +334,47 @@ func handleAIError(err error) error {
 	// Generic error handling
 	return fmt.Errorf("failed to generate configuration: %w", err)
 }
+
+// resolveAITimeout returns the AI tool timeout: the --ai-timeout flag if set,
+// otherwise ai.timeout from an existing configuration, otherwise the default
+func resolveAITimeout(cmd *cobra.Command, workingDir string) time.Duration {
+	if cmd.Flags().Changed("ai-timeout") || cmd.Flags().Changed("timeout") {
+		return aiTimeout
+	}
+
+	if aiCfg := existingAIConfig(workingDir); aiCfg != nil && aiCfg.Timeout > 0 {
+		return time.Duration(aiCfg.Timeout) * time.Millisecond
+	}
+
+	return aiTimeout
+}
+
+// existingConfigPath returns the path of an existing configuration, or ""
+func existingConfigPath(workingDir string) string {
+	if configPath == "" {
+		found, exists := config.FindConfigFile(workingDir)
+		if !exists {
+			return ""
+		}
+		return found
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		return ""
+	}
+	return configPath
+}
+
+// existingAIConfig returns the ai section of an existing configuration, if any
+func existingAIConfig(workingDir string) *pkgconfig.AIConfig {
+	path := existingConfigPath(workingDir)
+	if path == "" {
+		return nil
+	}
+
+	cfg, err := config.NewLoader().LoadFromPath(path)
+	if err != nil {
+		debug.LogError(err, "loading config for ai settings")
+		return nil
+	}
+	return cfg.AI
+}
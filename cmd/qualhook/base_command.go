@@ -5,6 +5,10 @@ import (
 	"os"
 
 	"github.com/bebsworthy/qualhook/internal/config"
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
 	"github.com/spf13/cobra"
 )
 
@@ -22,33 +26,272 @@ func createQualityCommandWithUsage(name, usageSuffix, short, long, example strin
 		Example: example,
 		RunE:    createRunFunc(name),
 	}
+	addRunFlags(cmd)
 	return cmd
 }
 
+// addRunFlags registers the per-run override flags shared by all quality commands
+func addRunFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&errorPatternOverrides, "error-pattern", nil,
+		"Extra error pattern (regex) for this run only; may be repeated")
+	cmd.Flags().StringVar(&outputFormat, "output", reporter.FormatDefault,
+		"Error report format: default, compact (one line per error), json, junit (JUnit XML for CI) or timeline (component start and finish times, then the default report)")
+	cmd.Flags().StringVar(&outputFormat, "report-format", reporter.FormatDefault,
+		"Alias of --output")
+	cmd.Flags().StringVar(&reportFile, "report-file", "",
+		"Write the report to this file instead of stdout, e.g. results.xml with --report-format junit")
+	cmd.Flags().BoolVar(&dedupErrors, "dedup", false,
+		"Merge identical errors reported by several components into one entry")
+	cmd.Flags().StringArrayVar(&commandOverrides, "command-override", nil,
+		"Override a command setting for this run only, e.g. lint.command=eslint_v9 (fields: command, args, timeout)")
+	cmd.Flags().StringArrayVar(&watchPaths, "watch-paths", nil,
+		"Only watch the directories matching this glob with --watch, e.g. 'src/**' (repeatable; default: the root and the configured paths)")
+	cmd.Flags().BoolVar(&streamReport, "stream-report", false,
+		"Report each failing component as soon as it completes")
+	cmd.Flags().BoolVar(&pickComponents, "pick", false,
+		"Interactively choose which components to run (requires a terminal)")
+	cmd.Flags().IntVar(&repeatRuns, "repeat", 1,
+		"Run the command N times and report a pass/fail tally to expose flaky checks")
+	cmd.Flags().BoolVar(&repeatParallel, "repeat-parallel", false,
+		"Run --repeat iterations in parallel instead of one after another")
+	cmd.Flags().StringVar(&statusFile, "status-file", "",
+		"Write a JSON pass/fail summary with per-command error counts to this path")
+	cmd.Flags().BoolVar(&liveOutput, "live", false,
+		"Show command output on stderr as it arrives; the error report still follows at the end")
+	cmd.Flags().BoolVar(&pagerOutput, "pager", false,
+		"Buffer the whole report in a stable order and show it in $PAGER when output is a terminal")
+	cmd.Flags().IntVar(&retryRuns, "retry-run", 0,
+		"Re-run the whole pipeline up to N times after transient infrastructure errors (never after quality failures)")
+	cmd.Flags().StringVar(&logDir, "log-dir", "",
+		"Write a separate debug log for each component and command to this directory")
+	cmd.Flags().StringVar(&captureDir, "capture-dir", "",
+		"Save the unfiltered stdout and stderr of each component's command to this directory; the report stays filtered")
+	cmd.Flags().StringVar(&maskWorkdir, "mask-workdir", "",
+		`Rewrite the working directory in reported paths: "relative", or a placeholder such as '$WORKSPACE'`)
+	cmd.Flags().IntVar(&maxTotalOutputBytes, "max-total-output-bytes", 0,
+		"Cap the combined reported output of all components in bytes; later output is suppressed (0: unlimited)")
+	cmd.Flags().IntVar(&errorExitCode, "error-exit-code", 0,
+		"Exit code for quality errors, overriding errorExitCode (default 2; execution errors always exit with 1)")
+	cmd.Flags().StringSliceVar(&targetFiles, "files", nil,
+		"Run for these files instead of the hook input (comma-separated or repeated), e.g. the staged files")
+	cmd.Flags().StringVar(&sinceRef, "since", "",
+		"Run for the files changed since this git ref instead of the hook input, e.g. main")
+	cmd.Flags().BoolVar(&watchMode, "watch", false,
+		"Keep running and re-run the command for the components whose files change, until interrupted")
+	cmd.Flags().StringArrayVar(&pathPrepend, "path-prepend", nil,
+		"Search this directory before PATH for commands, relative to the working directory (repeatable)")
+}
+
 // createRunFunc creates the RunE function for a command with the given name
 func createRunFunc(commandName string) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		// Load configuration
-		loader := config.NewLoader()
-		if configPath != "" {
-			cfg, err := loader.LoadFromPath(configPath)
+		if err := reporter.ValidateFormat(outputFormat); err != nil {
+			return err
+		}
+		if err := validateWatchPaths(watchPaths); err != nil {
+			return err
+		}
+		if repeatRuns < 1 {
+			return fmt.Errorf("--repeat must be at least 1, got %d", repeatRuns)
+		}
+		if retryRuns < 0 {
+			return fmt.Errorf("--retry-run must not be negative, got %d", retryRuns)
+		}
+		if maxTotalOutputBytes < 0 {
+			return fmt.Errorf("--max-total-output-bytes must not be negative, got %d", maxTotalOutputBytes)
+		}
+		if err := pkgconfig.ValidateErrorExitCode(errorExitCode); err != nil {
+			return fmt.Errorf("--error-exit-code %w", err)
+		}
+		if pagerOutput && (streamReport || liveOutput) {
+			return fmt.Errorf("--pager cannot be combined with --stream-report or --live, which write output as it arrives")
+		}
+		if watchMode && (repeatRuns > 1 || pickComponents || pagerOutput || dryRun) {
+			return fmt.Errorf("--watch cannot be combined with --repeat, --pick, --pager or --dry-run")
+		}
+		if len(watchPaths) > 0 && !watchMode {
+			return fmt.Errorf("--watch-paths requires --watch")
+		}
+		if commandTag != "" && (watchMode || repeatRuns > 1) {
+			return fmt.Errorf("--tag cannot be combined with --watch or --repeat")
+		}
+
+		cfg, err := loadRunConfig()
+		if err != nil {
+			return err
+		}
+
+		// --tag runs the tagged commands in place of the named one
+		commandNames := []string{commandName}
+		if commandTag != "" {
+			if commandNames, err = taggedCommands(cfg, commandTag); err != nil {
+				return err
+			}
+		}
+		for _, name := range commandNames {
+			applyStandardPrompt(cfg, name)
+		}
+
+		if err := prepareLogDir(logDir); err != nil {
+			return err
+		}
+		if err := prepareCaptureDir(captureDir); err != nil {
+			return err
+		}
+
+		if liveOutput {
+			liveWriter = executor.NewStreamingWriter(errorWriter)
+		}
+
+		defer setRunSecurity(cfg)()
+
+		runErrorExitCode = cfg.ErrorExitCode
+		if errorExitCode != 0 {
+			runErrorExitCode = errorExitCode
+		}
+		defer func() { runErrorExitCode = 0 }()
+
+		runNormalizePaths = cfg.NormalizePaths
+		defer func() { runNormalizePaths = false }()
+
+		if cfg.MaxTotalRetries > 0 {
+			runRetryBudget = executor.NewRetryBudget(cfg.MaxTotalRetries)
+		}
+		defer func() { runRetryBudget = nil }()
+
+		runJobs = cfg.MaxParallel
+		if jobsFlag > 0 {
+			runJobs = jobsFlag
+		}
+		defer func() { runJobs = 0 }()
+
+		if err := applyTimeoutOverride(cfg, timeoutOverride); err != nil {
+			return err
+		}
+
+		if err := applyCommandOverrides(cfg, commandOverrides); err != nil {
+			return err
+		}
+
+		for _, name := range commandNames {
+			if err := applyErrorPatternOverrides(cfg, name, errorPatternOverrides); err != nil {
+				return err
+			}
+		}
+
+		if commandTag != "" {
+			return executeCommands(cfg, commandNames, args)
+		}
+
+		if watchMode {
+			return watchUntilInterrupted(cfg, commandName, args)
+		}
+
+		if repeatRuns > 1 && !dryRun {
+			tally, err := runRepeated(cfg, commandName, args, repeatRuns, repeatParallel)
 			if err != nil {
-				return fmt.Errorf("failed to load config: %w", err)
+				return err
 			}
-			return executeCommand(cfg, commandName, args)
+			reportRepeatTally(commandName, tally)
+			return nil
 		}
 
-		// Load configuration based on current directory
-		cwd, err := os.Getwd()
+		return executeCommand(cfg, commandName, args)
+	}
+}
+
+// configRoot returns the project root of the run, see config.ProjectRoot
+func configRoot() string {
+	path := configPath
+	if path == "" {
+		// Without a configuration file, the working directory is the root
+		path, _ = newConfigLoader().FindPath() //nolint:errcheck // Handled by loading the configuration
+	}
+	return config.ProjectRoot(".", path)
+}
+
+// setRunSecurity applies the security settings of cfg, relative to the
+// project root, to the commands run until the returned function restores
+// the defaults
+func setRunSecurity(cfg *pkgconfig.Config) func() {
+	runSecurity = cfg.Security
+	if runSecurity == nil {
+		runSecurity = &pkgconfig.SecurityConfig{}
+	}
+	runProjectRoot = configRoot()
+	return func() { runSecurity, runProjectRoot = nil, "." }
+}
+
+// loadRunConfig loads the configuration for a quality command run
+func loadRunConfig() (*pkgconfig.Config, error) {
+	loader := newConfigLoader()
+	if lockedConfig {
+		return loadLockedConfig(loader)
+	}
+	if configPath != "" {
+		cfg, err := loader.LoadFromPath(configPath)
 		if err != nil {
-			return fmt.Errorf("failed to get working directory: %w", err)
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+		return cfg, nil
+	}
+
+	// Load configuration based on current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	cfg, err := loader.LoadForMonorepo(cwd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// newConfigLoader creates a configuration loader applying the profile selected
+// with --profile or QUALHOOK_PROFILE and, unless --no-config-cache is set,
+// reusing cached configurations
+func newConfigLoader() *config.Loader {
+	loader := config.NewLoader()
+	loader.Profile = profileName
+	if loader.Profile == "" {
+		loader.Profile = os.Getenv(config.ProfileEnvVar)
+	}
+	if !noConfigCache {
+		if cacheDir, err := config.DefaultCacheDir(); err == nil {
+			loader.CacheDir = cacheDir
+		} else {
+			debug.LogError(err, "finding the config cache directory")
 		}
+	}
+	return loader
+}
 
-		cfg, err := loader.LoadForMonorepo(cwd)
+// loadLockedConfig loads the lockfile of the configuration a run would use,
+// applying path configurations for the current directory like LoadForMonorepo
+func loadLockedConfig(loader *config.Loader) (*pkgconfig.Config, error) {
+	path := configPath
+	if path == "" {
+		found, err := loader.FindPath()
 		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+			return nil, fmt.Errorf("failed to load config: %w", err)
 		}
+		path = found
+	}
 
-		return executeCommand(cfg, commandName, args)
+	cfg, err := loader.LoadLocked(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if configPath != "" {
+		return cfg, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
 	}
+	return loader.ForDirectory(cfg, cwd), nil
 }
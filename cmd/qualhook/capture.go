@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// captureMu serializes writes to capture files, so parallel runs sharing a
+// file, e.g. with --repeat --parallel, do not interleave
+var captureMu sync.Mutex
+
+// prepareCaptureDir creates the --capture-dir directory, if set
+func prepareCaptureDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to create capture directory: %w", err)
+	}
+	return nil
+}
+
+// captureOutput appends the unfiltered output of a component's command to
+// its --capture-dir file. Each run is one entry; failing to write is logged
+// and does not fail the run.
+func captureOutput(componentPath, commandName, command string, args []string, result *executor.ExecResult) {
+	if captureDir == "" {
+		return
+	}
+
+	var entry strings.Builder
+	commandLine := strings.Join(append([]string{command}, args...), " ")
+	entry.WriteString(fmt.Sprintf("=== %s %s (exit code %d) ===\n", time.Now().Format(time.RFC3339), commandLine, result.ExitCode))
+	for _, stream := range []struct{ name, output string }{{"stdout", result.Stdout}, {"stderr", result.Stderr}} {
+		entry.WriteString(fmt.Sprintf("--- %s ---\n", stream.name))
+		entry.WriteString(stream.output)
+		if stream.output != "" && !strings.HasSuffix(stream.output, "\n") {
+			entry.WriteString("\n")
+		}
+	}
+
+	captureMu.Lock()
+	defer captureMu.Unlock()
+
+	path := filepath.Join(captureDir, captureFileName(componentPath, commandName))
+	// #nosec G304 - path is built from the user-provided --capture-dir
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		debug.LogError(err, "opening capture file")
+		return
+	}
+	defer func() { _ = file.Close() }() //nolint:errcheck // Best effort cleanup
+	if _, err := file.WriteString(entry.String()); err != nil {
+		debug.LogError(err, "writing capture file")
+	}
+}
+
+// captureFileName returns the capture file name for a component's command,
+// e.g. packages_frontend.lint.out next to the packages_frontend.lint.log log
+func captureFileName(componentPath, commandName string) string {
+	return strings.TrimSuffix(commandLogName(componentPath, commandName), ".log") + ".out"
+}
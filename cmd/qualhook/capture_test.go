@@ -0,0 +1,92 @@
+//go:build unit
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/watcher"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestRunComponentGroups_CaptureDir(t *testing.T) {
+	tempDir := t.TempDir()
+	webDir := filepath.Join(tempDir, "web")
+	if err := os.Mkdir(webDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(tempDir, "lint.sh")
+	if err := os.WriteFile(script, []byte("echo 'checked 3 files'\necho 'web/a.js:1: error: bad' >&2\necho 'web/a.js:2: note: details' >&2\nexit 1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	oldCaptureDir := captureDir
+	captureDir = filepath.Join(tempDir, "captures")
+	defer func() { captureDir = oldCaptureDir }()
+	if err := prepareCaptureDir(captureDir); err != nil {
+		t.Fatalf("prepareCaptureDir() error = %v", err)
+	}
+
+	groups := []watcher.ComponentGroup{{Path: webDir, Config: map[string]*config.CommandConfig{"lint": {
+		Command:       "sh",
+		Args:          []string{script},
+		ErrorPatterns: []*config.RegexPattern{{Pattern: "error:"}},
+	}}}}
+	results, err := runComponentGroups(groups, "lint", nil, nil)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("runComponentGroups() = %d results, %v", len(results), err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(captureDir, captureFileName(webDir, "lint"))) // #nosec G304 - test-controlled path
+	if err != nil {
+		t.Fatalf("expected a capture file: %v", err)
+	}
+	captured := string(data)
+	for _, want := range []string{"sh " + script + " (exit code 1) ===", "--- stdout ---\nchecked 3 files\n", "--- stderr ---\nweb/a.js:1: error: bad\nweb/a.js:2: note: details\n"} {
+		if !strings.Contains(captured, want) {
+			t.Errorf("expected %q in the capture:\n%s", want, captured)
+		}
+	}
+
+	// The report keeps only the filtered lines
+	if lines := results[0].FilteredOutput.Lines; len(lines) != 1 || lines[0] != "web/a.js:1: error: bad" {
+		t.Errorf("expected only the error line to be reported, got %v", lines)
+	}
+}
+
+func TestCaptureOutput_Concurrent(t *testing.T) {
+	oldCaptureDir := captureDir
+	captureDir = t.TempDir()
+	defer func() { captureDir = oldCaptureDir }()
+
+	output := strings.Repeat("line\n", 2000)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			captureOutput("", "test", "go", []string{"test"}, &executor.ExecResult{Stdout: output})
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(filepath.Join(captureDir, "root.test.out")) // #nosec G304 - test-controlled path
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each entry is written whole: every header is followed by its full output
+	entries := strings.Split(string(data), "=== ")[1:]
+	if len(entries) != 8 {
+		t.Fatalf("expected 8 entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if !strings.Contains(entry, "--- stdout ---\n"+output+"--- stderr ---\n") {
+			t.Fatal("expected the entries not to interleave")
+		}
+	}
+}
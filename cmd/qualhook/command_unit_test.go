@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/bebsworthy/qualhook/internal/reporter"
 	"github.com/spf13/cobra"
 )
 
@@ -202,6 +203,20 @@ func TestCommandFlags(t *testing.T) {
 	}
 }
 
+func TestReportFormatFlag(t *testing.T) {
+	defer func() { outputFormat = reporter.FormatDefault }()
+
+	for _, cmd := range []*cobra.Command{formatCmd, lintCmd, typecheckCmd, testCmd} {
+		outputFormat = reporter.FormatDefault
+		if err := cmd.ParseFlags([]string{"--report-format", "json"}); err != nil {
+			t.Fatalf("%s: failed to parse --report-format: %v", cmd.Name(), err)
+		}
+		if outputFormat != reporter.FormatJSON {
+			t.Errorf("%s: expected --report-format json to select the JSON report, got %q", cmd.Name(), outputFormat)
+		}
+	}
+}
+
 // TestTemplateSubcommands tests template subcommands structure
 func TestTemplateSubcommands(t *testing.T) {
 	// Check that template command has subcommands
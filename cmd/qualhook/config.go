@@ -2,10 +2,14 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/bebsworthy/qualhook/internal/config"
+	"github.com/bebsworthy/qualhook/internal/watcher"
 	"github.com/bebsworthy/qualhook/internal/wizard"
 	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
 	"github.com/spf13/cobra"
@@ -13,8 +17,11 @@ import (
 
 var (
 	validateFlag bool
+	detectOnly   bool
 	outputPath   string
 	forceFlag    bool
+	scopeExclude []string
+	sampleFiles  []string
 )
 
 // configCmd represents the config command
@@ -36,24 +43,149 @@ Examples:
   # Validate existing configuration
   qualhook config --validate
 
+  # Edit the existing configuration interactively
+  qualhook config edit
+
   # Create configuration in specific location
   qualhook config --output /path/to/.qualhook.json
 
   # Force overwrite existing configuration
-  qualhook config --force`,
+  qualhook config --force
+
+  # Print the detected project types and workspaces as JSON
+  qualhook config --detect-only --output json
+
+  # List the files a path pattern covers
+  qualhook config scope "frontend/**"
+
+  # Check which path configurations sample edits would trigger
+  qualhook config simulate --files src/app.js api/main.go
+
+  # Pin the effective configuration for reproducible runs
+  qualhook config lock
+
+  # Show which configuration file is used
+  qualhook config which
+
+  # Report which error patterns match real command output
+  qualhook config pattern-coverage
+
+  # Compare the resolved configuration of two files
+  qualhook config diff old.json .qualhook.json
+
+  # Print the JSON Schema of the configuration for editors
+  qualhook config schema > qualhook.schema.json`,
 	RunE: runConfig,
 }
 
+// configScopeCmd lists the files a path pattern covers in the working tree
+var configScopeCmd = &cobra.Command{
+	Use:   "scope <pattern>",
+	Short: "List files in the working tree matched by a path pattern",
+	Long: `List the files in the working tree that a path configuration pattern covers.
+
+Files ignored by .gitignore and the .git directory are skipped. This shows the
+blast radius of a "paths" entry before adding it to the configuration.
+
+Examples:
+  # Files covered by a component pattern
+  qualhook config scope "packages/frontend/**"
+
+  # Skip generated files
+  qualhook config scope "src/**/*.ts" --exclude "**/*.gen.ts"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigScope,
+}
+
+// configSimulateCmd maps sample files and reports path configuration coverage
+var configSimulateCmd = &cobra.Command{
+	Use:   "simulate --files <file>...",
+	Short: "Show which path configurations sample edited files would trigger",
+	Long: `Map sample edited files to path configurations and report coverage.
+
+Each path configuration is listed with the sample files it would run for.
+Configurations that never fire are flagged as possibly unreachable, along
+with files they matched but lost to a more specific pattern.
+
+Files may be given with --files or as arguments.
+
+Examples:
+  # Check a set of sample files
+  qualhook config simulate --files web/app.js api/main.go docs/index.md`,
+	RunE: runConfigSimulate,
+}
+
+// configLockCmd writes the fully-resolved effective configuration to a lockfile
+var configLockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Pin the effective configuration to " + config.LockFileName,
+	Long: `Write the fully-resolved effective configuration, with everything it
+extends merged in, to ` + config.LockFileName + ` next to the configuration file.
+
+Commit the lockfile and run with --locked to use it directly. A locked run
+fails when the configuration no longer resolves to the lockfile, e.g. after a
+base configuration changed, so runs are reproducible across machines.
+
+Examples:
+  # Lock the configuration
+  qualhook config lock
+
+  # Run against the lockfile
+  qualhook --locked lint`,
+	Args: cobra.NoArgs,
+	RunE: runConfigLock,
+}
+
+// configWhichCmd prints the configuration files in use and how they layer
+var configWhichCmd = &cobra.Command{
+	Use:   "which",
+	Short: "Show which configuration files are used and how they layer",
+	Long: `Print the configuration file qualhook uses in the current directory, how
+it was found, and the layering order of the files it extends.
+
+The configuration file is taken from --config, then the QUALHOOK_CONFIG
+environment variable, then the first search path containing one: the
+current directory, the project root above it and the home directory.
+
+Examples:
+  # Show the configuration used here
+  qualhook config which
+
+  # Show the layers of a specific configuration file
+  qualhook --config ./frontend/.qualhook.json config which`,
+	Args: cobra.NoArgs,
+	RunE: runConfigWhich,
+}
+
 func init() {
 	configCmd.Flags().BoolVar(&validateFlag, "validate", false, "Validate existing configuration")
-	configCmd.Flags().StringVar(&outputPath, "output", "", "Output path for configuration file")
+	configCmd.Flags().BoolVar(&detectOnly, "detect-only", false, "Only detect project types and workspaces; print them without prompting or writing configuration")
+	configCmd.Flags().StringVar(&outputPath, "output", "", "Output path for configuration file (with --detect-only: report format, text or json)")
 	configCmd.Flags().BoolVar(&forceFlag, "force", false, "Force overwrite existing configuration")
+
+	configScopeCmd.Flags().StringArrayVar(&scopeExclude, "exclude", nil, "Glob of files to leave out; may be repeated")
+	configCmd.AddCommand(configScopeCmd)
+
+	configSimulateCmd.Flags().StringSliceVar(&sampleFiles, "files", nil, "Sample edited files to map")
+	configCmd.AddCommand(configSimulateCmd)
+
+	configCmd.AddCommand(configLockCmd)
+	configCmd.AddCommand(configWhichCmd)
+	configCmd.AddCommand(configPatternCoverageCmd)
+
+	configDiffCmd.Flags().BoolVar(&diffJSON, "json", false, "Print the differences as JSON")
+	configCmd.AddCommand(configDiffCmd)
+	configCmd.AddCommand(configSchemaCmd)
+	configCmd.AddCommand(configEditCmd)
 }
 
 func runConfig(cmd *cobra.Command, args []string) error {
 	if validateFlag {
 		return runValidateConfig()
 	}
+	if detectOnly {
+		return runDetectOnly(cmd.OutOrStdout(), outputPath)
+	}
 
 	return runConfigWizard()
 }
@@ -63,7 +195,7 @@ func runValidateConfig() error {
 	fmt.Println("Validating qualhook configuration...")
 
 	// Load configuration
-	loader := config.NewLoader()
+	loader := newConfigLoader()
 	var cfg *pkgconfig.Config
 	var err error
 
@@ -126,5 +258,193 @@ func runConfigWizard() error {
 		return fmt.Errorf("failed to create wizard: %w", err)
 	}
 
+	w.SetCompact(compactJSON)
 	return w.Run(outputPath, forceFlag)
 }
+
+// runDetectOnly prints the wizard's detection results for the current
+// directory in the given format
+func runDetectOnly(out io.Writer, format string) error {
+	if format != "" && format != "text" && format != "json" {
+		return fmt.Errorf("unsupported --output %q with --detect-only (use text or json)", format)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	w, err := wizard.NewConfigWizard()
+	if err != nil {
+		return fmt.Errorf("failed to create wizard: %w", err)
+	}
+	report := w.Detect(cwd)
+
+	if format == "json" {
+		var data []byte
+		if compactJSON {
+			data, err = json.Marshal(report)
+		} else {
+			data, err = json.MarshalIndent(report, "", "  ")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to marshal detection report: %w", err)
+		}
+		_, _ = fmt.Fprintln(out, string(data)) //nolint:errcheck // Best effort output to stdout
+		return nil
+	}
+
+	if len(report.Types) == 0 && !report.Monorepo {
+		_, _ = fmt.Fprintln(out, "🔍 No project type detected") //nolint:errcheck // Best effort output to stdout
+		return nil
+	}
+	wizard.WriteDetectionReport(out, report)
+	return nil
+}
+
+// runConfigScope lists the files matched by a path pattern
+func runConfigScope(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	files, err := watcher.ScopeFiles(cwd, args[0], scopeExclude)
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	for _, file := range files {
+		_, _ = fmt.Fprintln(out, file) //nolint:errcheck // Best effort output to stdout
+	}
+	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "\n📁 %d file(s) in scope for %s\n", len(files), args[0]) //nolint:errcheck // Best effort output to stderr
+
+	return nil
+}
+
+// runConfigSimulate reports which path configurations fire for sample files
+func runConfigSimulate(cmd *cobra.Command, args []string) error {
+	files := append(append([]string{}, sampleFiles...), args...)
+	if len(files) == 0 {
+		return fmt.Errorf("no sample files given; use --files")
+	}
+
+	loader := newConfigLoader()
+	var cfg *pkgconfig.Config
+	var err error
+	if configPath != "" {
+		cfg, err = loader.LoadFromPath(configPath)
+	} else {
+		cfg, err = loader.Load()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if len(cfg.Paths) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No path configurations defined; every file runs the root commands.") //nolint:errcheck // Best effort output to stdout
+		return nil
+	}
+
+	printCoverage(cmd.OutOrStdout(), watcher.NewFileMapper(cfg).Simulate(files))
+	return nil
+}
+
+// runConfigLock writes the lockfile of the configuration file in use
+func runConfigLock(cmd *cobra.Command, args []string) error {
+	loader := config.NewLoader()
+	path := configPath
+	if path == "" {
+		found, err := loader.FindPath()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		path = found
+	}
+
+	lockPath, err := loader.WriteLockFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to lock configuration: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "🔒 Locked effective configuration of %s to %s\n", path, lockPath) //nolint:errcheck // Best effort output to stdout
+	return nil
+}
+
+// runConfigWhich prints the resolved configuration files of the current directory
+func runConfigWhich(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	loader := config.NewLoader()
+	resolution, err := loader.Resolve(configPath, cwd)
+	if err != nil {
+		return fmt.Errorf("failed to resolve configuration: %w", err)
+	}
+
+	printResolution(cmd.OutOrStdout(), resolution, loader.SearchPaths)
+	return nil
+}
+
+// printResolution writes the configuration file in use and its layering order
+func printResolution(w io.Writer, resolution *config.Resolution, searchPaths []string) {
+	_, _ = fmt.Fprintf(w, "📄 Configuration file: %s\n", resolution.Path) //nolint:errcheck // Best effort output
+	switch resolution.Source {
+	case config.SourceExplicit:
+		_, _ = fmt.Fprintln(w, "   Source: --config flag") //nolint:errcheck // Best effort output
+	case config.SourceEnv:
+		_, _ = fmt.Fprintf(w, "   Source: %s environment variable\n", config.ConfigEnvVar) //nolint:errcheck // Best effort output
+	default:
+		_, _ = fmt.Fprintf(w, "   Source: search paths (%s)\n", strings.Join(searchPaths, ", ")) //nolint:errcheck // Best effort output
+	}
+
+	_, _ = fmt.Fprintln(w, "\n📚 Layering order (later layers override earlier ones):") //nolint:errcheck // Best effort output
+	for i, layer := range resolution.Layers {
+		_, _ = fmt.Fprintf(w, "   %d. %s\n", i+1, layer) //nolint:errcheck // Best effort output
+	}
+	if resolution.PathConfig != "" {
+		_, _ = fmt.Fprintf(w, "   %d. path configuration %q for the current directory\n", len(resolution.Layers)+1, resolution.PathConfig) //nolint:errcheck // Best effort output
+	}
+
+	if lockedConfig {
+		_, _ = fmt.Fprintf(w, "\n🔒 With --locked, runs use %s instead\n", config.LockFilePath(resolution.Path)) //nolint:errcheck // Best effort output
+	}
+}
+
+// printCoverage writes a path configuration coverage report
+func printCoverage(w io.Writer, coverage *watcher.Coverage) {
+	_, _ = fmt.Fprintln(w, "🗺️  Path coverage:") //nolint:errcheck // Best effort output
+	for _, path := range coverage.Paths {
+		if path.Fired() {
+			_, _ = fmt.Fprintf(w, "   ✓ %s: %s\n", path.Path, strings.Join(path.Files, ", ")) //nolint:errcheck // Best effort output
+		} else {
+			_, _ = fmt.Fprintf(w, "   ✗ %s: never fired\n", path.Path) //nolint:errcheck // Best effort output
+		}
+		if len(path.Excluded) > 0 {
+			_, _ = fmt.Fprintf(w, "     excluded: %s\n", strings.Join(path.Excluded, ", ")) //nolint:errcheck // Best effort output
+		}
+	}
+	if len(coverage.RootFiles) > 0 {
+		_, _ = fmt.Fprintf(w, "   • root: %s\n", strings.Join(coverage.RootFiles, ", ")) //nolint:errcheck // Best effort output
+	}
+
+	unfired := coverage.Unfired()
+	if len(unfired) == 0 {
+		_, _ = fmt.Fprintln(w, "\n✅ Every path configuration fired for the sample files.") //nolint:errcheck // Best effort output
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "\n⚠️  %d path configuration(s) never fired:\n", len(unfired)) //nolint:errcheck // Best effort output
+	for _, path := range unfired {
+		if len(path.Shadowed) > 0 {
+			_, _ = fmt.Fprintf(w, "   • %s matched %s, but a more specific pattern won\n", path.Path, strings.Join(path.Shadowed, ", ")) //nolint:errcheck // Best effort output
+		} else if len(path.Excluded) > 0 {
+			_, _ = fmt.Fprintf(w, "   • %s matched only excluded files\n", path.Path) //nolint:errcheck // Best effort output
+		} else {
+			_, _ = fmt.Fprintf(w, "   • %s matched no sample file; check the pattern or add a sample\n", path.Path) //nolint:errcheck // Best effort output
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bebsworthy/qualhook/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// diffJSON prints config diff as JSON
+var diffJSON bool
+
+// configDiffCmd compares two resolved configuration files
+var configDiffCmd = &cobra.Command{
+	Use:   "diff <a.json> <b.json>",
+	Short: "Compare the resolved configuration of two files",
+	Long: `Load two configuration files, with everything they extend merged in, and
+print the commands, path configurations and settings that were added, removed
+or changed, field by field.
+
+Unlike a text diff this compares what qualhook actually runs, so moving a
+command into a base configuration shows no change.
+
+Examples:
+  # Review a configuration change
+  git show main:.qualhook.json > /tmp/old.json
+  qualhook config diff /tmp/old.json .qualhook.json
+
+  # Machine-readable output
+  qualhook config diff old.json new.json --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigDiff,
+}
+
+// runConfigDiff loads both configurations and prints their differences
+func runConfigDiff(cmd *cobra.Command, args []string) error {
+	loader := config.NewLoader()
+	from, err := loader.LoadFromPath(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", args[0], err)
+	}
+	to, err := loader.LoadFromPath(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", args[1], err)
+	}
+
+	diff, err := config.Diff(from, to)
+	if err != nil {
+		return fmt.Errorf("failed to compare configurations: %w", err)
+	}
+
+	if diffJSON {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode diff: %w", err)
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(data)) //nolint:errcheck // Best effort output to stdout
+		return nil
+	}
+
+	printConfigDiff(cmd.OutOrStdout(), args[0], args[1], diff)
+	return nil
+}
+
+// printConfigDiff writes a configuration diff in a readable form
+func printConfigDiff(w io.Writer, from, to string, diff *config.ConfigDiff) {
+	if diff.Empty() {
+		_, _ = fmt.Fprintf(w, "✅ %s and %s resolve to the same configuration\n", from, to) //nolint:errcheck // Best effort output
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "🔀 Changes from %s to %s:\n", from, to) //nolint:errcheck // Best effort output
+	if len(diff.Settings) > 0 {
+		_, _ = fmt.Fprintln(w, "\nSettings:") //nolint:errcheck // Best effort output
+		printFieldChanges(w, "   ", diff.Settings)
+	}
+	if len(diff.Commands) > 0 {
+		_, _ = fmt.Fprintln(w, "\nCommands:") //nolint:errcheck // Best effort output
+		printCommandChanges(w, "   ", diff.Commands)
+	}
+	if len(diff.Paths) > 0 {
+		_, _ = fmt.Fprintln(w, "\nPaths:") //nolint:errcheck // Best effort output
+		for _, path := range diff.Paths {
+			_, _ = fmt.Fprintf(w, "   %s %s\n", changeMarker(path.Change), path.Path) //nolint:errcheck // Best effort output
+			printFieldChanges(w, "       ", path.Fields)
+			printCommandChanges(w, "       ", path.Commands)
+		}
+	}
+}
+
+// printCommandChanges writes command changes at the given indentation
+func printCommandChanges(w io.Writer, indent string, changes []config.CommandChange) {
+	for _, change := range changes {
+		_, _ = fmt.Fprintf(w, "%s%s %s\n", indent, changeMarker(change.Change), change.Name) //nolint:errcheck // Best effort output
+		printFieldChanges(w, indent+"    ", change.Fields)
+	}
+}
+
+// printFieldChanges writes field changes as "field: old → new"
+func printFieldChanges(w io.Writer, indent string, changes []config.FieldChange) {
+	for _, change := range changes {
+		_, _ = fmt.Fprintf(w, "%s%s: %s → %s\n", indent, change.Field, diffValue(change.Old), diffValue(change.New)) //nolint:errcheck // Best effort output
+	}
+}
+
+// changeMarker returns the marker of a kind of change
+func changeMarker(change string) string {
+	switch change {
+	case config.ChangeAdded:
+		return "+"
+	case config.ChangeRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// diffValue formats a field value as JSON, or "(unset)"
+func diffValue(value any) string {
+	if value == nil {
+		return "(unset)"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
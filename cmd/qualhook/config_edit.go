@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bebsworthy/qualhook/internal/config"
+	"github.com/bebsworthy/qualhook/internal/wizard"
+	"github.com/spf13/cobra"
+)
+
+// configEditCmd edits an existing configuration interactively
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the existing configuration interactively",
+	Long: `Edit the configuration file of the current directory, or the --config file,
+through the prompts of the configuration wizard.
+
+Commands can be modified, added and removed, and path configurations added
+and removed. The configuration is validated before it is saved back in its
+own format. Settings the prompts do not cover, such as error patterns and
+fields qualhook does not know, are kept.
+
+Examples:
+  # Edit .qualhook.json
+  qualhook config edit
+
+  # Edit a specific configuration file
+  qualhook --config ./frontend/.qualhook.json config edit`,
+	Args: cobra.NoArgs,
+	RunE: runConfigEdit,
+}
+
+// runConfigEdit edits the configuration file in use
+func runConfigEdit(_ *cobra.Command, _ []string) error {
+	path, err := editedConfigPath()
+	if err != nil {
+		return err
+	}
+
+	w, err := wizard.NewConfigWizard()
+	if err != nil {
+		return fmt.Errorf("failed to create wizard: %w", err)
+	}
+	w.SetCompact(compactJSON)
+	return w.Edit(path)
+}
+
+// editedConfigPath returns the --config file, or the configuration file of
+// the current directory
+func editedConfigPath() (string, error) {
+	if configPath != "" {
+		return configPath, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+	path, found := config.FindConfigFile(cwd)
+	if !found {
+		return "", fmt.Errorf("no configuration file in %s, run 'qualhook config' to create one", cwd)
+	}
+	return path, nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+
+	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// configSchemaCmd prints the JSON Schema of the configuration file
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the configuration file",
+	Long: `Print a JSON Schema describing .qualhook.json, for completion and validation
+in editors.
+
+Save it next to the configuration and reference it with "$schema":
+
+  {
+    "$schema": "./qualhook.schema.json",
+    "version": "1.0",
+    "commands": {}
+  }
+
+Examples:
+  # Write the schema for editors
+  qualhook config schema > qualhook.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigSchema,
+}
+
+// runConfigSchema prints the configuration schema
+func runConfigSchema(cmd *cobra.Command, _ []string) error {
+	data, err := pkgconfig.MarshalJSONSchema()
+	if err != nil {
+		return fmt.Errorf("failed to encode schema: %w", err)
+	}
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(data)) //nolint:errcheck // Best effort output to stdout
+	return nil
+}
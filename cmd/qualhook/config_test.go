@@ -0,0 +1,410 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConfigSimulate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".qualhook.json")
+	data := `{
+		"version": "1.0",
+		"commands": {"lint": {"command": "echo"}},
+		"paths": [
+			{"path": "web/**", "commands": {"lint": {"command": "echo"}}},
+			{"path": "api/**", "commands": {"lint": {"command": "echo"}}},
+			{"path": "legacy/**/*.php", "commands": {"lint": {"command": "echo"}}}
+		]
+	}`
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	oldConfigPath, oldFiles := configPath, sampleFiles
+	configPath = file
+	sampleFiles = []string{"web/app.js"}
+	defer func() { configPath, sampleFiles = oldConfigPath, oldFiles }()
+
+	var out bytes.Buffer
+	configSimulateCmd.SetOut(&out)
+	defer configSimulateCmd.SetOut(nil)
+
+	if err := runConfigSimulate(configSimulateCmd, []string{"api/main.go", "README.md"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := out.String()
+	for _, want := range []string{
+		"✓ web/**: web/app.js",
+		"✓ api/**: api/main.go",
+		"✗ legacy/**/*.php: never fired",
+		"root: README.md",
+		"1 path configuration(s) never fired",
+		"legacy/**/*.php matched no sample file",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunConfigSimulate_NoFiles(t *testing.T) {
+	oldFiles := sampleFiles
+	sampleFiles = nil
+	defer func() { sampleFiles = oldFiles }()
+
+	if err := runConfigSimulate(configSimulateCmd, nil); err == nil {
+		t.Error("expected error without sample files")
+	}
+}
+
+func TestRunConfigLock_Locked(t *testing.T) {
+	tempDir := t.TempDir()
+	basePath := filepath.Join(tempDir, "base.json")
+	writeBase := func(command string) {
+		data := `{"version": "1.0", "commands": {"lint": {"command": "` + command + `"}}}`
+		if err := os.WriteFile(basePath, []byte(data), 0600); err != nil {
+			t.Fatalf("failed to write base config: %v", err)
+		}
+	}
+	writeBase("eslint")
+	file := filepath.Join(tempDir, ".qualhook.json")
+	if err := os.WriteFile(file, []byte(`{"version": "1.0", "extends": "base.json"}`), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	oldConfigPath, oldLocked := configPath, lockedConfig
+	configPath = file
+	defer func() { configPath, lockedConfig = oldConfigPath, oldLocked }()
+
+	out := &bytes.Buffer{}
+	configLockCmd.SetOut(out)
+	defer configLockCmd.SetOut(nil)
+	if err := runConfigLock(configLockCmd, nil); err != nil {
+		t.Fatalf("runConfigLock() error = %v", err)
+	}
+	if !strings.Contains(out.String(), ".qualhook.lock.json") {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+
+	lockedConfig = true
+	cfg, err := loadRunConfig()
+	if err != nil {
+		t.Fatalf("loadRunConfig() with --locked error = %v", err)
+	}
+	if cfg.Commands["lint"].Command != "eslint" {
+		t.Errorf("unexpected locked lint command: %+v", cfg.Commands["lint"])
+	}
+
+	writeBase("eslint_v9")
+	if _, err := loadRunConfig(); err == nil || !strings.Contains(err.Error(), "no longer resolves") {
+		t.Errorf("loadRunConfig() error = %v, want drift error under --locked", err)
+	}
+
+	lockedConfig = false
+	if cfg, err := loadRunConfig(); err != nil || cfg.Commands["lint"].Command != "eslint_v9" {
+		t.Errorf("unlocked run should use the changed config, got %v", err)
+	}
+}
+
+func TestLoadRunConfig_Profile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".qualhook.json")
+	data := `{
+		"version": "1.0",
+		"commands": {"test": {"command": "go", "args": ["test", "./..."]}},
+		"profiles": {
+			"ci": {"commands": {"test": {"args": ["test", "-race", "./..."]}}},
+			"dev": {"commands": {"test": {"args": ["test", "-short", "./..."]}}}
+		}
+	}`
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	oldConfigPath, oldProfile, oldLocked := configPath, profileName, lockedConfig
+	configPath = file
+	defer func() { configPath, profileName, lockedConfig = oldConfigPath, oldProfile, oldLocked }()
+
+	t.Setenv("QUALHOOK_PROFILE", "dev")
+	cfg, err := loadRunConfig()
+	if err != nil {
+		t.Fatalf("loadRunConfig() error = %v", err)
+	}
+	if got := cfg.Commands["test"].Args[1]; got != "-short" {
+		t.Errorf("QUALHOOK_PROFILE not applied, got arg %q", got)
+	}
+
+	// --profile takes precedence over the environment
+	profileName = "ci"
+	if cfg, err = loadRunConfig(); err != nil {
+		t.Fatalf("loadRunConfig() with --profile error = %v", err)
+	}
+	if got := cfg.Commands["test"].Args[1]; got != "-race" {
+		t.Errorf("--profile not applied, got arg %q", got)
+	}
+
+	// Lockfiles keep the profiles and apply them when loaded
+	configLockCmd.SetOut(&bytes.Buffer{})
+	defer configLockCmd.SetOut(nil)
+	if err := runConfigLock(configLockCmd, nil); err != nil {
+		t.Fatalf("runConfigLock() error = %v", err)
+	}
+	lockedConfig = true
+	if cfg, err = loadRunConfig(); err != nil {
+		t.Fatalf("loadRunConfig() with --locked error = %v", err)
+	}
+	if got := cfg.Commands["test"].Args[1]; got != "-race" {
+		t.Errorf("profile not applied to the locked config, got arg %q", got)
+	}
+	lockedConfig = false
+
+	profileName = "staging"
+	if _, err := loadRunConfig(); err == nil || !strings.Contains(err.Error(), `unknown profile "staging"`) {
+		t.Errorf("loadRunConfig() error = %v, want unknown profile error", err)
+	}
+}
+
+func TestNewConfigLoader_Cache(t *testing.T) {
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+	t.Setenv("HOME", cacheHome)
+
+	oldNoCache := noConfigCache
+	defer func() { noConfigCache = oldNoCache }()
+
+	noConfigCache = false
+	if dir := newConfigLoader().CacheDir; !strings.HasPrefix(dir, cacheHome) {
+		t.Errorf("expected the cache under the user cache directory, got %q", dir)
+	}
+
+	noConfigCache = true
+	if dir := newConfigLoader().CacheDir; dir != "" {
+		t.Errorf("expected --no-config-cache to disable the cache, got %q", dir)
+	}
+}
+
+func TestRunConfigWhich(t *testing.T) {
+	t.Setenv("QUALHOOK_CONFIG", "")
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to resolve temp dir: %v", err)
+	}
+	cwd := filepath.Join(root, "packages", "web")
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0750); err != nil {
+		t.Fatalf("failed to create project root: %v", err)
+	}
+	if err := os.MkdirAll(cwd, 0750); err != nil {
+		t.Fatalf("failed to create working directory: %v", err)
+	}
+	data := []byte(`{"version": "1.0", "commands": {"lint": {"command": "eslint"}}}`)
+	if err := os.WriteFile(filepath.Join(root, ".qualhook.json"), data, 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(cwd); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	run := func() string {
+		out := &bytes.Buffer{}
+		configWhichCmd.SetOut(out)
+		defer configWhichCmd.SetOut(nil)
+		if err := runConfigWhich(configWhichCmd, nil); err != nil {
+			t.Fatalf("runConfigWhich() error = %v", err)
+		}
+		return out.String()
+	}
+
+	// Only the project root has a configuration
+	if out := run(); !strings.Contains(out, "Configuration file: "+filepath.Join(root, ".qualhook.json")+"\n") {
+		t.Errorf("expected the parent configuration, got:\n%s", out)
+	}
+
+	// A configuration in the working directory wins
+	if err := os.WriteFile(filepath.Join(cwd, ".qualhook.json"), data, 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	out := run()
+	if !strings.Contains(out, "Configuration file: "+filepath.Join(cwd, ".qualhook.json")+"\n") {
+		t.Errorf("expected the working directory configuration, got:\n%s", out)
+	}
+	if !strings.Contains(out, "1. "+filepath.Join(cwd, ".qualhook.json")) {
+		t.Errorf("expected the layering order, got:\n%s", out)
+	}
+}
+
+func TestRunDetectOnly(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"package.json":              `{"name": "root", "private": true}`,
+		"lerna.json":                `{"packages": ["packages/*"]}`,
+		"packages/web/package.json": `{"name": "web"}`,
+		"packages/api/go.mod":       "module example.com/api\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	defer os.Chdir(oldDir) //nolint:errcheck // Best effort restore
+
+	var out bytes.Buffer
+	if err := runDetectOnly(&out, "json"); err != nil {
+		t.Fatalf("runDetectOnly() error = %v", err)
+	}
+
+	var report struct {
+		Types []struct {
+			Name       string  `json:"name"`
+			Confidence float64 `json:"confidence"`
+		} `json:"types"`
+		Monorepo     bool   `json:"monorepo"`
+		MonorepoType string `json:"monorepoType"`
+		Workspaces   []struct {
+			Path  string `json:"path"`
+			Types []struct {
+				Name string `json:"name"`
+			} `json:"types"`
+		} `json:"workspaces"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("expected a JSON report, got %q: %v", out.String(), err)
+	}
+	if len(report.Types) == 0 || report.Types[0].Name != "nodejs" || report.Types[0].Confidence == 0 {
+		t.Errorf("expected nodejs with a confidence, got %+v", report.Types)
+	}
+	if !report.Monorepo || report.MonorepoType != "lerna" {
+		t.Errorf("expected a lerna monorepo, got %v %q", report.Monorepo, report.MonorepoType)
+	}
+	workspaces := map[string]string{}
+	for _, ws := range report.Workspaces {
+		if len(ws.Types) > 0 {
+			workspaces[ws.Path] = ws.Types[0].Name
+		}
+	}
+	if workspaces[filepath.Join("packages", "web")] != "nodejs" || workspaces[filepath.Join("packages", "api")] != "go" {
+		t.Errorf("unexpected workspaces: %+v", report.Workspaces)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".qualhook.json")); !os.IsNotExist(err) {
+		t.Error("detection must not write a configuration")
+	}
+
+	out.Reset()
+	if err := runDetectOnly(&out, ""); err != nil || !strings.Contains(out.String(), "📦 Detected project types:") {
+		t.Errorf("unexpected text report %q: %v", out.String(), err)
+	}
+	if err := runDetectOnly(&out, "xml"); err == nil {
+		t.Error("expected an unsupported format error")
+	}
+}
+
+func TestRunConfigDiff(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		return path
+	}
+	write("base.json", `{"version": "1.0", "commands": {"lint": {"command": "eslint"}}}`)
+	// The same commands, with lint moved to the base configuration
+	from := write("a.json", `{"version": "1.0", "commands": {"lint": {"command": "eslint"}, "test": {"command": "jest"}}}`)
+	same := write("b.json", `{"version": "1.0", "extends": "./base.json", "commands": {"test": {"command": "jest"}}}`)
+	to := write("c.json", `{"version": "1.0", "commands": {"lint": {"command": "biome", "args": ["check"]}}}`)
+
+	run := func(args ...string) string {
+		out := &bytes.Buffer{}
+		configDiffCmd.SetOut(out)
+		defer configDiffCmd.SetOut(nil)
+		if err := runConfigDiff(configDiffCmd, args); err != nil {
+			t.Fatalf("runConfigDiff() error = %v", err)
+		}
+		return out.String()
+	}
+
+	if out := run(from, same); !strings.Contains(out, "resolve to the same configuration") {
+		t.Errorf("expected no differences through extends, got:\n%s", out)
+	}
+
+	out := run(from, to)
+	for _, want := range []string{
+		"Commands:\n   ~ lint\n",
+		`       args: (unset) → ["check"]`,
+		`       command: "eslint" → "biome"`,
+		"   - test\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+
+	diffJSON = true
+	defer func() { diffJSON = false }()
+	var diff struct {
+		Commands []struct{ Name, Change string }
+	}
+	if err := json.Unmarshal([]byte(run(from, to)), &diff); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if len(diff.Commands) != 2 || diff.Commands[0].Change != "changed" || diff.Commands[1].Change != "removed" {
+		t.Errorf("unexpected JSON diff %+v", diff)
+	}
+}
+
+func TestRunConfigSchema(t *testing.T) {
+	out := &bytes.Buffer{}
+	configSchemaCmd.SetOut(out)
+	defer configSchemaCmd.SetOut(nil)
+	if err := runConfigSchema(configSchemaCmd, nil); err != nil {
+		t.Fatalf("runConfigSchema() error = %v", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatalf("invalid schema JSON: %v\n%s", err, out.String())
+	}
+	if schema["$schema"] != "http://json-schema.org/draft-07/schema#" || schema["definitions"] == nil {
+		t.Errorf("expected a draft-07 schema with definitions, got %v", schema["$schema"])
+	}
+}
+
+func TestEditedConfigPath(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	if _, err := editedConfigPath(); err == nil || !strings.Contains(err.Error(), "no configuration file") {
+		t.Errorf("expected an error without a configuration file, got %v", err)
+	}
+
+	writeRepoFile(t, dir, ".qualhook.yaml", "version: \"1.0\"\n")
+	if path, err := editedConfigPath(); err != nil || filepath.Base(path) != ".qualhook.yaml" {
+		t.Errorf("editedConfigPath() = %q, %v; want the YAML configuration", path, err)
+	}
+
+	oldConfigPath := configPath
+	configPath = "custom.json"
+	defer func() { configPath = oldConfigPath }()
+	if path, _ := editedConfigPath(); path != "custom.json" {
+		t.Errorf("editedConfigPath() = %q, want the --config file", path)
+	}
+}
@@ -0,0 +1,85 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runBareRoot runs qualhook without arguments using the configuration at
+// file, returning stdout, stderr, help output and the exit code
+func runBareRoot(t *testing.T, file string) (string, string, string, int) {
+	t.Helper()
+
+	// Creating the root command resets the flag variables
+	root := newRootCmd()
+	oldConfigPath := configPath
+	configPath = file
+	defer func() { configPath = oldConfigPath }()
+
+	var stdout, stderr, help bytes.Buffer
+	oldOut, oldErr, oldExit := outputWriter, errorWriter, osExit
+	exitCode := 0
+	outputWriter, errorWriter = &stdout, &stderr
+	osExit = func(code int) { exitCode = code }
+	defer func() { outputWriter, errorWriter, osExit = oldOut, oldErr, oldExit }()
+
+	root.SetOut(&help)
+	root.SetArgs([]string{})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	return stdout.String(), stderr.String(), help.String(), exitCode
+}
+
+func TestRootCommand_RunsDefaultCommand(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "check.sh")
+	if err := os.WriteFile(script, []byte("echo 'main.go:3: check failed' >&2\nexit 1\n"), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	file := filepath.Join(dir, ".qualhook.json")
+	data := `{
+		"version": "1.0",
+		"defaultCommand": "check",
+		"commands": {"check": {"command": "sh", "args": ["` + script + `"]}}
+	}`
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	_, stderr, help, exitCode := runBareRoot(t, file)
+	if exitCode != 2 {
+		t.Errorf("expected exit code 2, got %d", exitCode)
+	}
+	if !strings.Contains(stderr, "check failed") {
+		t.Errorf("expected the default command's errors, got:\n%s", stderr)
+	}
+	if help != "" {
+		t.Errorf("expected no help output, got:\n%s", help)
+	}
+}
+
+func TestRootCommand_ShowsHelpWithoutDefaultCommand(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, ".qualhook.json")
+	if err := os.WriteFile(file, []byte(`{"version": "1.0", "commands": {"lint": {"command": "false"}}}`), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	for name, path := range map[string]string{"no default": file, "no config": filepath.Join(dir, "missing.json")} {
+		t.Run(name, func(t *testing.T) {
+			stdout, stderr, help, exitCode := runBareRoot(t, path)
+			if !strings.Contains(help, "Usage:") {
+				t.Errorf("expected help output, got:\n%s", help)
+			}
+			if stdout != "" || stderr != "" || exitCode != 0 {
+				t.Errorf("expected no command to run, got stdout %q, stderr %q, exit %d", stdout, stderr, exitCode)
+			}
+		})
+	}
+}
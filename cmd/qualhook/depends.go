@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// runCommandSequence runs the command after its prerequisites, followed by
+// its verification commands. The command is reported as skipped when a
+// prerequisite failed.
+func runCommandSequence(cfg *config.Config, commandName string, extraArgs []string, editedFiles []string, stream *reporter.StreamReporter) ([]executor.ComponentExecResult, error) {
+	results, failedBy, err := runPrerequisites(cfg, commandName, editedFiles, stream)
+	if err != nil {
+		return nil, err
+	}
+	if failedBy != "" {
+		debug.Log("Skipping %s: prerequisite %s failed", commandName, failedBy)
+		return append(results, skippedResult(cfg, commandName, failedBy)), nil
+	}
+
+	commandResults, err := runCommandResults(cfg, commandName, extraArgs, editedFiles, stream)
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, commandResults...)
+
+	verifyResults, err := runVerifyCommands(cfg, cfg.Commands[commandName], commandResults, editedFiles, stream)
+	if err != nil {
+		return nil, err
+	}
+	return append(results, verifyResults...), nil
+}
+
+// runPrerequisites runs the dependsOn commands of commandName, each after its
+// own prerequisites. A prerequisite depending on a failed one is skipped. It
+// also returns the failed prerequisite that keeps commandName from running.
+func runPrerequisites(cfg *config.Config, commandName string, editedFiles []string, stream *reporter.StreamReporter) ([]executor.ComponentExecResult, string, error) {
+	order, err := cfg.Prerequisites(commandName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var results []executor.ComponentExecResult
+	// failed maps each command that did not pass to the prerequisite that failed
+	failed := make(map[string]string)
+	for _, name := range order {
+		if failedBy := failedDependency(cfg.Commands[name], failed); failedBy != "" {
+			failed[name] = failedBy
+			results = append(results, skippedResult(cfg, name, failedBy))
+			continue
+		}
+
+		debug.Log("Running prerequisite: %s", name)
+		r, err := runCommandResults(cfg, name, nil, editedFiles, stream)
+		if err != nil {
+			return nil, "", fmt.Errorf("prerequisite %q: %w", name, err)
+		}
+		results = append(results, r...)
+		if newErrorReporter().Report(r).ExitCode != 0 {
+			failed[name] = name
+		}
+	}
+	return results, failedDependency(cfg.Commands[commandName], failed), nil
+}
+
+// failedDependency returns the failed prerequisite behind the first
+// dependency of cmdConfig that did not pass, if any
+func failedDependency(cmdConfig *config.CommandConfig, failed map[string]string) string {
+	for _, dependency := range cmdConfig.DependsOn {
+		if failedBy, ok := failed[dependency]; ok {
+			return failedBy
+		}
+	}
+	return ""
+}
+
+// skippedResult reports a command that did not run after a prerequisite failed
+func skippedResult(cfg *config.Config, commandName, failedBy string) executor.ComponentExecResult {
+	return executor.ComponentExecResult{
+		Command:       commandName,
+		CommandConfig: cfg.Commands[commandName],
+		SkippedBy:     failedBy,
+	}
+}
@@ -0,0 +1,75 @@
+//go:build unit
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestRunCommandSequence_DependsOn(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran")
+	buildScript := filepath.Join(dir, "build.sh")
+	if err := os.WriteFile(buildScript, []byte("echo 'build failed'\nexit \"$1\"\n"), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	newConfig := func(buildExit string) *config.Config {
+		return &config.Config{
+			Version: "1.0",
+			Commands: map[string]*config.CommandConfig{
+				"build":     {Command: "sh", Args: []string{buildScript, buildExit}},
+				"typecheck": {Command: "true", DependsOn: []string{"build"}},
+				"test":      {Command: "touch", Args: []string{marker}, DependsOn: []string{"typecheck", "build"}},
+			},
+		}
+	}
+
+	t.Run("passing prerequisites run first", func(t *testing.T) {
+		results, err := runCommandSequence(newConfig("0"), "test", nil, nil, nil)
+		if err != nil {
+			t.Fatalf("runCommandSequence() error = %v", err)
+		}
+		var order []string
+		for _, result := range results {
+			order = append(order, result.Command)
+		}
+		if strings.Join(order, ",") != "build,typecheck,test" {
+			t.Errorf("expected build, typecheck then test, got %v", order)
+		}
+		if _, err := os.Stat(marker); err != nil {
+			t.Errorf("expected test to run: %v", err)
+		}
+	})
+
+	t.Run("failed prerequisite skips dependents", func(t *testing.T) {
+		if err := os.Remove(marker); err != nil && !os.IsNotExist(err) {
+			t.Fatalf("failed to remove marker: %v", err)
+		}
+		results, err := runCommandSequence(newConfig("1"), "test", nil, nil, nil)
+		if err != nil {
+			t.Fatalf("runCommandSequence() error = %v", err)
+		}
+		if len(results) != 3 || results[1].SkippedBy != "build" || results[2].SkippedBy != "build" {
+			t.Fatalf("expected typecheck and test skipped by build, got %+v", results)
+		}
+		if _, err := os.Stat(marker); !os.IsNotExist(err) {
+			t.Error("expected test not to run after build failed")
+		}
+
+		report := reporter.NewErrorReporter().Report(results)
+		if report.ExitCode != 2 {
+			t.Errorf("expected exit code 2, got %d", report.ExitCode)
+		}
+		for _, want := range []string{"build failed", "Skipped typecheck: prerequisite build failed", "Skipped test: prerequisite build failed"} {
+			if !strings.Contains(report.Stderr, want) {
+				t.Errorf("expected %q in the report, got:\n%s", want, report.Stderr)
+			}
+		}
+	})
+}
@@ -0,0 +1,79 @@
+// Package main provides the doctor command for qualhook
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bebsworthy/qualhook/internal/selftest"
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the configured commands run healthily",
+	Long: `Run every configured command once and check that it exits as expected.
+
+A command is healthy when it exits with its expectedExitCode (0 unless
+configured). This is independent of exitCodes, which decide what counts as
+an error during normal runs. For example, a format command that rewrites
+files and exits 1 can declare "expectedExitCode": 1.
+
+Note that commands run for real, so a format command may modify files.
+
+Exit codes:
+  0 - All commands are healthy
+  1 - One or more commands are unhealthy`,
+	Example: `  # Check every configured command
+  qualhook doctor
+
+  # Check a specific configuration file
+  qualhook --config ./frontend/.qualhook.json doctor`,
+	RunE: runDoctor,
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	cfg, err := loadRunConfig()
+	if err != nil {
+		return err
+	}
+	if err := applyTimeoutOverride(cfg, timeoutOverride); err != nil {
+		return err
+	}
+
+	runner := selftest.NewRunner()
+	if err := runner.ApplySecurity(configRoot(), cfg.Security); err != nil {
+		return err
+	}
+	report := runner.CheckCommands(cfg)
+	printDoctorReport(outputWriter, report)
+
+	if !report.Passed() {
+		return fmt.Errorf("doctor found unhealthy commands")
+	}
+	return nil
+}
+
+// printDoctorReport writes a human-readable health report of the configured commands
+func printDoctorReport(w io.Writer, report *selftest.Report) {
+	if len(report.Checks) == 0 {
+		_, _ = fmt.Fprintln(w, "No commands configured.") //nolint:errcheck // Best effort output
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, "🩺 Commands:") //nolint:errcheck // Best effort output
+	for _, check := range report.Checks {
+		mark := "✓"
+		if !check.Passed {
+			mark = "✗"
+		}
+		_, _ = fmt.Fprintf(w, "   %s %s (%s)\n", mark, check.Name, check.Details) //nolint:errcheck // Best effort output
+	}
+
+	if report.Passed() {
+		_, _ = fmt.Fprintln(w, "\n✅ All commands are healthy!") //nolint:errcheck // Best effort output
+	} else {
+		_, _ = fmt.Fprintln(w, "\n❌ Some commands are unhealthy. Run with --debug for details.") //nolint:errcheck // Best effort output
+	}
+}
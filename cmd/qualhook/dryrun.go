@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// plannedCommand is a command a run would execute for one component
+type plannedCommand struct {
+	Name       string
+	Component  string
+	WorkingDir string
+	Command    string
+	Args       []string
+	Timeout    time.Duration
+	// Image is the container image for sandboxed commands
+	Image string
+	// Verify marks verifyWith commands, which only run once the fix passed
+	Verify bool
+	// Prerequisite marks dependsOn commands, which run first
+	Prerequisite bool
+}
+
+// planCommand resolves the commands a run of commandName would execute,
+// following the same component mapping as the run itself
+func planCommand(cfg *config.Config, commandName string, extraArgs []string, editedFiles []string) ([]plannedCommand, error) {
+	prerequisites, err := cfg.Prerequisites(commandName)
+	if err != nil {
+		return nil, err
+	}
+	var plan []plannedCommand
+	for _, name := range prerequisites {
+		prerequisitePlan, err := planCommandResults(cfg, name, nil, editedFiles)
+		if err != nil {
+			return nil, fmt.Errorf("prerequisite %q: %w", name, err)
+		}
+		for i := range prerequisitePlan {
+			prerequisitePlan[i].Prerequisite = true
+		}
+		plan = append(plan, prerequisitePlan...)
+	}
+
+	commandPlan, err := planCommandResults(cfg, commandName, extraArgs, editedFiles)
+	if err != nil {
+		return nil, err
+	}
+	plan = append(plan, commandPlan...)
+
+	for _, verifyName := range cfg.Commands[commandName].VerifyWith {
+		verifyPlan, err := planCommandResults(cfg, verifyName, nil, editedFiles)
+		if err != nil {
+			return nil, fmt.Errorf("verification command %q: %w", verifyName, err)
+		}
+		for i := range verifyPlan {
+			verifyPlan[i].Verify = true
+		}
+		plan = append(plan, verifyPlan...)
+	}
+
+	return plan, nil
+}
+
+// planCommandResults mirrors runCommandResults without executing anything
+func planCommandResults(cfg *config.Config, commandName string, extraArgs []string, editedFiles []string) ([]plannedCommand, error) {
+	if len(editedFiles) == 0 {
+		cmdConfig, exists := cfg.Commands[commandName]
+		if !exists {
+			return nil, fmt.Errorf("command %q not found in configuration", commandName)
+		}
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+		return []plannedCommand{newPlannedCommand(commandName, "", cwd, cmdConfig, extraArgs)}, nil
+	}
+
+	groups, err := mapComponentGroups(cfg, commandName, editedFiles)
+	if err != nil {
+		return nil, err
+	}
+	if pickComponents {
+		if groups, err = pickComponentGroups(groups, commandName); err != nil {
+			return nil, err
+		}
+	}
+
+	var plan []plannedCommand
+	for _, group := range groups {
+		cmdConfig := group.Config[commandName]
+		if cmdConfig == nil {
+			continue
+		}
+		plan = append(plan, newPlannedCommand(commandName, group.Path, group.Path, cmdConfig, extraArgs))
+	}
+	return plan, nil
+}
+
+// newPlannedCommand describes a run of cmdConfig in workingDir
+func newPlannedCommand(name, component, workingDir string, cmdConfig *config.CommandConfig, extraArgs []string) plannedCommand {
+	planned := plannedCommand{
+		Name:       name,
+		Component:  component,
+		WorkingDir: workingDir,
+		Command:    cmdConfig.Command,
+		Args:       commandArgs(cmdConfig, extraArgs),
+		Timeout:    commandTimeout(cmdConfig),
+	}
+	if cmdConfig.Sandbox != nil {
+		planned.Image = cmdConfig.Sandbox.Image
+	}
+	return planned
+}
+
+// printCommandPlan writes the commands a run would execute to w
+func printCommandPlan(w io.Writer, cfg *config.Config, commandName string, extraArgs []string, editedFiles []string) error {
+	plan, err := planCommand(cfg, commandName, extraArgs, editedFiles)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "🔍 Dry run of %q, nothing is executed.\n", commandName) //nolint:errcheck // Best effort output
+	if len(plan) == 0 {
+		_, _ = fmt.Fprintln(w, "\nNo component configures this command.") //nolint:errcheck // Best effort output
+		return nil
+	}
+
+	for _, planned := range plan {
+		component := planned.Component
+		if component == "" {
+			component = "(root)"
+		}
+		title := fmt.Sprintf("%s: %s", planned.Name, component)
+		if planned.Verify {
+			title += " (verification, runs if the fix passes)"
+		}
+		if planned.Prerequisite {
+			title += " (prerequisite, runs first)"
+		}
+		_, _ = fmt.Fprintf(w, "\n   %s\n", title)                                  //nolint:errcheck // Best effort output
+		_, _ = fmt.Fprintf(w, "      Working directory: %s\n", planned.WorkingDir) //nolint:errcheck // Best effort output
+		_, _ = fmt.Fprintf(w, "      Command:           %s\n", planned.Command)    //nolint:errcheck // Best effort output
+		_, _ = fmt.Fprintf(w, "      Args:              %q\n", planned.Args)       //nolint:errcheck // Best effort output
+		_, _ = fmt.Fprintf(w, "      Timeout:           %s\n", planned.Timeout)    //nolint:errcheck // Best effort output
+		if planned.Image != "" {
+			_, _ = fmt.Fprintf(w, "      Container image:   %s\n", planned.Image) //nolint:errcheck // Best effort output
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestPlanCommand(t *testing.T) {
+	cfg := &config.Config{
+		Version: "1.0",
+		Commands: map[string]*config.CommandConfig{
+			"format": {Command: "prettier", Args: []string{"--write", "."}, VerifyWith: []string{"lint"}},
+			"lint":   {Command: "eslint", Timeout: 30000},
+		},
+		Paths: []*config.PathConfig{
+			{Path: "web/**", Commands: map[string]*config.CommandConfig{
+				"lint": {Command: "npm", Args: []string{"run", "lint"}, Sandbox: &config.SandboxConfig{Image: "node:20"}},
+			}},
+		},
+	}
+
+	t.Run("single command", func(t *testing.T) {
+		plan, err := planCommand(cfg, "lint", []string{"--fix"}, nil)
+		if err != nil {
+			t.Fatalf("planCommand() error = %v", err)
+		}
+		cwd, _ := os.Getwd()
+		if len(plan) != 1 || plan[0].WorkingDir != cwd || plan[0].Command != "eslint" {
+			t.Fatalf("unexpected plan: %+v", plan)
+		}
+		if plan[0].Timeout != 30*time.Second || len(plan[0].Args) != 1 || plan[0].Args[0] != "--fix" {
+			t.Errorf("unexpected args or timeout: %+v", plan[0])
+		}
+	})
+
+	t.Run("file-aware with verification", func(t *testing.T) {
+		plan, err := planCommand(cfg, "lint", nil, []string{"web/app.js", "README.md"})
+		if err != nil {
+			t.Fatalf("planCommand() error = %v", err)
+		}
+		if len(plan) != 2 || plan[0].Component != "." || plan[1].Component != "web/**" {
+			t.Fatalf("unexpected components: %+v", plan)
+		}
+		if plan[1].Command != "npm" || plan[1].Image != "node:20" || plan[1].Timeout != defaultCommandTimeout {
+			t.Errorf("unexpected path command plan: %+v", plan[1])
+		}
+
+		plan, err = planCommand(cfg, "format", nil, []string{"README.md"})
+		if err != nil {
+			t.Fatalf("planCommand() error = %v", err)
+		}
+		if len(plan) != 2 || plan[0].Verify || !plan[1].Verify || plan[1].Name != "lint" {
+			t.Errorf("expected format followed by lint verification, got %+v", plan)
+		}
+	})
+
+	t.Run("prerequisites", func(t *testing.T) {
+		withPrerequisite := &config.Config{
+			Version: "1.0",
+			Commands: map[string]*config.CommandConfig{
+				"typecheck": {Command: "tsc"},
+				"test":      {Command: "jest", DependsOn: []string{"typecheck"}},
+			},
+		}
+		plan, err := planCommand(withPrerequisite, "test", nil, nil)
+		if err != nil {
+			t.Fatalf("planCommand() error = %v", err)
+		}
+		if len(plan) != 2 || !plan[0].Prerequisite || plan[0].Name != "typecheck" || plan[1].Prerequisite {
+			t.Errorf("expected typecheck to run before test, got %+v", plan)
+		}
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		if _, err := planCommand(cfg, "test", nil, nil); err == nil {
+			t.Error("expected error for unknown command")
+		}
+	})
+}
+
+func TestExecuteCommand_DryRun(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "ran")
+	cfg := &config.Config{
+		Version: "1.0",
+		Commands: map[string]*config.CommandConfig{
+			"lint": {Command: "touch", Args: []string{marker}},
+		},
+	}
+
+	oldDryRun, oldOut := dryRun, outputWriter
+	out := &bytes.Buffer{}
+	dryRun, outputWriter = true, out
+	defer func() { dryRun, outputWriter = oldDryRun, oldOut }()
+
+	if err := executeCommand(cfg, "lint", nil); err != nil {
+		t.Fatalf("executeCommand() error = %v", err)
+	}
+
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Error("dry run executed the command")
+	}
+	for _, want := range []string{"Dry run", "lint: (root)", "Command:           touch", marker, "Timeout:           2m0s"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("plan output missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,80 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestErrorExitCode(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "lint.sh")
+	if err := os.WriteFile(script, []byte("echo 'main.go:3: unused variable' >&2\nexit 1\n"), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	file := filepath.Join(dir, ".qualhook.json")
+	data := `{
+		"version": "1.0",
+		"errorExitCode": 3,
+		"commands": {"lint": {"command": "sh", "args": ["` + script + `"]}}
+	}`
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "from config", args: []string{"lint"}, want: 3},
+		{name: "flag overrides config", args: []string{"lint", "--error-exit-code", "4"}, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Creating the root command resets the flag variables
+			root := newRootCmd()
+			oldConfigPath := configPath
+			configPath = file
+			defer func() { configPath = oldConfigPath }()
+
+			var stdout, stderr bytes.Buffer
+			oldOut, oldErr, oldExit := outputWriter, errorWriter, osExit
+			exitCode := -1
+			outputWriter, errorWriter = &stdout, &stderr
+			osExit = func(code int) { exitCode = code }
+			defer func() { outputWriter, errorWriter, osExit = oldOut, oldErr, oldExit }()
+
+			root.SetArgs(tt.args)
+			if err := root.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if exitCode != tt.want {
+				t.Errorf("expected exit code %d, got %d", tt.want, exitCode)
+			}
+			if !strings.Contains(stderr.String(), "unused variable") {
+				t.Errorf("expected the lint error, got:\n%s", stderr.String())
+			}
+			if runErrorExitCode != 0 {
+				t.Errorf("expected the run exit code to be reset, got %d", runErrorExitCode)
+			}
+		})
+	}
+}
+
+func TestErrorExitCode_InvalidFlag(t *testing.T) {
+	root := newRootCmd()
+	defer func() { errorExitCode = 0 }()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"lint", "--error-exit-code", "1"})
+	err := root.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--error-exit-code must be between 2 and 255") {
+		t.Errorf("expected an invalid exit code error, got %v", err)
+	}
+}
@@ -2,17 +2,22 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/bebsworthy/qualhook/internal/ansi"
 	"github.com/bebsworthy/qualhook/internal/debug"
 	"github.com/bebsworthy/qualhook/internal/executor"
 	"github.com/bebsworthy/qualhook/internal/filter"
 	"github.com/bebsworthy/qualhook/internal/hook"
 	"github.com/bebsworthy/qualhook/internal/reporter"
+	"github.com/bebsworthy/qualhook/internal/security"
 	"github.com/bebsworthy/qualhook/internal/watcher"
 	"github.com/bebsworthy/qualhook/pkg/config"
 )
@@ -28,60 +33,191 @@ var (
 
 // executeCommand executes a configured command and processes its output
 func executeCommand(cfg *config.Config, commandName string, extraArgs []string) error {
+	return executeCommands(cfg, []string{commandName}, extraArgs)
+}
+
+// executeCommands executes configured commands, such as those carrying a
+// tag, and reports their results together
+func executeCommands(cfg *config.Config, commandNames []string, extraArgs []string) error {
 	start := time.Now()
 	debug.LogSection("Execute Command")
-	debug.Log("Command: %s", commandName)
+	debug.Log("Command: %s", strings.Join(commandNames, ", "))
 	debug.Log("Extra Args: %v", extraArgs)
 
-	// Check if command exists in configuration
-	cmdConfig, exists := cfg.Commands[commandName]
-	if !exists {
-		return fmt.Errorf("command %q not found in configuration", commandName)
+	// Check if the commands exist in configuration
+	for _, commandName := range commandNames {
+		if _, exists := cfg.Commands[commandName]; !exists {
+			return fmt.Errorf("command %q not found in configuration", commandName)
+		}
+	}
+
+	// Explicit files take the place of the files edited by the hook
+	editedFiles := explicitFiles(targetFiles)
+	if sinceRef != "" {
+		changed, err := changedFilesSince(sinceRef)
+		if err != nil {
+			return err
+		}
+		if len(changed) == 0 && len(editedFiles) == 0 {
+			if !quietFlag {
+				_, _ = fmt.Fprintf(outputWriter, "No files changed since %s, nothing to check\n", sinceRef) //nolint:errcheck // Best effort output
+			}
+			return nil
+		}
+		editedFiles = append(editedFiles, changed...)
+	}
+	if len(editedFiles) == 0 {
+		editedFiles = extractEditedFiles(parseHookInput())
 	}
 
-	// Parse hook input if available
-	hookInput := parseHookInput()
+	// Offer every configured component when picking without edited files
+	if pickComponents && len(editedFiles) == 0 {
+		editedFiles = componentFiles(cfg)
+	}
 
-	// Extract edited files if available
-	editedFiles := extractEditedFiles(hookInput)
+	if dryRun {
+		for i, commandName := range commandNames {
+			if i > 0 {
+				_, _ = fmt.Fprintln(outputWriter) //nolint:errcheck // Best effort output
+			}
+			if err := printCommandPlan(outputWriter, cfg, commandName, extraArgs, editedFiles); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 
-	// Determine execution mode
-	var results []executor.ComponentExecResult
+	var stream *reporter.StreamReporter
+	results, err := runWithRetry(retryRuns, func() ([]executor.ComponentExecResult, error) {
+		// Stream component reports as they complete if requested; each
+		// attempt starts a fresh stream so retried runs are not counted twice
+		if streamReport {
+			stream = reporter.NewStreamReporter(newErrorReporter(), errorWriter)
+		}
+
+		return runCommands(cfg, commandNames, extraArgs, editedFiles, stream)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Report and output results
+	reportAndOutputResults(results, start, stream)
 
+	return nil
+}
+
+// runCommandResults runs the named command for the edited files, or once in
+// the current directory when there are none
+func runCommandResults(cfg *config.Config, commandName string, extraArgs []string, editedFiles []string, stream *reporter.StreamReporter) ([]executor.ComponentExecResult, error) {
 	if len(editedFiles) > 0 {
-		r, err := executeFileAwareCommand(cfg, commandName, extraArgs, editedFiles)
-		if err != nil {
-			return err
+		return executeFileAwareCommand(cfg, commandName, extraArgs, editedFiles, stream)
+	}
+
+	cmdConfig, exists := cfg.Commands[commandName]
+	if !exists {
+		return nil, fmt.Errorf("command %q not found in configuration", commandName)
+	}
+	results, err := executeSingleCommand(cmdConfig, commandName, extraArgs)
+	if errors.Is(err, security.ErrCommandNotAllowed) {
+		// Report it like a component command that failed to run
+		results, err = []executor.ComponentExecResult{{Command: commandName, CommandConfig: cmdConfig, ExecutionError: err}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stream != nil {
+		for _, result := range results {
+			stream.Add(result)
 		}
-		results = r
-	} else {
-		r, err := executeSingleCommand(cmdConfig, commandName, extraArgs)
+	}
+	return results, nil
+}
+
+// runVerifyCommands runs the verifyWith commands of a fix command once its
+// results passed, in the same mode as the fix. A failed command stops the
+// rest of the sequence unless it sets continueOnError. Verification commands
+// do not trigger their own verifyWith commands.
+func runVerifyCommands(cfg *config.Config, cmdConfig *config.CommandConfig, fixResults []executor.ComponentExecResult, editedFiles []string, stream *reporter.StreamReporter) ([]executor.ComponentExecResult, error) {
+	if len(cmdConfig.VerifyWith) == 0 {
+		return nil, nil
+	}
+	if !cmdConfig.ContinueOnError && newErrorReporter().Report(fixResults).ExitCode != 0 {
+		debug.Log("Skipping verification: fix did not succeed")
+		return nil, nil
+	}
+
+	var results []executor.ComponentExecResult
+	for _, verifyName := range cmdConfig.VerifyWith {
+		debug.Log("Verifying with: %s", verifyName)
+		r, err := runCommandResults(cfg, verifyName, nil, editedFiles, stream)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("verification command %q: %w", verifyName, err)
+		}
+		results = append(results, r...)
+
+		if verifyConfig := cfg.Commands[verifyName]; !verifyConfig.ContinueOnError && newErrorReporter().Report(r).ExitCode != 0 {
+			debug.Log("Skipping remaining verification: %s did not succeed", verifyName)
+			break
 		}
-		results = r
 	}
+	return results, nil
+}
 
-	// Report and output results
-	reportAndOutputResults(results, start)
+// hookStdinTimeout bounds the wait for hook input on a stdin pipe that is
+// left open without data
+const hookStdinTimeout = 250 * time.Millisecond
 
-	return nil
+// hookStdin returns the hook input piped on stdin, or nil when stdin is a
+// terminal or not piped. It is a variable to allow feeding input in tests.
+var hookStdin = readHookStdin
+
+// stdinInput caches stdin, which can only be read once per process
+var stdinInput struct {
+	once sync.Once
+	data []byte
 }
 
-// parseHookInput parses Claude Code hook input from environment
-func parseHookInput() *hook.HookInput {
-	input := os.Getenv("CLAUDE_HOOK_INPUT")
-	if input == "" {
+// readHookStdin reads stdin once when it is a pipe or file, giving up after
+// hookStdinTimeout
+func readHookStdin() io.Reader {
+	stdinInput.once.Do(func() {
+		info, err := os.Stdin.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice != 0 {
+			return
+		}
+
+		read := make(chan []byte, 1)
+		go func() {
+			data, _ := io.ReadAll(os.Stdin) //nolint:errcheck // Unreadable stdin holds no hook input
+			read <- data
+		}()
+		select {
+		case stdinInput.data = <-read:
+		case <-time.After(hookStdinTimeout):
+			debug.Log("No hook input on stdin after %v", hookStdinTimeout)
+		}
+	})
+	if len(stdinInput.data) == 0 {
 		return nil
 	}
+	return bytes.NewReader(stdinInput.data)
+}
 
-	debug.Log("Claude Code hook input detected")
+// parseHookInput parses Claude Code hook input piped on stdin, or from the
+// CLAUDE_HOOK_INPUT environment variable when stdin holds none
+func parseHookInput() *hook.HookInput {
 	parser := hook.NewParser()
-	hookInput, err := parser.Parse(bytes.NewReader([]byte(input)))
+	hookInput, err := parser.ReadInput(hookStdin(), os.Getenv(hook.InputEnvVar))
 	if err != nil {
 		debug.LogError(err, "parsing hook input")
 		return nil
 	}
+	if hookInput == nil {
+		return nil
+	}
+
+	debug.Log("Claude Code hook input detected")
 
 	debug.Log("Session ID: %s", hookInput.SessionID)
 	debug.Log("Hook Event: %s", hookInput.HookEventName)
@@ -108,38 +244,134 @@ func extractEditedFiles(hookInput *hook.HookInput) []string {
 	return files
 }
 
-// executeFileAwareCommand executes command for edited files
-func executeFileAwareCommand(cfg *config.Config, commandName string, extraArgs []string, editedFiles []string) ([]executor.ComponentExecResult, error) {
+// explicitFiles cleans the files given with --files, dropping empty entries
+// and making absolute paths under the working directory relative to it
+func explicitFiles(files []string) []string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		debug.LogError(err, "getting working directory for --files")
+	}
+
+	var cleaned []string
+	for _, file := range files {
+		file = strings.TrimSpace(file)
+		if file == "" {
+			continue
+		}
+		if filepath.IsAbs(file) && cwd != "" {
+			if rel, err := filepath.Rel(cwd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+		cleaned = append(cleaned, filepath.Clean(file))
+	}
+	return cleaned
+}
+
+// executeFileAwareCommand executes command for edited files, running the
+// components in parallel. With a stream reporter, they are reported as they complete.
+func executeFileAwareCommand(cfg *config.Config, commandName string, extraArgs []string, editedFiles []string, stream *reporter.StreamReporter) ([]executor.ComponentExecResult, error) {
 	debug.LogSection("File-Aware Execution")
 	debug.Log("Edited files: %v", editedFiles)
 
-	// Map files to components
+	groups, err := mapComponentGroups(cfg, commandName, editedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return runComponentGroups(groups, commandName, extraArgs, stream)
+}
+
+// mapComponentGroups maps edited files to the component groups that run the
+// command for them, including components the files trigger the command for
+func mapComponentGroups(cfg *config.Config, commandName string, editedFiles []string) ([]watcher.ComponentGroup, error) {
 	mapper := watcher.NewFileMapper(cfg)
-	groups, err := mapper.MapFilesToComponents(editedFiles)
+	groups, err := mapper.MapFilesForCommand(editedFiles, commandName)
 	if err != nil {
 		debug.LogError(err, "mapping files to components")
 		return nil, err
 	}
 	debug.Log("Mapped to %d component groups", len(groups))
+	return groups, nil
+}
 
-	var results []executor.ComponentExecResult
-	for _, group := range groups {
-		result, err := executeComponentCommand(&group, commandName, extraArgs)
+// runComponentGroups runs the command for each component group, letting the
+// user choose the groups first when --pick is set
+func runComponentGroups(groups []watcher.ComponentGroup, commandName string, extraArgs []string, stream *reporter.StreamReporter) ([]executor.ComponentExecResult, error) {
+	if pickComponents {
+		picked, err := pickComponentGroups(groups, commandName)
 		if err != nil {
-			results = append(results, executor.ComponentExecResult{
-				Path:           group.Path,
-				Command:        commandName,
-				CommandConfig:  nil,
-				ExecutionError: err,
-			})
-			continue
+			return nil, err
 		}
+		groups = picked
+		debug.Log("Picked %d component groups", len(groups))
+	}
+
+	if stream != nil {
+		return executeComponentsStreaming(groups, commandName, extraArgs, stream), nil
+	}
+	return executeComponents(groups, commandName, extraArgs, nil), nil
+}
+
+// executeComponentsStreaming runs component groups like executeComponents
+// and hands each result to the stream reporter as soon as it completes
+func executeComponentsStreaming(groups []watcher.ComponentGroup, commandName string, extraArgs []string, stream *reporter.StreamReporter) []executor.ComponentExecResult {
+	return executeComponents(groups, commandName, extraArgs, stream.Add)
+}
+
+// executeComponents runs component groups concurrently, up to runJobs at
+// once, calling onResult, when set, with each result as soon as it completes.
+// Results are returned in group order.
+func executeComponents(groups []watcher.ComponentGroup, commandName string, extraArgs []string, onResult func(executor.ComponentExecResult)) []executor.ComponentExecResult {
+	ordered := make([]*executor.ComponentExecResult, len(groups))
+	sem := make(chan struct{}, executor.Workers(runJobs))
+	var wg sync.WaitGroup
+
+	for i := range groups {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result := runComponent(&groups[i], commandName, extraArgs)
+			if result != nil && onResult != nil {
+				onResult(*result)
+			}
+			ordered[i] = result
+		}(i)
+	}
+	wg.Wait()
+
+	var results []executor.ComponentExecResult
+	for _, result := range ordered {
 		if result != nil {
 			results = append(results, *result)
 		}
 	}
+	return results
+}
 
-	return results, nil
+// runComponent executes the command for a component, turning execution
+// failures into a result carrying the error. It returns nil when the
+// component does not configure the command.
+func runComponent(group *watcher.ComponentGroup, commandName string, extraArgs []string) *executor.ComponentExecResult {
+	start := time.Now()
+	result, err := executeComponentCommand(group, commandName, extraArgs)
+	if err != nil {
+		return &executor.ComponentExecResult{
+			Path:           group.Path,
+			Command:        commandName,
+			CommandConfig:  nil,
+			ExecutionError: err,
+			StartedAt:      start,
+			Duration:       time.Since(start),
+		}
+	}
+	if result != nil {
+		result.StartedAt, result.Duration = start, time.Since(start)
+	}
+	return result
 }
 
 // executeComponentCommand executes command for a single component
@@ -157,18 +389,25 @@ func executeComponentCommand(group *watcher.ComponentGroup, commandName string,
 	}
 
 	// Build the command arguments
-	args := make([]string, 0, len(cmdConfig.Args)+len(extraArgs))
-	args = append(args, cmdConfig.Args...)
-	args = append(args, extraArgs...)
+	args := commandArgs(cmdConfig, extraArgs)
+
+	log, closeLog := openCommandLog(group.Path, commandName)
+	defer closeLog()
+	log.LogCommand(cmdConfig.Command, args, group.Path)
 
 	// Execute command
+	execStart := time.Now()
 	result, err := executeWithOptions(cmdConfig, args, group.Path)
+	log.LogTiming("command execution", time.Since(execStart))
 	if err != nil {
+		log.LogError(err, "command execution")
 		return nil, err
 	}
+	logExecResult(log, result)
+	captureOutput(group.Path, commandName, cmdConfig.Command, args, result)
 
 	// Apply output filtering
-	filteredOutput := applyOutputFilter(cmdConfig, result)
+	filteredOutput := applyOutputFilter(cmdConfig, result, log)
 
 	return &executor.ComponentExecResult{
 		Path:           group.Path,
@@ -184,9 +423,7 @@ func executeSingleCommand(cmdConfig *config.CommandConfig, commandName string, e
 	debug.LogSection("Single Command Execution")
 
 	// Build the command arguments
-	args := make([]string, 0, len(cmdConfig.Args)+len(extraArgs))
-	args = append(args, cmdConfig.Args...)
-	args = append(args, extraArgs...)
+	args := commandArgs(cmdConfig, extraArgs)
 
 	// Get working directory
 	cwd, err := os.Getwd()
@@ -194,19 +431,24 @@ func executeSingleCommand(cmdConfig *config.CommandConfig, commandName string, e
 		return nil, fmt.Errorf("failed to get working directory: %w", err)
 	}
 
-	debug.LogCommand(cmdConfig.Command, args, cwd)
+	log, closeLog := openCommandLog("", commandName)
+	defer closeLog()
+	log.LogCommand(cmdConfig.Command, args, cwd)
 
 	// Execute command
 	execStart := time.Now()
 	result, err := executeWithOptions(cmdConfig, args, cwd)
-	debug.LogTiming("command execution", time.Since(execStart))
+	log.LogTiming("command execution", time.Since(execStart))
 
 	if err != nil {
+		log.LogError(err, "command execution")
 		return nil, fmt.Errorf("command execution failed: %w", err)
 	}
+	logExecResult(log, result)
+	captureOutput("", commandName, cmdConfig.Command, args, result)
 
 	// Apply output filtering
-	filteredOutput := applyOutputFilter(cmdConfig, result)
+	filteredOutput := applyOutputFilter(cmdConfig, result, log)
 
 	return []executor.ComponentExecResult{
 		{
@@ -215,33 +457,141 @@ func executeSingleCommand(cmdConfig *config.CommandConfig, commandName string, e
 			CommandConfig:  cmdConfig,
 			ExecResult:     result,
 			FilteredOutput: filteredOutput,
+			StartedAt:      execStart,
+			Duration:       time.Since(execStart),
 		},
 	}, nil
 }
 
+// defaultCommandTimeout applies to commands without a configured timeout
+const defaultCommandTimeout = 2 * time.Minute
+
+// commandArgs returns the arguments of a command run: the configured
+// arguments followed by the extra CLI arguments
+func commandArgs(cmdConfig *config.CommandConfig, extraArgs []string) []string {
+	args := make([]string, 0, len(cmdConfig.Args)+len(extraArgs))
+	args = append(args, cmdConfig.Args...)
+	return append(args, extraArgs...)
+}
+
+// commandTimeout returns the effective timeout of a command
+func commandTimeout(cmdConfig *config.CommandConfig) time.Duration {
+	if cmdConfig.Timeout > 0 {
+		return time.Duration(cmdConfig.Timeout) * time.Millisecond
+	}
+	return defaultCommandTimeout
+}
+
+// defaultRetryDelay is the wait before the first retry of commands without a
+// configured retryDelay
+const defaultRetryDelay = time.Second
+
+// commandRetryDelay returns the effective delay before a command's first retry
+func commandRetryDelay(cmdConfig *config.CommandConfig) time.Duration {
+	if cmdConfig.RetryDelay > 0 {
+		return time.Duration(cmdConfig.RetryDelay) * time.Millisecond
+	}
+	return defaultRetryDelay
+}
+
+// commandPathPrepend returns the directories searched before PATH: those
+// given with --path-prepend, then those configured for the command
+func commandPathPrepend(cmdConfig *config.CommandConfig) []string {
+	dirs := make([]string, 0, len(pathPrepend)+len(cmdConfig.PathPrepend))
+	dirs = append(dirs, pathPrepend...)
+	return append(dirs, cmdConfig.PathPrepend...)
+}
+
 // executeWithOptions executes command with configured options
 func executeWithOptions(cmdConfig *config.CommandConfig, args []string, workingDir string) (*executor.ExecResult, error) {
-	cmdExecutor := executor.NewCommandExecutor(2 * time.Minute)
+	if cmdConfig.WorkingDir != "" {
+		workingDir = cmdConfig.WorkingDir
+	}
+	hostExecutor := executor.NewCommandExecutor(defaultCommandTimeout)
+	if runSecurity != nil {
+		if err := hostExecutor.ApplySecurity(runProjectRoot, runSecurity); err != nil {
+			return nil, err
+		}
+	}
+	var cmdExecutor executor.Backend = hostExecutor
+	if sandbox := cmdConfig.Sandbox; sandbox != nil {
+		debug.Log("Running in container image: %s", sandbox.Image)
+		cmdExecutor = executor.NewContainerExecutor(hostExecutor, sandbox.Runtime, sandbox.Image, sandbox.Writable)
+	}
+	if cmdConfig.Retries > 0 {
+		retryExecutor := executor.NewRetryExecutor(cmdExecutor, cmdConfig.Retries, commandRetryDelay(cmdConfig))
+		retryExecutor.SetBudget(runRetryBudget)
+		cmdExecutor = retryExecutor
+	}
+	env, err := executor.CommandEnvironment(cmdConfig, true)
+	if err != nil {
+		return nil, err
+	}
 	execOptions := executor.ExecOptions{
-		WorkingDir: workingDir,
-		InheritEnv: true,
+		WorkingDir:  workingDir,
+		Environment: env,
+		InheritEnv:  true,
+		Timeout:     commandTimeout(cmdConfig),
+		PathPrepend: commandPathPrepend(cmdConfig),
 	}
-	if cmdConfig.Timeout > 0 {
-		execOptions.Timeout = time.Duration(cmdConfig.Timeout) * time.Millisecond
+	if liveWriter != nil {
+		execOptions.StreamStdout = liveWriter
+		execOptions.StreamStderr = liveWriter
+	}
+
+	result, err := cmdExecutor.Execute(cmdConfig.Command, args, execOptions)
+	if err != nil {
+		return nil, err
 	}
 
-	return cmdExecutor.Execute(cmdConfig.Command, args, execOptions)
+	result.Stdout = filter.SanitizeBinary(result.Stdout, cmdConfig.BinaryOutput)
+	result.Stderr = filter.SanitizeBinary(result.Stderr, cmdConfig.BinaryOutput)
+	return result, nil
 }
 
-// applyOutputFilter applies output filtering to execution result
-func applyOutputFilter(cmdConfig *config.CommandConfig, result *executor.ExecResult) *filter.FilteredOutput {
+// runSecurity holds the security settings of the current run. Commands may
+// only run in its allowed working directories, relative to the project root,
+// by default the project root and its subdirectories, and only run the
+// executables its command lists allow.
+var runSecurity *config.SecurityConfig
+
+// runProjectRoot is the project root of the current run, the directory of
+// its configuration
+var runProjectRoot = "."
+
+// runErrorExitCode is the exit code for quality errors of the current run,
+// from --error-exit-code or errorExitCode (0: the reporter default)
+var runErrorExitCode int
+
+// runNormalizePaths reports error locations with forward slashes, from
+// normalizePaths
+var runNormalizePaths bool
+
+// runRetryBudget caps the command retries of the current run, from
+// maxTotalRetries; nil leaves them unlimited
+var runRetryBudget *executor.RetryBudget
+
+// outputFilter extracts the reported lines from command output
+var outputFilter filter.Filter = filter.NewRegexFilter()
+
+// runJobs is the number of components run at once, from --jobs or
+// maxParallel (0: the number of CPUs)
+var runJobs int
+
+// liveWriter receives command output as it arrives when --live is set. It is
+// shared by all commands of a run so concurrent writes are never interleaved.
+var liveWriter *executor.StreamingWriter
+
+// applyOutputFilter applies output filtering to execution result, logging
+// the filtering to log (see openCommandLog)
+func applyOutputFilter(cmdConfig *config.CommandConfig, result *executor.ExecResult, log *debug.Logger) *filter.FilteredOutput {
 	// Check if we have any patterns to filter
-	if len(cmdConfig.ErrorPatterns) == 0 && len(cmdConfig.IncludePatterns) == 0 {
+	commandFilter := filter.ForCommand(cmdConfig, outputFilter)
+	if commandFilter == nil {
 		return nil
 	}
 
-	debug.LogSection("Output Filtering")
-	outputFilter := filter.NewSimpleOutputFilter()
+	log.LogSection("Output Filtering")
 
 	// Combine stdout and stderr for filtering
 	combinedOutput := result.Stdout
@@ -252,15 +602,26 @@ func applyOutputFilter(cmdConfig *config.CommandConfig, result *executor.ExecRes
 		combinedOutput += result.Stderr
 	}
 
-	filterStart := time.Now()
-	filteredOutput := outputFilter.FilterWithRules(combinedOutput, &filter.FilterRules{
-		ErrorPatterns:   cmdConfig.ErrorPatterns,
+	filterRules := &filter.FilterRules{
+		ErrorPatterns:   cmdConfig.DetectionPatterns(),
 		ContextPatterns: cmdConfig.IncludePatterns,
 		MaxLines:        cmdConfig.MaxOutput,
+		MaxBytes:        cmdConfig.MaxOutputBytes,
 		ContextLines:    cmdConfig.ContextLines,
-	})
-	debug.LogTiming("output filtering", time.Since(filterStart))
-	debug.LogFilterProcess(
+		BlockMode:       cmdConfig.BlockMode,
+		CaptureGroups:   cmdConfig.OutputTemplate != "",
+		Dedupe:          cmdConfig.Dedupe,
+	}
+	filterStart := time.Now()
+	filteredOutput, err := commandFilter.Filter(combinedOutput, filterRules)
+	log.LogTiming("output filtering", time.Since(filterStart))
+	filter.LogPatternStats(log, cmdConfig.Command, combinedOutput, filterRules)
+	if err != nil {
+		// Report the raw output rather than nothing
+		log.LogError(err, "filtering output")
+		return nil
+	}
+	log.LogFilterProcess(
 		strings.Count(combinedOutput, "\n")+1,
 		len(filteredOutput.Lines),
 		len(filteredOutput.Lines),
@@ -269,24 +630,91 @@ func applyOutputFilter(cmdConfig *config.CommandConfig, result *executor.ExecRes
 	return filteredOutput
 }
 
-// reportAndOutputResults reports execution results and outputs to stdout/stderr
-func reportAndOutputResults(results []executor.ComponentExecResult, start time.Time) {
-	debug.LogSection("Error Reporting")
+// newErrorReporter creates an error reporter configured from the run flags
+func newErrorReporter() *reporter.ErrorReporter {
 	errorReporter := reporter.NewErrorReporter()
-	report := errorReporter.Report(results)
+	if err := errorReporter.SetFormat(outputFormat); err != nil {
+		debug.LogError(err, "setting output format")
+	}
+	errorReporter.SetDedup(dedupErrors)
+	errorReporter.SetCompactJSON(compactJSON)
+	errorReporter.SetMaxTotalOutputBytes(maxTotalOutputBytes)
+	errorReporter.SetErrorExitCode(runErrorExitCode)
+	errorReporter.SetNormalizePaths(runNormalizePaths)
+	errorReporter.SetColor(colorOutput())
+	errorReporter.SetSummary(!quietFlag)
+	if maskWorkdir != "" {
+		if cwd, err := os.Getwd(); err == nil {
+			errorReporter.SetPathMask(cwd, maskWorkdir)
+		} else {
+			debug.LogError(err, "getting working directory for --mask-workdir")
+		}
+	}
+	return errorReporter
+}
+
+// colorOutput reports whether reports are colored
+func colorOutput() bool {
+	return ansi.Enabled(stdoutIsTerminal(), noColorFlag)
+}
+
+// reportAndOutputResults reports execution results and outputs to stdout/stderr.
+// When streaming, component errors were already written and only the summary remains.
+func reportAndOutputResults(results []executor.ComponentExecResult, start time.Time, stream *reporter.StreamReporter) {
+	debug.LogSection("Error Reporting")
+	var report *reporter.ReportResult
+	if stream != nil {
+		report = stream.Finish()
+	} else {
+		if pagerOutput {
+			results = stableOrder(results)
+		}
+		report = newErrorReporter().Report(results)
+	}
 
 	debug.Log("Exit code: %d", report.ExitCode)
 	debug.LogTiming("total execution", time.Since(start))
 
+	if reportFile != "" {
+		var err error
+		if report, err = writeReportFile(reportFile, report); err != nil {
+			_, _ = fmt.Fprintf(errorWriter, "[QUALHOOK] %v\n", err) //nolint:errcheck // Best effort output to stderr
+		}
+	}
+
 	// Output results
-	if report.Stdout != "" {
-		_, _ = fmt.Fprintln(outputWriter, report.Stdout) //nolint:errcheck // Best effort output to stdout
+	if pagerOutput {
+		pageReport(report)
+	} else {
+		writeReport(report)
 	}
-	if report.Stderr != "" {
-		_, _ = fmt.Fprintln(errorWriter, report.Stderr) //nolint:errcheck // Best effort output to stderr
+
+	if statusFile != "" {
+		if err := writeStatusFile(statusFile, results); err != nil {
+			_, _ = fmt.Fprintf(errorWriter, "[QUALHOOK] %v\n", err) //nolint:errcheck // Best effort output to stderr
+		}
 	}
 
 	if report.ExitCode != 0 {
 		osExit(report.ExitCode)
 	}
 }
+
+// writeReport writes the report to the output and error writers
+func writeReport(report *reporter.ReportResult) {
+	if stdout := reportStdout(report); stdout != "" {
+		_, _ = fmt.Fprintln(outputWriter, stdout) //nolint:errcheck // Best effort output to stdout
+	}
+	if report.Stderr != "" {
+		_, _ = fmt.Fprintln(errorWriter, report.Stderr) //nolint:errcheck // Best effort output to stderr
+	}
+}
+
+// reportStdout returns the standard output of the report, which --quiet
+// leaves out on success
+func reportStdout(report *reporter.ReportResult) string {
+	if quietFlag && report.ExitCode == 0 {
+		return ""
+	}
+	return report.Stdout
+}
@@ -3,10 +3,19 @@
 package main
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	"github.com/bebsworthy/qualhook/internal/watcher"
 	"github.com/bebsworthy/qualhook/pkg/config"
 )
 
@@ -191,3 +200,632 @@ func TestExecuteCommand_WithHookInput(t *testing.T) {
 	// Test would execute the command, but since executeCommand calls os.Exit,
 	// we can't easily test the full flow without refactoring
 }
+
+func TestParseHookInput_Stdin(t *testing.T) {
+	payload := `{
+		"session_id": "stdin-session",
+		"transcript_path": "/tmp/transcript",
+		"cwd": "/project",
+		"hook_event_name": "PostToolUse",
+		"tool_use": {"name": "Edit", "input": {"file_path": "frontend/src/app.js"}}
+	}`
+
+	oldStdin := hookStdin
+	hookStdin = func() io.Reader { return strings.NewReader(payload) }
+	defer func() { hookStdin = oldStdin }()
+
+	// Stdin takes precedence over the environment variable
+	t.Setenv("CLAUDE_HOOK_INPUT", `{"session_id": "env", "cwd": "/project", "hook_event_name": "PostToolUse"}`)
+
+	hookInput := parseHookInput()
+	if hookInput == nil || hookInput.SessionID != "stdin-session" {
+		t.Fatalf("expected the stdin hook input, got %+v", hookInput)
+	}
+
+	editedFiles := extractEditedFiles(hookInput)
+	if len(editedFiles) != 1 || editedFiles[0] != "frontend/src/app.js" {
+		t.Fatalf("unexpected edited files: %v", editedFiles)
+	}
+
+	cfg := &config.Config{
+		Version:  "1.0",
+		Commands: map[string]*config.CommandConfig{"lint": {Command: "echo", Args: []string{"root"}}},
+		Paths: []*config.PathConfig{
+			{Path: "frontend/**", Commands: map[string]*config.CommandConfig{"lint": {Command: "eslint"}}},
+			{Path: "backend/**", Commands: map[string]*config.CommandConfig{"lint": {Command: "golangci-lint"}}},
+		},
+	}
+	groups, err := mapComponentGroups(cfg, "lint", editedFiles)
+	if err != nil {
+		t.Fatalf("mapComponentGroups() error = %v", err)
+	}
+	if len(groups) != 1 || groups[0].Path != "frontend/**" || groups[0].Config["lint"].Command != "eslint" {
+		t.Errorf("expected the frontend component, got %+v", groups)
+	}
+
+	// Without stdin input the environment variable is used
+	hookStdin = func() io.Reader { return nil }
+	if hookInput := parseHookInput(); hookInput == nil || hookInput.SessionID != "env" {
+		t.Errorf("expected the environment hook input, got %+v", hookInput)
+	}
+}
+
+// timedWriter records when it first receives output
+type timedWriter struct {
+	mu         sync.Mutex
+	buf        bytes.Buffer
+	firstWrite time.Time
+}
+
+func (w *timedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.firstWrite.IsZero() {
+		w.firstWrite = time.Now()
+	}
+	return w.buf.Write(p)
+}
+
+func TestExecuteComponentsStreaming(t *testing.T) {
+	tempDir := t.TempDir()
+	fastDir := filepath.Join(tempDir, "fast")
+	slowDir := filepath.Join(tempDir, "slow")
+	for _, dir := range []string{fastDir, slowDir} {
+		if err := os.Mkdir(dir, 0750); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+
+	groups := []watcher.ComponentGroup{
+		{
+			Path: slowDir,
+			Config: map[string]*config.CommandConfig{
+				"lint": {Command: "sleep", Args: []string{"1"}, ExitCodes: []int{1}},
+			},
+		},
+		{
+			Path: fastDir,
+			Config: map[string]*config.CommandConfig{
+				"lint": {Command: "ls", Args: []string{"missing-file"}, ExitCodes: []int{1, 2}},
+			},
+		},
+	}
+
+	out := &timedWriter{}
+	stream := reporter.NewStreamReporter(reporter.NewErrorReporter(), out)
+
+	start := time.Now()
+	results := executeComponentsStreaming(groups, "lint", nil, stream)
+	elapsed := time.Since(start)
+
+	if len(results) != 2 || results[0].Path != slowDir || results[1].Path != fastDir {
+		t.Fatalf("expected results in group order, got %+v", results)
+	}
+	if elapsed < time.Second {
+		t.Fatalf("expected slow component to take at least 1s, took %v", elapsed)
+	}
+
+	out.mu.Lock()
+	firstWrite := out.firstWrite
+	output := out.buf.String()
+	out.mu.Unlock()
+
+	if firstWrite.IsZero() {
+		t.Fatal("expected the fast component's report to be written")
+	}
+	if firstWrite.Sub(start) >= time.Second {
+		t.Errorf("expected fast report before slow component finished, written after %v", firstWrite.Sub(start))
+	}
+	if !strings.Contains(output, "## "+fastDir) {
+		t.Errorf("expected fast component header in output, got:\n%s", output)
+	}
+	if strings.Contains(output, "## "+slowDir) {
+		t.Errorf("expected passing slow component to produce no report, got:\n%s", output)
+	}
+
+	final := stream.Finish()
+	if final.ExitCode != 2 {
+		t.Errorf("expected exit code 2, got %d", final.ExitCode)
+	}
+	if !strings.Contains(final.Stderr, "1 of 2 component(s) reported errors") {
+		t.Errorf("unexpected summary: %q", final.Stderr)
+	}
+}
+
+func TestExecuteComponentsStreaming_Jobs(t *testing.T) {
+	tempDir := t.TempDir()
+	var groups []watcher.ComponentGroup
+	for _, name := range []string{"a", "b", "c"} {
+		dir := filepath.Join(tempDir, name)
+		if err := os.Mkdir(dir, 0750); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+		groups = append(groups, watcher.ComponentGroup{
+			Path:   dir,
+			Config: map[string]*config.CommandConfig{"lint": {Command: "sleep", Args: []string{"0.3"}}},
+		})
+	}
+
+	runJobs = 1
+	defer func() { runJobs = 0 }()
+
+	start := time.Now()
+	results := executeComponentsStreaming(groups, "lint", nil, reporter.NewStreamReporter(reporter.NewErrorReporter(), io.Discard))
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("expected one component at a time with --jobs 1, took %v", elapsed)
+	}
+}
+
+func TestRunComponentGroups_Jobs(t *testing.T) {
+	tempDir := t.TempDir()
+	running := filepath.Join(tempDir, "running")
+	if err := os.Mkdir(running, 0750); err != nil {
+		t.Fatal(err)
+	}
+	// Each component records how many components run alongside it
+	script := filepath.Join(tempDir, "count.sh")
+	counts := filepath.Join(tempDir, "counts")
+	data := "touch " + running + "/$$\nls " + running + " | wc -l >> " + counts + "\nsleep 0.2\nrm " + running + "/$$\n"
+	if err := os.WriteFile(script, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var groups []watcher.ComponentGroup
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		dir := filepath.Join(tempDir, name)
+		if err := os.Mkdir(dir, 0750); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+		groups = append(groups, watcher.ComponentGroup{
+			Path:   dir,
+			Config: map[string]*config.CommandConfig{"lint": {Command: "sh", Args: []string{script}}},
+		})
+	}
+
+	runJobs = 2
+	defer func() { runJobs = 0 }()
+
+	results, err := runComponentGroups(groups, "lint", nil, nil)
+	if err != nil || len(results) != len(groups) {
+		t.Fatalf("runComponentGroups() = %d results, %v", len(results), err)
+	}
+	for i, result := range results {
+		if result.Path != groups[i].Path {
+			t.Errorf("expected results in group order, got %s at %d", result.Path, i)
+		}
+	}
+
+	output, err := os.ReadFile(counts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	most := 0
+	for _, field := range strings.Fields(string(output)) {
+		if n, err := strconv.Atoi(field); err == nil && n > most {
+			most = n
+		}
+	}
+	if most != 2 {
+		t.Errorf("expected 2 components at once with --jobs 2, got %d:\n%s", most, output)
+	}
+}
+
+func TestRunVerifyCommands(t *testing.T) {
+	lintScript := filepath.Join(t.TempDir(), "lint.sh")
+	if err := os.WriteFile(lintScript, []byte("echo 'src/app.js:3: error: unused variable'\nexit 1\n"), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	newConfig := func(formatExit string) *config.Config {
+		return &config.Config{
+			Version: "1.0",
+			Commands: map[string]*config.CommandConfig{
+				"format": {
+					Command:    "sh",
+					Args:       []string{"-c", "exit " + formatExit},
+					VerifyWith: []string{"lint"},
+				},
+				"lint": {
+					Command:       "sh",
+					Args:          []string{lintScript},
+					ExitCodes:     []int{1},
+					ErrorPatterns: []*config.RegexPattern{{Pattern: "error"}},
+				},
+			},
+		}
+	}
+
+	t.Run("successful fix runs verification", func(t *testing.T) {
+		cfg := newConfig("0")
+		fixResults, err := runCommandResults(cfg, "format", nil, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		verifyResults, err := runVerifyCommands(cfg, cfg.Commands["format"], fixResults, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(verifyResults) != 1 || verifyResults[0].Command != "lint" {
+			t.Fatalf("expected lint to run after format, got %+v", verifyResults)
+		}
+
+		report := reporter.NewErrorReporter().Report(append(fixResults, verifyResults...))
+		if report.ExitCode != 2 {
+			t.Errorf("expected combined exit code 2, got %d", report.ExitCode)
+		}
+		if !strings.Contains(report.Stderr, "unused variable") {
+			t.Errorf("expected lint errors in combined report, got:\n%s", report.Stderr)
+		}
+	})
+
+	t.Run("failed fix skips verification", func(t *testing.T) {
+		cfg := newConfig("1")
+		fixResults, err := runCommandResults(cfg, "format", nil, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		verifyResults, err := runVerifyCommands(cfg, cfg.Commands["format"], fixResults, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(verifyResults) != 0 {
+			t.Errorf("expected no verification after a failed fix, got %+v", verifyResults)
+		}
+	})
+
+	t.Run("passing verification keeps the run green", func(t *testing.T) {
+		cfg := newConfig("0")
+		cfg.Commands["lint"].Args = []string{"-c", "echo ok"}
+		fixResults, err := runCommandResults(cfg, "format", nil, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		verifyResults, err := runVerifyCommands(cfg, cfg.Commands["format"], fixResults, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report := reporter.NewErrorReporter().Report(append(fixResults, verifyResults...)); report.ExitCode != 0 {
+			t.Errorf("expected exit code 0, got %d: %s", report.ExitCode, report.Stderr)
+		}
+	})
+
+	t.Run("failed fix with continueOnError runs verification", func(t *testing.T) {
+		cfg := newConfig("1")
+		cfg.Commands["format"].ContinueOnError = true
+		fixResults, err := runCommandResults(cfg, "format", nil, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		verifyResults, err := runVerifyCommands(cfg, cfg.Commands["format"], fixResults, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(verifyResults) != 1 || verifyResults[0].Command != "lint" {
+			t.Errorf("expected lint to run after the failed fix, got %+v", verifyResults)
+		}
+	})
+}
+
+func TestRunVerifyCommands_ContinueOnError(t *testing.T) {
+	// check runs typecheck, then test, then docs; typecheck always fails
+	newConfig := func(continueOnError bool) *config.Config {
+		return &config.Config{
+			Version: "1.0",
+			Commands: map[string]*config.CommandConfig{
+				"check":     {Command: "true", VerifyWith: []string{"typecheck", "test", "docs"}},
+				"typecheck": {Command: "false", ContinueOnError: continueOnError},
+				"test":      {Command: "false", ContinueOnError: true},
+				"docs":      {Command: "true"},
+			},
+		}
+	}
+	ranCommands := func(results []executor.ComponentExecResult) []string {
+		var names []string
+		for _, result := range results {
+			names = append(names, result.Command)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name            string
+		continueOnError bool
+		want            []string
+	}{
+		{"failure stops the sequence", false, []string{"typecheck"}},
+		{"continueOnError continues the sequence", true, []string{"typecheck", "test", "docs"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig(tt.continueOnError)
+			fixResults, err := runCommandResults(cfg, "check", nil, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			verifyResults, err := runVerifyCommands(cfg, cfg.Commands["check"], fixResults, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := ranCommands(verifyResults); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v to run, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExecuteWithOptions_LiveOutput(t *testing.T) {
+	var live bytes.Buffer
+	liveWriter = executor.NewStreamingWriter(&live)
+	defer func() { liveWriter = nil }()
+
+	result, err := executeWithOptions(&config.CommandConfig{Command: "echo"}, []string{"live progress"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if live.String() != "live progress\n" {
+		t.Errorf("expected output to be streamed, got %q", live.String())
+	}
+	if result.Stdout != "live progress\n" {
+		t.Errorf("expected output to still be collected, got %q", result.Stdout)
+	}
+}
+
+func TestExecuteWithOptions_WorkingDir(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := filepath.Join(dir, "server")
+	if err := os.Mkdir(server, 0750); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, dir)
+	runSecurity = &config.SecurityConfig{}
+	defer func() { runSecurity = nil }()
+
+	// The command directory wins over the component one
+	result, err := executeWithOptions(&config.CommandConfig{Command: "pwd", WorkingDir: server}, nil, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.TrimSpace(result.Stdout) != server {
+		t.Errorf("expected the command to run in %s, got %q", server, result.Stdout)
+	}
+
+	// It is still subject to the working directory checks
+	if _, err := executeWithOptions(&config.CommandConfig{Command: "pwd", WorkingDir: filepath.Dir(dir)}, nil, dir); err == nil {
+		t.Error("expected a directory outside the project to be rejected")
+	}
+}
+
+func TestRun_WorkingDirFromSubdirectory(t *testing.T) {
+	dir := t.TempDir()
+	// Configuration is searched up to the repository root
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"version": "1.0", "security": {}, "commands": {"lint": {"command": "echo", "args": ["ok"], "workingDir": "."}}}`
+	if err := os.WriteFile(filepath.Join(dir, ".qualhook.json"), []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, filepath.Join(dir, "sub"))
+
+	root := newRootCmd()
+	var stdout, stderr bytes.Buffer
+	oldOut, oldErr, oldExit := outputWriter, errorWriter, osExit
+	exitCode := 0
+	outputWriter, errorWriter = &stdout, &stderr
+	osExit = func(code int) { exitCode = code }
+	defer func() { outputWriter, errorWriter, osExit = oldOut, oldErr, oldExit }()
+
+	// The working directory is allowed relative to the directory of the
+	// configuration, not the directory qualhook runs from
+	root.SetArgs([]string{"lint"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("expected exit code 0, got %d:\n%s", exitCode, stderr.String())
+	}
+}
+
+func TestExecuteWithOptions_CommandLists(t *testing.T) {
+	runSecurity = &config.SecurityConfig{AllowedCommands: []string{"echo", "pwd"}, DeniedCommands: []string{"pwd"}}
+	defer func() { runSecurity = nil }()
+
+	if _, err := executeWithOptions(&config.CommandConfig{Command: "echo"}, []string{"ok"}, ""); err != nil {
+		t.Errorf("expected an allowed command to run, got %v", err)
+	}
+	tests := map[string]string{"ls": "not in the allowed command list", "pwd": "in the denied command list"}
+	for command, want := range tests {
+		_, err := executeWithOptions(&config.CommandConfig{Command: command}, nil, "")
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %s to be rejected with %q, got %v", command, want, err)
+		}
+	}
+
+	// The reporter explains a rejected command
+	cfg := &config.Config{Commands: map[string]*config.CommandConfig{"lint": {Command: "ls"}}}
+	results, err := runCommandResults(cfg, "lint", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("expected the rejection as a result, got %v", err)
+	}
+	report := newErrorReporter().Report(results)
+	if report.ExitCode != 1 || !strings.Contains(report.Stderr, "Command not allowed") {
+		t.Errorf("expected a command not allowed error, got exit code %d:\n%s", report.ExitCode, report.Stderr)
+	}
+}
+
+func TestExecuteWithOptions_Retries(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "flaky.sh")
+	// Fails on its first two runs, printing the attempt number
+	content := "#!/bin/sh\necho run >> \"$0.count\"\nn=$(wc -l < \"$0.count\")\necho \"attempt $n\"\n[ \"$n\" -ge 3 ]\n"
+	if err := os.WriteFile(script, []byte(content), 0755); err != nil { // #nosec G306 - test script must be executable
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	cmdConfig := &config.CommandConfig{Command: script, Retries: 2, RetryDelay: 1}
+	result, err := executeWithOptions(cmdConfig, nil, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ExitCode != 0 {
+		t.Errorf("expected the third attempt to succeed, got exit code %d", result.ExitCode)
+	}
+	if result.Stdout != "attempt 3\n" {
+		t.Errorf("expected only the final attempt's output, got %q", result.Stdout)
+	}
+}
+
+func TestNewErrorReporter_MaskWorkdir(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+
+	oldMask := maskWorkdir
+	maskWorkdir = "$WORKSPACE"
+	defer func() { maskWorkdir = oldMask }()
+
+	results := []executor.ComponentExecResult{{
+		Command:       "lint",
+		CommandConfig: &config.CommandConfig{Command: "eslint"},
+		ExecResult:    &executor.ExecResult{ExitCode: 1, Stderr: filepath.Join(cwd, "src", "a.js") + ":1:1: error"},
+	}}
+
+	report := newErrorReporter().Report(results)
+	if strings.Contains(report.Stderr, cwd) || !strings.Contains(report.Stderr, "$WORKSPACE/src/a.js:1:1: error") {
+		t.Errorf("expected the working directory to be masked, got:\n%s", report.Stderr)
+	}
+}
+
+func TestExecuteWithOptions_Env(t *testing.T) {
+	t.Setenv("NODE_ENV", "development")
+
+	cmdConfig := &config.CommandConfig{Command: "printenv", Env: map[string]string{"NODE_ENV": "test"}}
+	result, err := executeWithOptions(cmdConfig, []string{"NODE_ENV"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.TrimSpace(result.Stdout); got != "test" {
+		t.Errorf("expected the configured env to override the inherited value, got %q", got)
+	}
+}
+
+func TestColorOutput(t *testing.T) {
+	oldTerminal, oldNoColor := stdoutIsTerminal, noColorFlag
+	defer func() { stdoutIsTerminal, noColorFlag = oldTerminal, oldNoColor }()
+	t.Setenv("NO_COLOR", "")
+
+	stdoutIsTerminal = func() bool { return true }
+	if !colorOutput() {
+		t.Error("expected color on a terminal")
+	}
+	noColorFlag = true
+	if colorOutput() {
+		t.Error("expected --no-color to disable color")
+	}
+	noColorFlag = false
+	t.Setenv("NO_COLOR", "1")
+	if colorOutput() {
+		t.Error("expected NO_COLOR to disable color")
+	}
+	t.Setenv("NO_COLOR", "")
+	stdoutIsTerminal = func() bool { return false }
+	if colorOutput() {
+		t.Error("expected no color when stdout is not a terminal")
+	}
+}
+
+func TestExecuteComponentsStreaming_Timeline(t *testing.T) {
+	tempDir := t.TempDir()
+	fastDir := filepath.Join(tempDir, "fast")
+	slowDir := filepath.Join(tempDir, "slow")
+	for _, dir := range []string{fastDir, slowDir} {
+		if err := os.Mkdir(dir, 0750); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+
+	groups := []watcher.ComponentGroup{
+		{Path: slowDir, Config: map[string]*config.CommandConfig{"lint": {Command: "sleep", Args: []string{"0.3"}}}},
+		{Path: fastDir, Config: map[string]*config.CommandConfig{"lint": {Command: "true"}}},
+	}
+
+	errorReporter := reporter.NewErrorReporter()
+	if err := errorReporter.SetFormat(reporter.FormatTimeline); err != nil {
+		t.Fatal(err)
+	}
+	stream := reporter.NewStreamReporter(errorReporter, &bytes.Buffer{})
+	results := executeComponentsStreaming(groups, "lint", nil, stream)
+
+	// Results are in group order; the timeline follows completion
+	var finished []string
+	for _, event := range errorReporter.Timeline(results) {
+		if event.Finished {
+			finished = append(finished, event.Component)
+			if event.Component == slowDir && event.Duration < 300*time.Millisecond {
+				t.Errorf("expected the slow component to take at least 300ms, got %v", event.Duration)
+			}
+		}
+	}
+	if len(finished) != 2 || finished[0] != fastDir || finished[1] != slowDir {
+		t.Errorf("expected finish events in completion order, got %v", finished)
+	}
+
+	final := stream.Finish()
+	if !strings.HasPrefix(final.Stdout, "Timeline:\n") {
+		t.Errorf("expected the timeline in the final report, got:\n%s", final.Stdout)
+	}
+}
+
+func TestWriteReport_Quiet(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	oldOut, oldErr := outputWriter, errorWriter
+	outputWriter, errorWriter = &stdout, &stderr
+	quietFlag = true
+	defer func() { outputWriter, errorWriter, quietFlag = oldOut, oldErr, false }()
+
+	writeReport(&reporter.ReportResult{Stdout: "All quality checks passed successfully."})
+	if stdout.Len() != 0 || stderr.Len() != 0 {
+		t.Errorf("expected nothing on success, got %q and %q", stdout.String(), stderr.String())
+	}
+
+	// Failures are reported as usual, including the JSON report on stdout
+	writeReport(&reporter.ReportResult{Stdout: `{"passed":false}`, Stderr: "errors", ExitCode: 2})
+	if stdout.String() != "{\"passed\":false}\n" || stderr.String() != "errors\n" {
+		t.Errorf("expected the failure report, got %q and %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestNewErrorReporter_Summary(t *testing.T) {
+	failing := &executor.ExecResult{ExitCode: 1, Stderr: "error"}
+	results := []executor.ComponentExecResult{
+		{Command: "lint", Path: "web", ExecResult: failing},
+		{Command: "lint", Path: "api", ExecResult: &executor.ExecResult{ExitCode: 0}},
+	}
+
+	if report := newErrorReporter().Report(results); !strings.HasSuffix(report.Stderr, "1 of 2 components failed (lint: 1)") {
+		t.Errorf("expected a summary line, got:\n%s", report.Stderr)
+	}
+
+	quietFlag = true
+	defer func() { quietFlag = false }()
+	if report := newErrorReporter().Report(results); strings.Contains(report.Stderr, "components failed") {
+		t.Errorf("expected --quiet to leave out the summary, got:\n%s", report.Stderr)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// unsafeLogNameChars matches characters replaced in log file names
+var unsafeLogNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// prepareLogDir creates the --log-dir directory, if set
+func prepareLogDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	return nil
+}
+
+// openCommandLog opens the --log-dir log file for a component's command.
+// Entries are appended, so retries and repeated runs share the file. The
+// returned logger is nil, and only writes to the global debug log, when
+// --log-dir is not set or the file cannot be opened.
+func openCommandLog(componentPath, commandName string) (*debug.Logger, func()) {
+	if logDir == "" {
+		return nil, func() {}
+	}
+
+	path := filepath.Join(logDir, commandLogName(componentPath, commandName))
+	// #nosec G304 - path is built from the user-provided --log-dir
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		debug.LogError(err, "opening command log")
+		return nil, func() {}
+	}
+
+	log := debug.NewLogger(file)
+	return log, func() { _ = file.Close() } //nolint:errcheck // Best effort cleanup
+}
+
+// commandLogName returns the log file name for a component's command, e.g.
+// packages_frontend.lint.log for the packages/frontend/** component
+func commandLogName(componentPath, commandName string) string {
+	component := filepath.ToSlash(componentPath)
+	component = strings.TrimSuffix(strings.TrimSuffix(component, "/**"), "/*")
+	component = strings.Trim(unsafeLogNameChars.ReplaceAllString(component, "_"), "_.")
+	if component == "" {
+		component = "root"
+	}
+	command := strings.Trim(unsafeLogNameChars.ReplaceAllString(commandName, "_"), "_.")
+	return component + "." + command + ".log"
+}
+
+// logExecResult logs the outcome of a command execution
+func logExecResult(log *debug.Logger, result *executor.ExecResult) {
+	log.Log("Exit code: %d (timed out: %v)", result.ExitCode, result.TimedOut)
+	log.Log("Output: %d bytes stdout, %d bytes stderr", len(result.Stdout), len(result.Stderr))
+	if result.Error != nil {
+		log.LogError(result.Error, "command execution")
+	}
+}
@@ -0,0 +1,84 @@
+//go:build unit
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/watcher"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestCommandLogName(t *testing.T) {
+	tests := []struct {
+		component, command, want string
+	}{
+		{"", "lint", "root.lint.log"},
+		{".", "lint", "root.lint.log"},
+		{"packages/frontend/**", "lint", "packages_frontend.lint.log"},
+		{"api/*", "deploy:api", "api.deploy_api.log"},
+	}
+	for _, tt := range tests {
+		if got := commandLogName(tt.component, tt.command); got != tt.want {
+			t.Errorf("commandLogName(%q, %q) = %q, want %q", tt.component, tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestRunComponentGroups_LogDir(t *testing.T) {
+	tempDir := t.TempDir()
+	webDir := filepath.Join(tempDir, "web")
+	apiDir := filepath.Join(tempDir, "api")
+	for _, dir := range []string{webDir, apiDir} {
+		if err := os.Mkdir(dir, 0750); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+
+	oldLogDir := logDir
+	logDir = filepath.Join(tempDir, "logs")
+	defer func() { logDir = oldLogDir }()
+	if err := prepareLogDir(logDir); err != nil {
+		t.Fatalf("prepareLogDir() error = %v", err)
+	}
+
+	groups := []watcher.ComponentGroup{
+		{Path: webDir, Config: map[string]*config.CommandConfig{"lint": {Command: "echo", Args: []string{"web-lint"}}}},
+		{Path: apiDir, Config: map[string]*config.CommandConfig{"lint": {Command: "echo", Args: []string{"api-lint"}}}},
+		// Components without the command are not executed and get no log
+		{Path: filepath.Join(tempDir, "docs"), Config: map[string]*config.CommandConfig{}},
+	}
+
+	results, err := runComponentGroups(groups, "lint", nil, nil)
+	if err != nil || len(results) != 2 {
+		t.Fatalf("runComponentGroups() = %d results, %v", len(results), err)
+	}
+
+	entries, err := os.ReadDir(logDir)
+	if err != nil {
+		t.Fatalf("failed to read log dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected one log per executed component, got %d", len(entries))
+	}
+
+	for dir, want := range map[string]string{webDir: "web-lint", apiDir: "api-lint"} {
+		path := filepath.Join(logDir, commandLogName(dir, "lint"))
+		data, err := os.ReadFile(path) // #nosec G304 - test-controlled path
+		if err != nil {
+			t.Fatalf("expected log file for %s: %v", dir, err)
+		}
+		log := string(data)
+		if !strings.Contains(log, "Working Directory: "+dir) || !strings.Contains(log, want) || !strings.Contains(log, "Exit code: 0") {
+			t.Errorf("log for %s missing its entries:\n%s", dir, log)
+		}
+		for other := range map[string]bool{webDir: true, apiDir: true} {
+			if other != dir && strings.Contains(log, other) {
+				t.Errorf("log for %s contains entries of %s:\n%s", dir, other, log)
+			}
+		}
+	}
+}
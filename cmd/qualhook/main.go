@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/bebsworthy/qualhook/internal/config"
 	"github.com/bebsworthy/qualhook/internal/debug"
@@ -17,8 +18,17 @@ var Version = "dev"
 
 // Global flags
 var (
-	debugFlag  bool
-	configPath string
+	debugFlag       bool
+	configPath      string
+	compactJSON     bool
+	timeoutOverride time.Duration
+	dryRun          bool
+	lockedConfig    bool
+	profileName     string
+	noConfigCache   bool
+	jobsFlag        int
+	quietFlag       bool
+	noColorFlag     bool
 )
 
 // newRootCmd creates and returns the root command
@@ -85,11 +95,21 @@ For more information, see: https://github.com/bebsworthy/qualhook`,
 
   # CI/CD integration
   qualhook lint || exit 2`,
+		RunE: runDefaultCommand,
 	}
 
 	// Global flags
 	cmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "Enable debug output")
 	cmd.PersistentFlags().StringVar(&configPath, "config", "", "Path to configuration file")
+	cmd.PersistentFlags().BoolVar(&compactJSON, "compact", false, "Write JSON output as minified single-line JSON")
+	cmd.PersistentFlags().DurationVar(&timeoutOverride, "timeout", 0, "Override every command's timeout for this run, e.g. 120s (0 uses the config)")
+	cmd.PersistentFlags().BoolVar(&lockedConfig, "locked", false, "Use the configuration lockfile and fail if the config no longer resolves to it")
+	cmd.PersistentFlags().StringVar(&profileName, "profile", "", "Configuration profile to merge over the commands (default: $QUALHOOK_PROFILE)")
+	cmd.PersistentFlags().BoolVar(&noConfigCache, "no-config-cache", false, "Parse and validate the configuration instead of reusing the cached result")
+	cmd.PersistentFlags().IntVar(&jobsFlag, "jobs", 0, "Number of components to run at once, overriding maxParallel (default: the number of CPUs)")
+	cmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Print nothing on success and no summary line after failures; errors and exit codes are unchanged")
+	cmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable colored output (also disabled by NO_COLOR and when stdout is not a terminal)")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the commands a run would execute, per component, without executing them")
 
 	// Disable the default completion command
 	cmd.CompletionOptions.DisableDefaultCmd = true
@@ -107,6 +127,10 @@ For more information, see: https://github.com/bebsworthy/qualhook`,
 	cmd.AddCommand(templateCmd)
 	cmd.AddCommand(completionCmd)
 	cmd.AddCommand(manCmd)
+	cmd.AddCommand(selftestCmd)
+	cmd.AddCommand(doctorCmd)
+	cmd.AddCommand(reportCmd)
+	cmd.AddCommand(runCmd)
 
 	return cmd
 }
@@ -123,27 +147,14 @@ func main() {
 		}
 	}
 
-	// Check if the first argument might be a custom command
-	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
-		// Check if it's a known command
-		cmdName := os.Args[1]
-		knownCommands := []string{"format", "lint", "typecheck", "test", "config", "ai-config", "template", "help", "completion", "man"}
-		isKnown := false
-		for _, known := range knownCommands {
-			if cmdName == known {
-				isKnown = true
-				break
-			}
-		}
-
-		// If not a known command, try to execute as custom command
-		if !isKnown {
-			// Extract global flags first
-			parseGlobalFlags()
+	// Register the standard commands of the configuration as subcommands
+	parseGlobalFlags()
+	registerConfiguredCommands(rootCmd)
 
-			if err := tryCustomCommand(cmdName, extractNonFlagArgs(os.Args[2:])); err == nil {
-				return
-			}
+	// Other commands may be custom commands from the configuration
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") && !isSubcommand(rootCmd, os.Args[1]) {
+		if err := tryCustomCommand(os.Args[1], extractNonFlagArgs(os.Args[2:])); err == nil {
+			return
 		}
 	}
 
@@ -153,17 +164,52 @@ func main() {
 	}
 }
 
+// runDefaultCommand runs the configured defaultCommand when qualhook is
+// invoked without a subcommand, and shows help otherwise
+func runDefaultCommand(cmd *cobra.Command, args []string) error {
+	cfg, err := loadRunConfig()
+	if err != nil || cfg.DefaultCommand == "" {
+		if err != nil {
+			debug.Log("No default command: %v", err)
+		}
+		return cmd.Help()
+	}
+
+	debug.Log("Running default command: %s", cfg.DefaultCommand)
+	return createRunFunc(cfg.DefaultCommand)(cmd, args)
+}
+
 // parseGlobalFlags extracts global flags from command line
 func parseGlobalFlags() {
 	for i := 1; i < len(os.Args); i++ {
 		switch os.Args[i] {
 		case "--debug":
 			debugFlag = true
+		case "--dry-run":
+			dryRun = true
+		case "--locked":
+			lockedConfig = true
+		case "--no-config-cache":
+			noConfigCache = true
+		case "--no-color":
+			noColorFlag = true
 		case "--config":
 			if i+1 < len(os.Args) && !strings.HasPrefix(os.Args[i+1], "-") {
 				configPath = os.Args[i+1]
 				i++
 			}
+		case "--profile":
+			if i+1 < len(os.Args) && !strings.HasPrefix(os.Args[i+1], "-") {
+				profileName = os.Args[i+1]
+				i++
+			}
+		case "--timeout":
+			if i+1 < len(os.Args) {
+				if timeout, err := time.ParseDuration(os.Args[i+1]); err == nil {
+					timeoutOverride = timeout
+				}
+				i++
+			}
 		}
 	}
 }
@@ -172,8 +218,8 @@ func parseGlobalFlags() {
 func extractNonFlagArgs(args []string) []string {
 	var result []string
 	for i := 0; i < len(args); i++ {
-		if args[i] == "--config" && i+1 < len(args) {
-			i++ // Skip the config value
+		if (args[i] == "--config" || args[i] == "--timeout" || args[i] == "--profile") && i+1 < len(args) {
+			i++ // Skip the flag value
 			continue
 		}
 		if !strings.HasPrefix(args[i], "-") {
@@ -183,30 +229,30 @@ func extractNonFlagArgs(args []string) []string {
 	return result
 }
 
-// tryCustomCommand attempts to execute a custom command from configuration
+// tryCustomCommand attempts to execute a custom command from configuration.
+// It runs like the standard commands, with the same security and run settings.
 func tryCustomCommand(cmdName string, args []string) error {
-	loader := config.NewLoader()
-	var cfg *pkgconfig.Config
-	var err error
-
-	if configPath != "" {
-		cfg, err = loader.LoadFromPath(configPath)
-	} else {
-		cwd, errWd := os.Getwd()
-		if errWd != nil {
-			return fmt.Errorf("failed to get working directory: %w", errWd)
-		}
-		cfg, err = loader.LoadForMonorepo(cwd)
-	}
-
+	cfg, err := loadRunConfig()
 	if err != nil {
 		return err
 	}
 
 	// Check if this is a configured command
 	if _, exists := cfg.Commands[cmdName]; exists {
-		return executeCommand(cfg, cmdName, args)
+		return createRunFunc(cmdName)(nil, args)
 	}
 
 	return fmt.Errorf("unknown command %q", cmdName)
 }
+
+// marshalConfigFile serializes a configuration in the format of path: YAML
+// for .yaml/.yml files, otherwise JSON honoring the --compact flag
+func marshalConfigFile(cfg *pkgconfig.Config, path string) ([]byte, error) {
+	if config.IsYAMLFile(path) {
+		return pkgconfig.SaveConfigYAML(cfg)
+	}
+	if compactJSON {
+		return pkgconfig.SaveConfigCompact(cfg)
+	}
+	return pkgconfig.SaveConfig(cfg)
+}
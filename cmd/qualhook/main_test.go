@@ -112,6 +112,11 @@ func TestExtractNonFlagArgs(t *testing.T) {
 			args:     []string{"--config", "test.json", "arg1"},
 			expected: []string{"arg1"},
 		},
+		{
+			name:     "timeout flag with value",
+			args:     []string{"--timeout", "120s", "arg1"},
+			expected: []string{"arg1"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -200,6 +205,73 @@ func TestTryCustomCommand(t *testing.T) {
 	}
 }
 
+func TestTryCustomCommand_RunSettings(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          *config.Config
+		wantExitCode int
+	}{
+		{
+			name: "denied command is blocked",
+			cfg: &config.Config{
+				Version:  "1.0",
+				Security: &config.SecurityConfig{DeniedCommands: []string{"echo"}},
+				Commands: map[string]*config.CommandConfig{
+					"mycheck": {Command: "echo", Args: []string{"checked"}},
+				},
+			},
+			wantExitCode: 1,
+		},
+		{
+			name: "errorExitCode is honored",
+			cfg: &config.Config{
+				Version:       "1.0",
+				ErrorExitCode: 3,
+				Commands: map[string]*config.CommandConfig{
+					"mycheck": {
+						Command:       "echo",
+						Args:          []string{"error: check failed"},
+						ExitCodes:     []int{0},
+						ErrorPatterns: []*config.RegexPattern{{Pattern: "error:"}},
+					},
+				},
+			},
+			wantExitCode: 3,
+		},
+	}
+
+	oldDir, _ := os.Getwd()
+	defer os.Chdir(oldDir)
+	oldConfigPath := configPath
+	configPath = ""
+	defer func() { configPath = oldConfigPath }()
+	oldExit, oldOutput, oldError := osExit, outputWriter, errorWriter
+	defer func() { osExit, outputWriter, errorWriter = oldExit, oldOutput, oldError }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			configData, _ := json.Marshal(tt.cfg)
+			if err := os.WriteFile(filepath.Join(tempDir, ".qualhook.json"), configData, 0644); err != nil {
+				t.Fatal(err)
+			}
+			os.Chdir(tempDir)
+
+			exitCode := 0
+			osExit = func(code int) { exitCode = code }
+			var stdout, stderr bytes.Buffer
+			outputWriter, errorWriter = &stdout, &stderr
+
+			if err := tryCustomCommand("mycheck", nil); err != nil {
+				t.Fatalf("tryCustomCommand() error = %v", err)
+			}
+			if exitCode != tt.wantExitCode {
+				t.Errorf("exit code = %d, want %d\nstdout: %s\nstderr: %s", exitCode, tt.wantExitCode, stdout.String(), stderr.String())
+			}
+		})
+	}
+}
+
 func TestRootCommand(t *testing.T) {
 	tests := []struct {
 		name        string
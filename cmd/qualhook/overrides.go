@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	internalconfig "github.com/bebsworthy/qualhook/internal/config"
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/security"
+	"github.com/bebsworthy/qualhook/pkg/config"
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// Per-run override flags shared by the quality commands
+var (
+	errorPatternOverrides []string
+	outputFormat          string
+	dedupErrors           bool
+	commandOverrides      []string
+	watchPaths            []string
+	streamReport          bool
+	pickComponents        bool
+	repeatRuns            int
+	repeatParallel        bool
+	statusFile            string
+	liveOutput            bool
+	retryRuns             int
+	logDir                string
+	maskWorkdir           string
+	maxTotalOutputBytes   int
+	errorExitCode         int
+	pathPrepend           []string
+	targetFiles           []string
+	sinceRef              string
+	pagerOutput           bool
+	watchMode             bool
+	reportFile            string
+	commandTag            string
+	captureDir            string
+)
+
+// applyErrorPatternOverrides appends CLI-supplied error patterns to every
+// configuration of the named command, including path-specific ones.
+// The loaded config is modified in memory only; nothing is written to disk.
+func applyErrorPatternOverrides(cfg *config.Config, commandName string, patterns []string) error {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	validator := security.NewSecurityValidator()
+	extra := make([]*config.RegexPattern, 0, len(patterns))
+	for _, p := range patterns {
+		pattern := &config.RegexPattern{Pattern: p}
+		if err := pattern.Validate(); err != nil {
+			return fmt.Errorf("invalid --error-pattern %q: %w", p, err)
+		}
+		if err := validator.ValidateRegexPattern(p); err != nil {
+			return fmt.Errorf("invalid --error-pattern %q: %w", p, err)
+		}
+		extra = append(extra, pattern)
+	}
+
+	for _, cmd := range commandConfigs(cfg, commandName) {
+		cmd.ErrorPatterns = append(cmd.ErrorPatterns, extra...)
+	}
+
+	return nil
+}
+
+// validateWatchPaths checks that every --watch-paths value is a valid glob
+func validateWatchPaths(patterns []string) error {
+	for _, p := range patterns {
+		if !doublestar.ValidatePattern(filepath.ToSlash(p)) {
+			return fmt.Errorf("invalid --watch-paths %q", p)
+		}
+	}
+	return nil
+}
+
+// applyTimeoutOverride sets the --timeout value on every command of the
+// configuration, root and path-specific. A zero timeout keeps the config values.
+func applyTimeoutOverride(cfg *config.Config, timeout time.Duration) error {
+	if timeout == 0 {
+		return nil
+	}
+	if err := security.NewSecurityValidator().ValidateTimeout(timeout); err != nil {
+		return fmt.Errorf("invalid --timeout %v: %w", timeout, err)
+	}
+
+	ms := int(timeout / time.Millisecond)
+	for _, cmd := range cfg.Commands {
+		if cmd != nil {
+			cmd.Timeout = ms
+		}
+	}
+	for _, pathCfg := range cfg.Paths {
+		for _, cmd := range pathCfg.Commands {
+			if cmd != nil {
+				cmd.Timeout = ms
+			}
+		}
+	}
+
+	debug.Log("Overriding command timeouts with %v", timeout)
+	return nil
+}
+
+// applyCommandOverrides applies "<command>.<field>=<value>" overrides to every
+// configuration of the named command. Supported fields are command, args
+// (comma-separated) and timeout (milliseconds or a duration such as 30s).
+// The overridden commands are validated afterwards; the config is only
+// modified in memory.
+func applyCommandOverrides(cfg *config.Config, overrides []string) error {
+	for _, override := range overrides {
+		key, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return fmt.Errorf("invalid --command-override %q: expected <command>.<field>=<value>", override)
+		}
+		commandName, field, ok := strings.Cut(key, ".")
+		if !ok || commandName == "" || field == "" {
+			return fmt.Errorf("invalid --command-override %q: expected <command>.<field>=<value>", override)
+		}
+
+		targets := commandConfigs(cfg, commandName)
+		if len(targets) == 0 {
+			return fmt.Errorf("invalid --command-override %q: command %q is not configured", override, commandName)
+		}
+
+		apply, err := overrideSetter(field, value)
+		if err != nil {
+			return fmt.Errorf("invalid --command-override %q: %w", override, err)
+		}
+		for _, cmd := range targets {
+			apply(cmd)
+		}
+	}
+
+	// Validate the overridden commands; existence is left to execution
+	validator := internalconfig.NewValidator()
+	validator.CheckCommands = false
+	for _, override := range overrides {
+		commandName, _, _ := strings.Cut(override, ".")
+		for _, cmd := range commandConfigs(cfg, commandName) {
+			if err := cmd.Validate(); err != nil {
+				return fmt.Errorf("command %q after override: %w", commandName, err)
+			}
+			if err := validator.ValidateCommand(cmd); err != nil {
+				return fmt.Errorf("command %q after override: %w", commandName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// overrideSetter returns a function applying a single field override
+func overrideSetter(field, value string) (func(*config.CommandConfig), error) {
+	switch field {
+	case "command":
+		return func(cmd *config.CommandConfig) { cmd.Command = value }, nil
+	case "args":
+		var args []string
+		if value != "" {
+			args = strings.Split(value, ",")
+		}
+		return func(cmd *config.CommandConfig) {
+			cmd.Args = append([]string(nil), args...)
+		}, nil
+	case "timeout":
+		timeout, err := parseOverrideTimeout(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(cmd *config.CommandConfig) { cmd.Timeout = timeout }, nil
+	default:
+		return nil, fmt.Errorf("unsupported field %q (use command, args or timeout)", field)
+	}
+}
+
+// parseOverrideTimeout parses a timeout in milliseconds or as a Go duration
+func parseOverrideTimeout(value string) (int, error) {
+	if ms, err := strconv.Atoi(value); err == nil {
+		return ms, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: use milliseconds or a duration like 30s", value)
+	}
+	return int(d / time.Millisecond), nil
+}
+
+// commandConfigs returns every configuration of the named command, root and path-specific
+func commandConfigs(cfg *config.Config, commandName string) []*config.CommandConfig {
+	var configs []*config.CommandConfig
+	if cmd, ok := cfg.Commands[commandName]; ok && cmd != nil {
+		configs = append(configs, cmd)
+	}
+	for _, pathCfg := range cfg.Paths {
+		if cmd, ok := pathCfg.Commands[commandName]; ok && cmd != nil {
+			configs = append(configs, cmd)
+		}
+	}
+	return configs
+}
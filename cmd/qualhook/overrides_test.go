@@ -0,0 +1,267 @@
+//go:build unit
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	internalconfig "github.com/bebsworthy/qualhook/internal/config"
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestApplyErrorPatternOverrides(t *testing.T) {
+	newConfig := func() *config.Config {
+		return &config.Config{
+			Version: "1.0",
+			Commands: map[string]*config.CommandConfig{
+				"lint": {
+					Command: "echo",
+					ErrorPatterns: []*config.RegexPattern{
+						{Pattern: "error"},
+					},
+				},
+			},
+			Paths: []*config.PathConfig{
+				{
+					Path: "frontend/**",
+					Commands: map[string]*config.CommandConfig{
+						"lint": {
+							Command: "echo",
+							ErrorPatterns: []*config.RegexPattern{
+								{Pattern: "error"},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	result := &executor.ExecResult{Stdout: "src/app.js:3 FAIL unexpected token\nall good"}
+
+	t.Run("config patterns alone miss the line", func(t *testing.T) {
+		cfg := newConfig()
+		filtered := applyOutputFilter(cfg.Commands["lint"], result, nil)
+		if filtered.HasErrors {
+			t.Fatal("expected config patterns not to match")
+		}
+	})
+
+	t.Run("CLI pattern catches the line", func(t *testing.T) {
+		cfg := newConfig()
+		if err := applyErrorPatternOverrides(cfg, "lint", []string{`FAIL`}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		filtered := applyOutputFilter(cfg.Commands["lint"], result, nil)
+		if !filtered.HasErrors {
+			t.Fatal("expected CLI pattern to mark output as having errors")
+		}
+		if len(filtered.Lines) == 0 || filtered.Lines[0] != "src/app.js:3 FAIL unexpected token" {
+			t.Errorf("expected FAIL line to be reported, got %v", filtered.Lines)
+		}
+
+		if got := len(cfg.Paths[0].Commands["lint"].ErrorPatterns); got != 2 {
+			t.Errorf("expected path command to receive the override, got %d patterns", got)
+		}
+	})
+
+	t.Run("other commands untouched", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Commands["test"] = &config.CommandConfig{Command: "echo"}
+		if err := applyErrorPatternOverrides(cfg, "lint", []string{`FAIL`}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(cfg.Commands["test"].ErrorPatterns) != 0 {
+			t.Error("expected test command to be unchanged")
+		}
+	})
+
+	t.Run("invalid regex rejected", func(t *testing.T) {
+		cfg := newConfig()
+		if err := applyErrorPatternOverrides(cfg, "lint", []string{`[unclosed`}); err == nil {
+			t.Error("expected error for invalid regex")
+		}
+	})
+
+	t.Run("ReDoS pattern rejected", func(t *testing.T) {
+		cfg := newConfig()
+		if err := applyErrorPatternOverrides(cfg, "lint", []string{`(a+)+`}); err == nil {
+			t.Error("expected error for ReDoS-prone pattern")
+		}
+	})
+}
+
+func TestValidateWatchPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		wantErr  bool
+	}{
+		{name: "none", patterns: nil},
+		{name: "globs", patterns: []string{"src/**", "test"}},
+		{name: "invalid glob", patterns: []string{"src/[a"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWatchPaths(tt.patterns)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateWatchPaths(%v) error = %v, wantErr %v", tt.patterns, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyCommandOverrides(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), ".qualhook.json")
+	data := `{
+		"version": "1.0",
+		"commands": {
+			"lint": {"command": "echo", "args": ["original"], "timeout": 1000}
+		},
+		"paths": [
+			{"path": "frontend/**", "commands": {"lint": {"command": "echo", "args": ["frontend"]}}}
+		]
+	}`
+	if err := os.WriteFile(configFile, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	load := func(t *testing.T) *config.Config {
+		t.Helper()
+		cfg, err := internalconfig.NewLoader().LoadFromPath(configFile)
+		if err != nil {
+			t.Fatalf("failed to load config: %v", err)
+		}
+		return cfg
+	}
+
+	t.Run("override changes the executed command for this run only", func(t *testing.T) {
+		cfg := load(t)
+		if err := applyCommandOverrides(cfg, []string{"lint.args=overridden,value", "lint.timeout=5s"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		lint := cfg.Commands["lint"]
+		if lint.Timeout != 5000 {
+			t.Errorf("expected timeout 5000ms, got %d", lint.Timeout)
+		}
+		if got := cfg.Paths[0].Commands["lint"].Args; len(got) != 2 || got[0] != "overridden" {
+			t.Errorf("expected path command args to be overridden, got %v", got)
+		}
+
+		result, err := executeWithOptions(lint, lint.Args, "")
+		if err != nil {
+			t.Fatalf("unexpected execution error: %v", err)
+		}
+		if strings.TrimSpace(result.Stdout) != "overridden value" {
+			t.Errorf("expected overridden output, got %q", result.Stdout)
+		}
+
+		// A fresh load is unaffected by the override
+		if args := load(t).Commands["lint"].Args; len(args) != 1 || args[0] != "original" {
+			t.Errorf("expected config on disk to be unchanged, got %v", args)
+		}
+	})
+
+	t.Run("command override", func(t *testing.T) {
+		cfg := load(t)
+		if err := applyCommandOverrides(cfg, []string{"lint.command=eslint_v9"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Commands["lint"].Command != "eslint_v9" {
+			t.Errorf("expected command eslint_v9, got %q", cfg.Commands["lint"].Command)
+		}
+	})
+
+	errorCases := []struct {
+		name     string
+		override string
+	}{
+		{"missing value", "lint.command"},
+		{"missing field", "lint=eslint"},
+		{"unknown command", "format.command=prettier"},
+		{"unsupported field", "lint.prompt=fix"},
+		{"invalid timeout", "lint.timeout=soon"},
+		{"unsafe command", "lint.command=echo;rm"},
+		{"empty command", "lint.command="},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := applyCommandOverrides(load(t), []string{tc.override}); err == nil {
+				t.Errorf("expected error for %q", tc.override)
+			}
+		})
+	}
+}
+
+func TestApplyTimeoutOverride(t *testing.T) {
+	newConfig := func() *config.Config {
+		return &config.Config{
+			Version: "1.0",
+			Commands: map[string]*config.CommandConfig{
+				"lint": {Command: "echo", Timeout: 1000},
+				"test": {Command: "echo"},
+			},
+			Paths: []*config.PathConfig{
+				{
+					Path:     "frontend/**",
+					Commands: map[string]*config.CommandConfig{"lint": {Command: "echo", Timeout: 2000}},
+				},
+			},
+		}
+	}
+
+	t.Run("overrides every command", func(t *testing.T) {
+		cfg := newConfig()
+		if err := applyTimeoutOverride(cfg, 2*time.Minute); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, cmd := range []*config.CommandConfig{cfg.Commands["lint"], cfg.Commands["test"], cfg.Paths[0].Commands["lint"]} {
+			if cmd.Timeout != 120000 {
+				t.Errorf("expected timeout 120000ms, got %d", cmd.Timeout)
+			}
+		}
+	})
+
+	t.Run("zero keeps config timeouts", func(t *testing.T) {
+		cfg := newConfig()
+		if err := applyTimeoutOverride(cfg, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Commands["lint"].Timeout != 1000 || cfg.Commands["test"].Timeout != 0 {
+			t.Errorf("expected config timeouts to be unchanged, got %d and %d",
+				cfg.Commands["lint"].Timeout, cfg.Commands["test"].Timeout)
+		}
+	})
+
+	t.Run("override reaches the executor", func(t *testing.T) {
+		cfg := &config.Config{
+			Version:  "1.0",
+			Commands: map[string]*config.CommandConfig{"lint": {Command: "sleep", Args: []string{"5"}, Timeout: 60000}},
+		}
+		if err := applyTimeoutOverride(cfg, 200*time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		start := time.Now()
+		result, err := executeWithOptions(cfg.Commands["lint"], cfg.Commands["lint"].Args, "")
+		if elapsed := time.Since(start); elapsed > 3*time.Second {
+			t.Errorf("expected the override to stop the command early, took %v", elapsed)
+		}
+		if err == nil && (result == nil || !result.TimedOut) {
+			t.Errorf("expected a timeout, got result %+v", result)
+		}
+	})
+
+	for _, timeout := range []time.Duration{-time.Second, 10 * time.Millisecond, 48 * time.Hour} {
+		t.Run("rejects "+timeout.String(), func(t *testing.T) {
+			if err := applyTimeoutOverride(newConfig(), timeout); err == nil {
+				t.Errorf("expected error for timeout %v", timeout)
+			}
+		})
+	}
+}
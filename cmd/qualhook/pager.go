@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	"golang.org/x/term"
+)
+
+// defaultPager is used for --pager when $PAGER is not set
+const defaultPager = "less"
+
+var (
+	// stdoutIsTerminal reports whether standard output is a terminal
+	stdoutIsTerminal = func() bool { return term.IsTerminal(int(os.Stdout.Fd())) }
+
+	// launchPager shows the text in the pager command line, returning an
+	// error only when the pager could not be started
+	launchPager = runPager
+)
+
+// stableOrder sorts results by command in order of first appearance, then by
+// component path, so a buffered report reads the same on every run however
+// the components were scheduled. Results are copied; the originals are left
+// untouched.
+func stableOrder(results []executor.ComponentExecResult) []executor.ComponentExecResult {
+	rank := make(map[string]int)
+	for _, result := range results {
+		if _, ok := rank[result.Command]; !ok {
+			rank[result.Command] = len(rank)
+		}
+	}
+
+	ordered := append([]executor.ComponentExecResult(nil), results...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Command != ordered[j].Command {
+			return rank[ordered[i].Command] < rank[ordered[j].Command]
+		}
+		return ordered[i].Path < ordered[j].Path
+	})
+	return ordered
+}
+
+// pageReport shows the whole report in the pager when standard output is a
+// terminal. Otherwise, or when the pager cannot be started, the report is
+// written as usual.
+func pageReport(report *reporter.ReportResult) {
+	pager := strings.TrimSpace(os.Getenv("PAGER"))
+	if pager == "" {
+		pager = defaultPager
+	}
+	if !stdoutIsTerminal() {
+		debug.Log("Standard output is not a terminal, not starting the pager")
+		writeReport(report)
+		return
+	}
+
+	var text strings.Builder
+	for _, part := range []string{reportStdout(report), report.Stderr} {
+		if part != "" {
+			text.WriteString(part)
+			text.WriteString("\n")
+		}
+	}
+	if text.Len() == 0 {
+		return
+	}
+
+	if err := launchPager(pager, strings.NewReader(text.String())); err != nil {
+		debug.Log("Failed to start the pager %q: %v", pager, err)
+		writeReport(report)
+	}
+}
+
+// runPager runs the pager command line with text on its stdin. less is told
+// to keep colors and to exit straight away when the text fits on one screen,
+// unless $LESS says otherwise.
+func runPager(pager string, text io.Reader) error {
+	fields := strings.Fields(pager)
+	path, err := exec.LookPath(fields[0])
+	if err != nil {
+		return fmt.Errorf("pager not found: %w", err)
+	}
+
+	cmd := exec.Command(path, fields[1:]...) // #nosec G204 - the pager is chosen by the user
+	cmd.Stdin = text
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start pager: %w", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		debug.Log("Pager exited: %v", err)
+	}
+	return nil
+}
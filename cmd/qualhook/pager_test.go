@@ -0,0 +1,120 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// failedResult is a failing run of command in the component at path
+func failedResult(command, path string) executor.ComponentExecResult {
+	line := path + "/main.go:1: " + command + " failed"
+	return executor.ComponentExecResult{
+		Path:           path,
+		Command:        command,
+		CommandConfig:  &config.CommandConfig{Command: command, ExitCodes: []int{1}},
+		ExecResult:     &executor.ExecResult{ExitCode: 1, Stderr: line},
+		FilteredOutput: &filter.FilteredOutput{Lines: []string{line}, HasErrors: true},
+	}
+}
+
+// capturePager replaces the pager and terminal check for the rest of the test
+// and returns the text given to the pager
+func capturePager(t *testing.T, terminal bool) *string {
+	t.Helper()
+	paged := new(string)
+	oldTerminal, oldLaunch := stdoutIsTerminal, launchPager
+	stdoutIsTerminal = func() bool { return terminal }
+	launchPager = func(pager string, text io.Reader) error {
+		data, err := io.ReadAll(text)
+		*paged = string(data)
+		return err
+	}
+	t.Cleanup(func() { stdoutIsTerminal, launchPager = oldTerminal, oldLaunch })
+	return paged
+}
+
+func TestStableOrder(t *testing.T) {
+	first := []executor.ComponentExecResult{
+		failedResult("lint", "web"), failedResult("lint", "api"), failedResult("typecheck", "cli"),
+	}
+	second := []executor.ComponentExecResult{
+		failedResult("lint", "api"), failedResult("typecheck", "cli"), failedResult("lint", "web"),
+	}
+
+	ordered := stableOrder(first)
+	var paths []string
+	for _, result := range ordered {
+		paths = append(paths, result.Command+":"+result.Path)
+	}
+	if got := strings.Join(paths, ","); got != "lint:api,lint:web,typecheck:cli" {
+		t.Errorf("unexpected order %s", got)
+	}
+	if first[0].Path != "web" {
+		t.Error("expected the results to be left untouched")
+	}
+
+	want := reporter.NewErrorReporter().Report(stableOrder(first)).Stderr
+	for i := 0; i < 10; i++ {
+		if got := reporter.NewErrorReporter().Report(stableOrder(second)).Stderr; got != want {
+			t.Fatalf("expected the same report whatever the completion order, got:\n%s\nwant:\n%s", got, want)
+		}
+	}
+	if strings.Index(want, "## api") > strings.Index(want, "## web") {
+		t.Errorf("expected components sorted by path, got:\n%s", want)
+	}
+}
+
+func TestPageReport_SkipsPagerWithoutTerminal(t *testing.T) {
+	paged := capturePager(t, false)
+	var stdout, stderr bytes.Buffer
+	oldOut, oldErr := outputWriter, errorWriter
+	outputWriter, errorWriter = &stdout, &stderr
+	defer func() { outputWriter, errorWriter = oldOut, oldErr }()
+
+	pageReport(&reporter.ReportResult{Stdout: "summary", Stderr: "errors", ExitCode: 2})
+
+	if *paged != "" {
+		t.Errorf("expected the pager not to start, got %q", *paged)
+	}
+	if stdout.String() != "summary\n" || stderr.String() != "errors\n" {
+		t.Errorf("expected the report to be written directly, got %q and %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestPageReport_PagesOnTerminal(t *testing.T) {
+	paged := capturePager(t, true)
+	var stdout, stderr bytes.Buffer
+	oldOut, oldErr := outputWriter, errorWriter
+	outputWriter, errorWriter = &stdout, &stderr
+	defer func() { outputWriter, errorWriter = oldOut, oldErr }()
+
+	pageReport(&reporter.ReportResult{Stdout: "summary", Stderr: "errors", ExitCode: 2})
+
+	if *paged != "summary\nerrors\n" {
+		t.Errorf("expected the whole report in the pager, got %q", *paged)
+	}
+	if stdout.Len() != 0 || stderr.Len() != 0 {
+		t.Errorf("expected nothing written directly, got %q and %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestPager_RejectsLiveOutput(t *testing.T) {
+	root := newRootCmd()
+	defer func() { pagerOutput, liveOutput = false, false }()
+
+	root.SetArgs([]string{"lint", "--pager", "--live"})
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "--pager cannot be combined") {
+		t.Errorf("expected --pager with --live to be rejected, got %v", err)
+	}
+}
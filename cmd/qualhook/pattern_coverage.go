@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/bebsworthy/qualhook/internal/filter"
+	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// configPatternCoverageCmd runs commands and reports which error patterns matched
+var configPatternCoverageCmd = &cobra.Command{
+	Use:   "pattern-coverage [command...]",
+	Short: "Run commands and report how often each error pattern matched",
+	Long: `Run the configured root commands in the current directory and report, per
+command, how many output lines each error pattern matched.
+
+Commands whose output matched no pattern rely on their exit code alone.
+Patterns that never matched are flagged as unused: they may be dead, or the
+run did not produce the errors they are meant for. Run it while the project
+has the errors the patterns should catch.
+
+Without arguments every root command runs.
+
+Examples:
+  # Check the patterns of every command
+  qualhook config pattern-coverage
+
+  # Check the lint patterns only
+  qualhook config pattern-coverage lint`,
+	RunE: runConfigPatternCoverage,
+}
+
+// commandPatternCoverage is the pattern hit count of one command run
+type commandPatternCoverage struct {
+	Command  string
+	ExitCode int
+	Patterns []*pkgconfig.RegexPattern
+	// Hits holds the number of matching output lines per pattern
+	Hits []int
+	// Err is set when the command could not run
+	Err error
+}
+
+// matched returns the number of patterns that matched at least one line
+func (c *commandPatternCoverage) matched() int {
+	matched := 0
+	for _, hits := range c.Hits {
+		if hits > 0 {
+			matched++
+		}
+	}
+	return matched
+}
+
+// runConfigPatternCoverage runs the commands and prints their pattern coverage
+func runConfigPatternCoverage(cmd *cobra.Command, args []string) error {
+	cfg, err := loadRunConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	defer setRunSecurity(cfg)()
+
+	names := args
+	if len(names) == 0 {
+		for name := range cfg.Commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	for _, name := range names {
+		if _, ok := cfg.Commands[name]; !ok {
+			return fmt.Errorf("command %q not found in configuration", name)
+		}
+	}
+
+	var coverage []*commandPatternCoverage
+	for _, name := range names {
+		coverage = append(coverage, measurePatternCoverage(name, cfg.Commands[name]))
+	}
+	printPatternCoverage(cmd.OutOrStdout(), coverage)
+	return nil
+}
+
+// measurePatternCoverage runs a command once and counts its pattern hits
+func measurePatternCoverage(name string, cmdConfig *pkgconfig.CommandConfig) *commandPatternCoverage {
+	coverage := &commandPatternCoverage{Command: name, Patterns: cmdConfig.ErrorPatterns}
+
+	result, err := executeWithOptions(cmdConfig, cmdConfig.Args, "")
+	if err == nil {
+		err = result.Error
+	}
+	if err != nil {
+		coverage.Err = err
+		return coverage
+	}
+
+	coverage.ExitCode = result.ExitCode
+	coverage.Hits, coverage.Err = filter.PatternHits(result.Stdout+"\n"+result.Stderr, cmdConfig.ErrorPatterns)
+	return coverage
+}
+
+// printPatternCoverage writes a pattern coverage report
+func printPatternCoverage(w io.Writer, coverage []*commandPatternCoverage) {
+	_, _ = fmt.Fprintln(w, "🔍 Pattern coverage:") //nolint:errcheck // Best effort output
+	var unused []string
+	for _, c := range coverage {
+		if c.Err != nil {
+			_, _ = fmt.Fprintf(w, "   ✗ %s: %v\n", c.Command, c.Err) //nolint:errcheck // Best effort output
+			continue
+		}
+
+		switch {
+		case len(c.Patterns) == 0:
+			_, _ = fmt.Fprintf(w, "   • %s (exit %d): no error patterns, the exit code decides\n", c.Command, c.ExitCode) //nolint:errcheck // Best effort output
+		case c.matched() == 0:
+			_, _ = fmt.Fprintf(w, "   • %s (exit %d): no pattern matched, the exit code decides\n", c.Command, c.ExitCode) //nolint:errcheck // Best effort output
+		default:
+			_, _ = fmt.Fprintf(w, "   ✓ %s (exit %d): %d of %d pattern(s) matched\n", c.Command, c.ExitCode, c.matched(), len(c.Patterns)) //nolint:errcheck // Best effort output
+		}
+
+		for i, pattern := range c.Patterns {
+			if c.Hits[i] > 0 {
+				_, _ = fmt.Fprintf(w, "     ✓ %q: %d line(s)\n", pattern.Pattern, c.Hits[i]) //nolint:errcheck // Best effort output
+			} else {
+				_, _ = fmt.Fprintf(w, "     ✗ %q: never matched\n", pattern.Pattern) //nolint:errcheck // Best effort output
+				unused = append(unused, fmt.Sprintf("%s: %q", c.Command, pattern.Pattern))
+			}
+		}
+	}
+
+	if len(unused) == 0 {
+		_, _ = fmt.Fprintln(w, "\n✅ Every error pattern matched the output of this run.") //nolint:errcheck // Best effort output
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "\n⚠️  %d error pattern(s) never matched and may be unused:\n", len(unused)) //nolint:errcheck // Best effort output
+	for _, pattern := range unused {
+		_, _ = fmt.Fprintf(w, "   • %s\n", pattern) //nolint:errcheck // Best effort output
+	}
+}
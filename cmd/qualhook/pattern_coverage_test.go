@@ -0,0 +1,110 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runPatternCoverage runs config pattern-coverage against a configuration
+func runPatternCoverage(t *testing.T, data string, args ...string) (string, error) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), ".qualhook.json")
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	oldConfigPath := configPath
+	configPath = file
+	defer func() { configPath = oldConfigPath }()
+
+	var out bytes.Buffer
+	configPatternCoverageCmd.SetOut(&out)
+	defer configPatternCoverageCmd.SetOut(nil)
+
+	err := runConfigPatternCoverage(configPatternCoverageCmd, args)
+	return out.String(), err
+}
+
+func TestRunConfigPatternCoverage(t *testing.T) {
+	output, err := runPatternCoverage(t, `{
+		"version": "1.0",
+		"commands": {
+			"lint": {
+				"command": "echo",
+				"args": ["src/app.js:1: error no-unused-vars"],
+				"errorPatterns": [{"pattern": "error"}, {"pattern": "FATAL"}]
+			},
+			"test": {
+				"command": "echo",
+				"args": ["all tests passed"],
+				"errorPatterns": [{"pattern": "FAIL"}]
+			},
+			"format": {"command": "echo", "args": ["formatted"]}
+		}
+	}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"✓ lint (exit 0): 1 of 2 pattern(s) matched",
+		`✓ "error": 1 line(s)`,
+		`✗ "FATAL": never matched`,
+		"• test (exit 0): no pattern matched, the exit code decides",
+		"• format (exit 0): no error patterns, the exit code decides",
+		"2 error pattern(s) never matched",
+		`• lint: "FATAL"`,
+		`• test: "FAIL"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunConfigPatternCoverage_SelectedCommands(t *testing.T) {
+	config := `{
+		"version": "1.0",
+		"commands": {
+			"lint": {"command": "echo", "args": ["error"], "errorPatterns": [{"pattern": "error"}]},
+			"test": {"command": "echo", "args": ["ok"], "errorPatterns": [{"pattern": "FAIL"}]}
+		}
+	}`
+
+	output, err := runPatternCoverage(t, config, "lint")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(output, "test") || !strings.Contains(output, "Every error pattern matched") {
+		t.Errorf("expected only lint to run, got:\n%s", output)
+	}
+
+	if _, err := runPatternCoverage(t, config, "missing"); err == nil {
+		t.Error("expected an unknown command to be rejected")
+	}
+}
+
+func TestRunConfigPatternCoverage_DeniedCommand(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "ran")
+	output, err := runPatternCoverage(t, `{
+		"version": "1.0",
+		"commands": {
+			"lint": {"command": "touch", "args": ["`+marker+`"], "errorPatterns": [{"pattern": "error"}]}
+		},
+		"security": {"deniedCommands": ["touch"]}
+	}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "✗ lint:") || !strings.Contains(output, "not allowed") {
+		t.Errorf("expected the denied command to be rejected, got:\n%s", output)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Error("expected the denied command not to run")
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/bebsworthy/qualhook/internal/watcher"
+	"github.com/bebsworthy/qualhook/pkg/config"
+	"golang.org/x/term"
+)
+
+// multiSelectPrompt asks the user to choose any number of options.
+// It is a variable so tests can script selections.
+var multiSelectPrompt = func(message string, options []string) ([]string, error) {
+	var selected []string
+	prompt := &survey.MultiSelect{
+		Message: message,
+		Options: options,
+		Default: options,
+	}
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// isInteractive reports whether stdin is a terminal; overridable in tests
+var isInteractive = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// pickComponentGroups lets the user choose which candidate components run.
+// Only groups that configure the command are offered.
+func pickComponentGroups(groups []watcher.ComponentGroup, commandName string) ([]watcher.ComponentGroup, error) {
+	var candidates []watcher.ComponentGroup
+	var options []string
+	for _, group := range groups {
+		if group.Config[commandName] == nil {
+			continue
+		}
+		candidates = append(candidates, group)
+		options = append(options, group.Path)
+	}
+
+	if len(candidates) <= 1 {
+		return candidates, nil
+	}
+
+	if !isInteractive() {
+		return nil, fmt.Errorf("--pick requires an interactive terminal")
+	}
+
+	selected, err := multiSelectPrompt(fmt.Sprintf("Select components to run %s for:", commandName), options)
+	if err != nil {
+		return nil, fmt.Errorf("component selection failed: %w", err)
+	}
+
+	chosen := make(map[string]bool, len(selected))
+	for _, path := range selected {
+		chosen[path] = true
+	}
+
+	var picked []watcher.ComponentGroup
+	for _, group := range candidates {
+		if chosen[group.Path] {
+			picked = append(picked, group)
+		}
+	}
+	return picked, nil
+}
+
+// componentFiles returns one representative file per configured component so
+// every component can be offered for picking when no files were edited
+func componentFiles(cfg *config.Config) []string {
+	if len(cfg.Paths) == 0 {
+		return nil
+	}
+
+	components := watcher.NewFileMapper(cfg).ListAllComponents()
+	files := make([]string, 0, len(components))
+	for _, component := range components {
+		if component == "." {
+			files = append(files, "qualhook-pick")
+		} else {
+			files = append(files, filepath.Join(strings.TrimSuffix(component, "/**"), "qualhook-pick"))
+		}
+	}
+	return files
+}
@@ -0,0 +1,134 @@
+//go:build unit
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/watcher"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// scriptPicker replaces the interactive prompt with a scripted selection
+func scriptPicker(t *testing.T, interactive bool, selection []string, err error) *[]string {
+	t.Helper()
+	var offered []string
+	origPrompt, origInteractive, origPick := multiSelectPrompt, isInteractive, pickComponents
+	multiSelectPrompt = func(message string, options []string) ([]string, error) {
+		offered = options
+		return selection, err
+	}
+	isInteractive = func() bool { return interactive }
+	pickComponents = true
+	t.Cleanup(func() {
+		multiSelectPrompt, isInteractive, pickComponents = origPrompt, origInteractive, origPick
+	})
+	return &offered
+}
+
+func TestRunComponentGroups_Pick(t *testing.T) {
+	tempDir := t.TempDir()
+	var groups []watcher.ComponentGroup
+	for _, name := range []string{"api", "web", "docs"} {
+		dir := filepath.Join(tempDir, name)
+		if err := os.Mkdir(dir, 0750); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+		groups = append(groups, watcher.ComponentGroup{
+			Path: dir,
+			Config: map[string]*config.CommandConfig{
+				"lint": {Command: "echo", Args: []string{"ran " + name}},
+			},
+		})
+	}
+	// A component without the command is never offered
+	groups = append(groups, watcher.ComponentGroup{Path: filepath.Join(tempDir, "other")})
+
+	t.Run("only picked components execute", func(t *testing.T) {
+		offered := scriptPicker(t, true, []string{groups[0].Path, groups[2].Path}, nil)
+
+		results, err := runComponentGroups(groups, "lint", nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(*offered) != 3 {
+			t.Errorf("expected 3 candidate components, got %v", *offered)
+		}
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(results))
+		}
+		for i, want := range []string{"ran api", "ran docs"} {
+			if results[i].ExecResult == nil || strings.TrimSpace(results[i].ExecResult.Stdout) != want {
+				t.Errorf("result %d: expected output %q, got %+v", i, want, results[i].ExecResult)
+			}
+		}
+	})
+
+	t.Run("nothing picked runs nothing", func(t *testing.T) {
+		scriptPicker(t, true, nil, nil)
+
+		results, err := runComponentGroups(groups, "lint", nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(results) != 0 {
+			t.Errorf("expected no results, got %d", len(results))
+		}
+	})
+
+	t.Run("non-interactive errors", func(t *testing.T) {
+		scriptPicker(t, false, nil, nil)
+
+		if _, err := runComponentGroups(groups, "lint", nil, nil); err == nil || !strings.Contains(err.Error(), "interactive") {
+			t.Errorf("expected interactive terminal error, got %v", err)
+		}
+	})
+
+	t.Run("prompt failure errors", func(t *testing.T) {
+		scriptPicker(t, true, nil, errors.New("interrupt"))
+
+		if _, err := runComponentGroups(groups, "lint", nil, nil); err == nil {
+			t.Error("expected selection error")
+		}
+	})
+
+	t.Run("single candidate skips the prompt", func(t *testing.T) {
+		offered := scriptPicker(t, false, nil, nil)
+
+		results, err := runComponentGroups(groups[:1], "lint", nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(results) != 1 || *offered != nil {
+			t.Errorf("expected the only component to run without prompting, got %d results", len(results))
+		}
+	})
+}
+
+func TestComponentFiles(t *testing.T) {
+	cfg := &config.Config{
+		Paths: []*config.PathConfig{{Path: "frontend/**"}, {Path: "backend/**"}},
+	}
+
+	groups, err := watcher.NewFileMapper(cfg).MapFilesToComponents(componentFiles(cfg))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var paths []string
+	for _, group := range groups {
+		paths = append(paths, group.Path)
+	}
+	if len(paths) != 3 {
+		t.Errorf("expected root and both path components, got %v", paths)
+	}
+
+	if files := componentFiles(&config.Config{}); files != nil {
+		t.Errorf("expected no files without paths, got %v", files)
+	}
+}
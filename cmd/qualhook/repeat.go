@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// repeatFailure records the report of one failed repeated run
+type repeatFailure struct {
+	Run    int
+	Report *reporter.ReportResult
+}
+
+// repeatTally summarizes the outcome of running a command several times
+type repeatTally struct {
+	Runs     int
+	Passed   int
+	Failed   int
+	Failures []repeatFailure
+}
+
+// runRepeated runs the resolved command runs times, serially or in parallel,
+// and tallies how many runs passed. Failures are returned in run order.
+func runRepeated(cfg *config.Config, commandName string, extraArgs []string, runs int, parallel bool) (*repeatTally, error) {
+	cmdConfig, exists := cfg.Commands[commandName]
+	if !exists {
+		return nil, fmt.Errorf("command %q not found in configuration", commandName)
+	}
+
+	reports := make([]*reporter.ReportResult, runs)
+	runOnce := func(i int) {
+		results, err := executeSingleCommand(cmdConfig, commandName, extraArgs)
+		if err != nil {
+			results = []executor.ComponentExecResult{
+				{Command: commandName, CommandConfig: cmdConfig, ExecutionError: err},
+			}
+		}
+		reports[i] = newErrorReporter().Report(results)
+		debug.Log("Repeat run %d/%d exit code: %d", i+1, runs, reports[i].ExitCode)
+	}
+
+	if parallel {
+		sem := make(chan struct{}, executor.Workers(runJobs))
+		var wg sync.WaitGroup
+		for i := 0; i < runs; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				runOnce(i)
+			}(i)
+		}
+		wg.Wait()
+	} else {
+		for i := 0; i < runs; i++ {
+			runOnce(i)
+		}
+	}
+
+	tally := &repeatTally{Runs: runs}
+	for i, report := range reports {
+		if report.ExitCode == 0 {
+			tally.Passed++
+			continue
+		}
+		tally.Failed++
+		tally.Failures = append(tally.Failures, repeatFailure{Run: i + 1, Report: report})
+	}
+	return tally, nil
+}
+
+// reportRepeatTally writes the tally and failure details, exiting with the
+// first failure's exit code when any run failed
+func reportRepeatTally(commandName string, tally *repeatTally) {
+	if quietFlag && tally.Failed == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(outputWriter, "🔁 Ran %s %d times: %d passed, %d failed\n", //nolint:errcheck // Best effort output to stdout
+		commandName, tally.Runs, tally.Passed, tally.Failed)
+
+	if tally.Failed == 0 {
+		return
+	}
+
+	var details strings.Builder
+	for i, failure := range tally.Failures {
+		if i > 0 {
+			details.WriteString("\n\n")
+		}
+		details.WriteString(fmt.Sprintf("## Run %d (exit code %d)\n\n", failure.Run, failure.Report.ExitCode))
+		details.WriteString(strings.TrimSpace(failure.Report.Stderr))
+	}
+	_, _ = fmt.Fprintln(errorWriter, details.String()) //nolint:errcheck // Best effort output to stderr
+
+	osExit(tally.Failures[0].Report.ExitCode)
+}
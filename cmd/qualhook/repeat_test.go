@@ -0,0 +1,119 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// flakyScript fails on every second run, tracked through a counter file
+const flakyScript = `n=$(cat "$1" 2>/dev/null || echo 0)
+n=$((n + 1))
+echo "$n" > "$1"
+if [ $((n % 2)) -eq 0 ]; then
+  echo "FAIL run $n" >&2
+  exit 1
+fi
+echo "ok run $n"
+`
+
+func TestRunRepeated(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "flaky.sh")
+	if err := os.WriteFile(script, []byte(flakyScript), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	cfg := &config.Config{
+		Version: "1.0",
+		Commands: map[string]*config.CommandConfig{
+			"test": {
+				Command:       "sh",
+				Args:          []string{script, filepath.Join(dir, "counter")},
+				ExitCodes:     []int{1},
+				ErrorPatterns: []*config.RegexPattern{{Pattern: "FAIL"}},
+			},
+			"pass": {Command: "true"},
+			"fail": {Command: "false", ExitCodes: []int{1}},
+		},
+	}
+
+	t.Run("serial tally matches the flaky pattern", func(t *testing.T) {
+		tally, err := runRepeated(cfg, "test", nil, 5, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tally.Runs != 5 || tally.Passed != 3 || tally.Failed != 2 {
+			t.Fatalf("expected 3 passed and 2 failed, got %+v", tally)
+		}
+		if tally.Failures[0].Run != 2 || tally.Failures[1].Run != 4 {
+			t.Errorf("expected runs 2 and 4 to fail, got %d and %d", tally.Failures[0].Run, tally.Failures[1].Run)
+		}
+		if !strings.Contains(tally.Failures[1].Report.Stderr, "FAIL run 4") {
+			t.Errorf("expected failure details, got %q", tally.Failures[1].Report.Stderr)
+		}
+	})
+
+	t.Run("parallel runs", func(t *testing.T) {
+		passing, err := runRepeated(cfg, "pass", nil, 6, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if passing.Passed != 6 || passing.Failed != 0 {
+			t.Errorf("expected all runs to pass, got %+v", passing)
+		}
+
+		failing, err := runRepeated(cfg, "fail", nil, 6, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if failing.Passed != 0 || failing.Failed != 6 {
+			t.Errorf("expected all runs to fail, got %+v", failing)
+		}
+		for i, failure := range failing.Failures {
+			if failure.Run != i+1 {
+				t.Errorf("expected failures in run order, got run %d at %d", failure.Run, i)
+			}
+		}
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		if _, err := runRepeated(cfg, "lint", nil, 2, false); err == nil {
+			t.Error("expected error for unknown command")
+		}
+	})
+
+	t.Run("report writes tally and exits on failure", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		oldOut, oldErr, oldExit := outputWriter, errorWriter, osExit
+		exitCode := -1
+		outputWriter, errorWriter = &stdout, &stderr
+		osExit = func(code int) { exitCode = code }
+		defer func() { outputWriter, errorWriter, osExit = oldOut, oldErr, oldExit }()
+
+		if err := os.Remove(filepath.Join(dir, "counter")); err != nil {
+			t.Fatalf("failed to reset counter: %v", err)
+		}
+		tally, err := runRepeated(cfg, "test", nil, 2, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		reportRepeatTally("test", tally)
+
+		if !strings.Contains(stdout.String(), "Ran test 2 times: 1 passed, 1 failed") {
+			t.Errorf("unexpected tally output: %q", stdout.String())
+		}
+		if !strings.Contains(stderr.String(), "## Run 2 (exit code 2)") {
+			t.Errorf("expected failure details, got %q", stderr.String())
+		}
+		if exitCode != 2 {
+			t.Errorf("expected exit code 2, got %d", exitCode)
+		}
+	})
+}
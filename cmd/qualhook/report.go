@@ -0,0 +1,93 @@
+// Package main provides the report command for qualhook
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	"github.com/spf13/cobra"
+)
+
+// reportCmd groups commands that work with saved JSON reports
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Work with saved JSON reports",
+	Long: `Work with reports saved from a quality command run with --output json.
+
+Available subcommands:
+  diff - Compare two reports and classify errors as new, fixed or persisting`,
+}
+
+// reportDiffCmd compares two JSON reports
+var reportDiffCmd = &cobra.Command{
+	Use:   "diff <old.json> <new.json>",
+	Short: "Compare two JSON reports",
+	Long: `Compare two JSON reports and classify their errors by fingerprint.
+
+Errors are matched by a fingerprint of the command, component, file and
+message, so an error keeps its identity when code above it moves and its
+line number changes.
+
+Exit codes:
+  0 - No new errors were introduced
+  1 - The new report contains errors that are not in the old one`,
+	Example: `  # Save a baseline and compare after a change
+  qualhook lint --output json > before.json
+  qualhook lint --output json > after.json
+  qualhook report diff before.json after.json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runReportDiff,
+}
+
+func init() {
+	reportCmd.AddCommand(reportDiffCmd)
+}
+
+func runReportDiff(cmd *cobra.Command, args []string) error {
+	oldReport, err := loadJSONReport(args[0])
+	if err != nil {
+		return err
+	}
+	newReport, err := loadJSONReport(args[1])
+	if err != nil {
+		return err
+	}
+
+	diff := reporter.DiffReports(oldReport, newReport)
+	printReportDiff(outputWriter, diff)
+
+	if len(diff.New) > 0 {
+		return fmt.Errorf("%d new error(s) introduced", len(diff.New))
+	}
+	return nil
+}
+
+// loadJSONReport reads a JSON report from disk
+func loadJSONReport(path string) (*reporter.JSONReport, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	report, err := reporter.ParseJSONReport(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return report, nil
+}
+
+// printReportDiff writes a human-readable report diff
+func printReportDiff(w io.Writer, diff *reporter.ReportDiff) {
+	printDiffSection(w, "🆕 New", diff.New)
+	printDiffSection(w, "✅ Fixed", diff.Fixed)
+	printDiffSection(w, "⏳ Persisting", diff.Persisting)
+}
+
+// printDiffSection writes one classification of a report diff
+func printDiffSection(w io.Writer, title string, entries []reporter.DiffEntry) {
+	_, _ = fmt.Fprintf(w, "%s (%d):\n", title, len(entries)) //nolint:errcheck // Best effort output
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(w, "   %s %s: %s\n", entry.Component, entry.Command, entry.Text) //nolint:errcheck // Best effort output
+	}
+}
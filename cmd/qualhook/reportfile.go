@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bebsworthy/qualhook/internal/reporter"
+)
+
+// writeReportFile writes the standard output of the report, such as a junit
+// or json report, to path and returns the report without it
+func writeReportFile(path string, report *reporter.ReportResult) (*reporter.ReportResult, error) {
+	data := report.Stdout
+	if data != "" {
+		data += "\n"
+	}
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		return report, fmt.Errorf("failed to write report file: %w", err)
+	}
+
+	rest := *report
+	rest.Stdout = ""
+	return &rest, nil
+}
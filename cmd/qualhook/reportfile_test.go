@@ -0,0 +1,53 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestReportAndOutputResults_ReportFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.xml")
+
+	var stdout bytes.Buffer
+	oldFile, oldFormat := reportFile, outputFormat
+	oldOut, oldErr, oldExit := outputWriter, errorWriter, osExit
+	reportFile, outputFormat = path, reporter.FormatJUnit
+	outputWriter, errorWriter = &stdout, &bytes.Buffer{}
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() {
+		reportFile, outputFormat = oldFile, oldFormat
+		outputWriter, errorWriter, osExit = oldOut, oldErr, oldExit
+	}()
+
+	results := []executor.ComponentExecResult{{
+		Path:           "api/**",
+		Command:        "lint",
+		CommandConfig:  &config.CommandConfig{Command: "eslint", ExitCodes: []int{1}},
+		ExecResult:     &executor.ExecResult{ExitCode: 1},
+		FilteredOutput: &filter.FilteredOutput{Lines: []string{"api/x.go:3:1: bad"}, HasErrors: true},
+	}}
+	reportAndOutputResults(results, time.Now(), nil)
+
+	data, err := os.ReadFile(path) // #nosec G304 - test-controlled path
+	if err != nil {
+		t.Fatalf("expected the report file: %v", err)
+	}
+	if !strings.Contains(string(data), `<failure message="lint failed with 1 error line(s)" type="failed">api/x.go:3:1: bad</failure>`) {
+		t.Errorf("expected the failure in the report file, got:\n%s", data)
+	}
+	if stdout.Len() != 0 || exitCode != 2 {
+		t.Errorf("expected no stdout and exit code 2, got %d:\n%s", exitCode, stdout.String())
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// retryRunDelay is the pause before re-running the pipeline; tests shorten it
+var retryRunDelay = 500 * time.Millisecond
+
+// runWithRetry runs the pipeline, re-running it up to retries more times
+// while it fails for infrastructure reasons. Quality failures are returned
+// as they are.
+func runWithRetry(retries int, run func() ([]executor.ComponentExecResult, error)) ([]executor.ComponentExecResult, error) {
+	for attempt := 1; ; attempt++ {
+		results, err := run()
+
+		cause := err
+		if cause == nil {
+			cause = infrastructureFailure(results)
+		}
+		if cause == nil || !executor.IsInfrastructureError(cause) || attempt > retries {
+			return results, err
+		}
+
+		debug.Log("Run attempt %d failed with infrastructure error, retrying: %v", attempt, cause)
+		time.Sleep(retryRunDelay)
+	}
+}
+
+// infrastructureFailure returns the first retryable execution error of the results
+func infrastructureFailure(results []executor.ComponentExecResult) error {
+	for _, result := range results {
+		err := result.ExecutionError
+		if err == nil && result.ExecResult != nil {
+			err = result.ExecResult.Error
+		}
+		if executor.IsInfrastructureError(err) {
+			return err
+		}
+	}
+	return nil
+}
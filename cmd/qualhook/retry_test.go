@@ -0,0 +1,102 @@
+//go:build unit
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestRunWithRetry(t *testing.T) {
+	oldDelay := retryRunDelay
+	retryRunDelay = 0
+	defer func() { retryRunDelay = oldDelay }()
+
+	qualityFailure := []executor.ComponentExecResult{
+		{
+			Command:       "lint",
+			CommandConfig: &config.CommandConfig{Command: "eslint", ExitCodes: []int{1}},
+			ExecResult:    &executor.ExecResult{ExitCode: 1, Stdout: "src/app.js:1:1: error"},
+		},
+	}
+	infrastructureFailure := []executor.ComponentExecResult{
+		{
+			Command:        "lint",
+			ExecutionError: &executor.ExecError{Type: executor.ErrorTypeExecution, Command: "eslint", Err: syscall.ETXTBSY},
+		},
+	}
+	passing := []executor.ComponentExecResult{
+		{Command: "lint", ExecResult: &executor.ExecResult{ExitCode: 0}},
+	}
+
+	t.Run("quality failure is not retried", func(t *testing.T) {
+		attempts := 0
+		results, err := runWithRetry(3, func() ([]executor.ComponentExecResult, error) {
+			attempts++
+			return qualityFailure, nil
+		})
+		if err != nil || attempts != 1 {
+			t.Errorf("expected a single attempt, got %d (err %v)", attempts, err)
+		}
+		if len(results) != 1 || results[0].ExecResult.ExitCode != 1 {
+			t.Errorf("expected the quality failure to be returned, got %+v", results)
+		}
+	})
+
+	t.Run("infrastructure error is retried until it clears", func(t *testing.T) {
+		attempts := 0
+		results, err := runWithRetry(3, func() ([]executor.ComponentExecResult, error) {
+			attempts++
+			if attempts < 3 {
+				return infrastructureFailure, nil
+			}
+			return passing, nil
+		})
+		if err != nil || attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d (err %v)", attempts, err)
+		}
+		if len(results) != 1 || results[0].ExecutionError != nil {
+			t.Errorf("expected the passing run to be returned, got %+v", results)
+		}
+	})
+
+	t.Run("retries are bounded", func(t *testing.T) {
+		attempts := 0
+		_, err := runWithRetry(2, func() ([]executor.ComponentExecResult, error) {
+			attempts++
+			return nil, fmt.Errorf("command execution failed: invalid working directory: /tmp/cache does not exist")
+		})
+		if err == nil || attempts != 3 {
+			t.Errorf("expected 3 attempts ending in an error, got %d (err %v)", attempts, err)
+		}
+	})
+
+	t.Run("no retries by default", func(t *testing.T) {
+		attempts := 0
+		_, _ = runWithRetry(0, func() ([]executor.ComponentExecResult, error) { //nolint:errcheck // Only attempts matter
+			attempts++
+			return infrastructureFailure, nil
+		})
+		if attempts != 1 {
+			t.Errorf("expected a single attempt, got %d", attempts)
+		}
+	})
+
+	t.Run("missing command is not retried", func(t *testing.T) {
+		attempts := 0
+		_, _ = runWithRetry(3, func() ([]executor.ComponentExecResult, error) { //nolint:errcheck // Only attempts matter
+			attempts++
+			return []executor.ComponentExecResult{
+				{Command: "lint", ExecutionError: &executor.ExecError{Type: executor.ErrorTypeCommandNotFound, Command: "eslint", Err: errors.New("not found")}},
+			}, nil
+		})
+		if attempts != 1 {
+			t.Errorf("expected a single attempt, got %d", attempts)
+		}
+	})
+}
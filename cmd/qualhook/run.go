@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// runCmd runs any configured command by name, e.g. from scripts that target
+// files with --files
+var runCmd = newRunCmd()
+
+// newRunCmd creates the run command
+func newRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <command> [args...]",
+		Short: "Run a configured command by name",
+		Long: `Run any command of the configuration, standard or custom, by name.
+
+Use --files to run the command for an explicit list of files instead of the
+Claude Code hook input. The files are mapped to their components like edited
+files, so in a monorepo only the affected components run. This suits git
+pre-commit scripts that already know the staged files.
+
+Use --tag instead of a command name to run every command carrying the tag in
+its "tags" list. The commands run in parallel unless one has prerequisites,
+and their results are reported together, grouped by command.
+
+Exit codes:
+  0 - The command succeeded
+  1 - Configuration or execution error
+  2 - Errors detected (for Claude Code integration)`,
+		Example: `  # Lint the staged files
+  qualhook run lint --files "$(git diff --cached --name-only | paste -sd, -)"
+
+  # Run a custom command for two files
+  qualhook run security --files src/a.js --files src/b.js
+
+  # Run the commands tagged "fast"
+  qualhook run --tag fast`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if commandTag != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("--tag runs the tagged commands and takes no command name, got %q", args[0])
+				}
+				return nil
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if commandTag != "" {
+				return createRunFunc("")(cmd, args)
+			}
+			return createRunFunc(args[0])(cmd, args[1:])
+		},
+	}
+	addRunFlags(cmd)
+	cmd.Flags().StringVar(&commandTag, "tag", "",
+		"Run every command carrying this tag instead of a named command")
+	return cmd
+}
@@ -0,0 +1,189 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestRunCommand_Files(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "audit.sh")
+	if err := os.WriteFile(script, []byte("echo 'src/a.js:1: error: unsafe eval' >&2\nexit 1\n"), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	file := filepath.Join(dir, ".qualhook.json")
+	data := `{
+		"version": "1.0",
+		"rootFallback": "skip",
+		"commands": {"audit": {"command": "sh", "args": ["` + script + `"]}},
+		"paths": [{"path": "docs/**", "commands": {"audit": {"command": "echo", "args": ["docs ok"]}}}]
+	}`
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// Hook input is ignored when files are given
+	t.Setenv("CLAUDE_HOOK_INPUT", "")
+	oldStdin := hookStdin
+	hookStdin = func() io.Reader { return strings.NewReader("") }
+	defer func() { hookStdin = oldStdin }()
+
+	run := func(args ...string) (int, string, string) {
+		// Creating the root command resets the flag variables
+		root := newRootCmd()
+		oldConfigPath := configPath
+		configPath = file
+		defer func() { configPath, targetFiles = oldConfigPath, nil }()
+
+		var stdout, stderr bytes.Buffer
+		oldOut, oldErr, oldExit := outputWriter, errorWriter, osExit
+		exitCode := -1
+		outputWriter, errorWriter = &stdout, &stderr
+		osExit = func(code int) { exitCode = code }
+		defer func() { outputWriter, errorWriter, osExit = oldOut, oldErr, oldExit }()
+
+		root.SetArgs(args)
+		if err := root.Execute(); err != nil {
+			t.Fatalf("Execute(%v) error = %v", args, err)
+		}
+		return exitCode, stdout.String(), stderr.String()
+	}
+
+	// Without files the command runs once for the project
+	if exitCode, _, stderr := run("run", "audit"); exitCode != 2 || !strings.Contains(stderr, "unsafe eval") {
+		t.Errorf("expected the root command to fail, got exit code %d:\n%s", exitCode, stderr)
+	}
+
+	// With files, only their components run: unmatched files are skipped here
+	hookStdin = func() io.Reader { t.Error("hook input read despite --files"); return nil }
+	if exitCode, stdout, stderr := run("run", "audit", "--files", "src/a.js, src/b.js"); exitCode > 0 || strings.Contains(stderr, "unsafe eval") {
+		t.Errorf("expected the files to be skipped, got exit code %d:\n%s%s", exitCode, stdout, stderr)
+	}
+}
+
+func TestRunCommand_RequiresCommand(t *testing.T) {
+	root := newRootCmd()
+	root.SetArgs([]string{"run"})
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	if err := root.Execute(); err == nil {
+		t.Error("expected an error without a command name")
+	}
+}
+
+func TestExplicitFiles(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := explicitFiles([]string{" src/a.js", "", filepath.Join(cwd, "pkg", "b.go"), "./c.txt"})
+	want := []string{"src/a.js", filepath.Join("pkg", "b.go"), "c.txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("explicitFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestRunCommand_Tag(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "audit.sh")
+	if err := os.WriteFile(script, []byte("echo 'src/a.js:1: error: unsafe eval' >&2\nexit 1\n"), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	file := filepath.Join(dir, ".qualhook.json")
+	data := `{
+		"version": "1.0",
+		"commands": {
+			"audit": {"command": "sh", "args": ["` + script + `"], "tags": ["fast"], "prompt": "Fix the audit errors:"},
+			"spell": {"command": "echo", "args": ["spelling ok"], "tags": ["fast", "docs"]},
+			"e2e": {"command": "sh", "args": ["` + script + `"], "tags": ["slow"]}
+		}
+	}`
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Setenv("CLAUDE_HOOK_INPUT", "")
+	oldStdin := hookStdin
+	hookStdin = func() io.Reader { return strings.NewReader("") }
+	defer func() { hookStdin = oldStdin }()
+
+	run := func(args ...string) (int, string, error) {
+		root := newRootCmd()
+		oldConfigPath := configPath
+		configPath = file
+		defer func() { configPath, commandTag = oldConfigPath, "" }()
+
+		var stdout, stderr bytes.Buffer
+		oldOut, oldErr, oldExit := outputWriter, errorWriter, osExit
+		exitCode := -1
+		outputWriter, errorWriter = &stdout, &stderr
+		osExit = func(code int) { exitCode = code }
+		defer func() { outputWriter, errorWriter, osExit = oldOut, oldErr, oldExit }()
+
+		root.SetArgs(args)
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		err := root.Execute()
+		return exitCode, stderr.String(), err
+	}
+
+	// Only the tagged commands run, and failures are grouped by command
+	exitCode, stderr, err := run("run", "--tag", "fast")
+	if err != nil {
+		t.Fatalf("run --tag fast error = %v", err)
+	}
+	if exitCode != 2 || strings.Count(stderr, "unsafe eval") != 1 || !strings.Contains(stderr, "Fix the audit errors:") {
+		t.Errorf("expected only audit to fail, got exit code %d:\n%s", exitCode, stderr)
+	}
+
+	if exitCode, stderr, err := run("run", "--tag", "docs"); err != nil || exitCode > 0 {
+		t.Errorf("expected the docs commands to pass, got exit code %d, error %v:\n%s", exitCode, err, stderr)
+	}
+
+	if _, _, err := run("run", "--tag", "unknown"); err == nil || !strings.Contains(err.Error(), `no command is tagged "unknown"`) {
+		t.Errorf("expected an error for an unknown tag, got %v", err)
+	}
+	if _, _, err := run("run", "audit", "--tag", "fast"); err == nil {
+		t.Error("expected an error for a command name with --tag")
+	}
+}
+
+func TestRunCommands_InOrder(t *testing.T) {
+	cfg := &config.Config{
+		Version: "1.0",
+		Commands: map[string]*config.CommandConfig{
+			"build":     {Command: "false", Tags: []string{"ci"}},
+			"typecheck": {Command: "true", DependsOn: []string{"build"}, Tags: []string{"ci"}},
+			"test":      {Command: "true", DependsOn: []string{"build"}, Tags: []string{"ci"}},
+		},
+	}
+	names, err := taggedCommands(cfg, "ci")
+	if err != nil {
+		t.Fatalf("taggedCommands() error = %v", err)
+	}
+	if want := []string{"build", "test", "typecheck"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("taggedCommands() = %v, want %v", names, want)
+	}
+
+	results, err := runCommands(cfg, names, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("runCommands() error = %v", err)
+	}
+	// The shared prerequisite runs once and its failure skips both dependents
+	var got []string
+	for _, result := range results {
+		got = append(got, result.Command+":"+result.SkippedBy)
+	}
+	if want := []string{"build:", "test:build", "typecheck:build"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("runCommands() ran %v, want %v", got, want)
+	}
+}
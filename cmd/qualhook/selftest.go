@@ -0,0 +1,79 @@
+// Package main provides the selftest command for qualhook
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bebsworthy/qualhook/internal/selftest"
+	"github.com/spf13/cobra"
+)
+
+// selftestCmd represents the selftest command
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Verify qualhook works in this environment",
+	Long: `Run a set of safe diagnostic checks to verify qualhook works in this environment.
+
+The selftest command will:
+  • Run harmless commands (echo, true, false) through the real executor
+  • Verify exit codes and output are captured correctly
+  • Confirm that security validation rejects unsafe commands and patterns
+  • Report environment capabilities such as shell and symlink support
+
+No project configuration is required and no project files are touched.
+
+Exit codes:
+  0 - All checks passed
+  1 - One or more checks failed`,
+	Example: `  # Run all self-test checks
+  qualhook selftest
+
+  # Show details of each executed command
+  qualhook --debug selftest`,
+	RunE: runSelftest,
+}
+
+func runSelftest(cmd *cobra.Command, args []string) error {
+	report := selftest.NewRunner().Run()
+	printSelftestReport(outputWriter, report)
+
+	if !report.Passed() {
+		return fmt.Errorf("selftest failed")
+	}
+	return nil
+}
+
+// printSelftestReport writes a human-readable self-test report
+func printSelftestReport(w io.Writer, report *selftest.Report) {
+	caps := report.Capabilities
+
+	_, _ = fmt.Fprintln(w, "🔍 Environment:")                                                           //nolint:errcheck // Best effort output
+	_, _ = fmt.Fprintf(w, "   Platform: %s/%s\n", caps.OS, caps.Arch)                                  //nolint:errcheck // Best effort output
+	_, _ = fmt.Fprintf(w, "   Shell: %s\n", capabilityText(caps.ShellAvailable, caps.Shell))           //nolint:errcheck // Best effort output
+	_, _ = fmt.Fprintf(w, "   Symlinks: %s\n", capabilityText(caps.SymlinkSupport, "supported"))       //nolint:errcheck // Best effort output
+	_, _ = fmt.Fprintf(w, "   Temp directory: %s\n", capabilityText(caps.TempDirWritable, "writable")) //nolint:errcheck // Best effort output
+
+	_, _ = fmt.Fprintln(w, "\n🧪 Checks:") //nolint:errcheck // Best effort output
+	for _, check := range report.Checks {
+		mark := "✓"
+		if !check.Passed {
+			mark = "✗"
+		}
+		_, _ = fmt.Fprintf(w, "   %s %s (%s)\n", mark, check.Name, check.Details) //nolint:errcheck // Best effort output
+	}
+
+	if report.Passed() {
+		_, _ = fmt.Fprintln(w, "\n✅ All checks passed!") //nolint:errcheck // Best effort output
+	} else {
+		_, _ = fmt.Fprintln(w, "\n❌ Some checks failed. Run with --debug for details.") //nolint:errcheck // Best effort output
+	}
+}
+
+// capabilityText renders a capability value for display
+func capabilityText(available bool, value string) string {
+	if !available {
+		return "not available"
+	}
+	return value
+}
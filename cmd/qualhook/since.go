@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// gitTimeout bounds the git commands listing changed files
+const gitTimeout = 30 * time.Second
+
+// changedFilesSince lists the files changed relative to the git ref, in the
+// working tree and the index, relative to the working directory. Deleted
+// files are left out as there is nothing left to check.
+func changedFilesSince(ref string) ([]string, error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid --since ref %q", ref)
+	}
+
+	git := executor.NewCommandExecutor(gitTimeout)
+	options := executor.ExecOptions{InheritEnv: true}
+
+	result, err := git.Execute("git", []string{"rev-parse", "--is-inside-work-tree"}, options)
+	if err == nil && result.Error != nil && result.ExitCode < 0 {
+		err = result.Error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("--since requires git: %w", err)
+	}
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("--since requires a git repository, but the working directory is not inside one")
+	}
+
+	result, err = git.Execute("git", []string{"diff", "--name-only", "--relative", "--diff-filter=d", ref, "--"}, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed since %s: %w", ref, err)
+	}
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to list files changed since %s: %s", ref, strings.TrimSpace(result.Stderr))
+	}
+
+	files := explicitFiles(strings.Split(result.Stdout, "\n"))
+	debug.Log("Files changed since %s: %v", ref, files)
+	return files, nil
+}
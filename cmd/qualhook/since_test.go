@@ -0,0 +1,144 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initSinceRepo creates a git repository with a committed lint script and
+// configuration, and makes it the working directory
+func initSinceRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "lint.sh")
+	if err := os.WriteFile(script, []byte("echo 'src/a.js:1: error: lint failed' >&2\nexit 1\n"), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	data := `{"version": "1.0", "commands": {"lint": {"command": "sh", "args": ["` + script + `"]}}}`
+	writeRepoFile(t, dir, ".qualhook.json", data)
+	writeRepoFile(t, dir, "src/a.js", "a\n")
+	writeRepoFile(t, dir, "README.md", "readme\n")
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+
+	chdir(t, dir)
+	return dir
+}
+
+// writeRepoFile writes a file under dir, creating its directory
+func writeRepoFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// chdir changes the working directory for the rest of the test
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldDir) }) //nolint:errcheck // Best effort restore
+}
+
+// runSince runs lint --since ref and returns the exit code, output and error
+func runSince(t *testing.T, ref string) (int, string, string, error) {
+	t.Helper()
+	root := newRootCmd()
+	oldConfigPath := configPath
+	configPath = ""
+	defer func() { configPath, sinceRef = oldConfigPath, "" }()
+
+	t.Setenv("CLAUDE_HOOK_INPUT", "")
+	oldStdin := hookStdin
+	hookStdin = func() io.Reader { return nil }
+	defer func() { hookStdin = oldStdin }()
+
+	var stdout, stderr bytes.Buffer
+	oldOut, oldErr, oldExit := outputWriter, errorWriter, osExit
+	exitCode := 0
+	outputWriter, errorWriter = &stdout, &stderr
+	osExit = func(code int) { exitCode = code }
+	defer func() { outputWriter, errorWriter, osExit = oldOut, oldErr, oldExit }()
+
+	root.SetArgs([]string{"lint", "--since", ref})
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+	err := root.Execute()
+	return exitCode, stdout.String(), stderr.String(), err
+}
+
+func TestSince_RunsChangedFiles(t *testing.T) {
+	dir := initSinceRepo(t)
+	writeRepoFile(t, dir, "src/a.js", "changed\n")
+
+	exitCode, stdout, stderr, err := runSince(t, "HEAD")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if exitCode != 2 || !strings.Contains(stderr, "lint failed") {
+		t.Errorf("expected the changed files to be linted, got exit code %d:\n%s%s", exitCode, stdout, stderr)
+	}
+}
+
+func TestSince_NoChanges(t *testing.T) {
+	initSinceRepo(t)
+
+	exitCode, stdout, stderr, err := runSince(t, "HEAD")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if exitCode != 0 || !strings.Contains(stdout, "No files changed since HEAD") {
+		t.Errorf("expected nothing to do, got exit code %d:\n%s%s", exitCode, stdout, stderr)
+	}
+}
+
+func TestSince_Errors(t *testing.T) {
+	initSinceRepo(t)
+	if _, _, _, err := runSince(t, "no-such-ref"); err == nil || !strings.Contains(err.Error(), "no-such-ref") {
+		t.Errorf("expected an unknown ref to fail, got %v", err)
+	}
+	if _, _, _, err := runSince(t, "--output=x"); err == nil || !strings.Contains(err.Error(), "invalid --since ref") {
+		t.Errorf("expected an option-like ref to be rejected, got %v", err)
+	}
+
+	// Outside a repository there are no changes to find
+	dir := t.TempDir()
+	writeRepoFile(t, dir, ".qualhook.json", `{"version": "1.0", "commands": {"lint": {"command": "echo"}}}`)
+	chdir(t, dir)
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	if _, _, _, err := runSince(t, "main"); err == nil || !strings.Contains(err.Error(), "requires a git repository") {
+		t.Errorf("expected a clear error outside a repository, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// registerConfiguredCommands registers the standard commands of the
+// configuration in use as subcommands of root. Configuration errors are left
+// to the command that runs, so they do not block unrelated subcommands.
+func registerConfiguredCommands(root *cobra.Command) {
+	cfg, err := loadRunConfig()
+	if err != nil {
+		debug.Log("Skipping standard commands: %v", err)
+		return
+	}
+	registerStandardCommands(root, cfg)
+}
+
+// registerStandardCommands adds a quality subcommand for each standard command
+// of cfg that does not name an existing subcommand
+func registerStandardCommands(root *cobra.Command, cfg *pkgconfig.Config) {
+	for _, name := range cfg.StandardCommands {
+		if isSubcommand(root, name) {
+			debug.Log("Standard command %q is already a subcommand", name)
+			continue
+		}
+		debug.Log("Registering standard command: %s", name)
+		root.AddCommand(newStandardCommand(name))
+	}
+}
+
+// isSubcommand reports whether name runs a subcommand of root
+func isSubcommand(root *cobra.Command, name string) bool {
+	if name == "help" {
+		return true
+	}
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// newStandardCommand creates the subcommand of a configured standard command
+func newStandardCommand(name string) *cobra.Command {
+	return createQualityCommand(
+		name,
+		fmt.Sprintf("Run the configured %s command", name),
+		fmt.Sprintf(`Run the configured %[1]s command for the current project.
+
+This command is listed in standardCommands of the configuration and works
+like the built-in quality commands: it executes the configured tool and
+filters its output to provide only relevant error information.
+
+Exit codes:
+  0 - The %[1]s command succeeded
+  1 - Configuration or execution error
+  2 - %[1]s errors detected (for Claude Code integration)`, name),
+		fmt.Sprintf(`  # Run %[1]s for the current project
+  qualhook %[1]s
+
+  # Run %[1]s in debug mode to see full output
+  qualhook --debug %[1]s`, name),
+	)
+}
+
+// applyStandardPrompt sets the default prompt of a configured standard command
+// on its root and path commands that have no prompt
+func applyStandardPrompt(cfg *pkgconfig.Config, commandName string) {
+	if !slices.Contains(cfg.StandardCommands, commandName) {
+		return
+	}
+
+	prompt := fmt.Sprintf("Fix the %s errors below:", commandName)
+	if cmd := cfg.Commands[commandName]; cmd != nil && cmd.Prompt == "" {
+		cmd.Prompt = prompt
+	}
+	for _, path := range cfg.Paths {
+		if cmd := path.Commands[commandName]; cmd != nil && cmd.Prompt == "" {
+			cmd.Prompt = prompt
+		}
+	}
+}
@@ -0,0 +1,98 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestRegisterStandardCommands(t *testing.T) {
+	root := newRootCmd()
+	cfg := &config.Config{
+		Version:          "1.0",
+		StandardCommands: []string{"build", "lint", "config"},
+		Commands:         map[string]*config.CommandConfig{"build": {Command: "go"}},
+	}
+	registerStandardCommands(root, cfg)
+
+	if !isSubcommand(root, "build") {
+		t.Fatal("expected build to be registered as a subcommand")
+	}
+	count := 0
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == "lint" || cmd.Name() == "config" {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Errorf("existing subcommands must not be registered again, found %d", count)
+	}
+	if isSubcommand(root, "vet") {
+		t.Error("vet is not a standard command")
+	}
+	if !isSubcommand(root, "help") {
+		t.Error("help should be a subcommand")
+	}
+
+	help := &bytes.Buffer{}
+	root.SetOut(help)
+	root.SetArgs([]string{"--help"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(help.String(), "Run the configured build command") {
+		t.Errorf("expected build in the help output, got:\n%s", help.String())
+	}
+}
+
+func TestStandardCommand_Run(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "build.sh")
+	if err := os.WriteFile(script, []byte("echo 'main.go:3: undefined: foo' >&2\nexit 1\n"), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	file := filepath.Join(dir, ".qualhook.json")
+	data := `{
+		"version": "1.0",
+		"standardCommands": ["build"],
+		"commands": {"build": {"command": "sh", "args": ["` + script + `"]}}
+	}`
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	// Creating the root command resets the flag variables
+	root := newRootCmd()
+	oldConfigPath := configPath
+	configPath = file
+	defer func() { configPath = oldConfigPath }()
+
+	var stdout, stderr bytes.Buffer
+	oldOut, oldErr, oldExit := outputWriter, errorWriter, osExit
+	exitCode := -1
+	outputWriter, errorWriter = &stdout, &stderr
+	osExit = func(code int) { exitCode = code }
+	defer func() { outputWriter, errorWriter, osExit = oldOut, oldErr, oldExit }()
+
+	registerConfiguredCommands(root)
+	root.SetArgs([]string{"build"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if exitCode != 2 {
+		t.Errorf("expected exit code 2, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "Fix the build errors below:") {
+		t.Errorf("expected the default build prompt, got:\n%s", stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "undefined: foo") {
+		t.Errorf("expected the build error, got:\n%s", stderr.String())
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// writeStatusFile writes the CI status summary for a run to path
+func writeStatusFile(path string, results []executor.ComponentExecResult) error {
+	status := newErrorReporter().BuildStatus(results)
+
+	var data []byte
+	var err error
+	if compactJSON {
+		data, err = json.Marshal(status)
+	} else {
+		data, err = json.MarshalIndent(status, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal status: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write status file: %w", err)
+	}
+	return nil
+}
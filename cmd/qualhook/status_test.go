@@ -0,0 +1,62 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestReportAndOutputResults_StatusFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status.json")
+
+	oldStatus, oldFormat := statusFile, outputFormat
+	oldOut, oldErr, oldExit := outputWriter, errorWriter, osExit
+	statusFile, outputFormat = path, reporter.FormatCompact
+	outputWriter, errorWriter = &bytes.Buffer{}, &bytes.Buffer{}
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() {
+		statusFile, outputFormat = oldStatus, oldFormat
+		outputWriter, errorWriter, osExit = oldOut, oldErr, oldExit
+	}()
+
+	cmdConfig := &config.CommandConfig{Command: "lint", ExitCodes: []int{1}}
+	results := []executor.ComponentExecResult{
+		{Path: "web/**", Command: "lint", CommandConfig: cmdConfig, ExecResult: &executor.ExecResult{}},
+		{
+			Path:           "api/**",
+			Command:        "lint",
+			CommandConfig:  cmdConfig,
+			ExecResult:     &executor.ExecResult{ExitCode: 1},
+			FilteredOutput: &filter.FilteredOutput{Lines: []string{"api/x.go:3:1: bad"}, HasErrors: true},
+		},
+	}
+
+	reportAndOutputResults(results, time.Now(), nil)
+
+	data, err := os.ReadFile(path) // #nosec G304 - test-controlled path
+	if err != nil {
+		t.Fatalf("expected status file: %v", err)
+	}
+	var status reporter.Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		t.Fatalf("invalid status file: %v", err)
+	}
+
+	if status.Passed || status.ExitCode != exitCode || status.ErrorCount != 1 {
+		t.Errorf("unexpected status %+v (process exit %d)", status, exitCode)
+	}
+	if len(status.Commands) != 2 || !status.Commands[0].Passed || status.Commands[1].Passed {
+		t.Errorf("unexpected per-command outcomes: %+v", status.Commands)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// taggedCommands returns the sorted names of the root commands carrying tag
+func taggedCommands(cfg *config.Config, tag string) ([]string, error) {
+	var names []string
+	for name, cmd := range cfg.Commands {
+		for _, t := range cmd.Tags {
+			if t == tag {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no command is tagged %q", tag)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// runCommands runs each command with its prerequisites and verification
+// commands. Several commands run in parallel unless one has prerequisites,
+// which then run once, before the commands depending on them. Results are
+// in the order of commandNames, so each command is reported as a group.
+func runCommands(cfg *config.Config, commandNames []string, extraArgs []string, editedFiles []string, stream *reporter.StreamReporter) ([]executor.ComponentExecResult, error) {
+	if len(commandNames) == 1 {
+		return runCommandSequence(cfg, commandNames[0], extraArgs, editedFiles, stream)
+	}
+	for _, name := range commandNames {
+		if order, err := cfg.Prerequisites(name); err != nil || len(order) > 0 {
+			return runCommandsInOrder(cfg, commandNames, extraArgs, editedFiles, stream)
+		}
+	}
+
+	perCommand := make([][]executor.ComponentExecResult, len(commandNames))
+	errs := make([]error, len(commandNames))
+	sem := make(chan struct{}, executor.Workers(runJobs))
+	var wg sync.WaitGroup
+	for i, name := range commandNames {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			perCommand[i], errs[i] = runCommandSequence(cfg, name, extraArgs, editedFiles, stream)
+		}(i, name)
+	}
+	wg.Wait()
+
+	var results []executor.ComponentExecResult
+	for i, name := range commandNames {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("%s: %w", name, errs[i])
+		}
+		results = append(results, perCommand[i]...)
+	}
+	return results, nil
+}
+
+// runCommandsInOrder runs the commands one after another, each after its
+// prerequisites. A command already run as a prerequisite is not run again,
+// and one depending on a failed command is skipped.
+func runCommandsInOrder(cfg *config.Config, commandNames []string, extraArgs []string, editedFiles []string, stream *reporter.StreamReporter) ([]executor.ComponentExecResult, error) {
+	requested := make(map[string]bool, len(commandNames))
+	for _, name := range commandNames {
+		requested[name] = true
+	}
+
+	var results []executor.ComponentExecResult
+	done := make(map[string]bool)
+	// failed maps each command that did not pass to the command that failed
+	failed := make(map[string]string)
+	for _, name := range commandNames {
+		order, err := cfg.Prerequisites(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, step := range append(order, name) {
+			if done[step] {
+				continue
+			}
+			done[step] = true
+
+			if failedBy := failedDependency(cfg.Commands[step], failed); failedBy != "" {
+				debug.Log("Skipping %s: prerequisite %s failed", step, failedBy)
+				failed[step] = failedBy
+				results = append(results, skippedResult(cfg, step, failedBy))
+				continue
+			}
+
+			var args []string
+			if requested[step] {
+				args = extraArgs
+			}
+			r, err := runCommandResults(cfg, step, args, editedFiles, stream)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", step, err)
+			}
+			results = append(results, r...)
+			if newErrorReporter().Report(r).ExitCode != 0 {
+				failed[step] = step
+			}
+
+			if requested[step] {
+				verifyResults, err := runVerifyCommands(cfg, cfg.Commands[step], r, editedFiles, stream)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", step, err)
+				}
+				results = append(results, verifyResults...)
+			}
+		}
+	}
+	return results, nil
+}
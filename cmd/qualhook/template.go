@@ -2,7 +2,9 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"text/tabwriter"
@@ -18,6 +20,7 @@ var (
 	templateDescription string
 	templateDir         string
 	mergeFlag           bool
+	templateListJSON    bool
 )
 
 // templateCmd represents the template command
@@ -72,14 +75,18 @@ Examples:
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List available templates",
-	Long: `List all available configuration templates.
+	Long: `List the built-in templates, the default configuration of each supported
+project type, and the templates installed in the template directory.
 
 Examples:
   # List all templates
   qualhook template list
 
   # List templates from custom directory
-  qualhook template list --dir ./templates`,
+  qualhook template list --dir ./templates
+
+  # List templates as JSON for scripting
+  qualhook template list --json`,
 	RunE: runListTemplates,
 }
 
@@ -105,6 +112,7 @@ func init() {
 
 	// List flags
 	listCmd.Flags().StringVar(&templateDir, "dir", "", "Directory to list templates from")
+	listCmd.Flags().BoolVar(&templateListJSON, "json", false, "Print the templates as JSON")
 }
 
 func runExportTemplate(cmd *cobra.Command, args []string) error {
@@ -128,6 +136,7 @@ func runExportTemplate(cmd *cobra.Command, args []string) error {
 	if templateDir != "" {
 		tm.SetTemplateDir(templateDir)
 	}
+	tm.SetCompact(compactJSON)
 
 	// Validate template
 	if err := tm.ValidateTemplate(cfg); err != nil {
@@ -167,6 +176,9 @@ func runImportTemplate(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to get current directory: %w", err)
 		}
 		outputPath = filepath.Join(cwd, config.ConfigFileName)
+		if existing, found := config.FindConfigFile(cwd); found {
+			outputPath = existing
+		}
 	}
 
 	// Handle merge if requested
@@ -201,7 +213,7 @@ func runImportTemplate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Save configuration
-	data, err := pkgconfig.SaveConfig(finalCfg)
+	data, err := marshalConfigFile(finalCfg, outputPath)
 	if err != nil {
 		return fmt.Errorf("failed to serialize configuration: %w", err)
 	}
@@ -233,24 +245,62 @@ func runListTemplates(cmd *cobra.Command, args []string) error {
 		tm.SetTemplateDir(templateDir)
 	}
 
-	// List templates
-	templates, err := tm.ListTemplates()
+	builtIn, err := config.BuiltInTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to list built-in templates: %w", err)
+	}
+	installed, err := tm.ListTemplates()
 	if err != nil {
 		return fmt.Errorf("failed to list templates: %w", err)
 	}
 
-	if len(templates) == 0 {
-		fmt.Println("No templates found.")
-		fmt.Println("\nCreate a template with: qualhook template export --name <name>")
+	out := cmd.OutOrStdout()
+	if templateListJSON {
+		templates := make([]config.TemplateInfo, 0, len(builtIn)+len(installed))
+		templates = append(append(templates, builtIn...), installed...)
+		var data []byte
+		if compactJSON {
+			data, err = json.Marshal(templates)
+		} else {
+			data, err = json.MarshalIndent(templates, "", "  ")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to marshal templates: %w", err)
+		}
+		_, _ = fmt.Fprintln(out, string(data)) //nolint:errcheck // Best effort output to stdout
 		return nil
 	}
 
-	// Display templates in a table
-	fmt.Printf("📋 Available templates (%d):\n\n", len(templates))
+	_, _ = fmt.Fprintf(out, "📋 Built-in templates (%d):\n\n", len(builtIn)) //nolint:errcheck // Best effort output
+	if err := printTemplateTable(out, builtIn, false); err != nil {
+		return err
+	}
+
+	if len(installed) == 0 {
+		_, _ = fmt.Fprintln(out, "\nNo installed templates found.")                                //nolint:errcheck // Best effort output
+		_, _ = fmt.Fprintln(out, "Create a template with: qualhook template export --name <name>") //nolint:errcheck // Best effort output
+	} else {
+		_, _ = fmt.Fprintf(out, "\n📋 Installed templates (%d):\n\n", len(installed)) //nolint:errcheck // Best effort output
+		if err := printTemplateTable(out, installed, true); err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprintln(out, "\nImport a template with: qualhook template import <name>") //nolint:errcheck // Best effort output
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(w, "NAME\tDESCRIPTION\tCREATED") //nolint:errcheck // Best effort table output
-	_, _ = fmt.Fprintln(w, "----\t-----------\t-------") //nolint:errcheck // Best effort table output
+	return nil
+}
+
+// printTemplateTable prints templates as a table, with their creation date
+// when withCreated is set
+func printTemplateTable(out io.Writer, templates []config.TemplateInfo, withCreated bool) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	header, rule := "NAME\tPROJECT TYPE\tDESCRIPTION", "----\t------------\t-----------"
+	if withCreated {
+		header, rule = header+"\tCREATED", rule+"\t-------"
+	}
+	_, _ = fmt.Fprintln(w, header) //nolint:errcheck // Best effort table output
+	_, _ = fmt.Fprintln(w, rule)   //nolint:errcheck // Best effort table output
 
 	for _, tmpl := range templates {
 		desc := tmpl.Description
@@ -260,23 +310,27 @@ func runListTemplates(cmd *cobra.Command, args []string) error {
 		if len(desc) > 50 {
 			desc = desc[:47] + "..."
 		}
+		projectType := tmpl.ProjectType
+		if projectType == "" {
+			projectType = "-"
+		}
 
-		created := tmpl.CreatedAt
-		if created != "" {
-			// Parse and format the date
-			if t, err := time.Parse(time.RFC3339, created); err == nil {
-				created = t.Format("2006-01-02")
+		row := fmt.Sprintf("%s\t%s\t%s", tmpl.Name, projectType, desc)
+		if withCreated {
+			created := tmpl.CreatedAt
+			if created != "" {
+				// Parse and format the date
+				if t, err := time.Parse(time.RFC3339, created); err == nil {
+					created = t.Format("2006-01-02")
+				}
 			}
+			row += "\t" + created
 		}
-
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", tmpl.Name, desc, created) //nolint:errcheck // Best effort table output
+		_, _ = fmt.Fprintln(w, row) //nolint:errcheck // Best effort table output
 	}
 
 	if err := w.Flush(); err != nil {
 		return fmt.Errorf("failed to flush table output: %w", err)
 	}
-
-	fmt.Println("\nImport a template with: qualhook template import <name>")
-
 	return nil
 }
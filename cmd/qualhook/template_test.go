@@ -0,0 +1,71 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/config"
+	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestRunListTemplates(t *testing.T) {
+	dir := t.TempDir()
+	tm := config.NewTemplateManager()
+	tm.SetTemplateDir(dir)
+	cfg := &pkgconfig.Config{
+		Version:     "1.0",
+		ProjectType: "go",
+		Commands:    map[string]*pkgconfig.CommandConfig{"lint": {Command: "golangci-lint"}},
+	}
+	if err := tm.ExportTemplate(cfg, "team-go", "Team Go checks"); err != nil {
+		t.Fatalf("ExportTemplate() error = %v", err)
+	}
+
+	oldDir, oldJSON := templateDir, templateListJSON
+	defer func() { templateDir, templateListJSON = oldDir, oldJSON }()
+	templateDir = dir
+
+	t.Run("text", func(t *testing.T) {
+		templateListJSON = false
+		var out bytes.Buffer
+		listCmd.SetOut(&out)
+		defer listCmd.SetOut(nil)
+		if err := runListTemplates(listCmd, nil); err != nil {
+			t.Fatalf("runListTemplates() error = %v", err)
+		}
+		for _, want := range []string{"Built-in templates (7)", "nodejs", "Installed templates (1)", "team-go", "Team Go checks"} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("expected %q in the output, got:\n%s", want, out.String())
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		templateListJSON = true
+		var out bytes.Buffer
+		listCmd.SetOut(&out)
+		defer listCmd.SetOut(nil)
+		if err := runListTemplates(listCmd, nil); err != nil {
+			t.Fatalf("runListTemplates() error = %v", err)
+		}
+
+		var templates []config.TemplateInfo
+		if err := json.Unmarshal(out.Bytes(), &templates); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, out.String())
+		}
+		if len(templates) != 8 {
+			t.Fatalf("expected 7 built-in and 1 installed template, got %+v", templates)
+		}
+		last := templates[len(templates)-1]
+		if last.Name != "team-go" || last.BuiltIn || last.ProjectType != "go" || last.Path == "" {
+			t.Errorf("unexpected installed template %+v", last)
+		}
+		if !templates[0].BuiltIn {
+			t.Errorf("expected built-in templates first, got %+v", templates[0])
+		}
+	})
+}
@@ -36,9 +36,12 @@ Exit codes:
   # Run tests matching a pattern
   qualhook test "**/user*.test.js"
 
-  # Run tests in watch mode (if supported)
+  # Re-run the tests of the components whose files change
   qualhook test --watch
 
+  # Run the suite 10 times to expose flaky tests
+  qualhook test --repeat 10
+
   # Common test runners configured:
   # JavaScript/TypeScript: jest, vitest, mocha
   # Go: go test ./...
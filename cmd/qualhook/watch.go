@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/watcher"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// watchDebounce is how long files must stay unchanged before --watch re-runs
+const watchDebounce = 300 * time.Millisecond
+
+// watchUntilInterrupted runs the command in watch mode until Ctrl-C or SIGTERM
+func watchUntilInterrupted(cfg *config.Config, commandName string, extraArgs []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return runWatch(ctx, cfg, commandName, extraArgs)
+}
+
+// runWatch runs the command, then runs it again for the components owning
+// the changed files whenever files change, until ctx is done. Failures are
+// reported without exiting.
+func runWatch(ctx context.Context, cfg *config.Config, commandName string, extraArgs []string) error {
+	root, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	// Without --watch-paths, the root and the configured paths are watched
+	dirs, err := watcher.WatchDirs(root, cfg, watchPaths, nil)
+	if err != nil {
+		return fmt.Errorf("failed to find the directories to watch: %w", err)
+	}
+	observer, err := watcher.NewObserver(root, dirs, watchDebounce)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = observer.Close() }() //nolint:errcheck // Best effort cleanup
+
+	if !quietFlag {
+		_, _ = fmt.Fprintf(outputWriter, "👀 Watching %d directories, re-running %s on changes (Ctrl-C to stop)\n", len(dirs), commandName) //nolint:errcheck // Best effort output to stdout
+	}
+	runWatchIteration(cfg, commandName, extraArgs, explicitFiles(targetFiles))
+
+	for {
+		files, err := observer.Next(ctx)
+		if ctx.Err() != nil {
+			if !quietFlag {
+				_, _ = fmt.Fprintln(outputWriter, "👋 Stopped watching") //nolint:errcheck // Best effort output to stdout
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		debug.Log("Changed files: %v", files)
+		if !quietFlag {
+			_, _ = fmt.Fprintf(outputWriter, "🔄 Re-running %s for %d changed file(s)\n", commandName, len(files)) //nolint:errcheck // Best effort output to stdout
+		}
+		runWatchIteration(cfg, commandName, extraArgs, files)
+	}
+}
+
+// runWatchIteration runs the command for the edited files, or the whole
+// project when there are none, and writes the report
+func runWatchIteration(cfg *config.Config, commandName string, extraArgs []string, editedFiles []string) {
+	results, err := runCommandSequence(cfg, commandName, extraArgs, editedFiles, nil)
+	if err != nil {
+		_, _ = fmt.Fprintf(errorWriter, "[QUALHOOK ERROR] %v\n", err) //nolint:errcheck // Best effort output to stderr
+		return
+	}
+	if len(results) == 0 {
+		debug.Log("No component runs %s for the changed files", commandName)
+		return
+	}
+	writeReport(newErrorReporter().Report(results))
+}
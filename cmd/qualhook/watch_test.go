@@ -0,0 +1,156 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// waitForOutput polls w until it contains want, failing after a timeout
+func waitForOutput(t *testing.T, w *timedWriter, want string) string {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		w.mu.Lock()
+		output := w.buf.String()
+		w.mu.Unlock()
+		if strings.Contains(output, want) {
+			return output
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	t.Fatalf("timed out waiting for %q, got:\n%s", want, w.buf.String())
+	return ""
+}
+
+func TestRunWatch(t *testing.T) {
+	dir := t.TempDir()
+	lintScript := func(name string) *config.CommandConfig {
+		script := filepath.Join(dir, name+".sh")
+		writeRepoFile(t, dir, name+".sh", "echo '"+name+"/a.js:1: error: "+name+" failed' >&2\nexit 1\n")
+		return &config.CommandConfig{Command: "sh", Args: []string{script}, ExitCodes: []int{1}, WorkingDir: dir}
+	}
+	cfg := &config.Config{
+		Version:  "1.0",
+		Commands: map[string]*config.CommandConfig{"lint": {Command: "echo", Args: []string{"root"}}},
+		Paths: []*config.PathConfig{
+			{Path: "web/**", Commands: map[string]*config.CommandConfig{"lint": lintScript("web")}},
+			{Path: "api/**", Commands: map[string]*config.CommandConfig{"lint": lintScript("api")}},
+		},
+	}
+	writeRepoFile(t, dir, "web/a.js", "a\n")
+	writeRepoFile(t, dir, "api/a.js", "a\n")
+	chdir(t, dir)
+
+	stdout, stderr := &timedWriter{}, &timedWriter{}
+	oldOut, oldErr := outputWriter, errorWriter
+	outputWriter, errorWriter = stdout, stderr
+	defer func() { outputWriter, errorWriter = oldOut, oldErr }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- runWatch(ctx, cfg, "lint", nil) }()
+
+	// The whole project is checked first
+	waitForOutput(t, stdout, "All quality checks passed")
+
+	// Then only the components whose files changed
+	writeRepoFile(t, dir, "web/a.js", "changed\n")
+	waitForOutput(t, stdout, "for 1 changed file(s)")
+	report := waitForOutput(t, stderr, "web failed")
+	if strings.Contains(report, "api failed") {
+		t.Errorf("expected only the changed component to run, got:\n%s", report)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("runWatch() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected runWatch to stop when interrupted")
+	}
+	waitForOutput(t, stdout, "Stopped watching")
+}
+
+func TestRunWatch_WatchPaths(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{
+		Version:  "1.0",
+		Commands: map[string]*config.CommandConfig{"lint": {Command: "echo", Args: []string{"root"}}},
+		Paths: []*config.PathConfig{
+			{Path: "web/**", Commands: map[string]*config.CommandConfig{"lint": {Command: "echo", Args: []string{"web"}}}},
+		},
+	}
+	writeRepoFile(t, dir, "web/src/a.js", "a\n")
+	writeRepoFile(t, dir, "api/a.js", "a\n")
+	writeRepoFile(t, dir, "docs/a.md", "a\n")
+	chdir(t, dir)
+
+	tests := []struct {
+		name       string
+		watchPaths []string
+		want       string
+	}{
+		// The root, web and web/src
+		{name: "default", want: "Watching 3 directories"},
+		// api and docs
+		{name: "watch paths", watchPaths: []string{"api", "docs"}, want: "Watching 2 directories"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watchPaths = tt.watchPaths
+			defer func() { watchPaths = nil }()
+			stdout, stderr := &timedWriter{}, &timedWriter{}
+			oldOut, oldErr := outputWriter, errorWriter
+			outputWriter, errorWriter = stdout, stderr
+			defer func() { outputWriter, errorWriter = oldOut, oldErr }()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() { done <- runWatch(ctx, cfg, "lint", nil) }()
+			waitForOutput(t, stdout, tt.want)
+			cancel()
+			if err := <-done; err != nil {
+				t.Errorf("runWatch() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestWatch_RejectsWatchPathsWithoutWatch(t *testing.T) {
+	root := newRootCmd()
+	defer func() { watchPaths = nil }()
+
+	var out bytes.Buffer
+	root.SetArgs([]string{"lint", "--watch-paths", "src/**"})
+	root.SetOut(&out)
+	root.SetErr(&out)
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "--watch-paths requires --watch") {
+		t.Errorf("expected --watch-paths without --watch to be rejected, got %v", err)
+	}
+}
+
+func TestWatch_RejectsRepeat(t *testing.T) {
+	root := newRootCmd()
+	defer func() { watchMode, repeatRuns = false, 1 }()
+
+	var out bytes.Buffer
+	root.SetArgs([]string{"lint", "--watch", "--repeat", "3"})
+	root.SetOut(&out)
+	root.SetErr(&out)
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "--watch cannot be combined") {
+		t.Errorf("expected --watch with --repeat to be rejected, got %v", err)
+	}
+}
@@ -286,8 +286,6 @@ func TestMonorepoParallelExecution(t *testing.T) {
 // TestMonorepoConfigurationInheritance tests configuration loading in monorepo context
 func TestMonorepoConfigurationInheritance(t *testing.T) {
 	// This test verifies that configs can be loaded from different directories
-	// Note: The current config loader doesn't support "extends" field,
-	// so we're just testing basic config loading
 
 	// Create test monorepo structure
 	tmpDir := t.TempDir()
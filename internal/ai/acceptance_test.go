@@ -71,7 +71,7 @@ func TestAcceptanceCriteria_Requirement2(t *testing.T) {
 	// Requirement 2.3: Clear prompt instructions
 	t.Run("2.3_prompt_instructions", func(t *testing.T) {
 		promptGen := NewPromptGenerator()
-		prompt := promptGen.GenerateConfigPrompt(".")
+		prompt := promptGen.GenerateConfigPrompt(".", 0)
 
 		// Verify prompt contains key instructions
 		assert.Contains(t, prompt, "monorepo")
@@ -164,7 +164,7 @@ func TestAcceptanceCriteria_Requirement4(t *testing.T) {
 	// Requirement 4.1: Exclude sensitive files from prompts
 	t.Run("4.1_exclude_sensitive_files", func(t *testing.T) {
 		promptGen := NewPromptGenerator()
-		prompt := promptGen.GenerateConfigPrompt(".")
+		prompt := promptGen.GenerateConfigPrompt(".", 0)
 
 		// Verify prompt instructs to exclude sensitive files
 		assert.Contains(t, prompt, ".env")
@@ -0,0 +1,88 @@
+package ai
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// APIKey is a secret for an AI tool. It formats as [REDACTED], so options
+// holding it can be logged without leaking it.
+type APIKey string
+
+// String redacts the key
+func (k APIKey) String() string {
+	if k == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// GoString redacts the key in %#v output
+func (k APIKey) GoString() string {
+	return k.String()
+}
+
+// Redact replaces every occurrence of the key in s
+func (k APIKey) Redact(s string) string {
+	if k == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, string(k), k.String())
+}
+
+// apiKeyEnvVars are the environment variables AI tools read their key from
+var apiKeyEnvVars = map[string]string{
+	"claude": "ANTHROPIC_API_KEY",
+	"gemini": "GEMINI_API_KEY",
+}
+
+// apiKeyEnvironment returns the environment passing key to the named tool,
+// or nil without a key
+func apiKeyEnvironment(toolName string, key APIKey) []string {
+	if key == "" {
+		return nil
+	}
+	envVar, ok := apiKeyEnvVars[toolName]
+	if !ok {
+		return nil
+	}
+	return []string{envVar + "=" + string(key)}
+}
+
+// ResolveAPIKey reads the AI tool key configured with apiKeyEnv or
+// apiKeyFile, the latter relative to baseDir. It returns "" when neither is set.
+func ResolveAPIKey(cfg *config.AIConfig, baseDir string) (APIKey, error) {
+	if cfg == nil {
+		return "", nil
+	}
+
+	switch {
+	case cfg.APIKeyFile != "":
+		path := cfg.APIKeyFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		// #nosec G304 - the key file is named by the user's configuration
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("ai: failed to read apiKeyFile: %w", err)
+		}
+		key := strings.TrimSpace(string(data))
+		if key == "" {
+			return "", fmt.Errorf("ai: apiKeyFile %s is empty", path)
+		}
+		return APIKey(key), nil
+	case cfg.APIKeyEnv != "":
+		key := os.Getenv(cfg.APIKeyEnv)
+		if key == "" {
+			return "", fmt.Errorf("ai: apiKeyEnv %s is not set", cfg.APIKeyEnv)
+		}
+		return APIKey(key), nil
+	default:
+		return "", nil
+	}
+}
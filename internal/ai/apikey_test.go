@@ -0,0 +1,119 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAPIKey = "sk-test-0123456789abcdef"
+
+// envCapturingExecutor records the environment a command ran with and
+// returns a fixed result
+type envCapturingExecutor struct {
+	result *executor.ExecResult
+	env    []string
+}
+
+func (e *envCapturingExecutor) Execute(_ string, _ []string, options executor.ExecOptions) (*executor.ExecResult, error) {
+	e.env = options.Environment
+	return e.result, nil
+}
+
+func TestAPIKey_Redacted(t *testing.T) {
+	key := APIKey(testAPIKey)
+	options := AIOptions{Tool: "claude", APIKey: key}
+
+	for _, formatted := range []string{
+		fmt.Sprintf("%v", key),
+		fmt.Sprintf("%+v", options),
+		fmt.Sprintf("%#v", options),
+	} {
+		assert.NotContains(t, formatted, testAPIKey)
+	}
+	assert.Equal(t, "[REDACTED]", key.String())
+	assert.Equal(t, "", APIKey("").String())
+	assert.Equal(t, "token=[REDACTED]", key.Redact("token="+testAPIKey))
+}
+
+func TestAssistant_APIKeyOnlyInToolEnvironment(t *testing.T) {
+	var logs bytes.Buffer
+	debug.SetWriter(&logs)
+	debug.Enable()
+	defer debug.SetWriter(io.Discard)
+
+	exec := &envCapturingExecutor{result: &executor.ExecResult{
+		ExitCode: 1,
+		Stderr:   "invalid key " + testAPIKey,
+	}}
+	assistant := NewAssistant(exec).(*assistantImpl)
+	tool := Tool{Name: "claude", Command: "claude", Available: true}
+
+	_, err := assistant.executeAITool(context.Background(), tool, "prompt", AIOptions{
+		WorkingDir: ".",
+		APIKey:     APIKey(testAPIKey),
+	})
+
+	assert.Equal(t, []string{"ANTHROPIC_API_KEY=" + testAPIKey}, exec.env)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), testAPIKey)
+	assert.Contains(t, err.Error(), "[REDACTED]")
+	assert.NotContains(t, logs.String(), testAPIKey)
+}
+
+func TestAssistant_NoAPIKeyEnvironment(t *testing.T) {
+	exec := &envCapturingExecutor{result: &executor.ExecResult{Stdout: "ok"}}
+	assistant := NewAssistant(exec).(*assistantImpl)
+	tool := Tool{Name: "claude", Command: "claude", Available: true}
+
+	_, err := assistant.executeAITool(context.Background(), tool, "prompt", AIOptions{WorkingDir: "."})
+	require.NoError(t, err)
+	assert.Empty(t, exec.env)
+}
+
+func TestResolveAPIKey(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.txt"), []byte(testAPIKey+"\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty.txt"), []byte("\n"), 0600))
+	t.Setenv("QUALHOOK_TEST_API_KEY", testAPIKey)
+
+	tests := []struct {
+		name    string
+		cfg     *config.AIConfig
+		want    APIKey
+		wantErr string
+	}{
+		{name: "no ai config", cfg: nil},
+		{name: "no key configured", cfg: &config.AIConfig{}},
+		{name: "from env", cfg: &config.AIConfig{APIKeyEnv: "QUALHOOK_TEST_API_KEY"}, want: testAPIKey},
+		{name: "unset env", cfg: &config.AIConfig{APIKeyEnv: "QUALHOOK_TEST_UNSET_KEY"}, wantErr: "is not set"},
+		{name: "from file", cfg: &config.AIConfig{APIKeyFile: "key.txt"}, want: testAPIKey},
+		{name: "empty file", cfg: &config.AIConfig{APIKeyFile: "empty.txt"}, wantErr: "is empty"},
+		{name: "missing file", cfg: &config.AIConfig{APIKeyFile: "missing.txt"}, wantErr: "failed to read apiKeyFile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveAPIKey(tt.cfg, dir)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.False(t, strings.Contains(err.Error(), testAPIKey))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -31,6 +31,8 @@ type assistantImpl struct {
 	toolSelectionTime time.Time
 	responseCache     map[string]*cachedResponse // Cache for AI responses
 	cacheMutex        sync.RWMutex
+	invocationSlots   chan struct{} // Bounds concurrent AI tool invocations
+	slotsOnce         sync.Once
 }
 
 // cachedResponse holds a cached AI response
@@ -65,7 +67,7 @@ func (a *assistantImpl) GenerateConfig(ctx context.Context, options AIOptions) (
 	}
 
 	// Phase 2: Generate Prompt
-	prompt := a.promptGen.GenerateConfigPrompt(options.WorkingDir)
+	prompt := a.promptGen.GenerateConfigPrompt(options.WorkingDir, options.MaxContextBytes)
 	debug.Log("Generated AI prompt with length: %d", len(prompt))
 
 	// Phase 3: Execute AI Tool
@@ -162,6 +164,9 @@ func (a *assistantImpl) SuggestCommand(ctx context.Context, commandType string,
 func (a *assistantImpl) selectTool(_ context.Context, options AIOptions) (Tool, error) {
 	// Detect available tools
 	tools, err := a.detector.DetectTools()
+	if err == nil && len(options.Tools) > 0 {
+		tools = withTools(tools, detectTools(a.executor, options.Tools))
+	}
 	if err != nil {
 		return Tool{}, NewErrorWithRecovery(
 			ErrTypeExecutionFailed,
@@ -240,9 +245,11 @@ func (a *assistantImpl) executeAITool(ctx context.Context, tool Tool, prompt str
 	cacheKey := a.generateCacheKey(tool.Name, prompt)
 
 	// Check cache for recent responses
-	if cached := a.getCachedResponse(cacheKey); cached != nil {
-		debug.Log("Using cached AI response for key: %s", cacheKey[:8])
-		return cached.response, nil
+	if !options.NoCache {
+		if cached := a.getCachedResponse(cacheKey, options.CacheDir); cached != nil {
+			debug.Log("Using cached AI response for key: %s", cacheKey[:8])
+			return cached.response, nil
+		}
 	}
 
 	// Start progress indicator if interactive
@@ -275,21 +282,39 @@ func (a *assistantImpl) executeAITool(ctx context.Context, tool Tool, prompt str
 		}()
 	}
 
-	// Execute the AI tool
+	// Execute the AI tool with the AI-specific timeout so the executor's
+	// default (quality command) timeout never applies to AI invocations
 	execOptions := executor.ExecOptions{
-		WorkingDir: options.WorkingDir,
-		InheritEnv: true,
-		Timeout:    0, // We handle timeout via context
+		WorkingDir:  options.WorkingDir,
+		Environment: apiKeyEnvironment(tool.Name, options.APIKey),
+		InheritEnv:  true,
+		Timeout:     options.Timeout,
 	}
 
-	// Build command args
-	args := buildAIToolArgs(tool.Name, prompt)
+	// Pass the prompt the way the tool expects it
+	args, stdin := tool.invocation(prompt)
+	if stdin != "" {
+		execOptions.Stdin = strings.NewReader(stdin)
+	}
+
+	// Wait for an invocation slot so parallel callers stay within the limit
+	slots := a.slots(options.MaxConcurrent)
+	select {
+	case slots <- struct{}{}:
+	case <-execCtx.Done():
+		if errors.Is(execCtx.Err(), context.Canceled) {
+			return "", NewAIError(ErrTypeUserCanceled, "AI analysis canceled by user", execCtx.Err())
+		}
+		return "", NewAIError(ErrTypeTimeout, "AI analysis timed out", execCtx.Err())
+	}
 
 	// Execute with proper context handling
 	resultChan := make(chan *executor.ExecResult, 1)
 	errChan := make(chan error, 1)
 
 	go func() {
+		// Release the slot only once the tool has actually exited
+		defer func() { <-slots }()
 		result, err := a.executor.Execute(tool.Command, args, execOptions)
 		if err != nil {
 			errChan <- err
@@ -310,21 +335,40 @@ func (a *assistantImpl) executeAITool(ctx context.Context, tool Tool, prompt str
 		return "", NewAIError(ErrTypeExecutionFailed, fmt.Sprintf("Failed to execute %s", tool.Name), err)
 
 	case result := <-resultChan:
+		if result.TimedOut {
+			return "", NewAIError(ErrTypeTimeout, "AI analysis timed out", result.Error)
+		}
 		if result.Error != nil {
 			return "", NewAIError(ErrTypeExecutionFailed, fmt.Sprintf("%s execution failed", tool.Name), result.Error)
 		}
 		if result.ExitCode != 0 {
-			debug.Log("AI tool returned non-zero exit code %d: %s", result.ExitCode, result.Stderr)
-			return "", NewAIError(ErrTypeExecutionFailed, fmt.Sprintf("%s failed with exit code %d: %s", tool.Name, result.ExitCode, result.Stderr), nil)
+			stderr := options.APIKey.Redact(result.Stderr)
+			debug.Log("AI tool returned non-zero exit code %d: %s", result.ExitCode, stderr)
+			return "", NewAIError(ErrTypeExecutionFailed, fmt.Sprintf("%s failed with exit code %d: %s", tool.Name, result.ExitCode, stderr), nil)
 		}
 
 		// Cache successful response
-		a.cacheResponse(cacheKey, result.Stdout, 10*time.Minute)
+		cached := a.cacheResponse(cacheKey, result.Stdout, DefaultCacheTTL)
+		if options.CacheDir != "" {
+			storePersistedResponse(options.CacheDir, cacheKey, cached)
+		}
 
 		return result.Stdout, nil
 	}
 }
 
+// slots returns the semaphore bounding AI tool invocations. The limit is
+// fixed by the first invocation; 0 means DefaultMaxConcurrent.
+func (a *assistantImpl) slots(maxConcurrent int) chan struct{} {
+	a.slotsOnce.Do(func() {
+		if maxConcurrent <= 0 {
+			maxConcurrent = DefaultMaxConcurrent
+		}
+		a.invocationSlots = make(chan struct{}, maxConcurrent)
+	})
+	return a.invocationSlots
+}
+
 // testAndRefineConfig tests the generated configuration and allows refinement
 func (a *assistantImpl) testAndRefineConfig(ctx context.Context, cfg *config.Config) error {
 	debug.Log("Starting command testing phase")
@@ -431,21 +475,6 @@ func (a *assistantImpl) cacheToolSelection(toolName string) {
 	a.toolSelectionTime = time.Now()
 }
 
-// buildAIToolArgs builds command arguments for the AI tool
-func buildAIToolArgs(toolName string, prompt string) []string {
-	switch toolName {
-	case "claude":
-		// Claude CLI expects the prompt as a direct argument
-		return []string{prompt}
-	case "gemini":
-		// Gemini CLI might need different args
-		return []string{"--prompt", prompt}
-	default:
-		// Default to Claude-style
-		return []string{prompt}
-	}
-}
-
 // extractCommandFromResponse attempts to extract a command from raw response text
 func extractCommandFromResponse(response string, cmdType string) *config.CommandConfig {
 	// This is a simple heuristic approach
@@ -502,32 +531,42 @@ func (a *assistantImpl) generateCacheKey(toolName, prompt string) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// getCachedResponse retrieves a cached response if it's still valid
-func (a *assistantImpl) getCachedResponse(key string) *cachedResponse {
-	a.cacheMutex.RLock()
-	defer a.cacheMutex.RUnlock()
+// getCachedResponse retrieves a cached response if it's still valid, from
+// memory or else from dir when set
+func (a *assistantImpl) getCachedResponse(key, dir string) *cachedResponse {
+	a.cacheMutex.Lock()
+	defer a.cacheMutex.Unlock()
 
 	if cached, exists := a.responseCache[key]; exists {
 		if time.Since(cached.timestamp) < cached.ttl {
 			return cached
 		}
 	}
-	return nil
+	if dir == "" {
+		return nil
+	}
+	cached := loadPersistedResponse(dir, key)
+	if cached != nil {
+		a.responseCache[key] = cached
+	}
+	return cached
 }
 
 // cacheResponse stores a response in the cache
-func (a *assistantImpl) cacheResponse(key, response string, ttl time.Duration) {
+func (a *assistantImpl) cacheResponse(key, response string, ttl time.Duration) *cachedResponse {
 	a.cacheMutex.Lock()
 	defer a.cacheMutex.Unlock()
 
-	a.responseCache[key] = &cachedResponse{
+	cached := &cachedResponse{
 		response:  response,
 		timestamp: time.Now(),
 		ttl:       ttl,
 	}
+	a.responseCache[key] = cached
 
 	// Clean up old entries
 	a.cleanupCache()
+	return cached
 }
 
 // cleanupCache removes expired entries from the cache
@@ -3,7 +3,9 @@ package ai
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -93,33 +95,47 @@ func TestAssistant_extractCommandFromResponse(t *testing.T) {
 	}
 }
 
-func TestAssistant_buildAIToolArgs(t *testing.T) {
+func TestTool_invocation(t *testing.T) {
 	tests := []struct {
-		toolName string
-		prompt   string
-		expected []string
+		name          string
+		tool          Tool
+		expected      []string
+		expectedStdin string
 	}{
 		{
-			toolName: "claude",
-			prompt:   "test prompt",
+			name:     "claude",
+			tool:     Tool{Name: "claude", Command: "claude"},
 			expected: []string{"test prompt"},
 		},
 		{
-			toolName: "gemini",
-			prompt:   "test prompt",
-			expected: []string{"--prompt", "test prompt"},
+			name:          "gemini",
+			tool:          Tool{Name: "gemini", Command: "gemini"},
+			expected:      []string{},
+			expectedStdin: "test prompt",
 		},
 		{
-			toolName: "unknown",
-			prompt:   "test prompt",
+			name:     "unknown",
+			tool:     Tool{Name: "unknown", Command: "unknown"},
 			expected: []string{"test prompt"},
 		},
+		{
+			name:     "flag template",
+			tool:     Tool{Name: "custom", Command: "custom", Args: []string{"--model", "large", "--prompt=" + PromptPlaceholder}},
+			expected: []string{"--model", "large", "--prompt=test prompt"},
+		},
+		{
+			name:          "stdin template",
+			tool:          Tool{Name: "ollama", Command: "ollama", Args: []string{"run", "llama3"}},
+			expected:      []string{"run", "llama3"},
+			expectedStdin: "test prompt",
+		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.toolName, func(t *testing.T) {
-			result := buildAIToolArgs(tt.toolName, tt.prompt)
-			assert.Equal(t, tt.expected, result)
+		t.Run(tt.name, func(t *testing.T) {
+			args, stdin := tt.tool.invocation("test prompt")
+			assert.Equal(t, tt.expected, args)
+			assert.Equal(t, tt.expectedStdin, stdin)
 		})
 	}
 }
@@ -198,3 +214,124 @@ func BenchmarkExtractCommandFromResponse(b *testing.B) {
 		extractCommandFromResponse(response, "typecheck")
 	}
 }
+
+// timeoutAwareExecutor simulates CommandExecutor timeout handling: a command
+// runs for delay and is killed at options.Timeout, or at defaultTimeout when
+// no per-invocation timeout is given
+type timeoutAwareExecutor struct {
+	delay          time.Duration
+	defaultTimeout time.Duration
+	gotTimeout     time.Duration
+}
+
+func (e *timeoutAwareExecutor) Execute(_ string, _ []string, options executor.ExecOptions) (*executor.ExecResult, error) {
+	e.gotTimeout = options.Timeout
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = e.defaultTimeout
+	}
+	if timeout < e.delay {
+		time.Sleep(timeout)
+		return &executor.ExecResult{ExitCode: -1, TimedOut: true, Error: errors.New("command timed out")}, nil
+	}
+	time.Sleep(e.delay)
+	return &executor.ExecResult{Stdout: "ok"}, nil
+}
+
+func TestAssistant_AITimeoutIndependentOfCommandTimeout(t *testing.T) {
+	tool := Tool{Name: "claude", Command: "claude", Available: true}
+
+	t.Run("AI timeout longer than command timeout", func(t *testing.T) {
+		exec := &timeoutAwareExecutor{delay: 50 * time.Millisecond, defaultTimeout: 10 * time.Millisecond}
+		assistant := NewAssistant(exec).(*assistantImpl)
+
+		response, err := assistant.executeAITool(context.Background(), tool, "prompt", AIOptions{
+			WorkingDir: ".",
+			Timeout:    time.Second,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "ok", response)
+		assert.Equal(t, time.Second, exec.gotTimeout)
+	})
+
+	t.Run("AI timeout shorter than command timeout", func(t *testing.T) {
+		exec := &timeoutAwareExecutor{delay: 2 * time.Second, defaultTimeout: time.Minute}
+		assistant := NewAssistant(exec).(*assistantImpl)
+
+		start := time.Now()
+		_, err := assistant.executeAITool(context.Background(), tool, "prompt", AIOptions{
+			WorkingDir: ".",
+			Timeout:    50 * time.Millisecond,
+		})
+		elapsed := time.Since(start)
+
+		require.Error(t, err)
+		var aiErr *AIError
+		require.True(t, errors.As(err, &aiErr))
+		assert.Equal(t, ErrTypeTimeout, aiErr.Type)
+		assert.Contains(t, err.Error(), "timed out")
+		assert.True(t, elapsed < time.Second, "expected AI timeout to fire early, took %v", elapsed)
+	})
+}
+
+// concurrencyTrackingExecutor records the peak number of simultaneous invocations
+type concurrencyTrackingExecutor struct {
+	delay  time.Duration
+	mu     sync.Mutex
+	active int
+	peak   int
+}
+
+func (e *concurrencyTrackingExecutor) Execute(_ string, _ []string, _ executor.ExecOptions) (*executor.ExecResult, error) {
+	e.mu.Lock()
+	e.active++
+	if e.active > e.peak {
+		e.peak = e.active
+	}
+	e.mu.Unlock()
+
+	time.Sleep(e.delay)
+
+	e.mu.Lock()
+	e.active--
+	e.mu.Unlock()
+	return &executor.ExecResult{Stdout: "ok"}, nil
+}
+
+func TestAssistant_MaxConcurrentAIInvocations(t *testing.T) {
+	tool := Tool{Name: "claude", Command: "claude", Available: true}
+
+	tests := []struct {
+		name          string
+		maxConcurrent int
+		wantPeak      int
+	}{
+		{"default limit", 0, DefaultMaxConcurrent},
+		{"configured limit", 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := &concurrencyTrackingExecutor{delay: 30 * time.Millisecond}
+			assistant := NewAssistant(exec).(*assistantImpl)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 6; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					// Distinct prompts so cached responses are not reused
+					_, err := assistant.executeAITool(context.Background(), tool, fmt.Sprintf("prompt %d", i), AIOptions{
+						WorkingDir:    ".",
+						Timeout:       time.Minute,
+						MaxConcurrent: tt.maxConcurrent,
+					})
+					assert.NoError(t, err)
+				}(i)
+			}
+			wg.Wait()
+
+			assert.Equal(t, tt.wantPeak, exec.peak)
+		})
+	}
+}
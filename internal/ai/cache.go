@@ -0,0 +1,117 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+)
+
+// DefaultCacheTTL is how long an AI response is reused
+const DefaultCacheTTL = 10 * time.Minute
+
+// persistedResponse is a cached AI response stored on disk
+type persistedResponse struct {
+	Response  string        `json:"response"`
+	Timestamp time.Time     `json:"timestamp"`
+	TTL       time.Duration `json:"ttl"`
+}
+
+// DefaultCacheDir returns the directory holding cached AI responses, under
+// the user cache directory ($XDG_CACHE_HOME on Linux)
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "qualhook", "ai"), nil
+}
+
+// ClearCache removes every AI response cached in dir
+func ClearCache(dir string) error {
+	entries, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list cached AI responses: %w", err)
+	}
+	for _, entry := range entries {
+		if err := os.Remove(entry); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to clear AI response cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// responseCachePath returns the cache file of the response with the given key
+func responseCachePath(dir, key string) string {
+	return filepath.Join(dir, key+".json")
+}
+
+// loadPersistedResponse returns the unexpired response with the given key
+// cached in dir. Expired entries are removed.
+func loadPersistedResponse(dir, key string) *cachedResponse {
+	path := responseCachePath(dir, key)
+	// #nosec G304 - cache files are named by qualhook under its cache directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var entry persistedResponse
+	if err := json.Unmarshal(data, &entry); err != nil {
+		debug.Log("Ignoring unreadable AI cache entry %s", key[:8])
+		return nil
+	}
+	if time.Since(entry.Timestamp) >= entry.TTL {
+		debug.Log("AI cache entry %s expired", key[:8])
+		_ = os.Remove(path) //nolint:errcheck // Best effort cleanup, expired entries are ignored
+		return nil
+	}
+	return &cachedResponse{response: entry.Response, timestamp: entry.Timestamp, ttl: entry.TTL}
+}
+
+// storePersistedResponse caches a response in dir. Failures only cost the
+// next run an AI invocation.
+func storePersistedResponse(dir, key string, cached *cachedResponse) {
+	data, err := json.Marshal(persistedResponse{
+		Response:  cached.response,
+		Timestamp: cached.timestamp,
+		TTL:       cached.ttl,
+	})
+	if err != nil {
+		debug.LogError(err, "caching AI response")
+		return
+	}
+	if err := writeCacheFile(responseCachePath(dir, key), data); err != nil {
+		debug.LogError(err, "caching AI response")
+	}
+}
+
+// writeCacheFile replaces a cache file atomically, so concurrent runs never
+// read a partial entry
+func writeCacheFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() //nolint:errcheck // Best effort cleanup, gone after the rename
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close() //nolint:errcheck // The write error is reported
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
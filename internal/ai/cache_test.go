@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingExecutor counts invocations and returns a fixed output
+type countingExecutor struct {
+	stdout string
+	calls  int
+}
+
+func (e *countingExecutor) Execute(_ string, _ []string, _ executor.ExecOptions) (*executor.ExecResult, error) {
+	e.calls++
+	return &executor.ExecResult{Stdout: e.stdout}, nil
+}
+
+var cacheTestTool = Tool{Name: "claude", Command: "claude", Available: true}
+
+func TestAssistant_PersistedCacheReusedAcrossAssistants(t *testing.T) {
+	cacheDir := t.TempDir()
+	exec := &countingExecutor{stdout: "response"}
+	options := AIOptions{WorkingDir: ".", CacheDir: cacheDir}
+
+	for i := 0; i < 2; i++ {
+		assistant := NewAssistant(exec).(*assistantImpl)
+		response, err := assistant.executeAITool(context.Background(), cacheTestTool, "prompt", options)
+		require.NoError(t, err)
+		assert.Equal(t, "response", response)
+	}
+	assert.Equal(t, 1, exec.calls, "the second assistant should reuse the persisted response")
+
+	entries, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestAssistant_PersistedCacheHonorsTTL(t *testing.T) {
+	cacheDir := t.TempDir()
+	exec := &countingExecutor{stdout: "fresh"}
+	assistant := NewAssistant(exec).(*assistantImpl)
+	key := assistant.generateCacheKey(cacheTestTool.Name, "prompt")
+
+	data, err := json.Marshal(persistedResponse{
+		Response:  "stale",
+		Timestamp: time.Now().Add(-time.Hour),
+		TTL:       DefaultCacheTTL,
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(responseCachePath(cacheDir, key), data, 0600))
+
+	response, err := assistant.executeAITool(context.Background(), cacheTestTool, "prompt",
+		AIOptions{WorkingDir: ".", CacheDir: cacheDir})
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", response)
+	assert.Equal(t, 1, exec.calls)
+}
+
+func TestAssistant_NoCacheInvokesTool(t *testing.T) {
+	cacheDir := t.TempDir()
+	exec := &countingExecutor{stdout: "response"}
+	assistant := NewAssistant(exec).(*assistantImpl)
+	options := AIOptions{WorkingDir: ".", CacheDir: cacheDir, NoCache: true}
+
+	for i := 0; i < 2; i++ {
+		_, err := assistant.executeAITool(context.Background(), cacheTestTool, "prompt", options)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, exec.calls)
+
+	// The fresh response is still cached for later runs
+	options.NoCache = false
+	_, err := NewAssistant(exec).(*assistantImpl).executeAITool(context.Background(), cacheTestTool, "prompt", options)
+	require.NoError(t, err)
+	assert.Equal(t, 2, exec.calls)
+}
+
+func TestClearCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	exec := &countingExecutor{stdout: "response"}
+	options := AIOptions{WorkingDir: ".", CacheDir: cacheDir}
+
+	_, err := NewAssistant(exec).(*assistantImpl).executeAITool(context.Background(), cacheTestTool, "prompt", options)
+	require.NoError(t, err)
+	require.NoError(t, ClearCache(cacheDir))
+
+	_, err = NewAssistant(exec).(*assistantImpl).executeAITool(context.Background(), cacheTestTool, "prompt", options)
+	require.NoError(t, err)
+	assert.Equal(t, 2, exec.calls)
+
+	assert.NoError(t, ClearCache(filepath.Join(cacheDir, "missing")))
+}
@@ -0,0 +1,164 @@
+package ai
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// contextFiles are the project files embedded in prompts, most relevant first.
+// Build and package manifests name the tools and scripts a project uses.
+var contextFiles = []string{
+	"package.json",
+	"go.mod",
+	"Makefile",
+	"pyproject.toml",
+	"Cargo.toml",
+	"pom.xml",
+	"build.gradle",
+	"build.gradle.kts",
+	"composer.json",
+	"Gemfile",
+	"requirements.txt",
+	"setup.cfg",
+	"pnpm-workspace.yaml",
+	"lerna.json",
+	"nx.json",
+	"turbo.json",
+	"tsconfig.json",
+	".golangci.yml",
+	".golangci.yaml",
+	"Taskfile.yml",
+	"justfile",
+}
+
+// minTruncatedContent is the smallest excerpt worth embedding for a file
+// that does not fit the budget; smaller ones are only listed by name
+const minTruncatedContent = 256
+
+// contextFile is a project file read for embedding
+type contextFile struct {
+	name    string
+	content string
+}
+
+// buildProjectContext embeds the key project files of dir within maxBytes
+// (0 = DefaultMaxContextBytes). Whole files are kept in order of relevance,
+// the remaining space holds the start of the first files that did not fit,
+// and any other files are listed by name.
+func buildProjectContext(dir string, maxBytes int) string {
+	if dir == "" {
+		return ""
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxContextBytes
+	}
+
+	files := readContextFiles(dir)
+	if len(files) == 0 {
+		return ""
+	}
+
+	sections := make([]string, len(files))
+	used := 0
+	for i, file := range files {
+		section := contextSection(file.name, file.content)
+		if used+len(section) <= maxBytes {
+			sections[i] = section
+			used += len(section)
+		}
+	}
+
+	var unfit []string
+	for i, file := range files {
+		if sections[i] == "" {
+			unfit = append(unfit, file.name)
+		}
+	}
+
+	// Room for listing every file that did not fit is kept for the summary
+	reserved := len(omittedFilesSummary(unfit, maxBytes-used))
+	var omitted []string
+	for i, file := range files {
+		if sections[i] != "" {
+			continue
+		}
+		if section := truncatedContextSection(file, maxBytes-used-reserved); section != "" {
+			sections[i] = section
+			used += len(section)
+			continue
+		}
+		omitted = append(omitted, file.name)
+	}
+
+	var b strings.Builder
+	for _, section := range sections {
+		b.WriteString(section)
+	}
+	if summary := omittedFilesSummary(omitted, maxBytes-used); summary != "" {
+		b.WriteString(summary)
+	}
+	return b.String()
+}
+
+// readContextFiles reads the key project files present in dir
+func readContextFiles(dir string) []contextFile {
+	var files []contextFile
+	for _, name := range contextFiles {
+		// #nosec G304 - names come from the fixed contextFiles list
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		files = append(files, contextFile{name: name, content: string(data)})
+	}
+	return files
+}
+
+// contextSection formats an embedded file
+func contextSection(name, content string) string {
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return fmt.Sprintf("--- %s ---\n%s\n", name, content)
+}
+
+// truncatedContextSection embeds the start of a file in at most budget
+// bytes, cut at a line boundary, or returns "" when too little space is left
+func truncatedContextSection(file contextFile, budget int) string {
+	header := fmt.Sprintf("--- %s (truncated) ---\n", file.name)
+	// The note is sized for the largest excerpt; a shorter one only shrinks it
+	note := truncationNote(budget, len(file.content))
+	// Leave room for a line break added to the excerpt and after the note
+	available := budget - len(header) - len(note) - 2
+	if available < minTruncatedContent {
+		return ""
+	}
+
+	excerpt := file.content[:available]
+	if cut := strings.LastIndex(excerpt, "\n"); cut > 0 {
+		excerpt = excerpt[:cut+1]
+	}
+	if !strings.HasSuffix(excerpt, "\n") {
+		excerpt += "\n"
+	}
+	return header + excerpt + truncationNote(len(excerpt), len(file.content)) + "\n"
+}
+
+// truncationNote reports how much of a file was embedded
+func truncationNote(shown, total int) string {
+	return fmt.Sprintf("[... truncated: %d of %d bytes shown]\n", shown, total)
+}
+
+// omittedFilesSummary lists the files that did not fit the budget, as many
+// as fit in the remaining budget bytes
+func omittedFilesSummary(names []string, budget int) string {
+	for n := len(names); n > 0; n-- {
+		summary := fmt.Sprintf("Other project files, not embedded: %s\n", strings.Join(names[:n], ", "))
+		if len(summary) <= budget {
+			return summary
+		}
+	}
+	return ""
+}
@@ -39,24 +39,7 @@ func (d *toolDetector) DetectTools() ([]Tool, error) {
 		return d.detectedTools, nil
 	}
 
-	// Use concurrent detection for better performance
-	tools := make([]Tool, 2)
-	var wg sync.WaitGroup
-	wg.Add(2)
-
-	// Check for Claude CLI concurrently
-	go func() {
-		defer wg.Done()
-		tools[0] = d.detectClaude()
-	}()
-
-	// Check for Gemini CLI concurrently
-	go func() {
-		defer wg.Done()
-		tools[1] = d.detectGemini()
-	}()
-
-	wg.Wait()
+	tools := detectTools(d.executor, builtInTools)
 
 	// Update cache
 	d.detectedTools = tools
@@ -90,22 +73,24 @@ func (d *toolDetector) isCacheValid() bool {
 	return time.Since(d.lastDetection) < d.cacheDuration
 }
 
-// detectClaude detects the Claude CLI tool
-func (d *toolDetector) detectClaude() Tool {
-	return d.detectTool("claude", "claude")
-}
-
-// detectGemini detects the Gemini CLI tool
-func (d *toolDetector) detectGemini() Tool {
-	return d.detectTool("gemini", "gemini")
+// detectTools detects the given tools concurrently, keeping their order
+func detectTools(exec commandExecutor, templates []Tool) []Tool {
+	tools := make([]Tool, len(templates))
+	var wg sync.WaitGroup
+	wg.Add(len(templates))
+	for i, template := range templates {
+		go func(i int, template Tool) {
+			defer wg.Done()
+			tools[i] = detectTool(exec, template)
+		}(i, template)
+	}
+	wg.Wait()
+	return tools
 }
 
-// detectTool is a generic tool detector for CLI tools
-func (d *toolDetector) detectTool(name, command string) Tool {
-	tool := Tool{
-		Name:    name,
-		Command: command,
-	}
+// detectTool checks whether the command of a tool can be run, and its version
+func detectTool(exec commandExecutor, tool Tool) Tool {
+	command := tool.Command
 
 	// Try to get version
 	options := executor.ExecOptions{
@@ -114,7 +99,7 @@ func (d *toolDetector) detectTool(name, command string) Tool {
 	}
 
 	// Try command --version
-	result, err := d.executor.Execute(command, []string{"--version"}, options)
+	result, err := exec.Execute(command, []string{"--version"}, options)
 	if err == nil && result.ExitCode == 0 {
 		tool.Available = true
 		tool.Version = extractVersion(result.Stdout)
@@ -122,7 +107,7 @@ func (d *toolDetector) detectTool(name, command string) Tool {
 	}
 
 	// Try command version (without --)
-	result, err = d.executor.Execute(command, []string{"version"}, options)
+	result, err = exec.Execute(command, []string{"version"}, options)
 	if err == nil && result.ExitCode == 0 {
 		tool.Available = true
 		tool.Version = extractVersion(result.Stdout)
@@ -131,7 +116,7 @@ func (d *toolDetector) detectTool(name, command string) Tool {
 
 	// If both version commands fail, check if we can at least run the command
 	// This handles the case where the tool exists but doesn't have a version flag
-	result, err = d.executor.Execute(command, []string{"--help"}, options)
+	result, err = exec.Execute(command, []string{"--help"}, options)
 	if err == nil && result.ExitCode == 0 {
 		tool.Available = true
 		return tool
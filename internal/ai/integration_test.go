@@ -176,13 +176,8 @@ func TestAIConfig_EndToEnd(t *testing.T) {
 			assistant.selectedTool = "claude"
 			assistant.toolSelectionTime = time.Now()
 
-			// Execute test
+			// Execute test; timeouts come from AIOptions.Timeout alone
 			ctx := context.Background()
-			if tt.options.Timeout > 0 {
-				var cancel context.CancelFunc
-				ctx, cancel = context.WithTimeout(ctx, tt.options.Timeout)
-				defer cancel()
-			}
 
 			cfg, err := assistant.GenerateConfig(ctx, tt.options)
 
@@ -618,13 +613,8 @@ func TestCancellation_AndErrorCases(t *testing.T) {
 			assistant.selectedTool = "claude"
 			assistant.toolSelectionTime = time.Now()
 
-			// Execute test
+			// Execute test; timeouts come from AIOptions.Timeout alone
 			ctx := context.Background()
-			if tt.options.Timeout > 0 {
-				var cancel context.CancelFunc
-				ctx, cancel = context.WithTimeout(ctx, tt.options.Timeout)
-				defer cancel()
-			}
 
 			cfg, err := assistant.GenerateConfig(ctx, tt.options)
 
@@ -1132,7 +1122,7 @@ func TestAIAssistant_ResponseCaching(t *testing.T) {
 			"test": {"command": "go", "args": ["test", "./..."]}
 		}
 	}`
-	cacheKey := assistant.generateCacheKey("claude", assistant.promptGen.GenerateConfigPrompt("."))
+	cacheKey := assistant.generateCacheKey("claude", assistant.promptGen.GenerateConfigPrompt(".", 0))
 	assistant.cacheResponse(cacheKey, cachedResponse, 10*time.Minute)
 
 	start := time.Now()
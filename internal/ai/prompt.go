@@ -28,7 +28,7 @@ func (p *promptGenerator) initializeTemplates() {
 }
 
 // GenerateConfigPrompt creates a prompt for full configuration generation
-func (p *promptGenerator) GenerateConfigPrompt(workingDir string) string {
+func (p *promptGenerator) GenerateConfigPrompt(workingDir string, maxContextBytes int) string {
 	template := p.templates["config"]
 
 	// Create the example response format
@@ -42,6 +42,7 @@ func (p *promptGenerator) GenerateConfigPrompt(workingDir string) string {
 	// Replace placeholders in template
 	prompt := strings.ReplaceAll(template, "{{WORKING_DIR}}", workingDir)
 	prompt = strings.ReplaceAll(prompt, "{{EXAMPLE_RESPONSE}}", string(exampleJSON))
+	prompt = strings.ReplaceAll(prompt, "{{PROJECT_FILES}}", projectFilesInfo(workingDir, maxContextBytes))
 
 	return prompt
 }
@@ -70,6 +71,8 @@ func (p *promptGenerator) GenerateCommandPrompt(commandType string, context Proj
 		}
 	}
 
+	contextInfo.WriteString(projectFilesInfo(context.WorkingDir, context.MaxContextBytes))
+
 	// Create example suggestion
 	exampleSuggestion := createExampleCommandSuggestion(commandType)
 	exampleJSON, err := json.MarshalIndent(exampleSuggestion, "", "  ")
@@ -86,9 +89,19 @@ func (p *promptGenerator) GenerateCommandPrompt(commandType string, context Proj
 	return prompt
 }
 
+// projectFilesInfo introduces the key project files embedded in a prompt,
+// or returns "" when there are none
+func projectFilesInfo(dir string, maxBytes int) string {
+	files := buildProjectContext(dir, maxBytes)
+	if files == "" {
+		return ""
+	}
+	return "\nKey project files:\n\n" + files
+}
+
 // Config generation prompt template
 const configPromptTemplate = `Analyze the project in the directory: {{WORKING_DIR}}
-
+{{PROJECT_FILES}}
 Your task is to generate a comprehensive quality check configuration for qualhook. Please:
 
 1. Detect if this is a monorepo and identify all workspaces/sub-projects
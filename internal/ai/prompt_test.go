@@ -2,6 +2,8 @@ package ai
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -63,7 +65,7 @@ func TestGenerateConfigPrompt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			prompt := pg.GenerateConfigPrompt(tt.workingDir)
+			prompt := pg.GenerateConfigPrompt(tt.workingDir, 0)
 
 			// Check that all expected content is present
 			for _, check := range tt.checks {
@@ -214,7 +216,7 @@ func TestPromptContent(t *testing.T) {
 	pg := NewPromptGenerator()
 
 	t.Run("config prompt instructions", func(t *testing.T) {
-		prompt := pg.GenerateConfigPrompt("/test/dir")
+		prompt := pg.GenerateConfigPrompt("/test/dir", 0)
 
 		// Verify important instructions are included
 		instructions := []string{
@@ -259,7 +261,7 @@ func TestPromptContent(t *testing.T) {
 
 func TestMonorepoInstructions(t *testing.T) {
 	pg := NewPromptGenerator()
-	prompt := pg.GenerateConfigPrompt("/monorepo/project")
+	prompt := pg.GenerateConfigPrompt("/monorepo/project", 0)
 
 	// Check for monorepo-specific instructions
 	monorepoChecks := []string{
@@ -324,7 +326,7 @@ func TestExampleFormats(t *testing.T) {
 
 func TestSecurityInstructions(t *testing.T) {
 	pg := NewPromptGenerator()
-	prompt := pg.GenerateConfigPrompt("/secure/project")
+	prompt := pg.GenerateConfigPrompt("/secure/project", 0)
 
 	// Verify security-related instructions
 	securityChecks := []string{
@@ -391,7 +393,7 @@ func TestProjectScenarios(t *testing.T) {
 	for _, scenario := range scenarios {
 		t.Run(scenario.name, func(t *testing.T) {
 			// Test config prompt
-			configPrompt := pg.GenerateConfigPrompt("/test/project")
+			configPrompt := pg.GenerateConfigPrompt("/test/project", 0)
 			if configPrompt == "" {
 				t.Error("Config prompt is empty")
 			}
@@ -413,3 +415,98 @@ func TestProjectScenarios(t *testing.T) {
 		})
 	}
 }
+
+// writeProjectFiles creates files with the given contents in a new directory
+func writeProjectFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGenerateConfigPrompt_EmbedsProjectFiles(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json": `{"scripts": {"lint": "eslint ."}}`,
+		"Makefile":     "test:\n\tgo test ./...\n",
+		"README.md":    "not a key project file",
+	})
+
+	prompt := NewPromptGenerator().GenerateConfigPrompt(dir, 0)
+
+	for _, want := range []string{"Key project files:", "--- package.json ---", `"lint": "eslint ."`, "--- Makefile ---", "go test ./..."} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected prompt to contain %q", want)
+		}
+	}
+	if strings.Contains(prompt, "README.md") {
+		t.Error("Expected only key project files to be embedded")
+	}
+}
+
+func TestGenerateConfigPrompt_RespectsMaxContextBytes(t *testing.T) {
+	const maxBytes = 2048
+	bigMakefile := strings.Repeat("target:\n\t@echo building the project\n", 500)
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json":     `{"name": "app", "scripts": {"test": "jest"}}`,
+		"Makefile":         bigMakefile,
+		"go.mod":           "module example.com/app\n\ngo 1.23\n",
+		"requirements.txt": strings.Repeat("some-package==1.0.0\n", 500),
+	})
+
+	pg := NewPromptGenerator()
+	bare := pg.GenerateConfigPrompt(t.TempDir(), maxBytes)
+	prompt := pg.GenerateConfigPrompt(dir, maxBytes)
+
+	if embedded := len(prompt) - len(bare) - len("\nKey project files:\n\n"); embedded > maxBytes {
+		t.Errorf("Expected at most %d bytes of project context, got %d", maxBytes, embedded)
+	}
+
+	// The small, most relevant files are kept whole
+	for _, want := range []string{"--- package.json ---", `"test": "jest"`, "--- go.mod ---", "module example.com/app"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected prompt to contain %q", want)
+		}
+	}
+
+	// Over-budget files are truncated or summarized
+	if !strings.Contains(prompt, "--- Makefile (truncated) ---") || !strings.Contains(prompt, "[... truncated:") {
+		t.Error("Expected the Makefile to be truncated")
+	}
+	if !strings.Contains(prompt, "Other project files, not embedded: requirements.txt") {
+		t.Error("Expected requirements.txt to be listed as not embedded")
+	}
+}
+
+func TestBuildProjectContext_Budget(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"package.json": strings.Repeat("x", 100) + "\n",
+		"go.mod":       strings.Repeat("module line\n", 1000),
+		"Makefile":     strings.Repeat("all:\n", 1000),
+	})
+
+	for _, maxBytes := range []int{50, 200, 500, 1000, 4000} {
+		if got := buildProjectContext(dir, maxBytes); len(got) > maxBytes {
+			t.Errorf("maxBytes %d: got %d bytes:\n%s", maxBytes, len(got), got)
+		}
+	}
+
+	if got := buildProjectContext(dir, 0); len(got) > DefaultMaxContextBytes || !strings.Contains(got, "--- package.json ---") {
+		t.Errorf("Expected the default budget to apply, got %d bytes", len(got))
+	}
+	if got := buildProjectContext("", 0); got != "" {
+		t.Errorf("Expected no context without a directory, got %q", got)
+	}
+}
+
+func TestGenerateCommandPrompt_EmbedsProjectFiles(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{"go.mod": "module example.com/app\n"})
+
+	prompt := NewPromptGenerator().GenerateCommandPrompt("lint", ProjectContext{WorkingDir: dir})
+	if !strings.Contains(prompt, "--- go.mod ---") {
+		t.Error("Expected the command prompt to embed go.mod")
+	}
+}
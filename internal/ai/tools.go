@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// PromptPlaceholder marks the argument of a tool's argv template that is
+// replaced by the prompt
+const PromptPlaceholder = "{prompt}"
+
+// builtInTools are the adapters of the supported AI CLIs, in detection order
+var builtInTools = []Tool{
+	// Claude CLI takes the prompt as its argument
+	{Name: "claude", Command: "claude", Args: []string{PromptPlaceholder}},
+	// Gemini CLI answers a prompt piped on stdin without starting a session,
+	// which also keeps large project contexts off the command line
+	{Name: "gemini", Command: "gemini", Args: []string{}},
+}
+
+// builtInTool returns the adapter of a supported AI CLI
+func builtInTool(name string) (Tool, bool) {
+	for _, tool := range builtInTools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return Tool{}, false
+}
+
+// ConfiguredTools returns the AI tools registered in the ai.tools setting,
+// sorted by name
+func ConfiguredTools(cfg *config.AIConfig) []Tool {
+	if cfg == nil {
+		return nil
+	}
+
+	tools := make([]Tool, 0, len(cfg.Tools))
+	for name, toolCfg := range cfg.Tools {
+		if toolCfg == nil {
+			continue
+		}
+		tools = append(tools, Tool{
+			Name:    name,
+			Command: toolCfg.Command,
+			Args:    append([]string{}, toolCfg.Args...),
+		})
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools
+}
+
+// invocation returns the arguments invoking tool with prompt and the input
+// for its stdin, which holds the prompt when the argv template has no
+// PromptPlaceholder. Tools without a template use the adapter of the
+// built-in tool of the same name, or take the prompt as their argument.
+func (t Tool) invocation(prompt string) (args []string, stdin string) {
+	template := t.Args
+	if template == nil {
+		builtIn, ok := builtInTool(t.Name)
+		if !ok {
+			return []string{prompt}, ""
+		}
+		template = builtIn.Args
+	}
+
+	args = make([]string, len(template))
+	placed := false
+	for i, arg := range template {
+		if strings.Contains(arg, PromptPlaceholder) {
+			arg = strings.ReplaceAll(arg, PromptPlaceholder, prompt)
+			placed = true
+		}
+		args[i] = arg
+	}
+	if !placed {
+		return args, prompt
+	}
+	return args, ""
+}
+
+// withTools returns detected with tools added, replacing detected tools of
+// the same name
+func withTools(detected, tools []Tool) []Tool {
+	merged := make([]Tool, 0, len(detected)+len(tools))
+	for _, tool := range detected {
+		replaced := false
+		for _, custom := range tools {
+			if custom.Name == tool.Name {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, tool)
+		}
+	}
+	return append(merged, tools...)
+}
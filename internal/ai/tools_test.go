@@ -0,0 +1,100 @@
+package ai
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// invocationRecorder answers version checks and records the AI invocation
+type invocationRecorder struct {
+	command string
+	args    []string
+	stdin   string
+}
+
+func (e *invocationRecorder) Execute(command string, args []string, options executor.ExecOptions) (*executor.ExecResult, error) {
+	if len(args) == 1 && (args[0] == "--version" || args[0] == "version" || args[0] == "--help") {
+		if command == "claude" {
+			return &executor.ExecResult{ExitCode: 127}, nil
+		}
+		return &executor.ExecResult{Stdout: command + " 1.0.0"}, nil
+	}
+
+	e.command, e.args, e.stdin = command, args, ""
+	if options.Stdin != nil {
+		data, err := io.ReadAll(options.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		e.stdin = string(data)
+	}
+	return &executor.ExecResult{Stdout: "response"}, nil
+}
+
+func TestConfiguredTools(t *testing.T) {
+	tools := ConfiguredTools(&config.AIConfig{Tools: map[string]*config.AIToolConfig{
+		"ollama": {Command: "ollama", Args: []string{"run", "llama3"}},
+		"aider":  {Command: "aider", Args: []string{"--message", PromptPlaceholder}},
+		"plain":  {Command: "plain"},
+	}})
+
+	require.Len(t, tools, 3)
+	assert.Equal(t, Tool{Name: "aider", Command: "aider", Args: []string{"--message", PromptPlaceholder}}, tools[0])
+	assert.Equal(t, "ollama", tools[1].Name)
+	// A tool without arguments reads the prompt from stdin
+	args, stdin := tools[2].invocation("prompt")
+	assert.Empty(t, args)
+	assert.Equal(t, "prompt", stdin)
+
+	assert.Nil(t, ConfiguredTools(nil))
+}
+
+func TestAssistant_GeminiPromptOnStdin(t *testing.T) {
+	exec := &invocationRecorder{}
+	assistant := NewAssistant(exec).(*assistantImpl)
+	tool, ok := builtInTool("gemini")
+	require.True(t, ok)
+
+	_, err := assistant.executeAITool(context.Background(), tool, "analyze this project", AIOptions{WorkingDir: "."})
+	require.NoError(t, err)
+
+	assert.Equal(t, "gemini", exec.command)
+	assert.Empty(t, exec.args)
+	assert.Equal(t, "analyze this project", exec.stdin)
+}
+
+func TestAssistant_SelectsConfiguredTool(t *testing.T) {
+	exec := &invocationRecorder{}
+	assistant := NewAssistant(exec).(*assistantImpl)
+	options := AIOptions{
+		Tool:       "ollama",
+		WorkingDir: ".",
+		Tools:      []Tool{{Name: "ollama", Command: "ollama", Args: []string{"run", "llama3"}}},
+	}
+
+	tool, err := assistant.selectTool(context.Background(), options)
+	require.NoError(t, err)
+	assert.True(t, tool.Available)
+
+	_, err = assistant.executeAITool(context.Background(), tool, "prompt", options)
+	require.NoError(t, err)
+	assert.Equal(t, "ollama", exec.command)
+	assert.Equal(t, []string{"run", "llama3"}, exec.args)
+	assert.Equal(t, "prompt", exec.stdin)
+}
+
+func TestWithTools_ReplacesBuiltInTools(t *testing.T) {
+	detected := []Tool{{Name: "claude", Command: "claude"}, {Name: "gemini", Command: "gemini"}}
+	custom := []Tool{{Name: "claude", Command: "claude", Args: []string{"-p", PromptPlaceholder}}}
+
+	merged := withTools(detected, custom)
+	require.Len(t, merged, 2)
+	assert.Equal(t, "gemini", merged[0].Name)
+	assert.Equal(t, []string{"-p", PromptPlaceholder}, merged[1].Args)
+}
@@ -23,10 +23,42 @@ type AIOptions struct {
 	// TestCommands indicates whether to test commands before returning
 	TestCommands bool
 
-	// Timeout is the maximum time for AI execution (0 = no limit)
+	// Timeout is the maximum time for AI tool execution, independent of the
+	// quality command timeouts (0 = executor default)
 	Timeout time.Duration
+
+	// MaxConcurrent limits simultaneous AI tool invocations to avoid rate
+	// limits (0 = DefaultMaxConcurrent)
+	MaxConcurrent int
+
+	// MaxContextBytes limits the project file content embedded in prompts
+	// (0 = DefaultMaxContextBytes)
+	MaxContextBytes int
+
+	// APIKey is passed to the AI tool only, in the environment variable it
+	// reads its key from; it is redacted from logs and errors
+	APIKey APIKey
+
+	// CacheDir persists AI responses across runs; empty keeps them in memory
+	CacheDir string
+
+	// NoCache always invokes the AI tool; the fresh response is still cached
+	NoCache bool
+
+	// Tools are AI CLIs offered besides claude and gemini, replacing those of
+	// the same name
+	Tools []Tool
 }
 
+// DefaultTimeout is the AI tool timeout used when none is configured
+const DefaultTimeout = 5 * time.Minute
+
+// DefaultMaxConcurrent is the AI invocation limit used when none is configured
+const DefaultMaxConcurrent = 1
+
+// DefaultMaxContextBytes is the project context budget used when none is configured
+const DefaultMaxContextBytes = 16 * 1024
+
 // Tool represents an available AI CLI tool
 type Tool struct {
 	// Name of the tool ("claude" or "gemini")
@@ -40,6 +72,10 @@ type Tool struct {
 
 	// Available indicates whether tool is installed and accessible
 	Available bool
+
+	// Args is the argv template of an invocation. PromptPlaceholder is
+	// replaced by the prompt; without it the prompt is written to stdin.
+	Args []string
 }
 
 // CommandSuggestion represents an AI-suggested command configuration
@@ -70,6 +106,13 @@ type ProjectContext struct {
 
 	// CustomCommands are additional command types beyond the standard ones
 	CustomCommands []string
+
+	// WorkingDir is the project directory whose key files are embedded in
+	// the prompt (empty = none)
+	WorkingDir string
+
+	// MaxContextBytes limits the embedded file content (0 = DefaultMaxContextBytes)
+	MaxContextBytes int
 }
 
 // TestResult contains the results of testing a command
@@ -144,8 +187,9 @@ type ToolDetector interface {
 
 // PromptGenerator creates prompts for AI tools
 type PromptGenerator interface {
-	// GenerateConfigPrompt creates a prompt for full configuration generation
-	GenerateConfigPrompt(workingDir string) string
+	// GenerateConfigPrompt creates a prompt for full configuration generation,
+	// embedding at most maxContextBytes of key project files
+	GenerateConfigPrompt(workingDir string, maxContextBytes int) string
 
 	// GenerateCommandPrompt creates a prompt for specific command suggestion
 	GenerateCommandPrompt(commandType string, context ProjectContext) string
@@ -0,0 +1,31 @@
+// Package ansi provides ANSI color support for qualhook's terminal output.
+package ansi
+
+import (
+	"os"
+	"strings"
+)
+
+// ANSI color codes
+const (
+	Red   = "\x1b[31m"
+	Green = "\x1b[32m"
+	Bold  = "\x1b[1m"
+	reset = "\x1b[0m"
+)
+
+// Enabled reports whether output should be colored: only on a terminal,
+// and never with noColor or the NO_COLOR environment variable set
+// (https://no-color.org)
+func Enabled(isTerminal, noColor bool) bool {
+	return isTerminal && !noColor && os.Getenv("NO_COLOR") == ""
+}
+
+// Paint wraps text in the color codes when enabled, and returns it
+// unchanged otherwise
+func Paint(enabled bool, text string, codes ...string) string {
+	if !enabled || text == "" || len(codes) == 0 {
+		return text
+	}
+	return strings.Join(codes, "") + text + reset
+}
@@ -0,0 +1,35 @@
+//go:build unit
+
+package ansi
+
+import "testing"
+
+func TestEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if !Enabled(true, false) {
+		t.Error("expected color on a terminal")
+	}
+	if Enabled(false, false) {
+		t.Error("expected no color off a terminal")
+	}
+	if Enabled(true, true) {
+		t.Error("expected no color with --no-color")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if Enabled(true, false) {
+		t.Error("expected no color with NO_COLOR set")
+	}
+}
+
+func TestPaint(t *testing.T) {
+	if got := Paint(true, "failed", Bold, Red); got != "\x1b[1m\x1b[31mfailed\x1b[0m" {
+		t.Errorf("Paint() = %q", got)
+	}
+	if got := Paint(false, "failed", Red); got != "failed" {
+		t.Errorf("Paint() with color disabled = %q, want the text unchanged", got)
+	}
+	if got := Paint(true, "", Red); got != "" {
+		t.Errorf("Paint() of empty text = %q, want empty", got)
+	}
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// cacheFormat is bumped whenever cached entries can no longer be read back
+const cacheFormat = 1
+
+// cacheSettleTime is how long a file must be unmodified before it is cached.
+// A file changed again within the timestamp resolution of the file system
+// could otherwise keep its modification time and size and be served stale.
+const cacheSettleTime = 2 * time.Second
+
+// cacheEntry is a parsed and validated configuration together with the
+// files it was loaded from
+type cacheEntry struct {
+	Format  int            `json:"format"`
+	Profile string         `json:"profile,omitempty"`
+	Files   []cachedFile   `json:"files"`
+	Config  *config.Config `json:"config"`
+}
+
+// cachedFile identifies the version of a configuration file a cache entry
+// was built from
+type cachedFile struct {
+	Path    string `json:"path"`
+	ModTime int64  `json:"modTime"`
+	Size    int64  `json:"size"`
+}
+
+// DefaultCacheDir returns the directory holding cached configurations, under
+// the user cache directory ($XDG_CACHE_HOME on Linux)
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "qualhook", "config"), nil
+}
+
+// cachePath returns the cache file of the configuration file at absPath,
+// loaded with the given profile
+func (l *Loader) cachePath(absPath string) string {
+	sum := sha256.Sum256([]byte(absPath + "\x00" + l.Profile))
+	return filepath.Join(l.CacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// loadCached returns the cached configuration of the file at path when no
+// file it was loaded from changed since
+func (l *Loader) loadCached(path string) (*config.Config, bool) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, false
+	}
+
+	// #nosec G304 - cache files are named by qualhook under its cache directory
+	data, err := os.ReadFile(l.cachePath(absPath))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Format != cacheFormat || entry.Config == nil {
+		debug.Log("Ignoring unreadable config cache entry for %s", absPath)
+		return nil, false
+	}
+	if entry.Profile != l.Profile || len(entry.Files) == 0 || entry.Files[0].Path != absPath {
+		return nil, false
+	}
+	for _, file := range entry.Files {
+		current, err := statCachedFile(file.Path)
+		if err != nil || current != file {
+			debug.Log("Config cache entry for %s is stale", absPath)
+			return nil, false
+		}
+	}
+
+	debug.Log("Using cached config for %s", absPath)
+	return entry.Config, true
+}
+
+// storeCached caches a validated configuration loaded from files, the first
+// of which is the file that was loaded. Failures only cost the next load.
+func (l *Loader) storeCached(cfg *config.Config, files []string) {
+	entry := cacheEntry{Format: cacheFormat, Profile: l.Profile, Config: cfg}
+	for _, path := range files {
+		file, err := statCachedFile(path)
+		if err != nil {
+			debug.LogError(err, "caching config")
+			return
+		}
+		if time.Since(time.Unix(0, file.ModTime)) < cacheSettleTime {
+			debug.Log("Not caching config: %s was just modified", path)
+			return
+		}
+		entry.Files = append(entry.Files, file)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		debug.LogError(err, "caching config")
+		return
+	}
+	if err := writeCacheFile(l.cachePath(files[0]), data); err != nil {
+		debug.LogError(err, "caching config")
+	}
+}
+
+// statCachedFile returns the current version of a configuration file
+func statCachedFile(path string) (cachedFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return cachedFile{}, err
+	}
+	return cachedFile{Path: path, ModTime: info.ModTime().UnixNano(), Size: info.Size()}, nil
+}
+
+// writeCacheFile replaces a cache file atomically, so concurrent runs never
+// read a partial entry
+func writeCacheFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() //nolint:errcheck // Best effort cleanup, gone after the rename
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close() //nolint:errcheck // The write error is reported
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
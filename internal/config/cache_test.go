@@ -0,0 +1,132 @@
+//go:build unit
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeSettledConfigFile writes a config file old enough to be cached
+func writeSettledConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+	writeConfigFile(t, path, content)
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatalf("Failed to age test config: %v", err)
+	}
+}
+
+// cacheEntries returns the cache files in dir
+func cacheEntries(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func TestLoader_CacheHit(t *testing.T) {
+	tempDir := t.TempDir()
+	cacheDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ConfigFileName)
+	writeSettledConfigFile(t, configPath, `{"version": "1.0", "commands": {"lint": {"command": "eslint"}}}`)
+
+	loader := &Loader{CacheDir: cacheDir}
+	if _, err := loader.LoadFromPath(configPath); err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+	entries := cacheEntries(t, cacheDir)
+	if len(entries) != 1 {
+		t.Fatalf("expected one cache entry, got %v", entries)
+	}
+
+	// A cache hit skips parsing: an edited entry is returned as is
+	data, err := os.ReadFile(entries[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(entries[0], []byte(strings.Replace(string(data), `"eslint"`, `"cached-eslint"`, 1)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loader.LoadFromPath(configPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+	if got := cfg.Commands["lint"].Command; got != "cached-eslint" {
+		t.Errorf("expected the cached config, got command %q", got)
+	}
+}
+
+func TestLoader_CacheInvalidatedByChanges(t *testing.T) {
+	tempDir := t.TempDir()
+	cacheDir := t.TempDir()
+	basePath := filepath.Join(tempDir, "base.json")
+	configPath := filepath.Join(tempDir, ConfigFileName)
+	writeSettledConfigFile(t, basePath, `{"version": "1.0", "commands": {"lint": {"command": "eslint"}}}`)
+	writeSettledConfigFile(t, configPath, `{"version": "1.0", "extends": "base.json", "commands": {"test": {"command": "jest"}}}`)
+
+	loader := &Loader{CacheDir: cacheDir}
+	if _, err := loader.LoadFromPath(configPath); err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+
+	// Changing the extended file invalidates the entry of the child
+	writeSettledConfigFile(t, basePath, `{"version": "1.0", "commands": {"lint": {"command": "golangci-lint"}}}`)
+
+	cfg, err := loader.LoadFromPath(configPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+	if got := cfg.Commands["lint"].Command; got != "golangci-lint" {
+		t.Errorf("expected the changed base config, got command %q", got)
+	}
+}
+
+func TestLoader_CacheSkipsRecentlyModifiedFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	cacheDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ConfigFileName)
+	writeConfigFile(t, configPath, `{"version": "1.0", "commands": {"lint": {"command": "eslint"}}}`)
+
+	loader := &Loader{CacheDir: cacheDir}
+	if _, err := loader.LoadFromPath(configPath); err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+	if entries := cacheEntries(t, cacheDir); len(entries) != 0 {
+		t.Errorf("expected a just-written config not to be cached, got %v", entries)
+	}
+}
+
+func TestLoader_CachePerProfile(t *testing.T) {
+	tempDir := t.TempDir()
+	cacheDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ConfigFileName)
+	writeSettledConfigFile(t, configPath, `{
+  "version": "1.0",
+  "commands": {"test": {"command": "go", "args": ["test", "./..."]}},
+  "profiles": {"ci": {"commands": {"test": {"args": ["test", "-race", "./..."]}}}}
+}`)
+
+	for _, profile := range []string{"", "ci", ""} {
+		cfg, err := (&Loader{CacheDir: cacheDir, Profile: profile}).LoadFromPath(configPath)
+		if err != nil {
+			t.Fatalf("LoadFromPath() error = %v", err)
+		}
+		wantArgs := 2
+		if profile == "ci" {
+			wantArgs = 3
+		}
+		if got := len(cfg.Commands["test"].Args); got != wantArgs {
+			t.Errorf("profile %q: expected %d args, got %v", profile, wantArgs, cfg.Commands["test"].Args)
+		}
+	}
+	if entries := cacheEntries(t, cacheDir); len(entries) != 2 {
+		t.Errorf("expected one cache entry per profile, got %v", entries)
+	}
+}
@@ -22,6 +22,15 @@ var (
 
 	//go:embed defaults/rust.json
 	defaultRustConfig string
+
+	//go:embed defaults/java.json
+	defaultJavaConfig string
+
+	//go:embed defaults/gradle.json
+	defaultGradleConfig string
+
+	//go:embed defaults/php.json
+	defaultPHPConfig string
 )
 
 // ProjectType represents a supported project type
@@ -40,6 +49,15 @@ const (
 	// ProjectTypeRust represents a Rust project
 	ProjectTypeRust ProjectType = "rust"
 
+	// ProjectTypeJava represents a Java project built with Maven
+	ProjectTypeJava ProjectType = "java"
+
+	// ProjectTypeGradle represents a Java or Kotlin project built with Gradle
+	ProjectTypeGradle ProjectType = "gradle"
+
+	// ProjectTypePHP represents a PHP project managed with Composer
+	ProjectTypePHP ProjectType = "php"
+
 	// ProjectTypeUnknown represents an unknown project type
 	ProjectTypeUnknown ProjectType = "unknown"
 )
@@ -61,6 +79,9 @@ func NewDefaultConfigs() (*DefaultConfigs, error) {
 		ProjectTypeGo:     defaultGoConfig,
 		ProjectTypePython: defaultPythonConfig,
 		ProjectTypeRust:   defaultRustConfig,
+		ProjectTypeJava:   defaultJavaConfig,
+		ProjectTypeGradle: defaultGradleConfig,
+		ProjectTypePHP:    defaultPHPConfig,
 	}
 
 	for projectType, configJSON := range configs {
@@ -85,6 +106,37 @@ func (dc *DefaultConfigs) GetConfig(projectType ProjectType) (*config.Config, er
 	return dc.cloneConfig(cfg), nil
 }
 
+// GetNodeJSConfig returns the Node.js default configuration with its commands
+// run through the given package manager (npm, yarn, pnpm or bun).
+// An empty package manager keeps the npm defaults.
+func (dc *DefaultConfigs) GetNodeJSConfig(packageManager string) (*config.Config, error) {
+	cfg, err := dc.GetConfig(ProjectTypeNodeJS)
+	if err != nil {
+		return nil, err
+	}
+
+	switch packageManager {
+	case "", "npm":
+		return cfg, nil
+	case "yarn", "pnpm", "bun":
+	default:
+		return nil, fmt.Errorf("unsupported package manager: %s", packageManager)
+	}
+
+	for _, cmd := range cfg.Commands {
+		if cmd.Command != "npm" {
+			continue
+		}
+		cmd.Command = packageManager
+		// "bun test" runs bun's built-in test runner, so scripts need an explicit "run"
+		if packageManager == "bun" && (len(cmd.Args) == 0 || cmd.Args[0] != "run") {
+			cmd.Args = append([]string{"run"}, cmd.Args...)
+		}
+	}
+
+	return cfg, nil
+}
+
 // GetAllTypes returns all supported project types
 func (dc *DefaultConfigs) GetAllTypes() []ProjectType {
 	types := make([]ProjectType, 0, len(dc.configs))
@@ -131,11 +183,31 @@ func (dc *DefaultConfigs) MergeWithDefaults(userConfig *config.Config, projectTy
 	if userConfig.ProjectType != "" {
 		merged.ProjectType = userConfig.ProjectType
 	}
+	if userConfig.Security != nil {
+		merged.Security = userConfig.Security.Clone()
+	}
+	if userConfig.ErrorExitCode != 0 {
+		merged.ErrorExitCode = userConfig.ErrorExitCode
+	}
+	if userConfig.DefaultCommand != "" {
+		merged.DefaultCommand = userConfig.DefaultCommand
+	}
+	if userConfig.NormalizePaths {
+		merged.NormalizePaths = true
+	}
+	if userConfig.MaxTotalRetries != 0 {
+		merged.MaxTotalRetries = userConfig.MaxTotalRetries
+	}
+	if userConfig.MaxParallel != 0 {
+		merged.MaxParallel = userConfig.MaxParallel
+	}
 
 	// Merge commands
 	for name, cmd := range userConfig.Commands {
 		merged.Commands[name] = cmd.Clone()
 	}
+	merged.Profiles = config.MergeProfiles(merged.Profiles, userConfig.Profiles)
+	merged.StandardCommands = mergeStandardCommands(merged.StandardCommands, userConfig.StandardCommands)
 
 	// Merge paths
 	if len(userConfig.Paths) > 0 {
@@ -151,14 +223,29 @@ func (dc *DefaultConfigs) MergeWithDefaults(userConfig *config.Config, projectTy
 // cloneConfig creates a deep copy of a configuration
 func (dc *DefaultConfigs) cloneConfig(cfg *config.Config) *config.Config {
 	clone := &config.Config{
-		Version:     cfg.Version,
-		ProjectType: cfg.ProjectType,
-		Commands:    make(map[string]*config.CommandConfig),
+		Version:         cfg.Version,
+		Extends:         cfg.Extends,
+		ProjectType:     cfg.ProjectType,
+		Commands:        make(map[string]*config.CommandConfig),
+		RootFallback:    cfg.RootFallback,
+		ErrorExitCode:   cfg.ErrorExitCode,
+		DefaultCommand:  cfg.DefaultCommand,
+		NormalizePaths:  cfg.NormalizePaths,
+		MaxTotalRetries: cfg.MaxTotalRetries,
+		MaxParallel:     cfg.MaxParallel,
 	}
 
+	if cfg.AI != nil {
+		ai := *cfg.AI
+		clone.AI = &ai
+	}
+	clone.Security = cfg.Security.Clone()
+
 	for name, cmd := range cfg.Commands {
 		clone.Commands[name] = cmd.Clone()
 	}
+	clone.Profiles = config.MergeProfiles(nil, cfg.Profiles)
+	clone.StandardCommands = mergeStandardCommands(nil, cfg.StandardCommands)
 
 	if len(cfg.Paths) > 0 {
 		clone.Paths = make([]*config.PathConfig, len(cfg.Paths))
@@ -177,6 +264,9 @@ func (dc *DefaultConfigs) clonePathConfig(p *config.PathConfig) *config.PathConf
 		Extends:  p.Extends,
 		Commands: make(map[string]*config.CommandConfig),
 	}
+	if p.Exclude != nil {
+		clone.Exclude = append([]string{}, p.Exclude...)
+	}
 
 	for name, cmd := range p.Commands {
 		clone.Commands[name] = cmd.Clone()
@@ -190,7 +280,7 @@ func DetectProjectType(markers []string) ProjectType {
 	// Check for specific marker files
 	for _, marker := range markers {
 		switch marker {
-		case "package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml":
+		case "package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "bun.lockb":
 			return ProjectTypeNodeJS
 		case "go.mod", "go.sum":
 			return ProjectTypeGo
@@ -198,6 +288,12 @@ func DetectProjectType(markers []string) ProjectType {
 			return ProjectTypePython
 		case "Cargo.toml", "Cargo.lock":
 			return ProjectTypeRust
+		case "pom.xml", "mvnw":
+			return ProjectTypeJava
+		case "build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts", "gradlew":
+			return ProjectTypeGradle
+		case "composer.json", "composer.lock":
+			return ProjectTypePHP
 		}
 	}
 
@@ -22,6 +22,9 @@ func TestNewDefaultConfigs(t *testing.T) {
 		ProjectTypeGo,
 		ProjectTypePython,
 		ProjectTypeRust,
+		ProjectTypeJava,
+		ProjectTypeGradle,
+		ProjectTypePHP,
 	}
 
 	for _, pt := range expectedTypes {
@@ -45,6 +48,9 @@ func TestDefaultConfigs_GetConfig(t *testing.T) {
 		{ProjectTypeGo, false},
 		{ProjectTypePython, false},
 		{ProjectTypeRust, false},
+		{ProjectTypeJava, false},
+		{ProjectTypeGradle, false},
+		{ProjectTypePHP, false},
 		{ProjectTypeUnknown, true},
 		{ProjectType("invalid"), true},
 	}
@@ -104,6 +110,78 @@ func TestDefaultConfigs_NodeJSConfig(t *testing.T) {
 	}
 }
 
+func TestDefaultConfigs_GetNodeJSConfig(t *testing.T) {
+	dc, err := NewDefaultConfigs()
+	if err != nil {
+		t.Fatalf("Failed to create default configs: %v", err)
+	}
+
+	tests := []struct {
+		packageManager string
+		wantCommand    string
+		wantLintArgs   []string
+		wantTestArgs   []string
+	}{
+		{"", "npm", []string{"run", "lint"}, []string{"test"}},
+		{"npm", "npm", []string{"run", "lint"}, []string{"test"}},
+		{"yarn", "yarn", []string{"run", "lint"}, []string{"test"}},
+		{"pnpm", "pnpm", []string{"run", "lint"}, []string{"test"}},
+		{"bun", "bun", []string{"run", "lint"}, []string{"run", "test"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("package manager "+tt.packageManager, func(t *testing.T) {
+			cfg, err := dc.GetNodeJSConfig(tt.packageManager)
+			if err != nil {
+				t.Fatalf("GetNodeJSConfig failed: %v", err)
+			}
+
+			for name, cmd := range cfg.Commands {
+				if cmd.Command != tt.wantCommand {
+					t.Errorf("%s command = %q, want %q", name, cmd.Command, tt.wantCommand)
+				}
+			}
+			if got := cfg.Commands["lint"].Args; !equalStrings(got, tt.wantLintArgs) {
+				t.Errorf("lint args = %v, want %v", got, tt.wantLintArgs)
+			}
+			if got := cfg.Commands["test"].Args; !equalStrings(got, tt.wantTestArgs) {
+				t.Errorf("test args = %v, want %v", got, tt.wantTestArgs)
+			}
+		})
+	}
+
+	t.Run("defaults are not modified", func(t *testing.T) {
+		if _, err := dc.GetNodeJSConfig("pnpm"); err != nil {
+			t.Fatalf("GetNodeJSConfig failed: %v", err)
+		}
+		cfg, err := dc.GetConfig(ProjectTypeNodeJS)
+		if err != nil {
+			t.Fatalf("GetConfig failed: %v", err)
+		}
+		if cfg.Commands["lint"].Command != "npm" {
+			t.Errorf("expected npm default to be preserved, got %q", cfg.Commands["lint"].Command)
+		}
+	})
+
+	t.Run("unsupported package manager", func(t *testing.T) {
+		if _, err := dc.GetNodeJSConfig("rush"); err == nil {
+			t.Error("expected error for unsupported package manager")
+		}
+	})
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func TestDefaultConfigs_GoConfig(t *testing.T) {
 	dc, err := NewDefaultConfigs()
 	if err != nil {
@@ -150,6 +228,9 @@ func TestDefaultConfigs_GetCommonErrorPatterns(t *testing.T) {
 		{ProjectTypeGo, 5},
 		{ProjectTypePython, 5},
 		{ProjectTypeRust, 5},
+		{ProjectTypeJava, 5},
+		{ProjectTypeGradle, 5},
+		{ProjectTypePHP, 5},
 	}
 
 	for _, tt := range tests {
@@ -259,6 +340,21 @@ func TestDetectProjectType(t *testing.T) {
 			expected:    ProjectTypeRust,
 			description: "Rust with Cargo.toml",
 		},
+		{
+			markers:     []string{"pom.xml", "src"},
+			expected:    ProjectTypeJava,
+			description: "Maven with pom.xml",
+		},
+		{
+			markers:     []string{"build.gradle.kts", "settings.gradle.kts"},
+			expected:    ProjectTypeGradle,
+			description: "Gradle with Kotlin DSL",
+		},
+		{
+			markers:     []string{"composer.json", "composer.lock"},
+			expected:    ProjectTypePHP,
+			description: "PHP with composer.json",
+		},
 		{
 			markers:     []string{"README.md", ".gitignore"},
 			expected:    ProjectTypeUnknown,
@@ -292,6 +388,9 @@ func TestDefaultConfigs_ExportTemplate(t *testing.T) {
 		ProjectTypeGo,
 		ProjectTypePython,
 		ProjectTypeRust,
+		ProjectTypeJava,
+		ProjectTypeGradle,
+		ProjectTypePHP,
 	}
 
 	for _, pt := range projectTypes {
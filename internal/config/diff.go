@@ -0,0 +1,183 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// Kinds of change in a configuration diff
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// ConfigDiff is the structured difference between two resolved configurations
+type ConfigDiff struct {
+	// Settings are the changed top-level fields other than commands and paths
+	Settings []FieldChange   `json:"settings,omitempty"`
+	Commands []CommandChange `json:"commands,omitempty"`
+	Paths    []PathChange    `json:"paths,omitempty"`
+}
+
+// Empty reports whether the configurations are the same
+func (d *ConfigDiff) Empty() bool {
+	return len(d.Settings) == 0 && len(d.Commands) == 0 && len(d.Paths) == 0
+}
+
+// FieldChange is a field whose value differs, named as in the configuration
+// file. Old or New is nil when the field is only set on one side.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old,omitempty"`
+	New   any    `json:"new,omitempty"`
+}
+
+// CommandChange is an added, removed or changed command
+type CommandChange struct {
+	Name   string        `json:"name"`
+	Change string        `json:"change"`
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// PathChange is an added, removed or changed path configuration
+type PathChange struct {
+	Path     string          `json:"path"`
+	Change   string          `json:"change"`
+	Fields   []FieldChange   `json:"fields,omitempty"`
+	Commands []CommandChange `json:"commands,omitempty"`
+}
+
+// Diff compares two resolved configurations. Extends is left out as both
+// configurations already have what they extend merged in.
+func Diff(from, to *config.Config) (*ConfigDiff, error) {
+	diff := &ConfigDiff{}
+
+	settings, err := fieldChanges(from, to, "commands", "paths", "extends")
+	if err != nil {
+		return nil, err
+	}
+	diff.Settings = settings
+
+	if diff.Commands, err = commandChanges(from.Commands, to.Commands); err != nil {
+		return nil, err
+	}
+
+	fromPaths, toPaths := pathsByPattern(from.Paths), pathsByPattern(to.Paths)
+	for _, path := range unionKeys(fromPaths, toPaths) {
+		oldPath, newPath := fromPaths[path], toPaths[path]
+		switch {
+		case oldPath == nil:
+			diff.Paths = append(diff.Paths, PathChange{Path: path, Change: ChangeAdded})
+		case newPath == nil:
+			diff.Paths = append(diff.Paths, PathChange{Path: path, Change: ChangeRemoved})
+		default:
+			fields, err := fieldChanges(oldPath, newPath, "commands", "path", "extends")
+			if err != nil {
+				return nil, err
+			}
+			commands, err := commandChanges(oldPath.Commands, newPath.Commands)
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) > 0 || len(commands) > 0 {
+				diff.Paths = append(diff.Paths, PathChange{Path: path, Change: ChangeChanged, Fields: fields, Commands: commands})
+			}
+		}
+	}
+
+	return diff, nil
+}
+
+// commandChanges compares two sets of commands by name
+func commandChanges(from, to map[string]*config.CommandConfig) ([]CommandChange, error) {
+	var changes []CommandChange
+	for _, name := range unionKeys(from, to) {
+		oldCmd, newCmd := from[name], to[name]
+		switch {
+		case oldCmd == nil:
+			changes = append(changes, CommandChange{Name: name, Change: ChangeAdded})
+		case newCmd == nil:
+			changes = append(changes, CommandChange{Name: name, Change: ChangeRemoved})
+		default:
+			fields, err := fieldChanges(oldCmd, newCmd)
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) > 0 {
+				changes = append(changes, CommandChange{Name: name, Change: ChangeChanged, Fields: fields})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// fieldChanges compares the JSON fields of two values, skipping the ignored
+// fields, in field name order
+func fieldChanges(from, to any, ignored ...string) ([]FieldChange, error) {
+	oldFields, err := jsonFields(from)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := jsonFields(to)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range ignored {
+		delete(oldFields, field)
+		delete(newFields, field)
+	}
+
+	var changes []FieldChange
+	for _, field := range unionKeys(oldFields, newFields) {
+		oldValue, newValue := oldFields[field], newFields[field]
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	return changes, nil
+}
+
+// jsonFields decodes the JSON encoding of v into its fields
+func jsonFields(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	fields := make(map[string]any)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+	return fields, nil
+}
+
+// pathsByPattern indexes path configurations by their pattern; for a
+// repeated pattern the last one wins
+func pathsByPattern(paths []*config.PathConfig) map[string]*config.PathConfig {
+	byPattern := make(map[string]*config.PathConfig, len(paths))
+	for _, path := range paths {
+		if path != nil {
+			byPattern[path.Path] = path
+		}
+	}
+	return byPattern
+}
+
+// unionKeys returns the keys of both maps, sorted
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
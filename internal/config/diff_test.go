@@ -0,0 +1,78 @@
+//go:build unit
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestDiff(t *testing.T) {
+	from := &config.Config{
+		Version: "1.0",
+		Commands: map[string]*config.CommandConfig{
+			"lint":   {Command: "eslint", Args: []string{"."}},
+			"format": {Command: "prettier"},
+		},
+		Paths: []*config.PathConfig{
+			{Path: "web/**", Commands: map[string]*config.CommandConfig{"lint": {Command: "eslint"}}},
+			{Path: "old/**", Commands: map[string]*config.CommandConfig{}},
+		},
+	}
+	to := &config.Config{
+		Version:       "1.0",
+		ErrorExitCode: 3,
+		Commands: map[string]*config.CommandConfig{
+			"lint": {Command: "eslint", Args: []string{"src"}, Timeout: 60000},
+			"test": {Command: "jest"},
+		},
+		Paths: []*config.PathConfig{
+			{Path: "web/**", Commands: map[string]*config.CommandConfig{"lint": {Command: "biome"}}, Exclude: []string{"*.gen.js"}},
+		},
+	}
+
+	diff, err := Diff(from, to)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	wantSettings := []FieldChange{{Field: "errorExitCode", New: float64(3)}}
+	if !reflect.DeepEqual(diff.Settings, wantSettings) {
+		t.Errorf("Settings = %+v, want %+v", diff.Settings, wantSettings)
+	}
+
+	wantCommands := []CommandChange{
+		{Name: "format", Change: ChangeRemoved},
+		{Name: "lint", Change: ChangeChanged, Fields: []FieldChange{
+			{Field: "args", Old: []any{"."}, New: []any{"src"}},
+			{Field: "timeout", New: float64(60000)},
+		}},
+		{Name: "test", Change: ChangeAdded},
+	}
+	if !reflect.DeepEqual(diff.Commands, wantCommands) {
+		t.Errorf("Commands = %+v, want %+v", diff.Commands, wantCommands)
+	}
+
+	wantPaths := []PathChange{
+		{Path: "old/**", Change: ChangeRemoved},
+		{Path: "web/**", Change: ChangeChanged,
+			Fields: []FieldChange{{Field: "exclude", New: []any{"*.gen.js"}}},
+			Commands: []CommandChange{{Name: "lint", Change: ChangeChanged, Fields: []FieldChange{
+				{Field: "command", Old: "eslint", New: "biome"},
+			}}},
+		},
+	}
+	if !reflect.DeepEqual(diff.Paths, wantPaths) {
+		t.Errorf("Paths = %+v, want %+v", diff.Paths, wantPaths)
+	}
+
+	same, err := Diff(from, from)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if !same.Empty() {
+		t.Errorf("expected no differences, got %+v", same)
+	}
+}
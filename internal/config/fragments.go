@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// ConfigDirName is the directory of configuration fragments, used when a
+// directory has no configuration file. Its *.json files are merged into one
+// configuration in lexical order.
+const ConfigDirName = ".qualhook"
+
+// FindConfigDir returns the configuration fragment directory in dir, if it
+// holds any fragments
+func FindConfigDir(dir string) (string, bool) {
+	path := filepath.Join(dir, ConfigDirName)
+	if !isDir(path) {
+		return "", false
+	}
+	fragments, err := fragmentFiles(path)
+	if err != nil || len(fragments) == 0 {
+		return "", false
+	}
+	return path, true
+}
+
+// isDir reports whether path is an existing directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// fragmentFiles returns the *.json files in dir in lexical order
+func fragmentFiles(dir string) ([]string, error) {
+	fragments, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config fragments: %w", err)
+	}
+	files := fragments[:0]
+	for _, fragment := range fragments {
+		if !isDir(fragment) {
+			files = append(files, fragment)
+		}
+	}
+	return files, nil
+}
+
+// loadFragments loads the fragments in dir, an absolute path already in
+// chain, and merges each over the previous ones like a configuration over
+// the one it extends: later fragments replace commands with the same name.
+// Fragments declaring different versions are rejected. It also returns the
+// files loaded, starting with dir.
+func (l *Loader) loadFragments(dir string, chain []string) (*config.Config, []string, error) {
+	fragments, err := fragmentFiles(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(fragments) == 0 {
+		return nil, nil, fmt.Errorf("no *.json config fragments in %s", dir)
+	}
+
+	var merged *config.Config
+	var versionFrom string
+	files := []string{dir}
+	for _, fragment := range fragments {
+		debug.Log("Loading config fragment: %s", fragment)
+		cfg, loaded, err := l.loadChain(fragment, chain)
+		if err != nil {
+			return nil, nil, fmt.Errorf("config fragment %s: %w", filepath.Base(fragment), err)
+		}
+		files = append(files, loaded...)
+
+		if merged == nil {
+			merged = cfg
+		} else {
+			if cfg.Version != "" && merged.Version != "" && cfg.Version != merged.Version {
+				return nil, nil, fmt.Errorf("version conflict: config fragment %s has version %q but %s has %q",
+					filepath.Base(fragment), cfg.Version, filepath.Base(versionFrom), merged.Version)
+			}
+			merged = extendConfig(merged, cfg)
+		}
+		if cfg.Version != "" && versionFrom == "" {
+			versionFrom = fragment
+		}
+	}
+	merged.Extends = ""
+	return merged, files, nil
+}
+
+// fragmentLayers returns the layers of the fragments in dir, in the order
+// they are merged
+func fragmentLayers(dir string) ([]string, error) {
+	fragments, err := fragmentFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var layers []string
+	for _, fragment := range fragments {
+		chain, err := extendsChain(fragment)
+		if err != nil {
+			return nil, fmt.Errorf("config fragment %s: %w", filepath.Base(fragment), err)
+		}
+		layers = append(layers, chain...)
+	}
+	return layers, nil
+}
@@ -0,0 +1,103 @@
+//go:build unit
+
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoader_Fragments(t *testing.T) {
+	tempDir := t.TempDir()
+	dir := filepath.Join(tempDir, ConfigDirName)
+	writeConfigFile(t, filepath.Join(dir, "10-lint.json"), `{
+  "version": "1.0",
+  "commands": {"lint": {"command": "eslint"}, "format": {"command": "prettier"}}
+}`)
+	writeConfigFile(t, filepath.Join(dir, "20-test.json"), `{
+  "commands": {"test": {"command": "jest"}, "lint": {"command": "biome"}}
+}`)
+	writeConfigFile(t, filepath.Join(dir, "notes.txt"), "not a fragment")
+	writeConfigFile(t, filepath.Join(dir, "templates", "ignored.json"), `{}`)
+
+	loader := &Loader{SearchPaths: []string{tempDir}}
+	path, err := loader.FindPath()
+	if err != nil || path != dir {
+		t.Fatalf("FindPath() = %q, %v; want %s", path, err, dir)
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Version != "1.0" || len(cfg.Commands) != 3 {
+		t.Fatalf("expected the fragments merged into one configuration, got version %q and %d commands", cfg.Version, len(cfg.Commands))
+	}
+	if got := cfg.Commands["lint"].Command; got != "biome" {
+		t.Errorf("expected the later fragment to override lint, got %q", got)
+	}
+
+	// A configuration file takes precedence over the fragments
+	writeConfigFile(t, filepath.Join(tempDir, ConfigFileName), `{"version": "1.0", "commands": {"lint": {"command": "eslint"}}}`)
+	if path, _ := loader.FindPath(); filepath.Base(path) != ConfigFileName {
+		t.Errorf("expected %s to be used, got %q", ConfigFileName, path)
+	}
+}
+
+func TestLoader_FragmentErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		fragments map[string]string
+		wantErr   string
+	}{
+		{
+			name: "version conflict",
+			fragments: map[string]string{
+				"a.json": `{"version": "1.0", "commands": {"lint": {"command": "eslint"}}}`,
+				"b.json": `{"version": "2.0", "commands": {"test": {"command": "jest"}}}`,
+			},
+			wantErr: `version conflict: config fragment b.json has version "2.0" but a.json has "1.0"`,
+		},
+		{
+			name:      "invalid fragment",
+			fragments: map[string]string{"a.json": `{"version": `},
+			wantErr:   "config fragment a.json",
+		},
+		{
+			name:      "invalid merged configuration",
+			fragments: map[string]string{"a.json": `{"commands": {"lint": {"command": "eslint"}}}`},
+			wantErr:   "invalid config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), ConfigDirName)
+			for name, content := range tt.fragments {
+				writeConfigFile(t, filepath.Join(dir, name), content)
+			}
+			_, err := (&Loader{}).LoadFromPath(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadFromPath() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolve_Fragments(t *testing.T) {
+	tempDir := t.TempDir()
+	dir := filepath.Join(tempDir, ConfigDirName)
+	writeConfigFile(t, filepath.Join(tempDir, "base.json"), `{"version": "1.0", "commands": {"build": {"command": "make"}}}`)
+	writeConfigFile(t, filepath.Join(dir, "a.json"), `{"version": "1.0", "extends": "../base.json", "commands": {"lint": {"command": "eslint"}}}`)
+	writeConfigFile(t, filepath.Join(dir, "b.json"), `{"commands": {"test": {"command": "jest"}}}`)
+
+	resolution, err := (&Loader{}).Resolve(dir, tempDir)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	want := []string{filepath.Join(tempDir, "base.json"), filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")}
+	if strings.Join(resolution.Layers, ",") != strings.Join(want, ",") {
+		t.Errorf("Layers = %v, want %v", resolution.Layers, want)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/bebsworthy/qualhook/internal/debug"
 	"github.com/bebsworthy/qualhook/pkg/config"
@@ -16,14 +17,50 @@ const (
 	// ConfigFileName is the default configuration file name
 	ConfigFileName = ".qualhook.json"
 
+	// ConfigFileNameYAML and ConfigFileNameYML are the YAML configuration file names
+	ConfigFileNameYAML = ".qualhook.yaml"
+	ConfigFileNameYML  = ".qualhook.yml"
+
 	// ConfigEnvVar is the environment variable to specify custom config path
 	ConfigEnvVar = "QUALHOOK_CONFIG"
+
+	// ProfileEnvVar is the environment variable to select a config profile
+	ProfileEnvVar = "QUALHOOK_PROFILE"
 )
 
+// ConfigFileNames lists the configuration file names searched for, in order
+var ConfigFileNames = []string{ConfigFileName, ConfigFileNameYAML, ConfigFileNameYML}
+
+// IsYAMLFile reports whether path names a YAML configuration file
+func IsYAMLFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// FindConfigFile returns the configuration file in dir, if any
+func FindConfigFile(dir string) (string, bool) {
+	for _, name := range ConfigFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
 // Loader handles loading and merging configuration files
 type Loader struct {
 	// SearchPaths contains the paths to search for configuration files
 	SearchPaths []string
+	// Profile names the profile merged over the loaded commands, if any
+	Profile string
+	// CacheDir holds parsed and validated configurations, reused while the
+	// files they were loaded from are unchanged. Empty disables the cache.
+	CacheDir string
 }
 
 // NewLoader creates a new configuration loader
@@ -47,22 +84,41 @@ func (l *Loader) Load() (*config.Config, error) {
 		return cfg, nil
 	}
 
-	// Search in default paths
+	configPath, err := l.findInSearchPaths()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := l.loadFromPath(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config from %s: %w", configPath, err)
+	}
+	return cfg, nil
+}
+
+// FindPath returns the path of the configuration file Load would use
+func (l *Loader) FindPath() (string, error) {
+	if envPath := os.Getenv(ConfigEnvVar); envPath != "" {
+		return envPath, nil
+	}
+	return l.findInSearchPaths()
+}
+
+// findInSearchPaths returns the first configuration file in the search paths
+func (l *Loader) findInSearchPaths() (string, error) {
 	debug.Log("Searching for config in default paths: %v", l.SearchPaths)
 	for _, searchPath := range l.SearchPaths {
-		configPath := filepath.Join(searchPath, ConfigFileName)
-		debug.Log("Checking path: %s", configPath)
-		if _, err := os.Stat(configPath); err == nil {
+		debug.Log("Checking path: %s", searchPath)
+		if configPath, found := FindConfigFile(searchPath); found {
 			debug.Log("Found config at: %s", configPath)
-			cfg, err := l.loadFromPath(configPath)
-			if err != nil {
-				return nil, fmt.Errorf("failed to load config from %s: %w", configPath, err)
-			}
-			return cfg, nil
+			return configPath, nil
+		}
+		if configDir, found := FindConfigDir(searchPath); found {
+			debug.Log("Found config fragments at: %s", configDir)
+			return configDir, nil
 		}
 	}
 
-	return nil, fmt.Errorf("no configuration file found in search paths: %v", l.SearchPaths)
+	return "", fmt.Errorf("no configuration file found in search paths: %v (run 'qualhook config' to create one)", l.SearchPaths)
 }
 
 // LoadFromPath loads configuration from a specific file path
@@ -78,16 +134,36 @@ func (l *Loader) LoadForMonorepo(workingDir string) (*config.Config, error) {
 		return nil, err
 	}
 
-	// If no path configurations, return root config
-	if len(rootConfig.Paths) == 0 {
-		return rootConfig, nil
+	return l.ForDirectory(rootConfig, workingDir), nil
+}
+
+// ForDirectory merges the most specific path configuration matching
+// workingDir into a loaded root configuration
+func (l *Loader) ForDirectory(rootConfig *config.Config, workingDir string) *config.Config {
+	bestMatch := l.pathConfigFor(rootConfig, workingDir)
+
+	// If no match found, return root config
+	if bestMatch == nil {
+		return rootConfig
+	}
+
+	// Merge the path-specific configuration with the root configuration
+	debug.Log("Applying path configuration %q for %s", bestMatch.Path, workingDir)
+	return l.mergeConfigs(rootConfig, bestMatch)
+}
+
+// pathConfigFor returns the most specific path configuration matching
+// workingDir, or nil when none does
+func (l *Loader) pathConfigFor(rootConfig *config.Config, workingDir string) *config.PathConfig {
+	if len(rootConfig.Paths) == 0 || len(l.SearchPaths) == 0 {
+		return nil
 	}
 
 	// Find the most specific path configuration that matches the working directory
 	relPath, err := filepath.Rel(l.SearchPaths[0], workingDir)
 	if err != nil {
-		// If we can't determine relative path, use root config
-		return rootConfig, nil
+		// If we can't determine relative path, only the root config applies
+		return nil
 	}
 
 	// Normalize the path for matching
@@ -104,18 +180,118 @@ func (l *Loader) LoadForMonorepo(workingDir string) (*config.Config, error) {
 		}
 	}
 
-	// If no match found, return root config
-	if bestMatch == nil {
-		return rootConfig, nil
-	}
-
-	// Merge the path-specific configuration with the root configuration
-	mergedConfig := l.mergeConfigs(rootConfig, bestMatch)
-	return mergedConfig, nil
+	return bestMatch
 }
 
 // loadFromPath loads and validates configuration from a file
 func (l *Loader) loadFromPath(path string) (*config.Config, error) {
+	debug.Log("Using config file: %s", path)
+	if l.CacheDir != "" {
+		if cfg, ok := l.loadCached(path); ok {
+			return cfg, nil
+		}
+	}
+
+	cfg, files, err := l.loadChain(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.applyProfile(cfg); err != nil {
+		return nil, err
+	}
+
+	cfg, err = validateLoaded(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if l.CacheDir != "" {
+		l.storeCached(cfg, files)
+	}
+	return cfg, nil
+}
+
+// applyProfile merges the selected profile, if any, over the loaded commands
+func (l *Loader) applyProfile(cfg *config.Config) error {
+	if l.Profile == "" {
+		return nil
+	}
+	debug.Log("Applying profile: %s", l.Profile)
+	if err := cfg.ApplyProfile(l.Profile); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	return nil
+}
+
+// validateLoaded validates a loaded configuration, after extends and the
+// selected profile were merged
+func validateLoaded(cfg *config.Config) (*config.Config, error) {
+	if err := cfg.Validate(); err != nil {
+		debug.LogError(err, "validating config")
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	debug.Log("Loaded config: version=%s, commands=%d, paths=%d",
+		cfg.Version, len(cfg.Commands), len(cfg.Paths))
+
+	return cfg, nil
+}
+
+// loadWithExtends parses the configuration file at path and merges it on top
+// of the configuration it extends. chain holds the files already being loaded
+// and is used to detect circular extends.
+func (l *Loader) loadWithExtends(path string, chain []string) (*config.Config, error) {
+	cfg, _, err := l.loadChain(path, chain)
+	return cfg, err
+}
+
+// loadChain is loadWithExtends, also returning the absolute paths of the
+// files that were loaded, starting with path and including the
+// errorPatternsFile of every command
+func (l *Loader) loadChain(path string, chain []string) (*config.Config, []string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve config path: %w", err)
+	}
+	for _, seen := range chain {
+		if seen == absPath {
+			return nil, nil, fmt.Errorf("circular extends: %s", strings.Join(append(chain, absPath), " -> "))
+		}
+	}
+	chain = append(chain, absPath)
+	if isDir(absPath) {
+		return l.loadFragments(absPath, chain)
+	}
+
+	cfg, err := parseConfigFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	patternFiles, err := loadErrorPatternsFiles(cfg, filepath.Dir(absPath))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
+	resolveWorkingDirs(cfg, filepath.Dir(absPath))
+	if cfg.Extends == "" {
+		return cfg, append(chain, patternFiles...), nil
+	}
+
+	basePath := cfg.Extends
+	if !filepath.IsAbs(basePath) {
+		basePath = filepath.Join(filepath.Dir(absPath), basePath)
+	}
+	debug.Log("Config %s extends %s", path, basePath)
+
+	base, files, err := l.loadChain(basePath, chain)
+	if err != nil {
+		return nil, nil, fmt.Errorf("extends %q: %w", cfg.Extends, err)
+	}
+
+	return extendConfig(base, cfg), append(files, patternFiles...), nil
+}
+
+// parseConfigFile reads and decodes a configuration file without validating it
+func parseConfigFile(path string) (*config.Config, error) {
 	debug.Log("Loading config from file: %s", path)
 
 	// #nosec G304 - path is validated by caller (LoadFromPath checks file existence)
@@ -133,26 +309,116 @@ func (l *Loader) loadFromPath(path string) (*config.Config, error) {
 	}
 
 	debug.Log("Config file size: %d bytes", len(data))
-	cfg, err := config.LoadConfig(data)
+	parse := config.ParseConfig
+	if IsYAMLFile(path) {
+		parse = config.ParseConfigYAML
+	}
+	cfg, err := parse(data)
 	if err != nil {
 		debug.LogError(err, "parsing config")
 		return nil, err
 	}
 
-	debug.Log("Loaded config: version=%s, commands=%d, paths=%d",
-		cfg.Version, len(cfg.Commands), len(cfg.Paths))
-
 	return cfg, nil
 }
 
+// extendConfig merges child on top of its base configuration: child commands
+// replace base commands with the same name and child paths are appended
+func extendConfig(base, child *config.Config) *config.Config {
+	merged := &config.Config{
+		Version:          child.Version,
+		Extends:          child.Extends,
+		ProjectType:      child.ProjectType,
+		Commands:         make(map[string]*config.CommandConfig),
+		RootFallback:     child.RootFallback,
+		AI:               child.AI,
+		Security:         child.Security.Clone(),
+		ErrorExitCode:    child.ErrorExitCode,
+		DefaultCommand:   child.DefaultCommand,
+		NormalizePaths:   child.NormalizePaths || base.NormalizePaths,
+		MaxTotalRetries:  child.MaxTotalRetries,
+		MaxParallel:      child.MaxParallel,
+		Profiles:         config.MergeProfiles(base.Profiles, child.Profiles),
+		StandardCommands: mergeStandardCommands(base.StandardCommands, child.StandardCommands),
+	}
+	if merged.Version == "" {
+		merged.Version = base.Version
+	}
+	if merged.ProjectType == "" {
+		merged.ProjectType = base.ProjectType
+	}
+	if merged.RootFallback == "" {
+		merged.RootFallback = base.RootFallback
+	}
+	if merged.AI == nil {
+		merged.AI = base.AI
+	}
+	if merged.Security == nil {
+		merged.Security = base.Security.Clone()
+	}
+	if merged.ErrorExitCode == 0 {
+		merged.ErrorExitCode = base.ErrorExitCode
+	}
+	if merged.DefaultCommand == "" {
+		merged.DefaultCommand = base.DefaultCommand
+	}
+	if merged.MaxTotalRetries == 0 {
+		merged.MaxTotalRetries = base.MaxTotalRetries
+	}
+	if merged.MaxParallel == 0 {
+		merged.MaxParallel = base.MaxParallel
+	}
+
+	for name, cmd := range base.Commands {
+		merged.Commands[name] = CloneCommandConfig(cmd)
+	}
+	for name, cmd := range child.Commands {
+		merged.Commands[name] = CloneCommandConfig(cmd)
+	}
+
+	for _, path := range base.Paths {
+		merged.Paths = append(merged.Paths, clonePathConfig(path))
+	}
+	for _, path := range child.Paths {
+		merged.Paths = append(merged.Paths, clonePathConfig(path))
+	}
+
+	return merged
+}
+
+// mergeStandardCommands returns the standard commands of base followed by
+// those of override it does not list yet
+func mergeStandardCommands(base, override []string) []string {
+	var merged []string
+	seen := make(map[string]bool, len(base)+len(override))
+	for _, name := range append(append([]string{}, base...), override...) {
+		if !seen[name] {
+			seen[name] = true
+			merged = append(merged, name)
+		}
+	}
+	return merged
+}
+
 // mergeConfigs merges path-specific configuration with root configuration
 func (l *Loader) mergeConfigs(root *config.Config, pathConfig *config.PathConfig) *config.Config {
 	// Create a new config based on root
 	merged := &config.Config{
-		Version:     root.Version,
-		ProjectType: root.ProjectType,
-		Commands:    make(map[string]*config.CommandConfig),
-		Paths:       root.Paths, // Keep paths for nested monorepo support
+		Version:          root.Version,
+		Extends:          root.Extends,
+		ProjectType:      root.ProjectType,
+		Commands:         make(map[string]*config.CommandConfig),
+		Paths:            root.Paths, // Keep paths for nested monorepo support
+		RootFallback:     root.RootFallback,
+		AI:               root.AI,
+		Security:         root.Security,
+		ErrorExitCode:    root.ErrorExitCode,
+		DefaultCommand:   root.DefaultCommand,
+		NormalizePaths:   root.NormalizePaths,
+		MaxTotalRetries:  root.MaxTotalRetries,
+		MaxParallel:      root.MaxParallel,
+		Profiles:         root.Profiles,
+		StandardCommands: root.StandardCommands,
 	}
 
 	// Copy root commands
@@ -203,40 +469,40 @@ func matchesPath(relPath, pattern string) (bool, int) {
 
 // getDefaultSearchPaths returns the default paths to search for configuration
 func getDefaultSearchPaths() []string {
-	paths := []string{}
-
-	// Current working directory
-	if cwd, err := os.Getwd(); err == nil {
-		paths = append(paths, cwd)
-
-		// Walk up the directory tree to find root of project
-		dir := cwd
-		for {
-			parent := filepath.Dir(dir)
-			if parent == dir {
-				break
-			}
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = ""
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ""
+	}
+	return searchPathsFrom(cwd, home)
+}
 
-			// Check for common project root indicators
-			if _, err := os.Stat(filepath.Join(parent, ".git")); err == nil {
-				paths = append(paths, parent)
-				break
-			}
-			if _, err := os.Stat(filepath.Join(parent, "go.mod")); err == nil {
-				paths = append(paths, parent)
-				break
-			}
-			if _, err := os.Stat(filepath.Join(parent, "package.json")); err == nil {
-				paths = append(paths, parent)
-				break
+// searchPathsFrom returns the paths to search for configuration from dir.
+// Inside a git repository these are dir and its parents up to the repository
+// root, so configuration outside the repository is never read. Otherwise
+// they are dir, the nearest parent holding a go.mod or package.json, and home.
+func searchPathsFrom(dir, home string) []string {
+	paths := []string{}
+	if dir != "" {
+		if root, found := findRepositoryRoot(dir); found {
+			for current := dir; ; current = filepath.Dir(current) {
+				paths = append(paths, current)
+				if current == root {
+					return paths
+				}
 			}
+		}
 
-			dir = parent
+		paths = append(paths, dir)
+		if root, found := findProjectRoot(dir); found {
+			paths = append(paths, root)
 		}
 	}
 
-	// Home directory
-	if home, err := os.UserHomeDir(); err == nil {
+	if home != "" {
 		paths = append(paths, home)
 	}
 
@@ -246,8 +512,65 @@ func getDefaultSearchPaths() []string {
 	return paths
 }
 
-// ValidateConfigFile validates a configuration file without loading it fully
+// findRepositoryRoot returns the nearest directory from dir upward holding
+// .git, a directory or a worktree file, stopping at the filesystem root
+func findRepositoryRoot(dir string) (string, bool) {
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current, true
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", false
+		}
+		current = parent
+	}
+}
+
+// findProjectRoot returns the nearest parent of dir holding a go.mod or
+// package.json, stopping at the filesystem root
+func findProjectRoot(dir string) (string, bool) {
+	for current := dir; ; {
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", false
+		}
+		for _, marker := range []string{"go.mod", "package.json"} {
+			if _, err := os.Stat(filepath.Join(parent, marker)); err == nil {
+				return parent, true
+			}
+		}
+		current = parent
+	}
+}
+
+// ValidateConfigFile validates a configuration file without loading it
+// fully. Fragment directories are loaded, as fragments are only valid together.
 func ValidateConfigFile(path string) error {
+	if isDir(path) {
+		_, err := NewLoader().loadFromPath(path)
+		return err
+	}
+	if IsYAMLFile(path) {
+		// #nosec G304 - path is provided by user for validation purposes
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to open config file: %w", err)
+		}
+		cfg, err := config.ParseConfigYAML(data)
+		if err != nil {
+			return err
+		}
+		if cfg.Extends != "" {
+			_, err := NewLoader().loadFromPath(path)
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config: %w", err)
+		}
+		return nil
+	}
+
 	// #nosec G304 - path is provided by user for validation purposes
 	file, err := os.Open(path)
 	if err != nil {
@@ -261,5 +584,10 @@ func ValidateConfigFile(path string) error {
 		return fmt.Errorf("invalid JSON: %w", err)
 	}
 
+	if cfg.Extends != "" {
+		_, err := NewLoader().loadFromPath(path)
+		return err
+	}
+
 	return cfg.Validate()
 }
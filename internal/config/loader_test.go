@@ -370,6 +370,11 @@ func TestLoader_LoadFixtures(t *testing.T) {
 			if err := os.WriteFile(configPath, configContent, 0644); err != nil {
 				t.Fatalf("Failed to write config: %v", err)
 			}
+			// Fixtures may extend the shared base fixture
+			baseContent := testutil.LoadFixture(t, "configs/base.qualhook.json")
+			if err := os.WriteFile(filepath.Join(tempDir, "base.qualhook.json"), baseContent, 0644); err != nil {
+				t.Fatalf("Failed to write base config: %v", err)
+			}
 
 			// Create loader pointing to temp directory
 			loader := &Loader{
@@ -468,3 +473,317 @@ func TestLoader_ProjectFixtures(t *testing.T) {
 		})
 	}
 }
+
+func TestLoader_LoadYAML(t *testing.T) {
+	for _, name := range []string{ConfigFileNameYAML, ConfigFileNameYML} {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			content := "version: \"1.0\"\ncommands:\n  lint:\n    command: golangci-lint\n    args: [run]\n"
+			if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0600); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			loader := &Loader{SearchPaths: []string{tempDir}}
+			cfg, err := loader.Load()
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			if cfg.Commands["lint"] == nil || cfg.Commands["lint"].Command != "golangci-lint" {
+				t.Errorf("unexpected lint command: %+v", cfg.Commands["lint"])
+			}
+
+			if err := ValidateConfigFile(filepath.Join(tempDir, name)); err != nil {
+				t.Errorf("ValidateConfigFile() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLoader_LoadPrefersJSON(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := testutil.NewConfigBuilder().
+		WithSimpleCommand("lint", "npm", "run", "lint").
+		WriteToFile(filepath.Join(tempDir, ConfigFileName)); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	yamlContent := "version: \"1.0\"\ncommands:\n  lint:\n    command: yarn\n"
+	if err := os.WriteFile(filepath.Join(tempDir, ConfigFileNameYAML), []byte(yamlContent), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	path, found := FindConfigFile(tempDir)
+	if !found || filepath.Base(path) != ConfigFileName {
+		t.Errorf("FindConfigFile() = %q, %v; want %s", path, found, ConfigFileName)
+	}
+}
+
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+}
+
+func TestLoader_LoadFromPathExtends(t *testing.T) {
+	tempDir := t.TempDir()
+	writeConfigFile(t, filepath.Join(tempDir, ConfigFileName), `{
+  "version": "1.0",
+  "projectType": "nodejs",
+  "commands": {
+    "lint": {"command": "npm", "args": ["run", "lint"]},
+    "test": {"command": "npm", "args": ["test"]}
+  },
+  "paths": [{"path": "shared/**", "commands": {"lint": {"command": "eslint"}}}]
+}`)
+	childPath := filepath.Join(tempDir, "packages", "frontend", ConfigFileName)
+	writeConfigFile(t, childPath, `{
+  "version": "1.0",
+  "extends": "../../.qualhook.json",
+  "commands": {
+    "test": {"command": "npm", "args": ["run", "test:frontend"]}
+  },
+  "paths": [{"path": "src/**", "commands": {"test": {"command": "jest"}}}]
+}`)
+
+	cfg, err := NewLoader().LoadFromPath(childPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+
+	if cfg.Commands["lint"] == nil || cfg.Commands["lint"].Command != "npm" {
+		t.Errorf("lint not inherited from base: %+v", cfg.Commands["lint"])
+	}
+	if got := cfg.Commands["test"].Args; len(got) != 2 || got[1] != "test:frontend" {
+		t.Errorf("test args = %v, want child override", got)
+	}
+	if cfg.ProjectType != "nodejs" {
+		t.Errorf("ProjectType = %q, want inherited nodejs", cfg.ProjectType)
+	}
+	if len(cfg.Paths) != 2 || cfg.Paths[0].Path != "shared/**" || cfg.Paths[1].Path != "src/**" {
+		t.Errorf("paths not appended in order: %+v", cfg.Paths)
+	}
+}
+
+func TestLoader_LoadFromPathExtendsChain(t *testing.T) {
+	tempDir := t.TempDir()
+	writeConfigFile(t, filepath.Join(tempDir, "base.json"), `{
+  "version": "1.0",
+  "commands": {"lint": {"command": "base-lint"}, "format": {"command": "base-format"}}
+}`)
+	writeConfigFile(t, filepath.Join(tempDir, "middle.json"), `{
+  "version": "1.0",
+  "extends": "base.json",
+  "commands": {"format": {"command": "middle-format"}}
+}`)
+	// A child may declare only paths, as the commands come from its bases
+	childPath := filepath.Join(tempDir, "child.yaml")
+	writeConfigFile(t, childPath, "version: \"1.0\"\nextends: middle.json\n")
+
+	cfg, err := NewLoader().LoadFromPath(childPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+	if cfg.Commands["lint"].Command != "base-lint" || cfg.Commands["format"].Command != "middle-format" {
+		t.Errorf("unexpected commands: lint=%s format=%s", cfg.Commands["lint"].Command, cfg.Commands["format"].Command)
+	}
+	if err := ValidateConfigFile(childPath); err != nil {
+		t.Errorf("ValidateConfigFile() error = %v", err)
+	}
+}
+
+func TestLoader_LoadFromPathExtendsStandardCommands(t *testing.T) {
+	tempDir := t.TempDir()
+	writeConfigFile(t, filepath.Join(tempDir, "base.json"), `{
+  "version": "1.0",
+  "standardCommands": ["build"],
+  "commands": {"build": {"command": "go"}}
+}`)
+	childPath := filepath.Join(tempDir, ConfigFileName)
+	writeConfigFile(t, childPath, `{
+  "version": "1.0",
+  "extends": "base.json",
+  "standardCommands": ["vet", "build"],
+  "commands": {"vet": {"command": "go", "args": ["vet", "./..."]}}
+}`)
+
+	cfg, err := NewLoader().LoadFromPath(childPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+	if got := strings.Join(cfg.StandardCommands, ","); got != "build,vet" {
+		t.Errorf("StandardCommands = %s, want base commands followed by new child commands", got)
+	}
+}
+
+func TestLoader_LoadFromPathCircularExtends(t *testing.T) {
+	tempDir := t.TempDir()
+	writeConfigFile(t, filepath.Join(tempDir, "a.json"), `{"version": "1.0", "extends": "b.json", "commands": {"lint": {"command": "a"}}}`)
+	writeConfigFile(t, filepath.Join(tempDir, "b.json"), `{"version": "1.0", "extends": "a.json", "commands": {"lint": {"command": "b"}}}`)
+
+	_, err := NewLoader().LoadFromPath(filepath.Join(tempDir, "a.json"))
+	if err == nil || !strings.Contains(err.Error(), "circular extends") {
+		t.Fatalf("LoadFromPath() error = %v, want circular extends", err)
+	}
+
+	writeConfigFile(t, filepath.Join(tempDir, "self.json"), `{"version": "1.0", "extends": "./self.json", "commands": {"lint": {"command": "a"}}}`)
+	if _, err := NewLoader().LoadFromPath(filepath.Join(tempDir, "self.json")); err == nil || !strings.Contains(err.Error(), "circular extends") {
+		t.Errorf("LoadFromPath() error = %v, want circular extends for self reference", err)
+	}
+}
+
+func TestLoader_LoadFromPathMissingExtends(t *testing.T) {
+	tempDir := t.TempDir()
+	childPath := filepath.Join(tempDir, ConfigFileName)
+	writeConfigFile(t, childPath, `{"version": "1.0", "extends": "missing.json", "commands": {"lint": {"command": "a"}}}`)
+
+	_, err := NewLoader().LoadFromPath(childPath)
+	if err == nil || !strings.Contains(err.Error(), `extends "missing.json"`) {
+		t.Errorf("LoadFromPath() error = %v, want missing extends error", err)
+	}
+}
+
+func TestLoader_LoadFromPathProfile(t *testing.T) {
+	tempDir := t.TempDir()
+	basePath := filepath.Join(tempDir, "base.json")
+	writeConfigFile(t, basePath, `{
+  "version": "1.0",
+  "commands": {"test": {"command": "go", "args": ["test", "./..."], "timeout": 60000}},
+  "profiles": {"dev": {"commands": {"test": {"args": ["test", "-short", "./..."]}}}}
+}`)
+	childPath := filepath.Join(tempDir, "child.yaml")
+	writeConfigFile(t, childPath, `version: "1.0"
+extends: base.json
+commands:
+  lint:
+    command: golangci-lint
+    args: [run]
+profiles:
+  ci:
+    commands:
+      lint:
+        args: [run, --max-issues-per-linter=0]
+      test:
+        timeout: 600000
+`)
+
+	loader := NewLoader()
+	cfg, err := loader.LoadFromPath(childPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+	if cfg.Commands["test"].Timeout != 60000 {
+		t.Errorf("base timeout = %d, want 60000 without a profile", cfg.Commands["test"].Timeout)
+	}
+
+	loader.Profile = "ci"
+	cfg, err = loader.LoadFromPath(childPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() with ci profile error = %v", err)
+	}
+	if got := cfg.Commands["lint"]; got.Command != "golangci-lint" || len(got.Args) != 2 {
+		t.Errorf("lint = %+v, want ci args over the base command", got)
+	}
+	if got := cfg.Commands["test"]; got.Timeout != 600000 || len(got.Args) != 2 {
+		t.Errorf("test = %+v, want ci timeout and base args", got)
+	}
+
+	// Profiles are inherited through extends
+	loader.Profile = "dev"
+	cfg, err = loader.LoadFromPath(childPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() with dev profile error = %v", err)
+	}
+	if got := cfg.Commands["test"].Args; len(got) != 3 || got[1] != "-short" {
+		t.Errorf("test args = %v, want dev args", got)
+	}
+
+	loader.Profile = "nightly"
+	_, err = loader.LoadFromPath(childPath)
+	if err == nil || !strings.Contains(err.Error(), `unknown profile "nightly" (available: ci, dev)`) {
+		t.Errorf("LoadFromPath() error = %v, want unknown profile error", err)
+	}
+}
+
+func TestLoader_LoadFromPathProfileValidatesMergedConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, ConfigFileName)
+	writeConfigFile(t, configPath, `{
+  "version": "1.0",
+  "commands": {"lint": {"command": "eslint"}},
+  "profiles": {"broken": {"commands": {"lint": {"timeout": -1}}}}
+}`)
+
+	loader := NewLoader()
+	if _, err := loader.LoadFromPath(configPath); err != nil {
+		t.Fatalf("LoadFromPath() without a profile error = %v", err)
+	}
+
+	loader.Profile = "broken"
+	_, err := loader.LoadFromPath(configPath)
+	if err == nil || !strings.Contains(err.Error(), `command "lint"`) {
+		t.Errorf("LoadFromPath() error = %v, want validation of the merged lint command", err)
+	}
+}
+
+func TestSearchPathsFrom_StopsAtRepositoryRoot(t *testing.T) {
+	outside := t.TempDir()
+	repo := filepath.Join(outside, "repo")
+	deep := filepath.Join(repo, "a", "b", "c")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(deep, 0750); err != nil {
+		t.Fatal(err)
+	}
+	// A configuration outside the repository must never be read
+	writeConfigFile(t, filepath.Join(outside, ConfigFileName), `{"version": "1.0", "commands": {"lint": {"command": "eslint"}}}`)
+
+	paths := searchPathsFrom(deep, outside)
+	want := []string{deep, filepath.Join(repo, "a", "b"), filepath.Join(repo, "a"), repo}
+	if strings.Join(paths, "|") != strings.Join(want, "|") {
+		t.Errorf("searchPathsFrom() = %v, want %v", paths, want)
+	}
+
+	_, err := (&Loader{SearchPaths: paths}).LoadForMonorepo(deep)
+	if err == nil || !strings.Contains(err.Error(), "no configuration file found") {
+		t.Errorf("expected a no configuration found error, got %v", err)
+	}
+
+	// A configuration at the repository root is found from the deep directory
+	writeConfigFile(t, filepath.Join(repo, ConfigFileName), `{"version": "1.0", "commands": {"test": {"command": "go"}}}`)
+	cfg, err := (&Loader{SearchPaths: paths}).LoadForMonorepo(deep)
+	if err != nil {
+		t.Fatalf("LoadForMonorepo() error = %v", err)
+	}
+	if _, ok := cfg.Commands["test"]; !ok {
+		t.Errorf("expected the repository configuration, got %v", cfg.Commands)
+	}
+}
+
+func TestSearchPathsFrom_OutsideRepository(t *testing.T) {
+	root := t.TempDir()
+	deep := filepath.Join(root, "x", "y", "z")
+	if err := os.MkdirAll(deep, 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := searchPathsFrom(deep, "")
+	if len(paths) != 1 || paths[0] != deep {
+		t.Errorf("expected only the directory itself without a repository or project root, got %v", paths)
+	}
+
+	_, err := (&Loader{SearchPaths: paths}).LoadForMonorepo(deep)
+	if err == nil || !strings.Contains(err.Error(), "no configuration file found") {
+		t.Errorf("expected a no configuration found error, got %v", err)
+	}
+
+	// The nearest project root is searched too
+	writeConfigFile(t, filepath.Join(root, "x", "go.mod"), "module example.com/x\n")
+	paths = searchPathsFrom(deep, "")
+	if len(paths) != 2 || paths[1] != filepath.Join(root, "x") {
+		t.Errorf("expected the directory and its project root, got %v", paths)
+	}
+}
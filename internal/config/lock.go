@@ -0,0 +1,95 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// LockFileName is the file holding the fully-resolved effective configuration
+const LockFileName = ".qualhook.lock.json"
+
+// LockFilePath returns the lockfile path for a configuration file, which sits
+// next to it
+func LockFilePath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), LockFileName)
+}
+
+// WriteLockFile resolves the configuration file at configPath, including
+// everything it extends, and writes the result to its lockfile. Profiles are
+// kept in the lockfile and applied when it is loaded.
+func (l *Loader) WriteLockFile(configPath string) (string, error) {
+	cfg, err := l.loadWithExtends(configPath, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err := validateLoaded(cfg); err != nil {
+		return "", err
+	}
+
+	data, err := lockData(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	lockPath := LockFilePath(configPath)
+	if err := os.WriteFile(lockPath, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write lockfile: %w", err)
+	}
+	debug.Log("Wrote lockfile: %s", lockPath)
+	return lockPath, nil
+}
+
+// LoadLocked loads the lockfile of the configuration file at configPath. It
+// fails when the configuration file no longer resolves to the locked config.
+func (l *Loader) LoadLocked(configPath string) (*config.Config, error) {
+	lockPath := LockFilePath(configPath)
+	debug.Log("Loading locked config from: %s", lockPath)
+
+	// #nosec G304 - lockfile sits next to the user's configuration file
+	locked, err := os.ReadFile(lockPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile (run 'qualhook config lock' first): %w", err)
+	}
+	cfg, err := config.LoadConfig(locked)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lockfile %s: %w", lockPath, err)
+	}
+
+	source, err := l.loadWithExtends(configPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := lockData(source)
+	if err != nil {
+		return nil, err
+	}
+	lockedData, err := lockData(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(resolved, lockedData) {
+		return nil, fmt.Errorf("%s no longer resolves to %s; run 'qualhook config lock' to update it", configPath, lockPath)
+	}
+
+	if err := l.applyProfile(cfg); err != nil {
+		return nil, err
+	}
+	return validateLoaded(cfg)
+}
+
+// lockData serializes a resolved configuration for the lockfile. Extends is
+// dropped as the lockfile already holds the inherited settings.
+func lockData(cfg *config.Config) ([]byte, error) {
+	standalone := *cfg
+	standalone.Extends = ""
+	data, err := config.SaveConfig(&standalone)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
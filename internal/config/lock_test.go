@@ -0,0 +1,63 @@
+//go:build unit
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoader_LockFile(t *testing.T) {
+	tempDir := t.TempDir()
+	basePath := filepath.Join(tempDir, "base.json")
+	writeConfigFile(t, basePath, `{"version": "1.0", "commands": {"lint": {"command": "eslint"}}}`)
+	childPath := filepath.Join(tempDir, "app", ConfigFileName)
+	writeConfigFile(t, childPath, `{
+  "version": "1.0",
+  "extends": "../base.json",
+  "commands": {"test": {"command": "jest"}}
+}`)
+
+	loader := NewLoader()
+	lockPath, err := loader.WriteLockFile(childPath)
+	if err != nil {
+		t.Fatalf("WriteLockFile() error = %v", err)
+	}
+	if lockPath != filepath.Join(tempDir, "app", LockFileName) {
+		t.Errorf("lockfile written to %s, want next to the config", lockPath)
+	}
+
+	data, err := os.ReadFile(lockPath) // #nosec G304 - test-controlled path
+	if err != nil {
+		t.Fatalf("failed to read lockfile: %v", err)
+	}
+	if strings.Contains(string(data), "extends") || !strings.Contains(string(data), "eslint") {
+		t.Errorf("lockfile should hold the resolved config without extends:\n%s", data)
+	}
+
+	cfg, err := loader.LoadLocked(childPath)
+	if err != nil {
+		t.Fatalf("LoadLocked() error = %v", err)
+	}
+	if cfg.Commands["lint"] == nil || cfg.Commands["test"] == nil {
+		t.Errorf("locked config missing commands: %+v", cfg.Commands)
+	}
+
+	// A changed parent config no longer resolves to the lockfile
+	writeConfigFile(t, basePath, `{"version": "1.0", "commands": {"lint": {"command": "eslint", "args": ["--max-warnings", "0"]}}}`)
+	if _, err := loader.LoadLocked(childPath); err == nil || !strings.Contains(err.Error(), "no longer resolves") {
+		t.Errorf("LoadLocked() error = %v, want drift error", err)
+	}
+}
+
+func TestLoader_LoadLockedMissingLockFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ConfigFileName)
+	writeConfigFile(t, configPath, `{"version": "1.0", "commands": {"lint": {"command": "eslint"}}}`)
+
+	_, err := NewLoader().LoadLocked(configPath)
+	if err == nil || !strings.Contains(err.Error(), "config lock") {
+		t.Errorf("LoadLocked() error = %v, want hint to run config lock", err)
+	}
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/security"
+	"github.com/bebsworthy/qualhook/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// loadErrorPatternsFiles appends the patterns of every errorPatternsFile in
+// cfg, resolved relative to dir, to the inline errorPatterns of the command.
+// It returns the absolute paths of the files that were read.
+func loadErrorPatternsFiles(cfg *config.Config, dir string) ([]string, error) {
+	validator := security.NewSecurityValidator()
+	var files []string
+	load := func(name string, cmd *config.CommandConfig) error {
+		if cmd == nil || cmd.ErrorPatternsFile == "" {
+			return nil
+		}
+		path := cmd.ErrorPatternsFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		patterns, err := readErrorPatternsFile(path, validator)
+		if err != nil {
+			return fmt.Errorf("command %q: errorPatternsFile %q: %w", name, cmd.ErrorPatternsFile, err)
+		}
+		debug.Log("Loaded %d error patterns for %s from %s", len(patterns), name, path)
+		cmd.ErrorPatterns = append(cmd.ErrorPatterns, patterns...)
+		files = append(files, path)
+		return nil
+	}
+
+	for name, cmd := range cfg.Commands {
+		if err := load(name, cmd); err != nil {
+			return nil, err
+		}
+	}
+	for _, pathCfg := range cfg.Paths {
+		if pathCfg == nil {
+			continue
+		}
+		for name, cmd := range pathCfg.Commands {
+			if err := load(name, cmd); err != nil {
+				return nil, fmt.Errorf("path %q: %w", pathCfg.Path, err)
+			}
+		}
+	}
+	return files, nil
+}
+
+// readErrorPatternsFile reads a JSON, YAML or TOML list of error patterns and
+// checks each with the security validator
+func readErrorPatternsFile(path string, validator *security.SecurityValidator) ([]*config.RegexPattern, error) {
+	// #nosec G304 - the file is named by the configuration being loaded
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patterns: %w", err)
+	}
+
+	switch {
+	case IsYAMLFile(path):
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse patterns: %w", err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("failed to parse patterns: %w", err)
+		}
+	case isTOMLFile(path):
+		doc, err := decodePatternsTOML(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse patterns: %w", err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("failed to parse patterns: %w", err)
+		}
+	}
+
+	var patterns []*config.RegexPattern
+	if err := json.Unmarshal(data, &patterns); err != nil {
+		return nil, fmt.Errorf("failed to parse patterns, expected a list of patterns: %w", err)
+	}
+	for i, pattern := range patterns {
+		if pattern == nil || pattern.Pattern == "" {
+			return nil, fmt.Errorf("pattern %d: pattern is required", i)
+		}
+		if pattern.Literal {
+			continue
+		}
+		if err := validator.ValidateRegexPattern(pattern.Pattern); err != nil {
+			return nil, fmt.Errorf("pattern %d: %w", i, err)
+		}
+	}
+	return patterns, nil
+}
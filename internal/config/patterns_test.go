@@ -0,0 +1,142 @@
+//go:build unit
+
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoader_ErrorPatternsFile(t *testing.T) {
+	tempDir := t.TempDir()
+	writeConfigFile(t, filepath.Join(tempDir, "shared", "patterns.yaml"), `
+- pattern: "error TS\\d+:"
+- pattern: "warning"
+  flags: i
+`)
+	writeConfigFile(t, filepath.Join(tempDir, "shared", "lint.json"), `[{"pattern": "\\d+ problems?"}]`)
+	configPath := filepath.Join(tempDir, "project", ConfigFileName)
+	writeConfigFile(t, configPath, `{
+  "version": "1.0",
+  "commands": {
+    "typecheck": {
+      "command": "tsc",
+      "errorPatterns": [{"pattern": "inline"}],
+      "errorPatternsFile": "../shared/patterns.yaml"
+    }
+  },
+  "paths": [
+    {"path": "web/**", "commands": {"lint": {"command": "eslint", "errorPatternsFile": "../shared/lint.json"}}}
+  ]
+}`)
+
+	cfg, err := (&Loader{}).LoadFromPath(configPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+
+	var got []string
+	for _, pattern := range cfg.Commands["typecheck"].ErrorPatterns {
+		got = append(got, pattern.Pattern+"/"+pattern.Flags)
+	}
+	if want := "inline/,error TS\\d+:/,warning/i"; strings.Join(got, ",") != want {
+		t.Errorf("expected inline patterns followed by the file's, got %v", got)
+	}
+
+	pathPatterns := cfg.Paths[0].Commands["lint"].ErrorPatterns
+	if len(pathPatterns) != 1 || pathPatterns[0].Pattern != "\\d+ problems?" {
+		t.Errorf("expected the path command to load its patterns file, got %v", pathPatterns)
+	}
+}
+
+func TestLoader_ErrorPatternsFileTOML(t *testing.T) {
+	tempDir := t.TempDir()
+	writeConfigFile(t, filepath.Join(tempDir, "patterns.toml"), `# Shared TypeScript patterns
+[[patterns]]
+pattern = 'error TS\d+:'
+
+[[patterns]]
+pattern = "Cannot find module"  # missing imports
+flags = "i"
+prompt = "Fix the missing imports below:"
+
+[[patterns]]
+pattern = "(not a regex"
+literal = true
+`)
+	configPath := filepath.Join(tempDir, ConfigFileName)
+	writeConfigFile(t, configPath, `{"version": "1.0", "commands": {"typecheck": {"command": "tsc", "errorPatternsFile": "patterns.toml"}}}`)
+
+	cfg, err := (&Loader{}).LoadFromPath(configPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+
+	patterns := cfg.Commands["typecheck"].ErrorPatterns
+	if len(patterns) != 3 {
+		t.Fatalf("expected 3 patterns, got %d", len(patterns))
+	}
+	if patterns[0].Pattern != `error TS\d+:` {
+		t.Errorf("expected the literal string to be kept as is, got %q", patterns[0].Pattern)
+	}
+	if patterns[1].Flags != "i" || patterns[1].Prompt != "Fix the missing imports below:" {
+		t.Errorf("expected the flags and prompt to be loaded, got %+v", patterns[1])
+	}
+	if !patterns[2].Literal {
+		t.Errorf("expected the literal pattern to be loaded, got %+v", patterns[2])
+	}
+}
+
+func TestLoader_ErrorPatternsFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns string
+		wantErr  string
+	}{
+		{name: "missing file", wantErr: "failed to read patterns"},
+		{name: "not a list", patterns: `{"pattern": "error"}`, wantErr: "expected a list of patterns"},
+		{name: "empty pattern", patterns: `[{"flags": "i"}]`, wantErr: "pattern 0: pattern is required"},
+		{name: "unsafe pattern", patterns: `[{"pattern": "error"}, {"pattern": "(a+)+"}]`, wantErr: "pattern 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			if tt.patterns != "" {
+				writeConfigFile(t, filepath.Join(tempDir, "patterns.json"), tt.patterns)
+			}
+			configPath := filepath.Join(tempDir, ConfigFileName)
+			writeConfigFile(t, configPath, `{"version": "1.0", "commands": {"lint": {"command": "eslint", "errorPatternsFile": "patterns.json"}}}`)
+
+			_, err := (&Loader{}).LoadFromPath(configPath)
+			if err == nil || !strings.Contains(err.Error(), `errorPatternsFile "patterns.json"`) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadFromPath() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoader_CacheInvalidatedByErrorPatternsFile(t *testing.T) {
+	tempDir := t.TempDir()
+	cacheDir := t.TempDir()
+	patternsPath := filepath.Join(tempDir, "patterns.json")
+	configPath := filepath.Join(tempDir, ConfigFileName)
+	writeSettledConfigFile(t, patternsPath, `[{"pattern": "error"}]`)
+	writeSettledConfigFile(t, configPath, `{"version": "1.0", "commands": {"lint": {"command": "eslint", "errorPatternsFile": "patterns.json"}}}`)
+
+	loader := &Loader{CacheDir: cacheDir}
+	if _, err := loader.LoadFromPath(configPath); err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+
+	writeSettledConfigFile(t, patternsPath, `[{"pattern": "error"}, {"pattern": "FAIL"}]`)
+
+	cfg, err := loader.LoadFromPath(configPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+	if got := len(cfg.Commands["lint"].ErrorPatterns); got != 2 {
+		t.Errorf("expected the changed patterns file to be loaded, got %d patterns", got)
+	}
+}
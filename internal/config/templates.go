@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,6 +18,8 @@ import (
 type TemplateManager struct {
 	// Directory to store/load templates
 	templateDir string
+	// compact writes templates as minified JSON
+	compact bool
 }
 
 // NewTemplateManager creates a new template manager
@@ -37,6 +40,11 @@ func (tm *TemplateManager) SetTemplateDir(dir string) {
 	tm.templateDir = dir
 }
 
+// SetCompact controls whether exported templates are written as minified JSON
+func (tm *TemplateManager) SetCompact(compact bool) {
+	tm.compact = compact
+}
+
 // ExportTemplate exports a configuration as a reusable template
 func (tm *TemplateManager) ExportTemplate(cfg *pkgconfig.Config, name, description string) error {
 	debug.LogSection("Export Template")
@@ -69,7 +77,13 @@ func (tm *TemplateManager) ExportTemplate(cfg *pkgconfig.Config, name, descripti
 
 	// Save template
 	templatePath := filepath.Join(tm.templateDir, name+".json")
-	data, err := json.MarshalIndent(template, "", "  ")
+	var data []byte
+	var err error
+	if tm.compact {
+		data, err = json.Marshal(template)
+	} else {
+		data, err = json.MarshalIndent(template, "", "  ")
+	}
 	if err != nil {
 		return fmt.Errorf("failed to marshal template: %w", err)
 	}
@@ -102,7 +116,14 @@ func (tm *TemplateManager) ImportTemplate(nameOrPath string) (*pkgconfig.Config,
 	data, err := os.ReadFile(templatePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("template not found: %s", nameOrPath)
+			// Names without an installed template may name a built-in one
+			if templatePath != nameOrPath {
+				if cfg, builtInErr := builtInTemplate(nameOrPath); builtInErr == nil {
+					debug.Log("Imported built-in template: %s", nameOrPath)
+					return cfg, nil
+				}
+			}
+			return nil, fmt.Errorf("template not found: %s (list templates with: qualhook template list)", nameOrPath)
 		}
 		return nil, fmt.Errorf("failed to read template: %w", err)
 	}
@@ -123,6 +144,15 @@ func (tm *TemplateManager) ImportTemplate(nameOrPath string) (*pkgconfig.Config,
 	return template.Config, nil
 }
 
+// builtInTemplate returns the default configuration of the named project type
+func builtInTemplate(name string) (*pkgconfig.Config, error) {
+	defaults, err := NewDefaultConfigs()
+	if err != nil {
+		return nil, err
+	}
+	return defaults.GetConfig(ProjectType(name))
+}
+
 // ListTemplates lists available templates
 func (tm *TemplateManager) ListTemplates() ([]TemplateInfo, error) {
 	debug.LogSection("List Templates")
@@ -160,12 +190,16 @@ func (tm *TemplateManager) ListTemplates() ([]TemplateInfo, error) {
 			continue
 		}
 
-		templates = append(templates, TemplateInfo{
+		info := TemplateInfo{
 			Name:        template.Name,
 			Description: template.Description,
 			Path:        templatePath,
 			CreatedAt:   template.Metadata.CreatedAt,
-		})
+		}
+		if template.Config != nil {
+			info.ProjectType = template.Config.ProjectType
+		}
+		templates = append(templates, info)
 	}
 
 	debug.Log("Found %d templates", len(templates))
@@ -189,6 +223,38 @@ func (tm *TemplateManager) MergeConfigs(target, source *pkgconfig.Config) *pkgco
 		merged.ProjectType = target.ProjectType
 	}
 
+	merged.RootFallback = source.RootFallback
+	if merged.RootFallback == "" {
+		merged.RootFallback = target.RootFallback
+	}
+	merged.AI = source.AI
+	if merged.AI == nil {
+		merged.AI = target.AI
+	}
+	merged.Security = source.Security.Clone()
+	if merged.Security == nil {
+		merged.Security = target.Security.Clone()
+	}
+	merged.ErrorExitCode = source.ErrorExitCode
+	if merged.ErrorExitCode == 0 {
+		merged.ErrorExitCode = target.ErrorExitCode
+	}
+	merged.DefaultCommand = source.DefaultCommand
+	if merged.DefaultCommand == "" {
+		merged.DefaultCommand = target.DefaultCommand
+	}
+	merged.NormalizePaths = source.NormalizePaths || target.NormalizePaths
+	merged.MaxTotalRetries = source.MaxTotalRetries
+	if merged.MaxTotalRetries == 0 {
+		merged.MaxTotalRetries = target.MaxTotalRetries
+	}
+	merged.MaxParallel = source.MaxParallel
+	if merged.MaxParallel == 0 {
+		merged.MaxParallel = target.MaxParallel
+	}
+	merged.Profiles = pkgconfig.MergeProfiles(target.Profiles, source.Profiles)
+	merged.StandardCommands = mergeStandardCommands(target.StandardCommands, source.StandardCommands)
+
 	// Copy target commands
 	for name, cmd := range target.Commands {
 		merged.Commands[name] = CloneCommandConfig(cmd)
@@ -260,10 +326,48 @@ type TemplateMetadata struct {
 
 // TemplateInfo provides basic information about a template
 type TemplateInfo struct {
-	Name        string
-	Description string
-	Path        string
-	CreatedAt   string
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ProjectType string `json:"projectType,omitempty"`
+	// BuiltIn is set for the default configurations shipped with qualhook
+	BuiltIn   bool   `json:"builtIn"`
+	Path      string `json:"path,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+// builtInTemplateDescriptions describe the default configuration of each
+// project type, offered as built-in templates
+var builtInTemplateDescriptions = map[ProjectType]string{
+	ProjectTypeNodeJS: "npm format, lint, typecheck and test scripts",
+	ProjectTypeGo:     "go fmt, golangci-lint, go build, vet and test",
+	ProjectTypePython: "black, flake8, mypy, pytest and pylint",
+	ProjectTypeRust:   "cargo fmt, clippy, check, test and build",
+	ProjectTypeJava:   "Maven spotless, checkstyle, compile and test",
+	ProjectTypeGradle: "Gradle spotless, checkstyle, compileJava, test",
+	ProjectTypePHP:    "php-cs-fixer, phpcs, phpstan and phpunit",
+}
+
+// BuiltInTemplates lists the default configurations shipped with qualhook,
+// importable by project type name
+func BuiltInTemplates() ([]TemplateInfo, error) {
+	defaults, err := NewDefaultConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	types := defaults.GetAllTypes()
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	templates := make([]TemplateInfo, 0, len(types))
+	for _, projectType := range types {
+		templates = append(templates, TemplateInfo{
+			Name:        string(projectType),
+			Description: builtInTemplateDescriptions[projectType],
+			ProjectType: string(projectType),
+			BuiltIn:     true,
+		})
+	}
+	return templates, nil
 }
 
 // CloneCommandConfig creates a deep copy of CommandConfig
@@ -287,6 +391,9 @@ func clonePathConfig(p *pkgconfig.PathConfig) *pkgconfig.PathConfig {
 		Extends:  p.Extends,
 		Commands: make(map[string]*pkgconfig.CommandConfig),
 	}
+	if p.Exclude != nil {
+		clone.Exclude = append([]string{}, p.Exclude...)
+	}
 
 	// Clone commands
 	for name, cmd := range p.Commands {
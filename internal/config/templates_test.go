@@ -0,0 +1,66 @@
+//go:build unit
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestBuiltInTemplates(t *testing.T) {
+	templates, err := BuiltInTemplates()
+	if err != nil {
+		t.Fatalf("BuiltInTemplates() error = %v", err)
+	}
+	if len(templates) != 7 {
+		t.Fatalf("expected a template per default configuration, got %+v", templates)
+	}
+	for i, tmpl := range templates {
+		if !tmpl.BuiltIn || tmpl.ProjectType != tmpl.Name || tmpl.Description == "" {
+			t.Errorf("unexpected built-in template %+v", tmpl)
+		}
+		if i > 0 && templates[i-1].Name >= tmpl.Name {
+			t.Errorf("expected templates sorted by name, got %q before %q", templates[i-1].Name, tmpl.Name)
+		}
+	}
+}
+
+func TestTemplateManager_ListAndImport(t *testing.T) {
+	tm := NewTemplateManager()
+	tm.SetTemplateDir(t.TempDir())
+
+	cfg := &pkgconfig.Config{
+		Version:     "1.0",
+		ProjectType: "nodejs",
+		Commands:    map[string]*pkgconfig.CommandConfig{"lint": {Command: "eslint"}},
+	}
+	if err := tm.ExportTemplate(cfg, "team", "Team lint setup"); err != nil {
+		t.Fatalf("ExportTemplate() error = %v", err)
+	}
+
+	templates, err := tm.ListTemplates()
+	if err != nil {
+		t.Fatalf("ListTemplates() error = %v", err)
+	}
+	if len(templates) != 1 || templates[0].Name != "team" || templates[0].ProjectType != "nodejs" || templates[0].BuiltIn {
+		t.Errorf("unexpected installed templates %+v", templates)
+	}
+
+	// Installed templates take precedence, built-in ones are a fallback
+	imported, err := tm.ImportTemplate("team")
+	if err != nil || imported.Commands["lint"].Command != "eslint" {
+		t.Errorf("ImportTemplate(team) = %+v, %v", imported, err)
+	}
+	imported, err = tm.ImportTemplate("go")
+	if err != nil || imported.ProjectType != "go" {
+		t.Errorf("ImportTemplate(go) = %+v, %v", imported, err)
+	}
+	if _, err := tm.ImportTemplate("missing"); err == nil {
+		t.Error("expected an error for an unknown template")
+	}
+	if _, err := tm.ImportTemplate(filepath.Join(t.TempDir(), "go.json")); err == nil {
+		t.Error("expected a missing template path not to fall back to a built-in template")
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// tomlBareKey matches the bare keys of a TOML key/value pair
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// isTOMLFile reports whether path names a TOML file
+func isTOMLFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".toml")
+}
+
+// decodePatternsTOML decodes a TOML patterns file, an array of [[patterns]]
+// tables holding string and boolean keys, e.g.
+//
+//	[[patterns]]
+//	pattern = 'error TS\d+:'
+//	flags = "i"
+//
+// Other TOML constructs are rejected with the line they appear on.
+func decodePatternsTOML(data []byte) ([]map[string]interface{}, error) {
+	var patterns []map[string]interface{}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if header, _ := cutTOMLComment(line); header != "[[patterns]]" {
+				return nil, fmt.Errorf("line %d: unsupported table %s, expected [[patterns]]", i+1, header)
+			}
+			patterns = append(patterns, map[string]interface{}{})
+			continue
+		}
+
+		key, rest, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", i+1)
+		}
+		key = strings.TrimSpace(key)
+		if !tomlBareKey.MatchString(key) {
+			return nil, fmt.Errorf("line %d: unsupported key %q", i+1, key)
+		}
+		if len(patterns) == 0 {
+			return nil, fmt.Errorf("line %d: key %q outside a [[patterns]] table", i+1, key)
+		}
+		value, err := parseTOMLValue(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		pattern := patterns[len(patterns)-1]
+		if _, exists := pattern[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", i+1, key)
+		}
+		pattern[key] = value
+	}
+	return patterns, nil
+}
+
+// parseTOMLValue parses a basic string, a literal string or a boolean,
+// optionally followed by a comment
+func parseTOMLValue(s string) (interface{}, error) {
+	var value interface{}
+	var rest string
+	switch {
+	case strings.HasPrefix(s, `"`):
+		end := 1
+		for ; end < len(s) && s[end] != '"'; end++ {
+			if s[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(s) {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		unquoted, err := strconv.Unquote(s[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s: %w", s[:end+1], err)
+		}
+		value, rest = unquoted, s[end+1:]
+	case strings.HasPrefix(s, "'"):
+		end := strings.IndexByte(s[1:], '\'')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		value, rest = s[1:end+1], s[end+2:]
+	default:
+		word, comment := cutTOMLComment(s)
+		switch word {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		default:
+			return nil, fmt.Errorf("unsupported value %s, expected a string or a boolean", word)
+		}
+		rest = comment
+	}
+
+	if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+		return nil, fmt.Errorf("unexpected %q after the value", rest)
+	}
+	return value, nil
+}
+
+// cutTOMLComment splits s at the start of a trailing comment
+func cutTOMLComment(s string) (string, string) {
+	if i := strings.IndexByte(s, '#'); i >= 0 {
+		return strings.TrimSpace(s[:i]), s[i:]
+	}
+	return s, ""
+}
@@ -0,0 +1,45 @@
+//go:build unit
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodePatternsTOML(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr string
+	}{
+		{name: "empty", data: "# no patterns\n", want: 0},
+		{name: "patterns", data: "[[patterns]]\npattern = \"a\\tb\"\n[[patterns]]\npattern = 'c'\nliteral = false\n", want: 2},
+		{name: "other table", data: "[patterns]\npattern = \"a\"\n", wantErr: "line 1: unsupported table [patterns]"},
+		{name: "key outside a table", data: "pattern = \"a\"\n", wantErr: "line 1: key \"pattern\" outside a [[patterns]] table"},
+		{name: "duplicate key", data: "[[patterns]]\npattern = \"a\"\npattern = \"b\"\n", wantErr: "line 3: duplicate key"},
+		{name: "unsupported value", data: "[[patterns]]\npattern = 42\n", wantErr: "line 2: unsupported value 42"},
+		{name: "unterminated string", data: "[[patterns]]\npattern = \"a\n", wantErr: "line 2: unterminated string"},
+		{name: "trailing text", data: "[[patterns]]\npattern = \"a\" b\n", wantErr: "line 2: unexpected"},
+		{name: "missing value", data: "[[patterns]]\npattern\n", wantErr: "line 2: expected key = value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patterns, err := decodePatternsTOML([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("decodePatternsTOML() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodePatternsTOML() error = %v", err)
+			}
+			if len(patterns) != tt.want {
+				t.Errorf("expected %d patterns, got %v", tt.want, patterns)
+			}
+		})
+	}
+}
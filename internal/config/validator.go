@@ -126,9 +126,12 @@ func (v *Validator) validateRegexPattern(pattern *config.RegexPattern) error {
 		return err
 	}
 
-	// Use security validator for comprehensive regex validation
-	if err := v.securityValidator.ValidateRegexPattern(pattern.Pattern); err != nil {
-		return fmt.Errorf("security validation failed: %w", err)
+	// Use security validator for comprehensive regex validation; literal
+	// patterns cannot backtrack
+	if !pattern.Literal {
+		if err := v.securityValidator.ValidateRegexPattern(pattern.Pattern); err != nil {
+			return fmt.Errorf("security validation failed: %w", err)
+		}
 	}
 
 	// Try to compile and test the pattern
@@ -138,7 +141,11 @@ func (v *Validator) validateRegexPattern(pattern *config.RegexPattern) error {
 	}
 
 	// Check for patterns that might match too much
-	if v.isTooGenericPattern(re, pattern.Pattern) {
+	generic := pattern.Pattern
+	if pattern.Literal {
+		generic = regexp.QuoteMeta(generic)
+	}
+	if v.isTooGenericPattern(re, generic) {
 		return fmt.Errorf("pattern %q is too generic and might match too much output", pattern.Pattern)
 	}
 
@@ -388,6 +395,18 @@ func (v *Validator) validateCommandPatterns(cmd *config.CommandConfig) error {
 		}
 	}
 
+	// Validate severity patterns
+	for _, severity := range cmd.Severities {
+		if severity == nil {
+			continue
+		}
+		for i, pattern := range severity.Patterns {
+			if err := v.validateRegexPattern(pattern); err != nil {
+				return fmt.Errorf("severity %q pattern %d: %w", severity.Name, i, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -154,6 +154,22 @@ func TestValidator_ValidateCommand(t *testing.T) {
 			wantErr: true,
 			errMsg:  "dangerous rm command",
 		},
+		{
+			name: "literal pattern skips the regex safety checks",
+			command: &config.CommandConfig{
+				Command:       "npm",
+				ErrorPatterns: []*config.RegexPattern{{Pattern: "(a+)+ failed", Literal: true}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "same pattern as a regex is rejected",
+			command: &config.CommandConfig{
+				Command:       "npm",
+				ErrorPatterns: []*config.RegexPattern{{Pattern: "(a+)+ failed"}},
+			},
+			wantErr: true,
+		},
 	}
 
 	// Test with allowed commands list
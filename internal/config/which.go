@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Configuration sources, in the order they take precedence
+const (
+	// SourceExplicit is a configuration file given explicitly, e.g. with --config
+	SourceExplicit = "explicit"
+	// SourceEnv is a configuration file named by the QUALHOOK_CONFIG environment variable
+	SourceEnv = "env"
+	// SourceSearch is a configuration file found in the search paths
+	SourceSearch = "search"
+)
+
+// Resolution describes which configuration files a run uses and how they layer
+type Resolution struct {
+	// Path is the configuration file, or fragment directory, in use
+	Path string
+	// Source is how Path was found: SourceExplicit, SourceEnv or SourceSearch
+	Source string
+	// Layers are the files making up the configuration, from the most distant
+	// extends base to Path. Later layers override earlier ones.
+	Layers []string
+	// PathConfig is the pattern of the path configuration merged in for the
+	// working directory, if any
+	PathConfig string
+}
+
+// Resolve reports the configuration a run in workingDir uses. An explicit
+// path takes precedence over the environment variable and the search paths.
+func (l *Loader) Resolve(explicitPath, workingDir string) (*Resolution, error) {
+	resolution := &Resolution{Path: explicitPath, Source: SourceExplicit}
+	if explicitPath == "" {
+		resolution.Source = SourceSearch
+		if os.Getenv(ConfigEnvVar) != "" {
+			resolution.Source = SourceEnv
+		}
+
+		path, err := l.FindPath()
+		if err != nil {
+			return nil, err
+		}
+		resolution.Path = path
+	}
+
+	layersOf := extendsChain
+	if isDir(resolution.Path) {
+		layersOf = fragmentLayers
+	}
+	layers, err := layersOf(resolution.Path)
+	if err != nil {
+		return nil, err
+	}
+	resolution.Layers = layers
+
+	// Path configurations only apply to discovered configurations, like in
+	// LoadForMonorepo
+	if resolution.Source != SourceExplicit {
+		cfg, err := l.loadFromPath(resolution.Path)
+		if err != nil {
+			return nil, err
+		}
+		if pathConfig := l.pathConfigFor(cfg, workingDir); pathConfig != nil {
+			resolution.PathConfig = pathConfig.Path
+		}
+	}
+
+	return resolution, nil
+}
+
+// extendsChain returns the absolute paths of a configuration file and the
+// files it extends, from the most distant base to the file itself
+func extendsChain(path string) ([]string, error) {
+	var chain []string
+	for path != "" {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve config path: %w", err)
+		}
+		for _, seen := range chain {
+			if seen == absPath {
+				return nil, fmt.Errorf("circular extends: %s", strings.Join(append(chain, absPath), " -> "))
+			}
+		}
+		chain = append(chain, absPath)
+
+		cfg, err := parseConfigFile(absPath)
+		if err != nil {
+			return nil, err
+		}
+		path = cfg.Extends
+		if path != "" && !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(absPath), path)
+		}
+	}
+
+	// Bases come first, as they are overridden by the files extending them
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain, nil
+}
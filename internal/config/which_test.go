@@ -0,0 +1,102 @@
+//go:build unit
+
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const whichTestConfig = `{"version": "1.0", "commands": {"lint": {"command": "eslint"}}}`
+
+func TestLoader_ResolveParentVersusCwd(t *testing.T) {
+	t.Setenv(ConfigEnvVar, "")
+	parent := t.TempDir()
+	cwd := filepath.Join(parent, "packages", "web")
+	writeConfigFile(t, filepath.Join(parent, ConfigFileName), whichTestConfig)
+	loader := &Loader{SearchPaths: []string{cwd, parent}}
+
+	resolution, err := loader.Resolve("", cwd)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if want := filepath.Join(parent, ConfigFileName); resolution.Path != want || resolution.Source != SourceSearch {
+		t.Errorf("expected the parent config %s from the search paths, got %s (%s)", want, resolution.Path, resolution.Source)
+	}
+
+	// A config in the cwd takes precedence over the parent
+	writeConfigFile(t, filepath.Join(cwd, ConfigFileName), whichTestConfig)
+	resolution, err = loader.Resolve("", cwd)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if want := filepath.Join(cwd, ConfigFileName); resolution.Path != want {
+		t.Errorf("expected the cwd config %s, got %s", want, resolution.Path)
+	}
+}
+
+func TestLoader_ResolveLayers(t *testing.T) {
+	t.Setenv(ConfigEnvVar, "")
+	dir := t.TempDir()
+	writeConfigFile(t, filepath.Join(dir, "shared", "base.json"), whichTestConfig)
+	writeConfigFile(t, filepath.Join(dir, "team.json"), `{"version": "1.0", "extends": "shared/base.json"}`)
+	writeConfigFile(t, filepath.Join(dir, ConfigFileName), `{
+		"version": "1.0",
+		"extends": "team.json",
+		"paths": [{"path": "web/**", "commands": {"lint": {"command": "biome"}}}]
+	}`)
+	loader := &Loader{SearchPaths: []string{dir}}
+
+	resolution, err := loader.Resolve("", filepath.Join(dir, "web", "src"))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "shared", "base.json"),
+		filepath.Join(dir, "team.json"),
+		filepath.Join(dir, ConfigFileName),
+	}
+	if !reflect.DeepEqual(resolution.Layers, want) {
+		t.Errorf("Layers = %v, want %v", resolution.Layers, want)
+	}
+	if resolution.PathConfig != "web/**" {
+		t.Errorf("PathConfig = %q, want %q", resolution.PathConfig, "web/**")
+	}
+}
+
+func TestLoader_ResolveSources(t *testing.T) {
+	dir := t.TempDir()
+	explicit := filepath.Join(dir, "explicit.json")
+	fromEnv := filepath.Join(dir, "env.json")
+	writeConfigFile(t, explicit, whichTestConfig)
+	writeConfigFile(t, fromEnv, whichTestConfig)
+	t.Setenv(ConfigEnvVar, fromEnv)
+	loader := &Loader{SearchPaths: []string{dir}}
+
+	resolution, err := loader.Resolve("", dir)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if resolution.Path != fromEnv || resolution.Source != SourceEnv {
+		t.Errorf("expected %s from the environment, got %s (%s)", fromEnv, resolution.Path, resolution.Source)
+	}
+
+	resolution, err = loader.Resolve(explicit, dir)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if resolution.Path != explicit || resolution.Source != SourceExplicit {
+		t.Errorf("expected the explicit %s, got %s (%s)", explicit, resolution.Path, resolution.Source)
+	}
+}
+
+func TestLoader_ResolveMissing(t *testing.T) {
+	t.Setenv(ConfigEnvVar, "")
+	loader := &Loader{SearchPaths: []string{t.TempDir()}}
+
+	if _, err := loader.Resolve("", loader.SearchPaths[0]); err == nil {
+		t.Error("expected an error without a configuration file")
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// ProjectRoot returns the project root of a run from dir with the
+// configuration at configPath, which may be empty: the repository root of
+// dir, or else the directory of the configuration when dir is inside it, or
+// else dir. The allowed working directories of the security settings are
+// relative to it.
+func ProjectRoot(dir, configPath string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	if root, found := findRepositoryRoot(dir); found {
+		return root
+	}
+	if configPath == "" {
+		return dir
+	}
+
+	configPath, err = filepath.Abs(configPath)
+	if err != nil {
+		return dir
+	}
+	configDir := filepath.Dir(configPath)
+	if rel, err := filepath.Rel(configDir, dir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return configDir
+	}
+	return dir
+}
+
+// resolveWorkingDirs makes the workingDir of every command in cfg absolute,
+// resolving it relative to dir, the directory of the configuration file.
+// The executor checks the result against the security path rules and the
+// allowed working directories when the command runs.
+func resolveWorkingDirs(cfg *config.Config, dir string) {
+	resolve := func(cmd *config.CommandConfig) {
+		if cmd == nil || cmd.WorkingDir == "" || filepath.IsAbs(cmd.WorkingDir) {
+			return
+		}
+		cmd.WorkingDir = filepath.Join(dir, cmd.WorkingDir)
+	}
+
+	for _, cmd := range cfg.Commands {
+		resolve(cmd)
+	}
+	for _, pathCfg := range cfg.Paths {
+		if pathCfg == nil {
+			continue
+		}
+		for _, cmd := range pathCfg.Commands {
+			resolve(cmd)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+//go:build unit
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProjectRoot(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	project := filepath.Join(dir, "project")
+	elsewhere := filepath.Join(dir, "elsewhere")
+	for _, sub := range []string{filepath.Join(repo, ".git"), filepath.Join(repo, "sub"), filepath.Join(project, "sub"), elsewhere} {
+		if err := os.MkdirAll(sub, 0750); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		dir        string
+		configPath string
+		want       string
+	}{
+		{"repository root", filepath.Join(repo, "sub"), "", repo},
+		{"configuration directory", filepath.Join(project, "sub"), filepath.Join(project, ConfigFileName), project},
+		{"fragment directory", filepath.Join(project, "sub"), filepath.Join(project, ConfigDirName), project},
+		{"configuration elsewhere", filepath.Join(project, "sub"), filepath.Join(elsewhere, ConfigFileName), filepath.Join(project, "sub")},
+		{"no configuration", project, "", project},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ProjectRoot(tt.dir, tt.configPath); got != tt.want {
+				t.Errorf("ProjectRoot(%q, %q) = %q, want %q", tt.dir, tt.configPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoader_WorkingDir(t *testing.T) {
+	tempDir := t.TempDir()
+	writeConfigFile(t, filepath.Join(tempDir, "base.json"), `{
+  "version": "1.0",
+  "commands": {"test": {"command": "go", "workingDir": "server"}}
+}`)
+	configPath := filepath.Join(tempDir, "project", ConfigFileName)
+	writeConfigFile(t, configPath, `{
+  "version": "1.0",
+  "extends": "../base.json",
+  "commands": {"lint": {"command": "eslint", "workingDir": "/opt/app"}},
+  "paths": [
+    {"path": "web/**", "commands": {"lint": {"command": "eslint", "workingDir": "web/src"}}}
+  ]
+}`)
+
+	cfg, err := (&Loader{}).LoadFromPath(configPath)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+
+	// Each file resolves its own directories
+	if got, want := cfg.Commands["test"].WorkingDir, filepath.Join(tempDir, "server"); got != want {
+		t.Errorf("expected the base directory to be resolved next to base.json, got %q, want %q", got, want)
+	}
+	if got := cfg.Commands["lint"].WorkingDir; got != "/opt/app" {
+		t.Errorf("expected an absolute directory to be kept, got %q", got)
+	}
+	if got, want := cfg.Paths[0].Commands["lint"].WorkingDir, filepath.Join(tempDir, "project", "web", "src"); got != want {
+		t.Errorf("expected the path command directory to be resolved, got %q, want %q", got, want)
+	}
+}
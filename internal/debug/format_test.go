@@ -0,0 +1,86 @@
+//go:build unit
+
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONFormat(t *testing.T) {
+	originalEnabled, originalWriter, originalFormat := globalLogger.enabled, globalLogger.writer, globalLogger.format
+	defer func() {
+		globalLogger.enabled, globalLogger.writer, globalLogger.format = originalEnabled, originalWriter, originalFormat
+		globalLogger.section = ""
+	}()
+
+	var buf bytes.Buffer
+	SetWriter(&buf)
+	Enable()
+	if err := SetFormat(FormatJSON); err != nil {
+		t.Fatalf("SetFormat() error = %v", err)
+	}
+
+	LogCommand("go", []string{"vet"}, "")
+	LogTiming("lint", 1500*time.Millisecond)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected one JSON object per event, got:\n%s", buf.String())
+	}
+	events := make([]map[string]any, len(lines))
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &events[i]); err != nil {
+			t.Fatalf("line %d is not JSON: %v\n%s", i, err, line)
+		}
+		if events[i]["time"] == "" || events[i]["section"] != "Command Execution" {
+			t.Errorf("expected a timestamp and the section, got %v", events[i])
+		}
+	}
+
+	if events[0]["message"] != "=== Command Execution ===" {
+		t.Errorf("unexpected section message %v", events[0]["message"])
+	}
+	fields, _ := events[1]["fields"].(map[string]any)
+	if events[1]["message"] != "Command: go" || fields["command"] != "go" {
+		t.Errorf("expected the command as a field, got %v", events[1])
+	}
+	fields, _ = events[3]["fields"].(map[string]any)
+	if fields["operation"] != "lint" || fields["durationMs"] != float64(1500) {
+		t.Errorf("expected the timing as fields, got %v", events[3])
+	}
+
+	// Loggers created later use the same format
+	var own bytes.Buffer
+	buf.Reset()
+	NewLogger(&own).Log("mine")
+	if !json.Valid(bytes.TrimSpace(own.Bytes())) || !json.Valid(bytes.TrimSpace(buf.Bytes())) {
+		t.Errorf("expected JSON from both loggers, got %q and %q", own.String(), buf.String())
+	}
+}
+
+func TestSetFormat(t *testing.T) {
+	originalFormat := globalLogger.format
+	defer func() { globalLogger.format = originalFormat }()
+
+	if err := SetFormat("xml"); err == nil {
+		t.Error("expected an unknown format to be rejected")
+	}
+	if err := SetFormat(FormatText); err != nil || globalLogger.format != FormatText {
+		t.Errorf("SetFormat(text) error = %v, format = %q", err, globalLogger.format)
+	}
+}
+
+func TestFormatFromEnv(t *testing.T) {
+	t.Setenv(FormatEnvVar, "JSON")
+	if got := formatFromEnv(); got != FormatJSON {
+		t.Errorf("formatFromEnv() = %q, want %q", got, FormatJSON)
+	}
+	t.Setenv(FormatEnvVar, "")
+	if got := formatFromEnv(); got != FormatText {
+		t.Errorf("formatFromEnv() = %q, want %q", got, FormatText)
+	}
+}
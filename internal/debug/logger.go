@@ -2,24 +2,73 @@
 package debug
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"sync"
 	"time"
 )
 
+// Debug log formats
+const (
+	// FormatText writes "[DEBUG 12ms] message" lines (the default)
+	FormatText = "text"
+	// FormatJSON writes one JSON object per event, for log aggregation
+	FormatJSON = "json"
+)
+
+// FormatEnvVar selects the debug log format, e.g. QUALHOOK_DEBUG_FORMAT=json
+const FormatEnvVar = "QUALHOOK_DEBUG_FORMAT"
+
 // Logger provides debug logging capabilities
 type Logger struct {
 	enabled bool
 	writer  io.Writer
 	start   time.Time
+	format  string
+	// mu guards section and writes, as components may log concurrently
+	mu sync.Mutex
+	// section is the title of the last section started
+	section string
+}
+
+// jsonEvent is a debug event in the JSON format
+type jsonEvent struct {
+	Time    string         `json:"time"`
+	Elapsed string         `json:"elapsed"`
+	Section string         `json:"section,omitempty"`
+	Message string         `json:"message"`
+	Fields  map[string]any `json:"fields,omitempty"`
 }
 
 // Global debug logger instance
 var globalLogger = &Logger{
 	enabled: false,
 	writer:  os.Stderr,
+	format:  formatFromEnv(),
+}
+
+// formatFromEnv returns the format selected by FormatEnvVar, or FormatText
+func formatFromEnv() string {
+	if strings.EqualFold(strings.TrimSpace(os.Getenv(FormatEnvVar)), FormatJSON) {
+		return FormatJSON
+	}
+	return FormatText
+}
+
+// SetFormat sets the format of the debug log and of loggers created later
+func SetFormat(format string) error {
+	switch format {
+	case FormatText, FormatJSON:
+	default:
+		return fmt.Errorf("invalid debug log format %q: must be %q or %q", format, FormatText, FormatJSON)
+	}
+	globalLogger.mu.Lock()
+	defer globalLogger.mu.Unlock()
+	globalLogger.format = format
+	return nil
 }
 
 // Enable enables debug logging
@@ -38,56 +87,142 @@ func SetWriter(w io.Writer) {
 	globalLogger.writer = w
 }
 
+// NewLogger creates an enabled logger writing to w, e.g. a per-command log
+// file. Its entries are also written to the global debug log when enabled.
+func NewLogger(w io.Writer) *Logger {
+	globalLogger.mu.Lock()
+	defer globalLogger.mu.Unlock()
+	return &Logger{
+		enabled: true,
+		writer:  w,
+		start:   time.Now(),
+		format:  globalLogger.format,
+	}
+}
+
 // Log writes a debug message if debugging is enabled
 func Log(format string, args ...interface{}) {
-	if !globalLogger.enabled {
+	globalLogger.write(nil, format, args...)
+}
+
+// Log writes a debug message to l and to the global debug log. A nil logger
+// only writes to the global debug log.
+func (l *Logger) Log(format string, args ...interface{}) {
+	l.logFields(nil, format, args...)
+}
+
+// logFields is Log with structured fields for the JSON format
+func (l *Logger) logFields(fields map[string]any, format string, args ...interface{}) {
+	if l != globalLogger {
+		globalLogger.write(fields, format, args...)
+	}
+	if l != nil {
+		l.write(fields, format, args...)
+	}
+}
+
+// active reports whether messages logged to l are written anywhere
+func (l *Logger) active() bool {
+	return globalLogger.enabled || (l != nil && l.enabled)
+}
+
+// write writes a message to this logger only
+func (l *Logger) write(fields map[string]any, format string, args ...interface{}) {
+	if !l.enabled {
 		return
 	}
 
-	elapsed := time.Since(globalLogger.start)
-	prefix := fmt.Sprintf("[DEBUG %s] ", formatDuration(elapsed))
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	elapsed := time.Since(l.start)
 	message := fmt.Sprintf(format, args...)
 
+	if l.format == FormatJSON {
+		data, err := json.Marshal(jsonEvent{
+			Time:    time.Now().UTC().Format(time.RFC3339Nano),
+			Elapsed: formatDuration(elapsed),
+			Section: l.section,
+			Message: strings.TrimSuffix(message, "\n"),
+			Fields:  fields,
+		})
+		if err != nil {
+			return
+		}
+		_, _ = fmt.Fprintf(l.writer, "%s\n", data) //nolint:errcheck // Debug output is best effort
+		return
+	}
+
+	prefix := fmt.Sprintf("[DEBUG %s] ", formatDuration(elapsed))
+
 	// Ensure message ends with newline
 	if !strings.HasSuffix(message, "\n") {
 		message += "\n"
 	}
 
-	_, _ = fmt.Fprint(globalLogger.writer, prefix+message) //nolint:errcheck // Debug output is best effort
+	_, _ = fmt.Fprint(l.writer, prefix+message) //nolint:errcheck // Debug output is best effort
 }
 
 // LogSection writes a section header for better organization
 func LogSection(title string) {
-	if !globalLogger.enabled {
+	globalLogger.LogSection(title)
+}
+
+// LogSection writes a section header to l and to the global debug log
+func (l *Logger) LogSection(title string) {
+	if !l.active() {
 		return
 	}
 
-	Log("=== %s ===", title)
+	if l != globalLogger {
+		globalLogger.setSection(title)
+	}
+	if l != nil {
+		l.setSection(title)
+	}
+	l.Log("=== %s ===", title)
+}
+
+// setSection records the section that following events belong to
+func (l *Logger) setSection(title string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.section = title
 }
 
 // LogCommand logs command execution details
 func LogCommand(command string, args []string, workingDir string) {
-	if !globalLogger.enabled {
+	globalLogger.LogCommand(command, args, workingDir)
+}
+
+// LogCommand logs command execution details to l and to the global debug log
+func (l *Logger) LogCommand(command string, args []string, workingDir string) {
+	if !l.active() {
 		return
 	}
 
-	LogSection("Command Execution")
-	Log("Command: %s", command)
+	l.LogSection("Command Execution")
+	l.logFields(map[string]any{"command": command}, "Command: %s", command)
 	if len(args) > 0 {
-		Log("Arguments: %v", args)
+		l.logFields(map[string]any{"args": args}, "Arguments: %v", args)
 	}
 	if workingDir != "" {
-		Log("Working Directory: %s", workingDir)
+		l.logFields(map[string]any{"workingDir": workingDir}, "Working Directory: %s", workingDir)
 	}
 }
 
 // LogTiming logs timing information
 func LogTiming(operation string, duration time.Duration) {
-	if !globalLogger.enabled {
+	globalLogger.LogTiming(operation, duration)
+}
+
+// LogTiming logs timing information to l and to the global debug log
+func (l *Logger) LogTiming(operation string, duration time.Duration) {
+	if !l.active() {
 		return
 	}
 
-	Log("Timing: %s took %s", operation, formatDuration(duration))
+	l.logFields(map[string]any{"operation": operation, "durationMs": duration.Milliseconds()},
+		"Timing: %s took %s", operation, formatDuration(duration))
 }
 
 // LogPatternMatch logs pattern matching details
@@ -101,25 +236,53 @@ func LogPatternMatch(pattern, input string, matched bool) {
 		status = "matched"
 	}
 
-	Log("Pattern: %q against %q - %s", pattern, truncate(input, 80), status)
+	globalLogger.write(map[string]any{"pattern": pattern, "input": truncate(input, 80), "matched": matched},
+		"Pattern: %q against %q - %s", pattern, truncate(input, 80), status)
 }
 
 // LogFilterProcess logs the filtering process
 func LogFilterProcess(totalLines, matchedLines, outputLines int) {
-	if !globalLogger.enabled {
+	globalLogger.LogFilterProcess(totalLines, matchedLines, outputLines)
+}
+
+// LogFilterProcess logs the filtering process to l and to the global debug log
+func (l *Logger) LogFilterProcess(totalLines, matchedLines, outputLines int) {
+	if !l.active() {
+		return
+	}
+
+	l.logFields(map[string]any{"totalLines": totalLines, "matchedLines": matchedLines, "outputLines": outputLines},
+		"Filter: %d total lines -> %d matched -> %d output", totalLines, matchedLines, outputLines)
+}
+
+// LogPatternStats logs how many of the scanned lines of a command's output
+// a pattern matched, and how long matching took
+func LogPatternStats(command, pattern string, matches, totalLines int, duration time.Duration) {
+	globalLogger.LogPatternStats(command, pattern, matches, totalLines, duration)
+}
+
+// LogPatternStats logs pattern statistics to l and to the global debug log
+func (l *Logger) LogPatternStats(command, pattern string, matches, totalLines int, duration time.Duration) {
+	if !l.active() {
 		return
 	}
 
-	Log("Filter: %d total lines -> %d matched -> %d output", totalLines, matchedLines, outputLines)
+	l.logFields(map[string]any{"command": command, "pattern": pattern, "matches": matches, "totalLines": totalLines, "durationMs": duration.Milliseconds()},
+		"Pattern stats: %s %q matched %d of %d lines in %s", command, pattern, matches, totalLines, formatDuration(duration))
 }
 
 // LogError logs error details
 func LogError(err error, context string) {
-	if !globalLogger.enabled {
+	globalLogger.LogError(err, context)
+}
+
+// LogError logs error details to l and to the global debug log
+func (l *Logger) LogError(err error, context string) {
+	if !l.active() {
 		return
 	}
 
-	Log("Error in %s: %v", context, err)
+	l.logFields(map[string]any{"context": context, "error": fmt.Sprint(err)}, "Error in %s: %v", context, err)
 }
 
 // formatDuration formats a duration for display
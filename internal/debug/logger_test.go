@@ -192,6 +192,19 @@ func TestLogFilterProcess(t *testing.T) {
 	}
 }
 
+func TestLogPatternStats(t *testing.T) {
+	var buf bytes.Buffer
+	SetWriter(&buf)
+	Enable()
+
+	buf.Reset()
+	LogPatternStats("eslint", "error", 3, 120, 2*time.Millisecond)
+	output := buf.String()
+	if !strings.Contains(output, `Pattern stats: eslint "error" matched 3 of 120 lines in 2ms`) {
+		t.Errorf("LogPatternStats output incorrect: %q", output)
+	}
+}
+
 func TestLogError(t *testing.T) {
 	var buf bytes.Buffer
 	SetWriter(&buf)
@@ -295,3 +308,41 @@ func TestDebugPrefix(t *testing.T) {
 		t.Error("Log output should contain the message after the prefix")
 	}
 }
+
+func TestNewLogger(t *testing.T) {
+	originalEnabled := globalLogger.enabled
+	originalWriter := globalLogger.writer
+	defer func() {
+		globalLogger.enabled = originalEnabled
+		globalLogger.writer = originalWriter
+	}()
+
+	var global, own bytes.Buffer
+	SetWriter(&global)
+	globalLogger.enabled = false
+
+	logger := NewLogger(&own)
+	logger.LogCommand("npm", []string{"run", "lint"}, "packages/frontend")
+	logger.LogError(errors.New("boom"), "execution")
+
+	if !strings.Contains(own.String(), "Command: npm") || !strings.Contains(own.String(), "Error in execution: boom") {
+		t.Errorf("logger output missing entries: %q", own.String())
+	}
+	if global.Len() > 0 {
+		t.Errorf("disabled global log received entries: %q", global.String())
+	}
+
+	// Entries are mirrored to the global log when debugging is enabled
+	Enable()
+	logger.Log("Mirrored %d", 1)
+	if !strings.Contains(global.String(), "Mirrored 1") {
+		t.Errorf("global log missing mirrored entry: %q", global.String())
+	}
+
+	// A nil logger only writes to the global log
+	var nilLogger *Logger
+	nilLogger.LogSection("Nil")
+	if !strings.Contains(global.String(), "=== Nil ===") {
+		t.Errorf("global log missing nil logger entry: %q", global.String())
+	}
+}
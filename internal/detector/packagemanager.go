@@ -0,0 +1,41 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+)
+
+// Supported Node.js package managers
+const (
+	PackageManagerNPM  = "npm"
+	PackageManagerYarn = "yarn"
+	PackageManagerPNPM = "pnpm"
+	PackageManagerBun  = "bun"
+)
+
+// packageManagerLockfiles maps lockfiles to package managers, in order of precedence
+// when a repository contains more than one lockfile
+var packageManagerLockfiles = []struct {
+	lockfile       string
+	packageManager string
+}{
+	{"pnpm-lock.yaml", PackageManagerPNPM},
+	{"yarn.lock", PackageManagerYarn},
+	{"bun.lockb", PackageManagerBun},
+	{"bun.lock", PackageManagerBun},
+	{"package-lock.json", PackageManagerNPM},
+}
+
+// DetectPackageManager identifies the Node.js package manager used in path from
+// its lockfile. It returns an empty string when no lockfile is present.
+func (d *ProjectDetector) DetectPackageManager(path string) string {
+	for _, entry := range packageManagerLockfiles {
+		if _, err := os.Stat(filepath.Join(path, entry.lockfile)); err == nil {
+			debug.Log("Detected package manager %s from %s", entry.packageManager, entry.lockfile)
+			return entry.packageManager
+		}
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+//go:build unit
+
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/config"
+)
+
+func TestProjectDetector_DetectPackageManager(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      []string
+		want       string
+		wantRunner string
+	}{
+		{name: "npm lockfile", files: []string{"package.json", "package-lock.json"}, want: PackageManagerNPM, wantRunner: "npm"},
+		{name: "yarn lockfile", files: []string{"package.json", "yarn.lock"}, want: PackageManagerYarn, wantRunner: "yarn"},
+		{name: "pnpm lockfile", files: []string{"package.json", "pnpm-lock.yaml"}, want: PackageManagerPNPM, wantRunner: "pnpm"},
+		{name: "bun lockfile", files: []string{"package.json", "bun.lockb"}, want: PackageManagerBun, wantRunner: "bun"},
+		{name: "no lockfile", files: []string{"package.json"}, want: "", wantRunner: "npm"},
+		{name: "pnpm wins over stale npm lockfile", files: []string{"package-lock.json", "pnpm-lock.yaml"}, want: PackageManagerPNPM, wantRunner: "pnpm"},
+	}
+
+	defaults, err := config.NewDefaultConfigs()
+	if err != nil {
+		t.Fatalf("failed to load defaults: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, file := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, file), []byte("{}"), 0600); err != nil {
+					t.Fatalf("failed to create %s: %v", file, err)
+				}
+			}
+
+			got := New().DetectPackageManager(dir)
+			if got != tt.want {
+				t.Fatalf("DetectPackageManager() = %q, want %q", got, tt.want)
+			}
+
+			cfg, err := defaults.GetNodeJSConfig(got)
+			if err != nil {
+				t.Fatalf("GetNodeJSConfig failed: %v", err)
+			}
+			if lint := cfg.Commands["lint"]; lint.Command != tt.wantRunner {
+				t.Errorf("lint runner = %q, want %q", lint.Command, tt.wantRunner)
+			}
+		})
+	}
+}
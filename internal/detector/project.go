@@ -2,6 +2,7 @@
 package detector
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,10 @@ import (
 	"github.com/bebsworthy/qualhook/internal/debug"
 )
 
+// composerConfidence is the lowest confidence of a PHP project whose
+// composer.json declares dependencies, autoloading or scripts
+const composerConfidence = 0.9
+
 // ProjectType represents a detected project type with confidence score
 type ProjectType struct {
 	Name       string   // Project type name (e.g., "nodejs", "go", "rust")
@@ -79,6 +84,13 @@ func New() *ProjectDetector {
 				{name: "gradlew", weight: 0.5},
 				{name: ".mvn", weight: 0.4},
 			},
+			"gradle": {
+				// Groovy and Kotlin DSL build scripts count as one marker
+				{name: "build.gradle*", weight: 1.0},
+				{name: "settings.gradle*", weight: 0.7},
+				{name: "gradlew", weight: 0.5},
+				{name: "gradle.properties", weight: 0.3},
+			},
 			"ruby": {
 				{name: "Gemfile", weight: 1.0},
 				{name: "Gemfile.lock", weight: 0.7},
@@ -132,6 +144,9 @@ func (d *ProjectDetector) Detect(path string) ([]ProjectType, error) {
 	for projectName, score := range projectScores {
 		if score.totalWeight > 0 {
 			confidence := score.score / score.maxPossibleScore()
+			if projectName == "php" && d.hasComposerManifest(path) {
+				confidence = max(confidence, composerConfidence)
+			}
 			results = append(results, ProjectType{
 				Name:       projectName,
 				Confidence: confidence,
@@ -319,6 +334,28 @@ func (d *ProjectDetector) checkYarnWorkspaces(path string) bool {
 	return strings.Contains(string(data), `"workspaces"`)
 }
 
+// hasComposerManifest checks if composer.json is a Composer manifest, an
+// object with the fields a PHP project declares, rather than a file of the
+// same name
+func (d *ProjectDetector) hasComposerManifest(path string) bool {
+	// #nosec G304 - path is from project detection, not user input
+	data, err := os.ReadFile(filepath.Join(path, "composer.json"))
+	if err != nil {
+		return false
+	}
+
+	var manifest map[string]json.RawMessage
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return false
+	}
+	for _, field := range []string{"require", "require-dev", "autoload", "scripts"} {
+		if _, ok := manifest[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // scanWorkspaces scans for workspace directories in a monorepo
 func (d *ProjectDetector) scanWorkspaces(info *MonorepoInfo) {
 	// Common workspace patterns
@@ -374,6 +411,7 @@ func GetDefaultConfigName(projectType string) string {
 		"rust":   "rust.json",
 		"python": "python.json",
 		"java":   "java.json",
+		"gradle": "gradle.json",
 		"ruby":   "ruby.json",
 		"php":    "php.json",
 		"dotnet": "dotnet.json",
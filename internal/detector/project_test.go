@@ -59,6 +59,18 @@ func TestProjectDetector_Detect(t *testing.T) {
 			expectedTypes: []string{"java"},
 			minConfidence: 0.4,
 		},
+		{
+			name:          "Gradle project",
+			files:         []string{"build.gradle", "settings.gradle", "gradlew"},
+			expectedTypes: []string{"java", "gradle"},
+			minConfidence: 0.4,
+		},
+		{
+			name:          "Kotlin DSL Gradle project",
+			files:         []string{"build.gradle.kts", "settings.gradle.kts"},
+			expectedTypes: []string{"gradle"},
+			minConfidence: 0.3,
+		},
 		{
 			name:          "Ruby project",
 			files:         []string{"Gemfile", "Gemfile.lock", ".ruby-version"},
@@ -212,6 +224,73 @@ func TestProjectDetector_DetectWithDotNetProject(t *testing.T) {
 	}
 }
 
+func TestProjectDetector_DetectGradleRanksFirst(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, file := range []string{"build.gradle", "settings.gradle", "gradlew"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, file), []byte("test content"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := New().Detect(tmpDir)
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(results) < 2 || results[0].Name != "gradle" || results[1].Name != "java" {
+		t.Errorf("expected gradle ahead of java, got %+v", results)
+	}
+}
+
+func TestProjectDetector_DetectComposerManifest(t *testing.T) {
+	detector := New()
+
+	tests := []struct {
+		name          string
+		content       string
+		minConfidence float64
+		maxConfidence float64
+	}{
+		{
+			name:          "Composer manifest",
+			content:       `{"name": "acme/app", "require": {"php": "^8.2"}, "autoload": {"psr-4": {"App\\": "src/"}}}`,
+			minConfidence: 0.9,
+			maxConfidence: 1.0,
+		},
+		{
+			name:          "Manifest without PHP fields",
+			content:       `{"name": "acme/app"}`,
+			minConfidence: 0.0,
+			maxConfidence: 0.5,
+		},
+		{
+			name:          "Invalid JSON",
+			content:       "test content",
+			minConfidence: 0.0,
+			maxConfidence: 0.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "composer.json"), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			results, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("Detect() error = %v", err)
+			}
+			if len(results) == 0 || results[0].Name != "php" {
+				t.Fatalf("expected a PHP project, got %+v", results)
+			}
+			if results[0].Confidence < tt.minConfidence || results[0].Confidence > tt.maxConfidence {
+				t.Errorf("confidence = %f, want between %f and %f", results[0].Confidence, tt.minConfidence, tt.maxConfidence)
+			}
+		})
+	}
+}
+
 func TestProjectDetector_DetectInvalidPath(t *testing.T) {
 	detector := New()
 
@@ -450,6 +529,7 @@ func TestProjectDetector_GetDefaultConfigName(t *testing.T) {
 		{"rust", "rust.json"},
 		{"python", "python.json"},
 		{"java", "java.json"},
+		{"gradle", "gradle.json"},
 		{"ruby", "ruby.json"},
 		{"php", "php.json"},
 		{"dotnet", "dotnet.json"},
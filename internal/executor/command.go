@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/bebsworthy/qualhook/internal/security"
+	"github.com/bebsworthy/qualhook/pkg/config"
 )
 
 // ExecOptions defines options for command execution
@@ -25,6 +26,17 @@ type ExecOptions struct {
 	Timeout time.Duration
 	// Whether to inherit parent process environment
 	InheritEnv bool
+	// PathPrepend lists directories searched before PATH, relative to the
+	// working directory unless absolute, e.g. node_modules/.bin
+	PathPrepend []string
+	// StreamStdout and StreamStderr receive the command output as it arrives,
+	// in addition to it being collected in the result
+	StreamStdout io.Writer
+	StreamStderr io.Writer
+	// Context stops the command when canceled; nil means only Timeout applies
+	Context context.Context
+	// Stdin is fed to the command; nil connects it to the null device
+	Stdin io.Reader
 }
 
 // ExecResult contains the result of command execution
@@ -37,6 +49,9 @@ type ExecResult struct {
 	ExitCode int
 	// Whether the command timed out
 	TimedOut bool
+	// Whether the command was stopped, or never started, because its
+	// context was canceled
+	Canceled bool
 	// Error if command failed to start
 	Error error
 }
@@ -47,6 +62,8 @@ type CommandExecutor struct {
 	defaultTimeout time.Duration
 	// Security validator for command validation
 	securityValidator *security.SecurityValidator
+	// workingDirPolicy restricts working directories when set
+	workingDirPolicy *workingDirPolicy
 }
 
 // NewCommandExecutor creates a new command executor
@@ -60,6 +77,26 @@ func NewCommandExecutor(defaultTimeout time.Duration) *CommandExecutor {
 	}
 }
 
+// SetCommandLists restricts the commands that may run: when allowed is not
+// empty only those commands may run, and denied commands never run. Entries
+// match the command as configured or its base name.
+func (e *CommandExecutor) SetCommandLists(allowed, denied []string) {
+	e.securityValidator.SetAllowedCommands(allowed)
+	e.securityValidator.SetDeniedCommands(denied)
+}
+
+// ApplySecurity restricts the commands that may run to the command lists of
+// settings, and their working directories to its allowed working
+// directories, relative to root. Nil settings allow any command, in root and
+// its subdirectories.
+func (e *CommandExecutor) ApplySecurity(root string, settings *config.SecurityConfig) error {
+	if settings == nil {
+		settings = &config.SecurityConfig{}
+	}
+	e.SetCommandLists(settings.AllowedCommands, settings.DeniedCommands)
+	return e.SetAllowedWorkingDirs(root, settings.AllowedWorkingDirs)
+}
+
 // Execute runs a command with the given options
 func (e *CommandExecutor) Execute(command string, args []string, options ExecOptions) (*ExecResult, error) {
 	// Validate command using security validator
@@ -74,11 +111,16 @@ func (e *CommandExecutor) Execute(command string, args []string, options ExecOpt
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	parent := options.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
 	defer cancel()
 
 	// Create command
 	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Stdin = options.Stdin
 
 	// Set working directory
 	if options.WorkingDir != "" {
@@ -100,17 +142,39 @@ func (e *CommandExecutor) Execute(command string, args []string, options ExecOpt
 		}
 		cmd.Dir = absPath
 	}
+	if e.workingDirPolicy != nil {
+		dir := cmd.Dir
+		if dir == "" {
+			dir = "."
+		}
+		if err := e.workingDirPolicy.check(dir); err != nil {
+			return nil, fmt.Errorf("working directory not allowed: %w", err)
+		}
+	}
 
 	// Set environment
 	env := e.prepareEnvironment(options)
+	if len(options.PathPrepend) > 0 {
+		dirs, err := resolvePathDirs(options.PathPrepend, cmd.Dir)
+		if err != nil {
+			return nil, err
+		}
+		if env, err = prependPath(env, dirs); err != nil {
+			return nil, fmt.Errorf("invalid path prepend: %w", err)
+		}
+		if path, ok := lookPathIn(command, dirs); ok {
+			cmd.Path = path
+			cmd.Err = nil
+		}
+	}
 	if len(env) > 0 {
 		cmd.Env = env
 	}
 
-	// Capture output
+	// Capture output, streaming it as well when requested
 	var stdoutBuf, stderrBuf bytes.Buffer
-	cmd.Stdout = &stdoutBuf
-	cmd.Stderr = &stderrBuf
+	cmd.Stdout = teeOutput(&stdoutBuf, options.StreamStdout)
+	cmd.Stderr = teeOutput(&stderrBuf, options.StreamStderr)
 
 	// Start the command
 	err := cmd.Start()
@@ -123,119 +187,25 @@ func (e *CommandExecutor) Execute(command string, args []string, options ExecOpt
 		}, nil
 	}
 
+	// Flush streamed output on every exit path, including timeouts
+	defer flushOutput(options.StreamStdout)
+	defer flushOutput(options.StreamStderr)
+
 	// Wait for command to complete
 	waitErr := cmd.Wait()
 
-	// Check if context was canceled (timeout)
-	timedOut := false
-	if ctx.Err() == context.DeadlineExceeded {
-		timedOut = true
-		// Ensure process is cleaned up after timeout
-		_ = HandleTimeoutCleanup(cmd) //nolint:errcheck // Best effort cleanup after timeout
-	}
-
-	// Get exit code
-	exitCode := 0
-	if waitErr != nil {
-		if exitErr, ok := waitErr.(*exec.ExitError); ok {
-			exitCode = exitErr.ExitCode()
-		} else {
-			// Command failed to run properly
-			return &ExecResult{
-				Stdout:   stdoutBuf.String(),
-				Stderr:   stderrBuf.String(),
-				ExitCode: -1,
-				TimedOut: timedOut,
-				Error:    waitErr,
-			}, nil
-		}
-	}
-
-	return &ExecResult{
-		Stdout:   stdoutBuf.String(),
-		Stderr:   stderrBuf.String(),
-		ExitCode: exitCode,
-		TimedOut: timedOut,
-	}, nil
-}
-
-// ExecuteWithStreaming runs a command and streams output to the provided writers
-func (e *CommandExecutor) ExecuteWithStreaming(command string, args []string, options ExecOptions, stdoutWriter, stderrWriter io.Writer) (*ExecResult, error) {
-	// Validate command using security validator
-	if err := e.securityValidator.ValidateCommand(command, args); err != nil {
-		return nil, fmt.Errorf("command validation failed: %w", err)
-	}
-
-	// Set default timeout if not specified
-	timeout := options.Timeout
-	if timeout <= 0 {
-		timeout = e.defaultTimeout
-	}
-
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	// Create command
-	cmd := exec.CommandContext(ctx, command, args...)
-
-	// Set working directory
-	if options.WorkingDir != "" {
-		// Validate the working directory path
-		if err := e.securityValidator.ValidatePath(options.WorkingDir); err != nil {
-			return nil, fmt.Errorf("invalid working directory: %w", err)
-		}
-
-		absPath, err := filepath.Abs(options.WorkingDir)
-		if err != nil {
-			return nil, fmt.Errorf("invalid working directory: %w", err)
-		}
-		// Check if directory exists
-		if _, err := os.Stat(absPath); err != nil {
-			if os.IsNotExist(err) {
-				return nil, fmt.Errorf("invalid working directory: %s does not exist", absPath)
-			}
-			return nil, fmt.Errorf("invalid working directory: %w", err)
-		}
-		cmd.Dir = absPath
-	}
-
-	// Set environment
-	env := e.prepareEnvironment(options)
-	if len(env) > 0 {
-		cmd.Env = env
-	}
-
-	// Create buffers to capture output while also streaming
-	var stdoutBuf, stderrBuf bytes.Buffer
-
-	// Create multi-writers to both stream and capture
-	if stdoutWriter != nil {
-		cmd.Stdout = io.MultiWriter(stdoutWriter, &stdoutBuf)
-	} else {
-		cmd.Stdout = &stdoutBuf
-	}
-
-	if stderrWriter != nil {
-		cmd.Stderr = io.MultiWriter(stderrWriter, &stderrBuf)
-	} else {
-		cmd.Stderr = &stderrBuf
-	}
-
-	// Start the command
-	err := cmd.Start()
-	if err != nil {
-		// Classify the error
-		execErr := ClassifyError(err, command, args)
+	// Check if the caller canceled the command before it finished
+	if waitErr != nil && parent.Err() != nil {
+		_ = HandleTimeoutCleanup(cmd) //nolint:errcheck // Best effort cleanup after cancellation
 		return &ExecResult{
+			Stdout:   stdoutBuf.String(),
+			Stderr:   stderrBuf.String(),
 			ExitCode: -1,
-			Error:    execErr,
+			Canceled: true,
+			Error:    parent.Err(),
 		}, nil
 	}
 
-	// Wait for command to complete
-	waitErr := cmd.Wait()
-
 	// Check if context was canceled (timeout)
 	timedOut := false
 	if ctx.Err() == context.DeadlineExceeded {
@@ -269,6 +239,14 @@ func (e *CommandExecutor) ExecuteWithStreaming(command string, args []string, op
 	}, nil
 }
 
+// ExecuteWithStreaming runs a command and streams output to the provided writers
+// as it arrives, while still collecting it in the result
+func (e *CommandExecutor) ExecuteWithStreaming(command string, args []string, options ExecOptions, stdoutWriter, stderrWriter io.Writer) (*ExecResult, error) {
+	options.StreamStdout = stdoutWriter
+	options.StreamStderr = stderrWriter
+	return e.Execute(command, args, options)
+}
+
 // prepareEnvironment prepares the environment variables for the command
 func (e *CommandExecutor) prepareEnvironment(options ExecOptions) []string {
 	var baseEnv []string
@@ -296,6 +274,24 @@ func (e *CommandExecutor) prepareEnvironment(options ExecOptions) []string {
 	return baseEnv
 }
 
+// teeOutput returns a writer collecting output in buf and copying it to stream, if any
+func teeOutput(buf *bytes.Buffer, stream io.Writer) io.Writer {
+	if stream == nil {
+		return buf
+	}
+	return io.MultiWriter(stream, buf)
+}
+
+// flushOutput flushes a streaming writer that buffers its output
+func flushOutput(w io.Writer) {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		_ = f.Flush() //nolint:errcheck // Best effort flush of streamed output
+	case interface{ Flush() }:
+		f.Flush()
+	}
+}
+
 // StreamingWriter is a thread-safe writer that can be used for streaming output
 type StreamingWriter struct {
 	mu     sync.Mutex
@@ -313,3 +309,13 @@ func (sw *StreamingWriter) Write(p []byte) (n int, err error) {
 	defer sw.mu.Unlock()
 	return sw.writer.Write(p)
 }
+
+// Flush flushes the underlying writer if it buffers output
+func (sw *StreamingWriter) Flush() error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if f, ok := sw.writer.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
@@ -3,11 +3,15 @@
 package executor
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -200,6 +204,85 @@ func TestExecuteWithStreaming(t *testing.T) {
 	}
 }
 
+func TestExecute_StreamOutput(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	script := filepath.Join(t.TempDir(), "progress.sh")
+	content := "echo step 1\necho warning >&2\nsleep 0.5\necho step 2\n"
+	if err := os.WriteFile(script, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	stdout := &firstWriteRecorder{}
+	var stderr bytes.Buffer
+	start := time.Now()
+	result, err := NewCommandExecutor(10*time.Second).Execute("sh", []string{script}, ExecOptions{
+		StreamStdout: stdout,
+		StreamStderr: &stderr,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first := stdout.firstWrite.Sub(start); first >= 500*time.Millisecond {
+		t.Errorf("expected output to stream before the command finished, first write after %v", first)
+	}
+	if stdout.buf.String() != "step 1\nstep 2\n" || result.Stdout != stdout.buf.String() {
+		t.Errorf("expected streamed and collected stdout to match, got %q and %q", stdout.buf.String(), result.Stdout)
+	}
+	if stderr.String() != "warning\n" || result.Stderr != "warning\n" {
+		t.Errorf("expected stderr to be streamed and collected, got %q and %q", stderr.String(), result.Stderr)
+	}
+}
+
+func TestExecute_StreamOutputFlushedOnTimeout(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	script := filepath.Join(t.TempDir(), "hang.sh")
+	if err := os.WriteFile(script, []byte("echo started\nexec sleep 5\n"), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	var out bytes.Buffer
+	buffered := bufio.NewWriter(&out)
+	result, err := NewCommandExecutor(10*time.Second).Execute("sh", []string{script}, ExecOptions{
+		Timeout:      300 * time.Millisecond,
+		StreamStdout: NewStreamingWriter(buffered),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.TimedOut {
+		t.Error("expected the command to time out")
+	}
+	if out.String() != "started\n" {
+		t.Errorf("expected buffered stream to be flushed on timeout, got %q", out.String())
+	}
+}
+
+// firstWriteRecorder records output and when it was first written
+type firstWriteRecorder struct {
+	mu         sync.Mutex
+	buf        bytes.Buffer
+	firstWrite time.Time
+}
+
+func (r *firstWriteRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.firstWrite.IsZero() {
+		r.firstWrite = time.Now()
+	}
+	return r.buf.Write(p)
+}
+
 func TestPrepareEnvironment(t *testing.T) {
 	// Skip this test as prepareEnvironment is now using security sanitization
 	t.Skip("prepareEnvironment now uses security sanitization - testing through integration tests")
@@ -320,3 +403,15 @@ func TestStreamingWriter(t *testing.T) {
 		}
 	}
 }
+
+func TestCommandExecutor_Stdin(t *testing.T) {
+	result, err := NewCommandExecutor(10*time.Second).Execute("cat", nil, ExecOptions{
+		Stdin: strings.NewReader("from stdin\n"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Stdout != "from stdin\n" {
+		t.Errorf("expected stdin to be fed to the command, got %q", result.Stdout)
+	}
+}
@@ -0,0 +1,100 @@
+// Package executor provides container-based command execution.
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Backend executes commands; CommandExecutor and ContainerExecutor both implement it
+type Backend interface {
+	Execute(command string, args []string, options ExecOptions) (*ExecResult, error)
+}
+
+// Ensure both executors satisfy Backend
+var (
+	_ Backend = (*CommandExecutor)(nil)
+	_ Backend = (*ContainerExecutor)(nil)
+)
+
+// ContainerWorkDir is the mount point of the project inside the container
+const ContainerWorkDir = "/workspace"
+
+// ContainerExecutor runs commands inside a container (docker or podman)
+// with the working directory mounted into the container
+type ContainerExecutor struct {
+	executor *CommandExecutor
+	runtime  string
+	image    string
+	writable bool
+}
+
+// NewContainerExecutor creates a container backend on top of a command executor.
+// An empty runtime defaults to docker.
+func NewContainerExecutor(executor *CommandExecutor, runtime, image string, writable bool) *ContainerExecutor {
+	if runtime == "" {
+		runtime = "docker"
+	}
+	return &ContainerExecutor{
+		executor: executor,
+		runtime:  runtime,
+		image:    image,
+		writable: writable,
+	}
+}
+
+// Execute runs the command inside a new container and returns its result
+func (c *ContainerExecutor) Execute(command string, args []string, options ExecOptions) (*ExecResult, error) {
+	// Validate the inner command before wrapping it
+	if err := c.executor.securityValidator.ValidateCommand(command, args); err != nil {
+		return nil, fmt.Errorf("command validation failed: %w", err)
+	}
+
+	runtimeArgs, err := c.BuildArgs(command, args, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// The container receives the environment via -e flags; the runtime CLI
+	// itself only needs the host environment
+	hostOptions := options
+	hostOptions.Environment = nil
+	hostOptions.PathPrepend = nil
+
+	return c.executor.Execute(c.runtime, runtimeArgs, hostOptions)
+}
+
+// BuildArgs builds the container runtime arguments for running a command
+func (c *ContainerExecutor) BuildArgs(command string, args []string, options ExecOptions) ([]string, error) {
+	if c.image == "" {
+		return nil, fmt.Errorf("container image is required")
+	}
+
+	hostDir := options.WorkingDir
+	if hostDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+		hostDir = cwd
+	}
+	absDir, err := filepath.Abs(hostDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid working directory: %w", err)
+	}
+
+	mount := absDir + ":" + ContainerWorkDir
+	if !c.writable {
+		mount += ":ro"
+	}
+
+	runtimeArgs := []string{"run", "--rm", "-v", mount, "-w", ContainerWorkDir}
+	for _, env := range options.Environment {
+		runtimeArgs = append(runtimeArgs, "-e", env)
+	}
+	runtimeArgs = append(runtimeArgs, c.image, command)
+	runtimeArgs = append(runtimeArgs, args...)
+
+	return runtimeArgs, nil
+}
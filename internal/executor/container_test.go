@@ -0,0 +1,112 @@
+//go:build unit
+
+package executor
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestContainerExecutor_BuildArgs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		t.Fatalf("failed to resolve temp dir: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		runtime  string
+		writable bool
+		env      []string
+		want     []string
+	}{
+		{
+			name: "read-only mount by default",
+			want: []string{"run", "--rm", "-v", absDir + ":/workspace:ro", "-w", "/workspace", "alpine", "echo", "hello"},
+		},
+		{
+			name:     "writable mount",
+			writable: true,
+			want:     []string{"run", "--rm", "-v", absDir + ":/workspace", "-w", "/workspace", "alpine", "echo", "hello"},
+		},
+		{
+			name: "environment passed to container",
+			env:  []string{"FOO=bar"},
+			want: []string{"run", "--rm", "-v", absDir + ":/workspace:ro", "-w", "/workspace", "-e", "FOO=bar", "alpine", "echo", "hello"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := NewContainerExecutor(NewCommandExecutor(time.Second), tt.runtime, "alpine", tt.writable)
+			got, err := c.BuildArgs("echo", []string{"hello"}, ExecOptions{WorkingDir: dir, Environment: tt.env})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("BuildArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerExecutor_Defaults(t *testing.T) {
+	t.Parallel()
+	c := NewContainerExecutor(NewCommandExecutor(time.Second), "", "alpine", false)
+	if c.runtime != "docker" {
+		t.Errorf("expected default runtime docker, got %q", c.runtime)
+	}
+
+	noImage := NewContainerExecutor(NewCommandExecutor(time.Second), "podman", "", false)
+	if _, err := noImage.BuildArgs("echo", nil, ExecOptions{}); err == nil {
+		t.Error("expected error when image is missing")
+	}
+}
+
+func TestContainerExecutor_RejectsInjection(t *testing.T) {
+	t.Parallel()
+	c := NewContainerExecutor(NewCommandExecutor(time.Second), "docker", "alpine", false)
+	if _, err := c.Execute("echo", []string{"hi; rm -rf /"}, ExecOptions{}); err == nil {
+		t.Error("expected inner command validation to fail")
+	}
+}
+
+func TestContainerExecutor_Docker(t *testing.T) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker not available")
+	}
+	if err := exec.Command("docker", "info").Run(); err != nil {
+		t.Skip("docker daemon not reachable")
+	}
+
+	dir := t.TempDir()
+	c := NewContainerExecutor(NewCommandExecutor(2*time.Minute), "docker", "alpine", false)
+	result, err := c.Execute("sh", []string{"-c", "echo ok; echo 'main.go:1: error: boom'"}, ExecOptions{WorkingDir: dir, InheritEnv: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ExitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr: %s)", result.ExitCode, result.Stderr)
+	}
+
+	outputFilter := filter.NewSimpleOutputFilter()
+	filtered := outputFilter.FilterWithRules(result.Stdout, &filter.FilterRules{
+		ErrorPatterns: []*config.RegexPattern{{Pattern: "error:"}},
+		MaxLines:      10,
+	})
+	if !filtered.HasErrors {
+		t.Fatal("expected filtered output to contain errors")
+	}
+	if len(filtered.Lines) != 1 || filtered.Lines[0] != "main.go:1: error: boom" {
+		t.Errorf("unexpected filtered lines: %v", filtered.Lines)
+	}
+}
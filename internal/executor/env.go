@@ -0,0 +1,54 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/security"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// envReference matches ${VAR} references in configured environment values
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// CommandEnvironment returns the configured environment of a command in
+// KEY=VALUE form. With inherit, ${VAR} references expand to the parent
+// environment as passed to commands, so filtered variables such as tokens
+// expand to nothing; without it they always do.
+func CommandEnvironment(cmdConfig *config.CommandConfig, inherit bool) ([]string, error) {
+	if len(cmdConfig.Env) == 0 {
+		return nil, nil
+	}
+
+	parent := make(map[string]string)
+	if inherit {
+		for _, entry := range security.SanitizeEnvironment(os.Environ(), true) {
+			if key, value, ok := strings.Cut(entry, "="); ok {
+				parent[key] = value
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(cmdConfig.Env))
+	for key := range cmdConfig.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := envReference.ReplaceAllStringFunc(cmdConfig.Env[key], func(ref string) string {
+			return parent[envReference.FindStringSubmatch(ref)[1]]
+		})
+		env = append(env, key+"="+value)
+	}
+
+	// Reject values the executor would refuse to pass on
+	if _, err := security.MergeEnvironment(nil, env); err != nil {
+		return nil, fmt.Errorf("invalid env: %w", err)
+	}
+	return env, nil
+}
@@ -0,0 +1,62 @@
+//go:build unit
+
+package executor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestCommandEnvironment(t *testing.T) {
+	t.Setenv("QUALHOOK_TEST_HOME", "/home/dev")
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	cmdConfig := &config.CommandConfig{
+		Command: "jest",
+		Env: map[string]string{
+			"NODE_ENV": "test",
+			"CACHE":    "${QUALHOOK_TEST_HOME}/.cache",
+			"TOKEN":    "${GITHUB_TOKEN}",
+			"PRICE":    "$5",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		inherit bool
+		want    []string
+	}{
+		{
+			name:    "inherited",
+			inherit: true,
+			want:    []string{"CACHE=/home/dev/.cache", "NODE_ENV=test", "PRICE=$5", "TOKEN="},
+		},
+		{
+			name:    "not inherited",
+			inherit: false,
+			want:    []string{"CACHE=/.cache", "NODE_ENV=test", "PRICE=$5", "TOKEN="},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := CommandEnvironment(cmdConfig, tt.inherit)
+			if err != nil {
+				t.Fatalf("CommandEnvironment() error = %v", err)
+			}
+			if !reflect.DeepEqual(env, tt.want) {
+				t.Errorf("CommandEnvironment() = %v, want %v", env, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandEnvironment_InvalidValue(t *testing.T) {
+	cmdConfig := &config.CommandConfig{Command: "jest", Env: map[string]string{"FLAGS": "a && b"}}
+	if _, err := CommandEnvironment(cmdConfig, true); err == nil || !strings.Contains(err.Error(), "invalid env") {
+		t.Errorf("expected the dangerous value to be rejected, got %v", err)
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"syscall"
+
+	"github.com/bebsworthy/qualhook/internal/security"
 )
 
 // Error types for command execution
@@ -40,6 +43,9 @@ const (
 	ErrorTypeWorkingDirectory
 	// ErrorTypeExecution indicates general execution error
 	ErrorTypeExecution
+	// ErrorTypeCommandNotAllowed indicates the security configuration
+	// rejected the command
+	ErrorTypeCommandNotAllowed
 )
 
 // ExecError represents a detailed execution error
@@ -69,6 +75,8 @@ func (e *ExecError) Error() string {
 		return fmt.Sprintf("working directory error: %s", e.Details)
 	case ErrorTypeExecution:
 		return fmt.Sprintf("execution error for %s: %v", cmd, e.Err)
+	case ErrorTypeCommandNotAllowed:
+		return fmt.Sprintf("command not allowed: %s", e.Details)
 	default:
 		return fmt.Sprintf("unknown error for %s: %v", cmd, e.Err)
 	}
@@ -113,6 +121,13 @@ func ClassifyError(err error, command string, args []string) *ExecError {
 		return execErr
 	}
 
+	// Check for commands rejected by the allowed or denied command lists
+	if errors.Is(err, security.ErrCommandNotAllowed) {
+		execErr.Type = ErrorTypeCommandNotAllowed
+		execErr.Details = err.Error()
+		return execErr
+	}
+
 	// Check for exec.Error which indicates command not found or permission issues
 	if errType := classifyExecError(err); errType != ErrorTypeUnknown {
 		execErr.Type = errType
@@ -136,6 +151,38 @@ func ClassifyError(err error, command string, args []string) *ExecError {
 	return execErr
 }
 
+// transientErrnos are system errors that usually clear up when retried
+var transientErrnos = []error{
+	syscall.EAGAIN,
+	syscall.EBUSY,
+	syscall.ETXTBSY,
+	syscall.EINTR,
+	syscall.EMFILE,
+	syscall.ENFILE,
+}
+
+// IsInfrastructureError reports whether err is a transient environment
+// failure worth retrying the run for: a working directory that could not be
+// used, or a system error such as EAGAIN, EBUSY, ETXTBSY, EINTR, EMFILE or
+// ENFILE. Missing commands, permission problems, timeouts and validation
+// errors are not, as retrying does not change their outcome.
+func IsInfrastructureError(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, errno := range transientErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+
+	var execErr *ExecError
+	if !errors.As(err, &execErr) {
+		execErr = ClassifyError(err, "", nil)
+	}
+	return execErr.Type == ErrorTypeWorkingDirectory
+}
+
 // HandleTimeoutCleanup performs cleanup after a timeout occurs
 func HandleTimeoutCleanup(cmd *exec.Cmd) error {
 	if cmd == nil || cmd.Process == nil {
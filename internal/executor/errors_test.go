@@ -5,9 +5,14 @@ package executor
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
 	"os/exec"
 	"strings"
+	"syscall"
 	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/security"
 )
 
 func TestExecError_Error(t *testing.T) {
@@ -219,6 +224,11 @@ func TestClassifyError(t *testing.T) {
 			err:          errors.New("chdir failed"),
 			expectedType: ErrorTypeWorkingDirectory,
 		},
+		{
+			name:         "command not allowed",
+			err:          fmt.Errorf("command validation failed: %w", security.ErrCommandNotAllowed),
+			expectedType: ErrorTypeCommandNotAllowed,
+		},
 		{
 			name:         "unknown error",
 			err:          errors.New("something else"),
@@ -335,3 +345,30 @@ func TestErrorMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestIsInfrastructureError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"busy text file", &ExecError{Type: ErrorTypeExecution, Err: syscall.ETXTBSY}, true},
+		{"resource temporarily unavailable", fmt.Errorf("start: %w", syscall.EAGAIN), true},
+		{"too many open files", &os.PathError{Op: "open", Path: "/tmp/x", Err: syscall.EMFILE}, true},
+		{"working directory", &ExecError{Type: ErrorTypeWorkingDirectory, Details: "chdir failed"}, true},
+		{"unclassified working directory message", errors.New("invalid working directory: /tmp/x does not exist"), true},
+		{"command not found", &ExecError{Type: ErrorTypeCommandNotFound}, false},
+		{"permission denied", &ExecError{Type: ErrorTypePermissionDenied}, false},
+		{"timeout", &ExecError{Type: ErrorTypeTimeout}, false},
+		{"validation", errors.New("command validation failed: potential shell injection"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsInfrastructureError(tt.err); got != tt.want {
+				t.Errorf("IsInfrastructureError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bebsworthy/qualhook/internal/ansi"
+	"github.com/bebsworthy/qualhook/internal/debug"
 	"github.com/bebsworthy/qualhook/internal/filter"
 	"github.com/bebsworthy/qualhook/internal/hook"
 	"github.com/bebsworthy/qualhook/internal/watcher"
@@ -29,31 +31,54 @@ type ComponentExecResult struct {
 	CommandConfig *config.CommandConfig
 	// Any execution error (distinct from command errors)
 	ExecutionError error
+	// StartedAt is when the component run started (zero when not recorded)
+	StartedAt time.Time
+	// Duration is how long the run took, including output filtering
+	Duration time.Duration
+	// SkippedBy names the failed prerequisite that kept the command from running
+	SkippedBy string
 }
 
 // FileAwareExecutor executes commands based on edited files
 type FileAwareExecutor struct {
 	commandExecutor  *CommandExecutor
+	securityErr      error // Set when the security settings could not be applied
 	parallelExecutor *ParallelExecutor
 	mapper           *watcher.FileMapper
 	hookParser       *hook.Parser
+	outputFilter     filter.Filter
 	debugMode        bool
+	color            bool
 }
 
 // NewFileAwareExecutor creates a new file-aware executor
 func NewFileAwareExecutor(cfg *config.Config, debugMode bool) *FileAwareExecutor {
 	defaultTimeout := 2 * time.Minute
 	commandExecutor := NewCommandExecutor(defaultTimeout)
+	// Components are mapped relative to the working directory, the project root
+	securityErr := commandExecutor.ApplySecurity(".", cfg.Security)
 
 	return &FileAwareExecutor{
 		commandExecutor:  commandExecutor,
-		parallelExecutor: NewParallelExecutor(commandExecutor, 4), // Default max concurrent
+		securityErr:      securityErr,
+		parallelExecutor: NewParallelExecutor(commandExecutor, cfg.MaxParallel),
 		mapper:           watcher.NewFileMapper(cfg),
 		hookParser:       hook.NewParser(),
+		outputFilter:     filter.NewRegexFilter(),
 		debugMode:        debugMode,
 	}
 }
 
+// SetColor enables ANSI colors in debug output
+func (e *FileAwareExecutor) SetColor(color bool) {
+	e.color = color
+}
+
+// SetFilter replaces the regex filter applied to command output
+func (e *FileAwareExecutor) SetFilter(f filter.Filter) {
+	e.outputFilter = f
+}
+
 // ExecuteForEditedFiles executes the appropriate commands based on edited files
 func (e *FileAwareExecutor) ExecuteForEditedFiles(hookInput *hook.HookInput, commandName string, extraArgs []string) ([]ComponentExecResult, error) {
 	// Extract edited files from hook input
@@ -72,7 +97,7 @@ func (e *FileAwareExecutor) ExecuteForEditedFiles(hookInput *hook.HookInput, com
 	}
 
 	// Map files to components
-	componentGroups, err := e.mapper.MapFilesToComponents(editedFiles)
+	componentGroups, err := e.mapper.MapFilesForCommand(editedFiles, commandName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map files to components: %w", err)
 	}
@@ -106,6 +131,10 @@ func (e *FileAwareExecutor) executeForComponent(componentPath string, files []st
 	}
 
 	result.CommandConfig = cmdConfig
+	if e.securityErr != nil {
+		result.ExecutionError = fmt.Errorf("failed to apply security settings: %w", e.securityErr)
+		return result, result.ExecutionError
+	}
 
 	// Build the command arguments
 	args := make([]string, 0, len(cmdConfig.Args)+len(extraArgs))
@@ -120,33 +149,52 @@ func (e *FileAwareExecutor) executeForComponent(componentPath string, files []st
 		// For example: npm run lint --prefix frontend
 		workingDir = ""
 	}
+	if cmdConfig.WorkingDir != "" {
+		workingDir = cmdConfig.WorkingDir
+	}
+
+	env, err := CommandEnvironment(cmdConfig, true)
+	if err != nil {
+		result.ExecutionError = err
+		return result, result.ExecutionError
+	}
 
 	// Execute the command
 	execOptions := ExecOptions{
-		WorkingDir: workingDir,
-		InheritEnv: true,
-		Timeout:    time.Duration(cmdConfig.Timeout) * time.Millisecond,
+		WorkingDir:  workingDir,
+		Environment: env,
+		InheritEnv:  true,
+		Timeout:     time.Duration(cmdConfig.Timeout) * time.Millisecond,
 	}
 
 	if execOptions.Timeout == 0 {
 		execOptions.Timeout = 2 * time.Minute
 	}
 
-	execResult, err := e.commandExecutor.Execute(cmdConfig.Command, args, execOptions)
+	var backend Backend = e.commandExecutor
+	if sandbox := cmdConfig.Sandbox; sandbox != nil {
+		backend = NewContainerExecutor(e.commandExecutor, sandbox.Runtime, sandbox.Image, sandbox.Writable)
+	}
+
+	execResult, err := backend.Execute(cmdConfig.Command, args, execOptions)
 	if err != nil {
 		result.ExecutionError = fmt.Errorf("failed to execute command: %w", err)
 		return result, result.ExecutionError
 	}
+	execResult.Stdout = filter.SanitizeBinary(execResult.Stdout, cmdConfig.BinaryOutput)
+	execResult.Stderr = filter.SanitizeBinary(execResult.Stderr, cmdConfig.BinaryOutput)
 	result.ExecResult = execResult
 
 	// Filter the output if patterns are configured
-	if len(cmdConfig.ErrorPatterns) > 0 || len(cmdConfig.IncludePatterns) > 0 {
-		outputFilter := filter.NewSimpleOutputFilter()
+	if outputFilter := filter.ForCommand(cmdConfig, e.outputFilter); outputFilter != nil {
 		filterRules := &filter.FilterRules{
-			ErrorPatterns:   cmdConfig.ErrorPatterns,
+			ErrorPatterns:   cmdConfig.DetectionPatterns(),
 			ContextPatterns: cmdConfig.IncludePatterns,
 			MaxLines:        cmdConfig.MaxOutput,
+			MaxBytes:        cmdConfig.MaxOutputBytes,
 			ContextLines:    cmdConfig.ContextLines,
+			BlockMode:       cmdConfig.BlockMode,
+			CaptureGroups:   cmdConfig.OutputTemplate != "",
 		}
 		// Combine stdout and stderr for filtering
 		combinedOutput := execResult.Stdout
@@ -156,7 +204,13 @@ func (e *FileAwareExecutor) executeForComponent(componentPath string, files []st
 			}
 			combinedOutput += execResult.Stderr
 		}
-		result.FilteredOutput = outputFilter.FilterWithRules(combinedOutput, filterRules)
+		filteredOutput, err := outputFilter.Filter(combinedOutput, filterRules)
+		filter.LogPatternStats(nil, cmdConfig.Command, combinedOutput, filterRules)
+		if err != nil {
+			// Report the raw output rather than nothing
+			debug.LogError(err, "filtering output")
+		}
+		result.FilteredOutput = filteredOutput
 	}
 
 	return result, nil
@@ -203,12 +257,13 @@ func (e *FileAwareExecutor) ExecuteForAllComponents(commandName string, extraArg
 	return results, nil
 }
 
-// getStatusText returns a status text for debug output
-func getStatusText(output *filter.FilteredOutput) string {
+// getStatusText returns a status text for debug output, green or red
+// with color
+func getStatusText(output *filter.FilteredOutput, color bool) string {
 	if output == nil || !output.HasErrors {
-		return "✓ passed"
+		return ansi.Paint(color, "✓ passed", ansi.Green)
 	}
-	return "✗ failed"
+	return ansi.Paint(color, "✗ failed", ansi.Red)
 }
 
 // executeForRootComponent executes command on root when no files were edited
@@ -291,7 +346,7 @@ func (e *FileAwareExecutor) debugLogExecutionSummary(results []ComponentExecResu
 		fmt.Printf("  - Component %s: %s command %s (files: %v)\n",
 			result.Path,
 			commandName,
-			getStatusText(result.FilteredOutput),
+			getStatusText(result.FilteredOutput, e.color),
 			result.Files)
 	}
 }
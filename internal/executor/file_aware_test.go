@@ -184,11 +184,15 @@ func TestFileAwareExecutor_getStatusText(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := getStatusText(tt.output); got != tt.want {
+			if got := getStatusText(tt.output, false); got != tt.want {
 				t.Errorf("getStatusText() = %v, want %v", got, tt.want)
 			}
 		})
 	}
+
+	if got := getStatusText(&filter.FilteredOutput{HasErrors: true}, true); got != "\x1b[31m✗ failed\x1b[0m" {
+		t.Errorf("getStatusText() with color = %q, want red text", got)
+	}
 }
 
 func TestNewFileAwareExecutor(t *testing.T) {
@@ -990,3 +994,74 @@ func TestFileAwareExecutor_ComplexGlobPatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestFileAwareExecutor_CommandEnvironment(t *testing.T) {
+	cfg := &config.Config{
+		Version: "1.0",
+		Commands: map[string]*config.CommandConfig{
+			"lint": {
+				Command: "sh",
+				Args:    []string{"-c", "echo mode=$QUALHOOK_MODE"},
+				Env:     map[string]string{"QUALHOOK_MODE": "ci"},
+			},
+		},
+	}
+
+	executor := NewFileAwareExecutor(cfg, false)
+	results, err := executor.executeForRootComponent("lint", nil)
+	if err != nil {
+		t.Fatalf("executeForRootComponent() error = %v", err)
+	}
+	if len(results) != 1 || results[0].ExecResult == nil || !strings.Contains(results[0].ExecResult.Stdout, "mode=ci") {
+		t.Errorf("expected the configured env to be passed, got %+v", results)
+	}
+}
+
+// upperFilter reports every line of output as an error, upper-cased
+type upperFilter struct{}
+
+func (upperFilter) Filter(output string, _ *filter.FilterRules) (*filter.FilteredOutput, error) {
+	return &filter.FilteredOutput{Lines: []string{strings.ToUpper(strings.TrimSpace(output))}, HasErrors: true}, nil
+}
+
+func TestFileAwareExecutor_SetFilter(t *testing.T) {
+	cfg := &config.Config{
+		Version: "1.0",
+		Commands: map[string]*config.CommandConfig{
+			"lint": {
+				Command:       "echo",
+				Args:          []string{"custom output"},
+				ErrorPatterns: []*config.RegexPattern{{Pattern: "never-matches"}},
+			},
+		},
+	}
+
+	executor := NewFileAwareExecutor(cfg, false)
+	executor.SetFilter(upperFilter{})
+	results, err := executor.executeForRootComponent("lint", nil)
+	if err != nil {
+		t.Fatalf("executeForRootComponent() error = %v", err)
+	}
+	if len(results) != 1 || results[0].FilteredOutput == nil {
+		t.Fatalf("expected a filtered result, got %+v", results)
+	}
+	if got := results[0].FilteredOutput.Lines; len(got) != 1 || got[0] != "CUSTOM OUTPUT" {
+		t.Errorf("expected the injected filter to be used, got %v", got)
+	}
+}
+
+func TestFileAwareExecutor_AllowedWorkingDirs(t *testing.T) {
+	cfg := &config.Config{
+		Version: "1.0",
+		Commands: map[string]*config.CommandConfig{
+			"lint": {Command: "echo", Args: []string{"escaped"}, WorkingDir: t.TempDir()},
+		},
+	}
+
+	// The temporary directory is outside the working directory, the project root
+	executor := NewFileAwareExecutor(cfg, false)
+	results, err := executor.executeForRootComponent("lint", nil)
+	if err == nil && (len(results) != 1 || results[0].ExecutionError == nil) {
+		t.Fatalf("expected the working directory to be rejected, got %+v", results)
+	}
+}
@@ -4,6 +4,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -21,8 +22,13 @@ type ParallelResult struct {
 	HasFailures bool
 	// Count of successful executions
 	SuccessCount int
-	// Count of failed executions
+	// Count of failed executions, including canceled ones
 	FailureCount int
+	// Count of commands not run or stopped because the run was canceled
+	CanceledCount int
+	// Count of commands that timed out, including those not run or stopped
+	// once the total timeout elapsed
+	TimedOutCount int
 }
 
 // ParallelCommand represents a command to be executed in parallel
@@ -44,23 +50,52 @@ type ProgressCallback func(completed int, total int, currentID string)
 type ParallelExecutor struct {
 	executor    *CommandExecutor
 	maxParallel int
+	// totalTimeout bounds the wall-clock time of a whole run; zero means none
+	totalTimeout time.Duration
 }
 
-// NewParallelExecutor creates a new parallel executor
+// NewParallelExecutor creates a new parallel executor running up to
+// maxParallel commands at once (see Workers)
 func NewParallelExecutor(executor *CommandExecutor, maxParallel int) *ParallelExecutor {
-	if maxParallel <= 0 {
-		maxParallel = 4 // Default parallelism
-	}
 	return &ParallelExecutor{
 		executor:    executor,
-		maxParallel: maxParallel,
+		maxParallel: Workers(maxParallel),
 	}
 }
 
+// SetTotalTimeout bounds the wall-clock time of each run. Once it elapses,
+// commands still running are stopped and queued ones are not started; both
+// are marked TimedOut. Zero disables the limit.
+func (pe *ParallelExecutor) SetTotalTimeout(timeout time.Duration) {
+	pe.totalTimeout = timeout
+}
+
+// Workers returns the number of workers for a requested count, falling back
+// to the number of CPUs when it is zero or negative
+func Workers(requested int) int {
+	if requested <= 0 {
+		return runtime.NumCPU()
+	}
+	return requested
+}
+
 // Execute runs multiple commands in parallel
+func (pe *ParallelExecutor) Execute(ctx context.Context, commands []ParallelCommand, progress ProgressCallback) (*ParallelResult, error) {
+	return pe.execute(ctx, commands, progress, false)
+}
+
+// ExecuteFailFast runs multiple commands in parallel and stops at the first
+// failure: queued commands are not started and in-flight ones are stopped.
+// Those commands are marked Canceled in the result.
+func (pe *ParallelExecutor) ExecuteFailFast(ctx context.Context, commands []ParallelCommand, progress ProgressCallback) (*ParallelResult, error) {
+	return pe.execute(ctx, commands, progress, true)
+}
+
+// execute runs commands in parallel, canceling the rest after the first
+// failure when failFast is set
 //
 //nolint:gocyclo // Complex function handling multiple edge cases for parallel execution
-func (pe *ParallelExecutor) Execute(ctx context.Context, commands []ParallelCommand, progress ProgressCallback) (*ParallelResult, error) {
+func (pe *ParallelExecutor) execute(ctx context.Context, commands []ParallelCommand, progress ProgressCallback, failFast bool) (*ParallelResult, error) {
 	if len(commands) == 0 {
 		return &ParallelResult{
 			Results: make(map[string]*ExecResult),
@@ -70,6 +105,19 @@ func (pe *ParallelExecutor) Execute(ctx context.Context, commands []ParallelComm
 
 	startTime := time.Now()
 
+	// Bound the whole run, keeping the deadline context apart so commands it
+	// stopped can be told from those canceled by the caller or by fail-fast
+	deadline := ctx
+	if pe.totalTimeout > 0 {
+		var cancelDeadline context.CancelFunc
+		deadline, cancelDeadline = context.WithTimeout(ctx, pe.totalTimeout)
+		defer cancelDeadline()
+	}
+
+	// Share one cancelable context so a fail-fast run can stop the others
+	ctx, cancel := context.WithCancel(deadline)
+	defer cancel()
+
 	// Initialize result
 	result := &ParallelResult{
 		Results: make(map[string]*ExecResult),
@@ -109,17 +157,22 @@ func (pe *ParallelExecutor) Execute(ctx context.Context, commands []ParallelComm
 			select {
 			case <-ctx.Done():
 				resultMutex.Lock()
-				result.Results[pc.ID] = &ExecResult{
+				result.Results[pc.ID] = pe.markTotalTimeout(deadline, &ExecResult{
 					ExitCode: -1,
+					Canceled: true,
 					Error:    ctx.Err(),
-				}
+				})
 				resultMutex.Unlock()
 				return
 			default:
 			}
 
 			// Execute command
-			execResult, err := pe.executor.Execute(pc.Command, pc.Args, pc.Options)
+			options := pc.Options
+			if options.Context == nil {
+				options.Context = ctx
+			}
+			execResult, err := pe.executor.Execute(pc.Command, pc.Args, options)
 
 			if err != nil {
 				// The Execute method may return both a result and an error
@@ -162,11 +215,17 @@ func (pe *ParallelExecutor) Execute(ctx context.Context, commands []ParallelComm
 				}
 			}
 
+			execResult = pe.markTotalTimeout(deadline, execResult)
+
 			// Store result
 			resultMutex.Lock()
 			result.Results[pc.ID] = execResult
 			resultMutex.Unlock()
 
+			if failFast && !execResult.Canceled && failed(execResult) {
+				cancel()
+			}
+
 			// Update progress
 			if progress != nil {
 				progressMutex.Lock()
@@ -185,7 +244,13 @@ func (pe *ParallelExecutor) Execute(ctx context.Context, commands []ParallelComm
 	// Calculate statistics
 	result.TotalTime = time.Since(startTime)
 	for _, execResult := range result.Results {
-		if execResult.Error != nil || execResult.ExitCode != 0 || execResult.TimedOut {
+		if execResult.Canceled {
+			result.CanceledCount++
+		}
+		if execResult.TimedOut {
+			result.TimedOutCount++
+		}
+		if failed(execResult) {
 			result.FailureCount++
 			result.HasFailures = true
 		} else {
@@ -196,6 +261,23 @@ func (pe *ParallelExecutor) Execute(ctx context.Context, commands []ParallelComm
 	return result, nil
 }
 
+// markTotalTimeout turns a command canceled because the total timeout
+// elapsed into a timed out one
+func (pe *ParallelExecutor) markTotalTimeout(deadline context.Context, execResult *ExecResult) *ExecResult {
+	if pe.totalTimeout <= 0 || !execResult.Canceled || deadline.Err() != context.DeadlineExceeded {
+		return execResult
+	}
+	execResult.Canceled = false
+	execResult.TimedOut = true
+	execResult.Error = fmt.Errorf("run exceeded the total timeout of %v: %w", pe.totalTimeout, context.DeadlineExceeded)
+	return execResult
+}
+
+// failed reports whether a command did not pass
+func failed(execResult *ExecResult) bool {
+	return execResult.Error != nil || execResult.ExitCode != 0 || execResult.TimedOut || execResult.Canceled
+}
+
 // ExecuteWithAggregation runs commands and aggregates output
 func (pe *ParallelExecutor) ExecuteWithAggregation(ctx context.Context, commands []ParallelCommand, progress ProgressCallback) (*AggregatedResult, error) {
 	// Execute commands in parallel
@@ -232,7 +314,7 @@ func (pe *ParallelExecutor) ExecuteWithAggregation(ctx context.Context, commands
 		}
 
 		// Track failures
-		if execResult.Error != nil || execResult.ExitCode != 0 || execResult.TimedOut {
+		if failed(execResult) {
 			aggregated.FailedCommands = append(aggregated.FailedCommands, id)
 		}
 	}
@@ -261,6 +343,8 @@ func (ar *AggregatedResult) GetFailureSummary() string {
 	for _, id := range ar.FailedCommands {
 		if result, ok := ar.Results[id]; ok {
 			switch {
+			case result.Canceled:
+				summary += fmt.Sprintf("  - %s: canceled\n", id)
 			case result.TimedOut:
 				summary += fmt.Sprintf("  - %s: timed out\n", id)
 			case result.Error != nil:
@@ -5,6 +5,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -27,14 +28,14 @@ func TestNewParallelExecutor(t *testing.T) {
 			expectedParallel: 8,
 		},
 		{
-			name:             "zero value defaults to 4",
+			name:             "zero value defaults to the CPU count",
 			maxParallel:      0,
-			expectedParallel: 4,
+			expectedParallel: runtime.NumCPU(),
 		},
 		{
-			name:             "negative value defaults to 4",
+			name:             "negative value defaults to the CPU count",
 			maxParallel:      -1,
-			expectedParallel: 4,
+			expectedParallel: runtime.NumCPU(),
 		},
 	}
 
@@ -284,6 +285,116 @@ func TestParallelExecute_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestParallelExecute_FailFast(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow test in short mode")
+	}
+	t.Parallel()
+	cmdExecutor := NewCommandExecutor(10 * time.Second)
+	pe := NewParallelExecutor(cmdExecutor, 2)
+
+	fCmd, fArgs := pc.exit(1)
+	commands := []ParallelCommand{{ID: "fail", Command: fCmd, Args: fArgs}}
+	for i := 0; i < 3; i++ {
+		cmd, args := pc.sleep(2)
+		commands = append(commands, ParallelCommand{ID: fmt.Sprintf("slow-%d", i), Command: cmd, Args: args})
+	}
+
+	start := time.Now()
+	result, err := pe.ExecuteFailFast(context.Background(), commands, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 2*time.Second {
+		t.Errorf("expected remaining commands to be stopped, took %v", elapsed)
+	}
+
+	if failResult := result.Results["fail"]; failResult == nil || failResult.Canceled || failResult.ExitCode != 1 {
+		t.Errorf("expected the failing command to keep its result, got %+v", failResult)
+	}
+	for i := 0; i < 3; i++ {
+		id := fmt.Sprintf("slow-%d", i)
+		if r := result.Results[id]; r == nil || !r.Canceled || r.Error == nil {
+			t.Errorf("expected %s to be marked canceled, got %+v", id, r)
+		}
+	}
+	if result.CanceledCount != 3 || result.SuccessCount != 0 || !result.HasFailures {
+		t.Errorf("unexpected counts: canceled=%d success=%d failures=%d", result.CanceledCount, result.SuccessCount, result.FailureCount)
+	}
+}
+
+func TestParallelExecute_TotalTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow test in short mode")
+	}
+	t.Parallel()
+	cmdExecutor := NewCommandExecutor(10 * time.Second)
+	pe := NewParallelExecutor(cmdExecutor, 3)
+	pe.SetTotalTimeout(500 * time.Millisecond)
+
+	qCmd, qArgs := pc.echo("quick")
+	commands := []ParallelCommand{{ID: "quick", Command: qCmd, Args: qArgs}}
+	for i := 0; i < 2; i++ {
+		cmd, args := pc.sleep(2)
+		commands = append(commands, ParallelCommand{ID: fmt.Sprintf("slow-%d", i), Command: cmd, Args: args})
+	}
+
+	start := time.Now()
+	result, err := pe.Execute(context.Background(), commands, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 2*time.Second {
+		t.Errorf("expected the run to stop at the total timeout, took %v", elapsed)
+	}
+
+	if r := result.Results["quick"]; r == nil || r.TimedOut || r.ExitCode != 0 {
+		t.Errorf("expected the quick command to pass, got %+v", r)
+	}
+	for i := 0; i < 2; i++ {
+		id := fmt.Sprintf("slow-%d", i)
+		if r := result.Results[id]; r == nil || !r.TimedOut || r.Canceled || !strings.Contains(fmt.Sprint(r.Error), "total timeout") {
+			t.Errorf("expected %s to be marked timed out, got %+v", id, r)
+		}
+	}
+	if result.TimedOutCount != 2 || result.CanceledCount != 0 || result.SuccessCount != 1 {
+		t.Errorf("unexpected counts: timedOut=%d canceled=%d success=%d", result.TimedOutCount, result.CanceledCount, result.SuccessCount)
+	}
+
+	// Queued commands are not started once the total timeout elapsed
+	queued := NewParallelExecutor(cmdExecutor, 1)
+	queued.SetTotalTimeout(200 * time.Millisecond)
+	result, err = queued.Execute(context.Background(), commands[1:], nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TimedOutCount != 2 || !result.HasFailures {
+		t.Errorf("expected the running and the queued command to time out, got timedOut=%d", result.TimedOutCount)
+	}
+}
+
+func TestParallelExecute_FailuresDoNotCancel(t *testing.T) {
+	t.Parallel()
+	cmdExecutor := NewCommandExecutor(10 * time.Second)
+	pe := NewParallelExecutor(cmdExecutor, 1)
+
+	fCmd, fArgs := pc.exit(1)
+	sCmd, sArgs := pc.echo("after failure")
+	commands := []ParallelCommand{
+		{ID: "fail", Command: fCmd, Args: fArgs},
+		{ID: "success", Command: sCmd, Args: sArgs},
+	}
+
+	result, err := pe.Execute(context.Background(), commands, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.CanceledCount != 0 || result.SuccessCount != 1 || result.FailureCount != 1 {
+		t.Errorf("expected every command to run, got canceled=%d success=%d failures=%d",
+			result.CanceledCount, result.SuccessCount, result.FailureCount)
+	}
+}
+
 func TestParallelExecute_Empty(t *testing.T) {
 	t.Parallel()
 	cmdExecutor := NewCommandExecutor(10 * time.Second)
@@ -0,0 +1,60 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/security"
+)
+
+// resolvePathDirs makes prepended PATH directories absolute, resolving
+// relative ones against the working directory of the command
+func resolvePathDirs(dirs []string, workingDir string) ([]string, error) {
+	if workingDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+		workingDir = cwd
+	}
+
+	resolved := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(workingDir, dir)
+		}
+		resolved = append(resolved, filepath.Clean(dir))
+	}
+	return resolved, nil
+}
+
+// prependPath puts dirs in front of the PATH of env. The new PATH is
+// validated like any other custom environment variable.
+func prependPath(env []string, dirs []string) ([]string, error) {
+	pathValue := strings.Join(dirs, string(os.PathListSeparator))
+	for _, entry := range env {
+		if current, ok := strings.CutPrefix(entry, "PATH="); ok && current != "" {
+			pathValue += string(os.PathListSeparator) + current
+		}
+	}
+	return security.MergeEnvironment(env, []string{"PATH=" + pathValue})
+}
+
+// lookPathIn finds a bare command name in dirs, returning the first
+// executable match. Commands given as paths are left to the default lookup.
+func lookPathIn(command string, dirs []string) (string, bool) {
+	if strings.ContainsRune(command, os.PathSeparator) || strings.Contains(command, "/") {
+		return "", false
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, command)
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() || info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		return path, true
+	}
+	return "", false
+}
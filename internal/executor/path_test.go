@@ -0,0 +1,93 @@
+//go:build unit
+
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecute_PathPrepend(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the local tool")
+	}
+
+	dir := t.TempDir()
+	binDir := filepath.Join(dir, "node_modules", ".bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\necho \"local tool\"\necho \"PATH=$PATH\"\n"
+	if err := os.WriteFile(filepath.Join(binDir, "qualhook-local-tool"), []byte(script), 0755); err != nil { //nolint:gosec // Test tool must be executable
+		t.Fatal(err)
+	}
+
+	executor := NewCommandExecutor(10 * time.Second)
+	result, err := executor.Execute("qualhook-local-tool", nil, ExecOptions{
+		WorkingDir:  dir,
+		InheritEnv:  true,
+		PathPrepend: []string{"node_modules/.bin"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.Error != nil || result.ExitCode != 0 {
+		t.Fatalf("expected the local tool to run, got exit code %d: %v", result.ExitCode, result.Error)
+	}
+	if !strings.Contains(result.Stdout, "local tool") {
+		t.Errorf("expected the local tool output, got %q", result.Stdout)
+	}
+
+	wantPath := binDir + string(os.PathListSeparator) + os.Getenv("PATH")
+	if !strings.Contains(result.Stdout, "PATH="+wantPath+"\n") {
+		t.Errorf("expected PATH %q, got %q", wantPath, result.Stdout)
+	}
+
+	// Other commands still resolve through the rest of PATH
+	result, err = executor.Execute("echo", []string{"still found"}, ExecOptions{
+		WorkingDir:  dir,
+		InheritEnv:  true,
+		PathPrepend: []string{"node_modules/.bin"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if strings.TrimSpace(result.Stdout) != "still found" {
+		t.Errorf("expected echo to be found on PATH, got %q (%v)", result.Stdout, result.Error)
+	}
+}
+
+func TestExecute_PathPrependValidated(t *testing.T) {
+	executor := NewCommandExecutor(10 * time.Second)
+	_, err := executor.Execute("echo", nil, ExecOptions{
+		WorkingDir:  t.TempDir(),
+		PathPrepend: []string{"bin;rm -rf"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "invalid path prepend") {
+		t.Errorf("expected the prepended PATH to be rejected, got %v", err)
+	}
+}
+
+func TestLookPathIn(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "tool"), []byte("#!/bin/sh\n"), 0755); err != nil { //nolint:gosec // Test tool must be executable
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data"), []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if path, ok := lookPathIn("tool", []string{t.TempDir(), dir}); !ok || path != filepath.Join(dir, "tool") {
+		t.Errorf("lookPathIn(tool) = %q, %v", path, ok)
+	}
+	if _, ok := lookPathIn("data", []string{dir}); ok && runtime.GOOS != "windows" {
+		t.Error("expected a non-executable file to be skipped")
+	}
+	if _, ok := lookPathIn("./tool", []string{dir}); ok {
+		t.Error("expected commands given as paths to be left to the default lookup")
+	}
+}
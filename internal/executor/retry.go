@@ -0,0 +1,97 @@
+package executor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+)
+
+// Ensure the retrying executor satisfies Backend
+var _ Backend = (*RetryExecutor)(nil)
+
+// RetryExecutor re-runs commands that exit with an error code, doubling the
+// delay between attempts. Only the result of the final attempt is returned.
+type RetryExecutor struct {
+	backend    Backend
+	maxRetries int
+	delay      time.Duration
+	// budget caps the retries shared with other executors; nil is unlimited
+	budget *RetryBudget
+	// sleep waits between attempts; tests replace it
+	sleep func(time.Duration)
+}
+
+// RetryBudget caps the total retries of every RetryExecutor sharing it, so
+// a run of many flaky commands cannot retry without bound. It is safe for
+// concurrent use.
+type RetryBudget struct {
+	mu        sync.Mutex
+	remaining int
+}
+
+// NewRetryBudget creates a budget allowing retries in total
+func NewRetryBudget(retries int) *RetryBudget {
+	return &RetryBudget{remaining: retries}
+}
+
+// take claims a retry, reporting false once the budget is spent
+func (b *RetryBudget) take() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.remaining <= 0 {
+		return false
+	}
+	b.remaining--
+	return true
+}
+
+// NewRetryExecutor wraps backend so that failing commands are re-run up to
+// maxRetries more times, waiting delay before the first retry
+func NewRetryExecutor(backend Backend, maxRetries int, delay time.Duration) *RetryExecutor {
+	return &RetryExecutor{
+		backend:    backend,
+		maxRetries: maxRetries,
+		delay:      delay,
+		sleep:      time.Sleep,
+	}
+}
+
+// SetBudget makes every retry of the executor claim one from budget
+func (e *RetryExecutor) SetBudget(budget *RetryBudget) {
+	e.budget = budget
+}
+
+// Execute runs the command, retrying it while it exits with a non-zero code
+// or times out. Commands that fail to start or are canceled are not retried.
+func (e *RetryExecutor) Execute(command string, args []string, options ExecOptions) (*ExecResult, error) {
+	delay := e.delay
+	for attempt := 0; ; attempt++ {
+		result, err := e.backend.Execute(command, args, options)
+		if err != nil || !retryable(result) || attempt >= e.maxRetries {
+			return result, err
+		}
+		if options.Context != nil && options.Context.Err() != nil {
+			return result, nil
+		}
+		if !e.budget.take() {
+			debug.Log("Not retrying %s: the total retry limit of the run is reached", command)
+			return result, nil
+		}
+
+		debug.Log("Attempt %d of %s exited with code %d, retrying in %s", attempt+1, command, result.ExitCode, delay)
+		e.sleep(delay)
+		delay *= 2
+	}
+}
+
+// retryable reports whether a result is worth another attempt
+func retryable(result *ExecResult) bool {
+	if result.Canceled || (result.Error != nil && !result.TimedOut) {
+		return false
+	}
+	return result.ExitCode != 0 || result.TimedOut
+}
@@ -0,0 +1,141 @@
+//go:build unit
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// scriptedBackend returns its results in order, one per Execute call
+type scriptedBackend struct {
+	results []*ExecResult
+	calls   int
+}
+
+func (b *scriptedBackend) Execute(command string, args []string, options ExecOptions) (*ExecResult, error) {
+	result := b.results[b.calls]
+	b.calls++
+	return result, nil
+}
+
+func newTestRetryExecutor(backend Backend, maxRetries int) (*RetryExecutor, *[]time.Duration) {
+	var delays []time.Duration
+	e := NewRetryExecutor(backend, maxRetries, 10*time.Millisecond)
+	e.sleep = func(d time.Duration) { delays = append(delays, d) }
+	return e, &delays
+}
+
+func TestRetryExecutor_RetriesUntilSuccess(t *testing.T) {
+	backend := &scriptedBackend{results: []*ExecResult{
+		{ExitCode: 1, Stdout: "first"},
+		{ExitCode: 1, Stdout: "second"},
+		{ExitCode: 0, Stdout: "third"},
+	}}
+	e, delays := newTestRetryExecutor(backend, 3)
+
+	result, err := e.Execute("go", []string{"test"}, ExecOptions{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if backend.calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", backend.calls)
+	}
+	if result.Stdout != "third" || result.ExitCode != 0 {
+		t.Errorf("expected the final attempt's result, got %+v", result)
+	}
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}
+	if len(*delays) != len(want) || (*delays)[0] != want[0] || (*delays)[1] != want[1] {
+		t.Errorf("expected exponential backoff %v, got %v", want, *delays)
+	}
+}
+
+func TestRetryExecutor_ReportsLastFailure(t *testing.T) {
+	backend := &scriptedBackend{results: []*ExecResult{
+		{ExitCode: 1, Stdout: "first"},
+		{TimedOut: true, ExitCode: -1, Stdout: "second"},
+		{ExitCode: 2, Stdout: "third"},
+	}}
+	e, _ := newTestRetryExecutor(backend, 2)
+
+	result, err := e.Execute("go", []string{"test"}, ExecOptions{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if backend.calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", backend.calls)
+	}
+	if result.Stdout != "third" || result.ExitCode != 2 {
+		t.Errorf("expected the final attempt's result, got %+v", result)
+	}
+}
+
+func TestRetryExecutor_DoesNotRetry(t *testing.T) {
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		result  *ExecResult
+		options ExecOptions
+	}{
+		{name: "success", result: &ExecResult{ExitCode: 0}},
+		{name: "start failure", result: &ExecResult{ExitCode: -1, Error: errors.New("exec format error")}},
+		{name: "canceled", result: &ExecResult{ExitCode: -1, Canceled: true, Error: context.Canceled}},
+		{name: "canceled run", result: &ExecResult{ExitCode: 1}, options: ExecOptions{Context: canceledCtx}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &scriptedBackend{results: []*ExecResult{tt.result, {ExitCode: 0}}}
+			e, _ := newTestRetryExecutor(backend, 3)
+
+			if _, err := e.Execute("go", []string{"test"}, tt.options); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if backend.calls != 1 {
+				t.Errorf("expected 1 attempt, got %d", backend.calls)
+			}
+		})
+	}
+}
+
+func TestRetryExecutor_RealCommand(t *testing.T) {
+	e := NewRetryExecutor(NewCommandExecutor(0), 2, time.Millisecond)
+
+	result, err := e.Execute("false", nil, ExecOptions{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.ExitCode == 0 {
+		t.Error("expected the final attempt to fail")
+	}
+}
+
+func TestRetryExecutor_SharedBudget(t *testing.T) {
+	budget := NewRetryBudget(3)
+	failing := func() *scriptedBackend {
+		return &scriptedBackend{results: []*ExecResult{{ExitCode: 1}, {ExitCode: 1}, {ExitCode: 1}}}
+	}
+
+	// Three commands allowed two retries each share three retries in total
+	var calls []int
+	for i := 0; i < 3; i++ {
+		backend := failing()
+		e, _ := newTestRetryExecutor(backend, 2)
+		e.SetBudget(budget)
+		if _, err := e.Execute("go", []string{"test"}, ExecOptions{}); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		calls = append(calls, backend.calls)
+	}
+
+	want := []int{3, 2, 1}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("expected attempts %v once the budget is spent, got %v", want, calls)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package executor
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// workingDirPolicy restricts the working directories commands may run in
+type workingDirPolicy struct {
+	// root is the resolved project root that relative patterns refer to
+	root string
+	// patterns are the allowed globs; empty allows the root subtree
+	patterns []string
+}
+
+// SetAllowedWorkingDirs restricts every command's working directory to those
+// matching patterns, globs relative to root unless absolute. Without
+// patterns, root and its subdirectories are allowed. Symbolic links are
+// resolved first, so a link cannot lead a command outside the allowed dirs.
+func (e *CommandExecutor) SetAllowedWorkingDirs(root string, patterns []string) error {
+	for _, pattern := range patterns {
+		if pattern == "" || !doublestar.ValidatePattern(filepath.ToSlash(pattern)) {
+			return fmt.Errorf("invalid allowed working directory pattern %q", pattern)
+		}
+	}
+
+	resolved, err := resolveDir(root)
+	if err != nil {
+		return fmt.Errorf("invalid project root: %w", err)
+	}
+	e.workingDirPolicy = &workingDirPolicy{root: resolved, patterns: patterns}
+	return nil
+}
+
+// check returns an error when dir, an existing absolute directory, is not allowed
+func (p *workingDirPolicy) check(dir string) error {
+	resolved, err := resolveDir(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(p.patterns) == 0 {
+		if _, inside := p.relative(resolved); inside {
+			return nil
+		}
+		return fmt.Errorf("%s is outside the project root %s", dir, p.root)
+	}
+
+	for _, pattern := range p.patterns {
+		if p.matches(pattern, resolved) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s does not match the allowed working directories %v", dir, p.patterns)
+}
+
+// matches reports whether the resolved directory dir matches pattern
+func (p *workingDirPolicy) matches(pattern, dir string) bool {
+	if filepath.IsAbs(pattern) {
+		matched, err := doublestar.Match(filepath.ToSlash(pattern), filepath.ToSlash(dir))
+		return err == nil && matched
+	}
+
+	rel, inside := p.relative(dir)
+	if !inside {
+		return false
+	}
+	matched, err := doublestar.Match(filepath.ToSlash(filepath.Clean(pattern)), filepath.ToSlash(rel))
+	return err == nil && matched
+}
+
+// relative returns dir relative to the root and whether it is inside it
+func (p *workingDirPolicy) relative(dir string) (string, bool) {
+	rel, err := filepath.Rel(p.root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// resolveDir returns the absolute path of dir with symbolic links resolved
+func resolveDir(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
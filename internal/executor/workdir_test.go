@@ -0,0 +1,132 @@
+//go:build unit
+
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// mkdirs creates the given directories under root
+func mkdirs(t *testing.T, root string, dirs ...string) {
+	t.Helper()
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestExecute_AllowedWorkingDirsDefault(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "project")
+	outside := filepath.Join(base, "other")
+	mkdirs(t, base, "project/src", "other")
+
+	executor := NewCommandExecutor(10 * time.Second)
+	if err := executor.SetAllowedWorkingDirs(root, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range []string{root, filepath.Join(root, "src")} {
+		if _, err := executor.Execute("echo", []string{"ok"}, ExecOptions{WorkingDir: dir}); err != nil {
+			t.Errorf("expected %s to be allowed, got %v", dir, err)
+		}
+	}
+
+	// The directory exists and passes the path validation, but is outside the root
+	_, err := executor.Execute("echo", []string{"escaped"}, ExecOptions{WorkingDir: outside})
+	if err == nil || !strings.Contains(err.Error(), "working directory not allowed") {
+		t.Errorf("expected a working directory outside the root to be blocked, got %v", err)
+	}
+}
+
+func TestExecute_AllowedWorkingDirsSymlink(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "project")
+	mkdirs(t, base, "project", "other")
+	link := filepath.Join(root, "link")
+	if err := os.Symlink(filepath.Join(base, "other"), link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	executor := NewCommandExecutor(10 * time.Second)
+	if err := executor.SetAllowedWorkingDirs(root, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executor.Execute("echo", []string{"escaped"}, ExecOptions{WorkingDir: link}); err == nil {
+		t.Error("expected a symlink leading outside the root to be blocked")
+	}
+}
+
+func TestExecute_AllowedWorkingDirsPatterns(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "project")
+	mkdirs(t, base, "project/packages/api", "project/tools", "shared")
+	shared, err := filepath.EvalSymlinks(filepath.Join(base, "shared"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executor := NewCommandExecutor(10 * time.Second)
+	if err := executor.SetAllowedWorkingDirs(root, []string{"packages/*", shared}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dir     string
+		allowed bool
+	}{
+		{filepath.Join(root, "packages", "api"), true},
+		{shared, true},
+		{root, false},
+		{filepath.Join(root, "tools"), false},
+	}
+	for _, tt := range tests {
+		_, err := executor.Execute("echo", []string{"ok"}, ExecOptions{WorkingDir: tt.dir})
+		if allowed := err == nil; allowed != tt.allowed {
+			t.Errorf("%s: expected allowed=%v, got error %v", tt.dir, tt.allowed, err)
+		}
+	}
+}
+
+func TestApplySecurity(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "project")
+	mkdirs(t, base, "project", "other")
+
+	// Without settings, the root subtree is still the only one allowed
+	executor := NewCommandExecutor(10 * time.Second)
+	if err := executor.ApplySecurity(root, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executor.Execute("echo", []string{"ok"}, ExecOptions{WorkingDir: root}); err != nil {
+		t.Errorf("expected the root to be allowed, got %v", err)
+	}
+	if _, err := executor.Execute("echo", []string{"escaped"}, ExecOptions{WorkingDir: filepath.Join(base, "other")}); err == nil {
+		t.Error("expected a directory outside the root to be blocked")
+	}
+
+	executor = NewCommandExecutor(10 * time.Second)
+	if err := executor.ApplySecurity(root, &config.SecurityConfig{DeniedCommands: []string{"echo"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executor.Execute("echo", []string{"denied"}, ExecOptions{WorkingDir: root}); err == nil {
+		t.Error("expected a denied command to be blocked")
+	}
+}
+
+func TestSetAllowedWorkingDirs_InvalidPattern(t *testing.T) {
+	executor := NewCommandExecutor(10 * time.Second)
+	if err := executor.SetAllowedWorkingDirs(t.TempDir(), []string{"packages/[a"}); err == nil {
+		t.Error("expected an invalid pattern to be rejected")
+	}
+	if err := executor.SetAllowedWorkingDirs(t.TempDir(), []string{""}); err == nil {
+		t.Error("expected an empty pattern to be rejected")
+	}
+}
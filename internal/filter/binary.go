@@ -0,0 +1,76 @@
+package filter
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// binaryHexdumpBytes is how much of a binary output the hexdump policy shows
+const binaryHexdumpBytes = 256
+
+// binaryControlRatio is the share of control or invalid UTF-8 bytes above
+// which output is treated as binary
+const binaryControlRatio = 0.3
+
+// IsBinary reports whether output looks like binary data rather than text.
+// Output is binary when it contains NUL bytes or when control characters and
+// invalid UTF-8 bytes make up a large part of it, so text with a few stray
+// bytes (e.g. Latin-1 file names in compiler output) is still text.
+func IsBinary(output string) bool {
+	if output == "" {
+		return false
+	}
+	if strings.IndexByte(output, 0) >= 0 {
+		return true
+	}
+
+	suspicious := 0
+	for i := 0; i < len(output); {
+		r, size := utf8.DecodeRuneInString(output[i:])
+		if (r == utf8.RuneError && size == 1) || isBinaryControl(r) {
+			suspicious++
+		}
+		i += size
+	}
+	return float64(suspicious) > binaryControlRatio*float64(len(output))
+}
+
+// isBinaryControl reports whether r is a control character that does not
+// occur in ordinary terminal output
+func isBinaryControl(r rune) bool {
+	switch r {
+	case '\t', '\n', '\r', '\f', '\v', '\b', '\x1b':
+		return false
+	}
+	return r < 0x20 || r == 0x7f
+}
+
+// SanitizeBinary returns a safe representation of binary command output
+// according to policy. Invalid UTF-8 sequences in text output are replaced
+// with U+FFFD; other text output is returned unchanged.
+func SanitizeBinary(output, policy string) string {
+	if output == "" {
+		return output
+	}
+	if !IsBinary(output) {
+		return strings.ToValidUTF8(output, "\uFFFD")
+	}
+
+	switch policy {
+	case config.BinaryOutputRaw:
+		return output
+	case config.BinaryOutputHexdump:
+		n := len(output)
+		if n > binaryHexdumpBytes {
+			n = binaryHexdumpBytes
+		}
+		return fmt.Sprintf("binary output (%d bytes), first %d bytes:\n%s",
+			len(output), n, strings.TrimRight(hex.Dump([]byte(output[:n])), "\n"))
+	default:
+		return fmt.Sprintf("binary output suppressed (%d bytes)", len(output))
+	}
+}
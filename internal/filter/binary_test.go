@@ -0,0 +1,106 @@
+//go:build unit
+
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestIsBinary(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{"plain text", "src/app.js:1:1 error\n", false},
+		{"utf8 text", "✗ échec de compilation", false},
+		{"nul bytes", "core\x00dump", true},
+		{"invalid utf8", "\xff\xfe\xfd", true},
+		{"stray latin-1 byte", "caf\xe9.c:3:1: error: expected ';'\n", false},
+		{"ansi colors", "\x1b[31merror\x1b[0m: failed\n", false},
+		{"mostly control bytes", "\x01\x02\x03\x04ab", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBinary(tt.output); got != tt.want {
+				t.Errorf("IsBinary(%q) = %v, want %v", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeBinary(t *testing.T) {
+	binary := string([]byte{0x7f, 'E', 'L', 'F', 0x00, 0xff, 0xfe, 0x01}) + strings.Repeat("\x00", 400)
+
+	t.Run("default suppresses with a note", func(t *testing.T) {
+		got := SanitizeBinary(binary, "")
+		if got != "binary output suppressed (408 bytes)" {
+			t.Errorf("unexpected representation: %q", got)
+		}
+	})
+
+	t.Run("skip suppresses with a note", func(t *testing.T) {
+		if got := SanitizeBinary(binary, config.BinaryOutputSkip); !strings.Contains(got, "suppressed") {
+			t.Errorf("unexpected representation: %q", got)
+		}
+	})
+
+	t.Run("hexdump shows the first bytes", func(t *testing.T) {
+		got := SanitizeBinary(binary, config.BinaryOutputHexdump)
+		if !strings.HasPrefix(got, "binary output (408 bytes), first 256 bytes:") {
+			t.Errorf("unexpected header: %q", got)
+		}
+		if !strings.Contains(got, "7f 45 4c 46 00 ff fe 01") {
+			t.Errorf("expected hex bytes in dump, got %q", got)
+		}
+		if IsBinary(got) {
+			t.Error("expected hexdump to be text")
+		}
+	})
+
+	t.Run("raw passes through", func(t *testing.T) {
+		if got := SanitizeBinary(binary, config.BinaryOutputRaw); got != binary {
+			t.Error("expected raw output to be unchanged")
+		}
+	})
+
+	t.Run("text is unchanged", func(t *testing.T) {
+		if got := SanitizeBinary("error: failed\n", ""); got != "error: failed\n" {
+			t.Errorf("expected text unchanged, got %q", got)
+		}
+	})
+
+	t.Run("stray invalid bytes in text are replaced", func(t *testing.T) {
+		got := SanitizeBinary("caf\xe9.c:3:1: error: expected ';'\n", "")
+		if got != "caf\uFFFD.c:3:1: error: expected ';'\n" {
+			t.Errorf("expected the invalid byte replaced, got %q", got)
+		}
+	})
+
+	t.Run("errors in text with stray bytes are kept", func(t *testing.T) {
+		rules := &FilterRules{
+			ErrorPatterns: []*config.RegexPattern{{Pattern: "error:"}},
+			MaxLines:      10,
+		}
+		output := "compiling caf\xe9.c\ncaf\xe9.c:3:1: error: expected ';'\n"
+		result := NewSimpleOutputFilter().FilterWithRules(SanitizeBinary(output, ""), rules)
+		if !result.HasErrors || len(result.Lines) != 1 || !strings.Contains(result.Lines[0], "error: expected ';'") {
+			t.Errorf("expected the compiler error to be reported, got %+v", result)
+		}
+	})
+
+	t.Run("filtering sanitized output does not panic", func(t *testing.T) {
+		rules := &FilterRules{
+			ErrorPatterns: []*config.RegexPattern{{Pattern: "suppressed"}},
+			MaxLines:      10,
+		}
+		result := NewSimpleOutputFilter().FilterWithRules(SanitizeBinary(binary, ""), rules)
+		if !result.HasErrors || len(result.Lines) != 1 {
+			t.Errorf("expected the note to be reported, got %+v", result)
+		}
+	})
+}
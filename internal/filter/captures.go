@@ -0,0 +1,68 @@
+package filter
+
+import (
+	"regexp"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// captureGroups returns the named groups captured by the first error pattern
+// matching each line. Lines without a match or named groups get a nil entry.
+func (f *OutputFilter) captureGroups(lines []string) []map[string]string {
+	var compiled []*regexp.Regexp
+	for _, pattern := range f.rules.ErrorPatterns {
+		if re := f.compileCapturing(pattern); re != nil {
+			compiled = append(compiled, re)
+		}
+	}
+	if len(compiled) == 0 {
+		return nil
+	}
+
+	captures := make([]map[string]string, len(lines))
+	for i, line := range lines {
+		for _, re := range compiled {
+			if groups := namedGroups(re, line); groups != nil {
+				captures[i] = groups
+				break
+			}
+		}
+	}
+	return captures
+}
+
+// compileCapturing compiles a pattern with named groups, returning nil for
+// patterns without any or that fail to compile
+func (f *OutputFilter) compileCapturing(pattern *config.RegexPattern) *regexp.Regexp {
+	var re *regexp.Regexp
+	var err error
+	if f.patternCache != nil {
+		re, err = f.patternCache.GetOrCompile(pattern)
+	} else {
+		re, err = pattern.Compile()
+	}
+	if err != nil {
+		return nil
+	}
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return re
+		}
+	}
+	return nil
+}
+
+// namedGroups returns the named groups of the first match of re in line
+func namedGroups(re *regexp.Regexp, line string) map[string]string {
+	match := re.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+	groups := make(map[string]string)
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			groups[name] = match[i]
+		}
+	}
+	return groups
+}
@@ -0,0 +1,53 @@
+//go:build unit
+
+package filter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestFilterWithRules_CaptureGroups(t *testing.T) {
+	output := "src/app.js:10:5: no-unused-vars: 'x' is never used\nsummary line\nlib/util.js:3:1: semi: Missing semicolon"
+	rules := &FilterRules{
+		ErrorPatterns: []*config.RegexPattern{
+			{Pattern: `^(?P<file>[^:]+):(?P<line>\d+):(?P<col>\d+): (?P<code>[\w-]+): (?P<message>.*)$`},
+		},
+		ContextPatterns: []*config.RegexPattern{{Pattern: `summary`}},
+		MaxLines:        100,
+		CaptureGroups:   true,
+	}
+
+	result := NewSimpleOutputFilter().FilterWithRules(output, rules)
+
+	if len(result.Captures) != len(result.Lines) {
+		t.Fatalf("expected one capture entry per line, got %d for %d lines", len(result.Captures), len(result.Lines))
+	}
+	want := map[string]string{"file": "src/app.js", "line": "10", "col": "5", "code": "no-unused-vars", "message": "'x' is never used"}
+	if !reflect.DeepEqual(result.Captures[0], want) {
+		t.Errorf("Captures[0] = %v, want %v", result.Captures[0], want)
+	}
+	for i, line := range result.Lines {
+		if line == "summary line" && result.Captures[i] != nil {
+			t.Errorf("expected no captures for context line, got %v", result.Captures[i])
+		}
+	}
+}
+
+func TestFilterWithRules_CaptureGroupsDisabled(t *testing.T) {
+	rules := &FilterRules{
+		ErrorPatterns: []*config.RegexPattern{{Pattern: `(?P<file>\S+): error`}},
+		MaxLines:      100,
+	}
+
+	result := NewSimpleOutputFilter().FilterWithRules("app.go: error", rules)
+
+	if !result.HasErrors {
+		t.Fatal("expected the line to match")
+	}
+	if result.Captures != nil {
+		t.Errorf("expected no captures without CaptureGroups, got %v", result.Captures)
+	}
+}
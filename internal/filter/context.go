@@ -0,0 +1,55 @@
+package filter
+
+// contextWindow emits matched lines of a stream with up to size lines of
+// context before and after each, like grep -C. Overlapping windows are
+// merged and "..." separates blocks that are not contiguous.
+type contextWindow struct {
+	size int
+	emit func(line string)
+	// before holds the lines just before the current one that were not emitted
+	before []string
+	// after is the number of lines still to emit after the last match
+	after int
+	// lastEmitted is the 1-based number of the last emitted line (0: none)
+	lastEmitted int
+}
+
+// newContextWindow creates a context window passing emitted lines to emit
+func newContextWindow(size int, emit func(line string)) *contextWindow {
+	if size < 0 {
+		size = 0
+	}
+	return &contextWindow{size: size, emit: emit}
+}
+
+// add processes line, the 1-based lineNum-th line of the stream
+func (w *contextWindow) add(lineNum int, line string, matched bool) {
+	if matched {
+		first := lineNum - len(w.before)
+		if w.lastEmitted > 0 && first > w.lastEmitted+1 {
+			w.emit("...")
+		}
+		for _, contextLine := range w.before {
+			w.emit(contextLine)
+		}
+		w.emit(line)
+		w.before = w.before[:0]
+		w.after = w.size
+		w.lastEmitted = lineNum
+		return
+	}
+
+	if w.after > 0 {
+		w.emit(line)
+		w.after--
+		w.lastEmitted = lineNum
+		return
+	}
+
+	if w.size > 0 {
+		if len(w.before) == w.size {
+			w.before = append(w.before[:0], w.before[1:]...)
+		}
+		w.before = append(w.before, line)
+	}
+}
@@ -0,0 +1,110 @@
+//go:build unit
+
+package filter
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+const contextInput = `line 1
+line 2
+ERROR a
+line 4
+ERROR b
+line 6
+line 7
+line 8
+line 9
+ERROR c
+line 11`
+
+func TestContextWindow(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		want []string
+	}{
+		{
+			name: "no context",
+			size: 0,
+			want: []string{"ERROR a", "...", "ERROR b", "...", "ERROR c"},
+		},
+		{
+			name: "overlapping windows merged",
+			size: 1,
+			want: []string{"line 2", "ERROR a", "line 4", "ERROR b", "line 6", "...", "line 9", "ERROR c", "line 11"},
+		},
+		{
+			name: "adjacent windows without separator",
+			size: 2,
+			want: []string{"line 1", "line 2", "ERROR a", "line 4", "ERROR b", "line 6", "line 7", "line 8", "line 9", "ERROR c", "line 11"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			window := newContextWindow(tt.size, func(line string) { got = append(got, line) })
+			for i, line := range strings.Split(contextInput, "\n") {
+				window.add(i+1, line, strings.HasPrefix(line, "ERROR"))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamingFiltersMatchFilterContext(t *testing.T) {
+	rules := &FilterRules{
+		ErrorPatterns: []*config.RegexPattern{{Pattern: "ERROR"}},
+		ContextLines:  1,
+		MaxLines:      100,
+	}
+
+	outputFilter, err := NewOutputFilter(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := outputFilter.Filter(contextInput).Lines
+
+	var streamed bytes.Buffer
+	if err := outputFilter.StreamFilter(strings.NewReader(contextInput), &streamed); err != nil {
+		t.Fatalf("StreamFilter() error = %v", err)
+	}
+	if got := strings.Split(strings.TrimSuffix(streamed.String(), "\n"), "\n"); !reflect.DeepEqual(got, want) {
+		t.Errorf("StreamFilter() = %q, want %q", got, want)
+	}
+
+	optimized, err := NewOptimizedOutputFilter(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := optimized.FilterOptimized(contextInput).Lines; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterOptimized() = %q, want %q", got, want)
+	}
+}
+
+func TestOptimizedFilter_MaxLinesAppliesToContext(t *testing.T) {
+	optimized, err := NewOptimizedOutputFilter(&FilterRules{
+		ErrorPatterns: []*config.RegexPattern{{Pattern: "ERROR"}},
+		ContextLines:  2,
+		MaxLines:      4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := optimized.FilterOptimized(contextInput)
+	if len(result.Lines) != 4 || !result.Truncated {
+		t.Errorf("expected 4 lines and truncation, got %q (truncated %v)", result.Lines, result.Truncated)
+	}
+	if result.TotalLines != 11 {
+		t.Errorf("TotalLines = %d, want 11", result.TotalLines)
+	}
+}
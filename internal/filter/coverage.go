@@ -0,0 +1,27 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// PatternHits counts the lines of output each pattern matches, in the order
+// of patterns. A pattern that never matches real output is likely dead.
+func PatternHits(output string, patterns []*config.RegexPattern) ([]int, error) {
+	hits := make([]int, len(patterns))
+	lines := strings.Split(output, "\n")
+	for i, pattern := range patterns {
+		re, err := pattern.Compile()
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern.Pattern, err)
+		}
+		for _, line := range lines {
+			if re.MatchString(line) {
+				hits[i]++
+			}
+		}
+	}
+	return hits, nil
+}
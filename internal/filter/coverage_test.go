@@ -0,0 +1,31 @@
+//go:build unit
+
+package filter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestPatternHits(t *testing.T) {
+	output := "src/a.ts:1: error TS2322\nsrc/b.ts:4: Error TS2345\nFound 2 errors\n"
+	patterns := []*config.RegexPattern{
+		{Pattern: "error TS\\d+"},
+		{Pattern: "error ts\\d+", Flags: "i"},
+		{Pattern: "FATAL"},
+	}
+
+	hits, err := PatternHits(output, patterns)
+	if err != nil {
+		t.Fatalf("PatternHits() error = %v", err)
+	}
+	if want := []int{1, 2, 0}; !reflect.DeepEqual(hits, want) {
+		t.Errorf("PatternHits() = %v, want %v", hits, want)
+	}
+
+	if _, err := PatternHits(output, []*config.RegexPattern{{Pattern: "[invalid"}}); err == nil {
+		t.Error("expected an invalid pattern to be reported")
+	}
+}
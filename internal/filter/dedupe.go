@@ -0,0 +1,33 @@
+package filter
+
+import "fmt"
+
+// dedupeMatches keeps the first of identical matched lines and drops the
+// others, with their context. Lines kept for several occurrences get the
+// count appended in the returned copy of lines, e.g. "error TS2307 (x12)".
+// repeats adds the occurrences that were counted without being stored.
+func dedupeMatches(matches []lineMatch, lines []string, repeats map[string]int) ([]lineMatch, []string) {
+	counts := make(map[string]int, len(matches))
+	for _, match := range matches {
+		counts[match.line]++
+	}
+	if len(counts) == len(matches) && len(repeats) == 0 {
+		return matches, lines
+	}
+
+	annotated := append([]string(nil), lines...)
+	kept := make([]lineMatch, 0, len(counts))
+	for _, match := range matches {
+		n, ok := counts[match.line]
+		n += repeats[match.line]
+		if !ok {
+			continue
+		}
+		delete(counts, match.line)
+		kept = append(kept, match)
+		if n > 1 {
+			annotated[match.lineNum] = fmt.Sprintf("%s (x%d)", match.line, n)
+		}
+	}
+	return kept, annotated
+}
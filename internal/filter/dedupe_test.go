@@ -0,0 +1,83 @@
+//go:build unit
+
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestOutputFilter_Dedupe(t *testing.T) {
+	var input []string
+	for i := 0; i < 12; i++ {
+		input = append(input, "ERROR in ./src/index.ts: Module not found: 'lodash'")
+		if i%4 == 0 {
+			input = append(input, "ERROR in ./src/app.ts: TS2322")
+		}
+		input = append(input, "compiling...")
+	}
+
+	rules := &FilterRules{
+		ErrorPatterns: []*config.RegexPattern{{Pattern: "^ERROR"}},
+		Dedupe:        true,
+	}
+	filter, err := NewOutputFilter(rules)
+	if err != nil {
+		t.Fatalf("NewOutputFilter() error = %v", err)
+	}
+
+	result := filter.Filter(strings.Join(input, "\n"))
+
+	want := []string{
+		"ERROR in ./src/index.ts: Module not found: 'lodash' (x12)",
+		"ERROR in ./src/app.ts: TS2322 (x3)",
+	}
+	if !reflect.DeepEqual(result.Lines, want) {
+		t.Errorf("deduped lines = %q, want %q", result.Lines, want)
+	}
+	if result.TotalLines != len(input) {
+		t.Errorf("expected TotalLines to count every line, got %d, want %d", result.TotalLines, len(input))
+	}
+	if !result.HasErrors {
+		t.Error("expected deduped result to report errors")
+	}
+
+	// Without dedupe every occurrence is kept
+	rules.Dedupe = false
+	errors := 0
+	for _, line := range filter.Filter(strings.Join(input, "\n")).Lines {
+		if strings.HasPrefix(line, "ERROR") {
+			errors++
+		}
+	}
+	if errors != 15 {
+		t.Errorf("without dedupe got %d error lines, want 15", errors)
+	}
+}
+
+func TestOutputFilter_DedupeBeforeTruncation(t *testing.T) {
+	input := strings.Repeat("error: same failure\n", 50) + "error: other failure\n"
+
+	filter, err := NewOutputFilter(&FilterRules{
+		ErrorPatterns: []*config.RegexPattern{{Pattern: "error"}},
+		MaxLines:      5,
+		Dedupe:        true,
+	})
+	if err != nil {
+		t.Fatalf("NewOutputFilter() error = %v", err)
+	}
+
+	result := filter.Filter(input)
+	if want := []string{"error: same failure (x50)", "...", "error: other failure"}; !reflect.DeepEqual(result.Lines, want) {
+		t.Errorf("deduped lines = %q, want %q", result.Lines, want)
+	}
+	if result.Truncated {
+		t.Error("expected deduped output within MaxLines not to be truncated")
+	}
+	if result.TotalLines != 51 {
+		t.Errorf("expected TotalLines 51, got %d", result.TotalLines)
+	}
+}
@@ -0,0 +1,29 @@
+package filter
+
+import "fmt"
+
+// Filter extracts the lines worth reporting from command output. RegexFilter
+// is the default; other implementations can understand structured output
+// such as SARIF instead of matching it line by line.
+type Filter interface {
+	Filter(output string, rules *FilterRules) (*FilteredOutput, error)
+}
+
+// RegexFilter is the default Filter, keeping the lines matching the error and
+// context patterns of the rules
+type RegexFilter struct {
+	output *OutputFilter
+}
+
+// NewRegexFilter creates a regex filter sharing one pattern cache across calls
+func NewRegexFilter() *RegexFilter {
+	return &RegexFilter{output: NewSimpleOutputFilter()}
+}
+
+// Filter applies the rules to output
+func (f *RegexFilter) Filter(output string, rules *FilterRules) (*FilteredOutput, error) {
+	if rules == nil {
+		return nil, fmt.Errorf("filter rules cannot be nil")
+	}
+	return f.output.FilterWithRules(output, rules), nil
+}
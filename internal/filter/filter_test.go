@@ -0,0 +1,28 @@
+//go:build unit
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestRegexFilter(t *testing.T) {
+	var f Filter = NewRegexFilter()
+
+	result, err := f.Filter("ok\nerror: broken\ndone", &FilterRules{
+		ErrorPatterns: []*config.RegexPattern{{Pattern: "error"}},
+		MaxLines:      10,
+	})
+	if err != nil {
+		t.Fatalf("Filter() error = %v", err)
+	}
+	if !result.HasErrors || len(result.Lines) != 1 || result.Lines[0] != "error: broken" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if _, err := f.Filter("output", nil); err == nil {
+		t.Error("expected nil rules to be rejected")
+	}
+}
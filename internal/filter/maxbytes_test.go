@@ -0,0 +1,163 @@
+//go:build unit
+
+package filter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestOutputFilter_MaxBytes(t *testing.T) {
+	errorRules := func(maxLines, maxBytes int) *FilterRules {
+		return &FilterRules{
+			ErrorPatterns: []*config.RegexPattern{{Pattern: "error"}},
+			MaxLines:      maxLines,
+			MaxBytes:      maxBytes,
+		}
+	}
+	output := "error: one\nerror: two\nerror: three\nerror: four"
+
+	tests := []struct {
+		name        string
+		output      string
+		rules       *FilterRules
+		wantLines   []string
+		truncatedBy string
+	}{
+		{
+			name:        "byte limit reached first",
+			output:      output,
+			rules:       errorRules(10, 25),
+			wantLines:   []string{"error: one", "error: two", "err"},
+			truncatedBy: TruncatedByBytes,
+		},
+		{
+			name:        "line limit reached first",
+			output:      output,
+			rules:       errorRules(2, 1000),
+			truncatedBy: TruncatedByLines,
+		},
+		{
+			name:        "single giant line",
+			output:      "error: " + strings.Repeat("é", 100),
+			rules:       errorRules(10, 20),
+			wantLines:   []string{"error: " + strings.Repeat("é", 6)},
+			truncatedBy: TruncatedByBytes,
+		},
+		{
+			name:      "within both limits",
+			output:    output,
+			rules:     errorRules(10, 1000),
+			wantLines: strings.Split(output, "\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewSimpleOutputFilter().FilterWithRules(tt.output, tt.rules)
+
+			if result.TruncatedBy != tt.truncatedBy || result.Truncated != (tt.truncatedBy != "") {
+				t.Errorf("Truncated = %v by %q, want %q", result.Truncated, result.TruncatedBy, tt.truncatedBy)
+			}
+			if tt.wantLines != nil && strings.Join(result.Lines, "\n") != strings.Join(tt.wantLines, "\n") {
+				t.Errorf("Lines = %q, want %q", result.Lines, tt.wantLines)
+			}
+			if !result.HasErrors {
+				t.Error("expected errors to be detected from the whole lines")
+			}
+		})
+	}
+}
+
+// lineReader generates n lines without holding them, to feed FilterReader
+// output far larger than its budget
+type lineReader struct {
+	n, next int
+	line    func(i int) string
+	pending []byte
+}
+
+func (r *lineReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.next == r.n {
+			return 0, io.EOF
+		}
+		r.pending = []byte(r.line(r.next) + "\n")
+		r.next++
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func TestOutputFilter_FilterReaderBeyondBudget(t *testing.T) {
+	const total = 200000
+	numbered := func(i int) string { return fmt.Sprintf("error: line %06d", i) }
+	cycling := func(i int) string { return fmt.Sprintf("error: %c", 'a'+i%4) }
+
+	tests := []struct {
+		name        string
+		rules       *FilterRules
+		line        func(i int) string
+		wantLines   []string
+		truncatedBy string
+	}{
+		{
+			name:  "line budget",
+			rules: &FilterRules{ErrorPatterns: []*config.RegexPattern{{Pattern: "error"}}, MaxLines: 3},
+			line:  numbered,
+			wantLines: []string{
+				"error: line 000000",
+				"error: line 000001",
+				fmt.Sprintf("... truncated %d lines (preserved 2 error lines) ...", total-2),
+			},
+			truncatedBy: TruncatedByLines,
+		},
+		{
+			name:  "deduped repeats counted after the budget",
+			rules: &FilterRules{ErrorPatterns: []*config.RegexPattern{{Pattern: "error"}}, MaxLines: 3, Dedupe: true},
+			line:  cycling,
+			wantLines: []string{
+				fmt.Sprintf("error: a (x%d)", total/4),
+				fmt.Sprintf("error: b (x%d)", total/4),
+				fmt.Sprintf("... truncated %d lines (preserved 2 error lines) ...", total-2),
+			},
+			truncatedBy: TruncatedByLines,
+		},
+		{
+			name:        "byte budget",
+			rules:       &FilterRules{ErrorPatterns: []*config.RegexPattern{{Pattern: "error"}}, MaxBytes: 25},
+			line:        numbered,
+			wantLines:   []string{"error: line 000000", "error:"},
+			truncatedBy: TruncatedByBytes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewOutputFilter(tt.rules)
+			if err != nil {
+				t.Fatalf("NewOutputFilter() error = %v", err)
+			}
+			reader := &lineReader{n: total, line: tt.line}
+			result := filter.FilterReader(reader)
+
+			if reader.next != total {
+				t.Errorf("read %d lines, want the reader drained (%d)", reader.next, total)
+			}
+			if result.TotalLines != total {
+				t.Errorf("TotalLines = %d, want %d", result.TotalLines, total)
+			}
+			if !result.HasErrors || result.TruncatedBy != tt.truncatedBy {
+				t.Errorf("HasErrors = %v, TruncatedBy = %q, want true, %q", result.HasErrors, result.TruncatedBy, tt.truncatedBy)
+			}
+			if strings.Join(result.Lines, "\n") != strings.Join(tt.wantLines, "\n") {
+				t.Errorf("Lines = %q, want %q", result.Lines, tt.wantLines)
+			}
+		})
+	}
+}
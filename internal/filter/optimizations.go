@@ -45,21 +45,23 @@ func NewOptimizedPatternSet(patterns []*config.RegexPattern) (*OptimizedPatternS
 // addPattern analyzes a pattern and adds it to the appropriate optimization bucket
 func (ops *OptimizedPatternSet) addPattern(pattern *config.RegexPattern) error {
 	// Check if it's a simple literal
-	if isLiteral(pattern.Pattern) {
+	if isLiteral(pattern.Pattern) || (pattern.Literal && pattern.Flags == "") {
 		ops.literals[pattern.Pattern] = true
 		return nil
 	}
 
-	// Check if it's a simple prefix pattern
-	if prefix, ok := isSimplePrefix(pattern.Pattern); ok {
-		ops.prefixes = append(ops.prefixes, prefix)
-		return nil
-	}
+	if !pattern.Literal {
+		// Check if it's a simple prefix pattern
+		if prefix, ok := isSimplePrefix(pattern.Pattern); ok {
+			ops.prefixes = append(ops.prefixes, prefix)
+			return nil
+		}
 
-	// Check if it's a simple suffix pattern
-	if suffix, ok := isSimpleSuffix(pattern.Pattern); ok {
-		ops.suffixes = append(ops.suffixes, suffix)
-		return nil
+		// Check if it's a simple suffix pattern
+		if suffix, ok := isSimpleSuffix(pattern.Pattern); ok {
+			ops.suffixes = append(ops.suffixes, suffix)
+			return nil
+		}
 	}
 
 	// Fall back to regex compilation
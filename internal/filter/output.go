@@ -7,6 +7,7 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/bebsworthy/qualhook/internal/debug"
 	"github.com/bebsworthy/qualhook/pkg/config"
@@ -25,8 +26,23 @@ type FilteredOutput struct {
 	HasErrors  bool
 	Truncated  bool
 	TotalLines int
+	// TruncatedBy names the limit that truncated Lines, TruncatedByLines or
+	// TruncatedByBytes
+	TruncatedBy string
+	// Captures holds the named groups captured from each line of Lines by the
+	// first matching error pattern (nil entries for other lines). It is only
+	// set when FilterRules.CaptureGroups is enabled.
+	Captures []map[string]string
 }
 
+// Limits that truncate filtered output, named after their configuration fields
+const (
+	// TruncatedByLines is the line limit, maxOutput
+	TruncatedByLines = "maxOutput"
+	// TruncatedByBytes is the byte limit, maxOutputBytes
+	TruncatedByBytes = "maxOutputBytes"
+)
+
 // NewOutputFilter creates a new output filter with the given rules
 func NewOutputFilter(rules *FilterRules) (*OutputFilter, error) {
 	if rules == nil {
@@ -73,24 +89,61 @@ func (f *OutputFilter) FilterReader(reader io.Reader) *FilteredOutput {
 		allLines     []string
 		matchedLines []lineMatch
 		totalLines   int
+
+		// Once the stored errors spend the output budget, later lines can't
+		// change what is kept; they're drained and counted as skipped
+		skipped      int
+		storedErrors int
+		errorBytes   int
+		distinct     = make(map[string]bool)
+		repeats      = make(map[string]int)
 	)
 
+	isError := f.lineMatcher(f.rules.ErrorPatterns)
+	isContext := f.lineMatcher(f.rules.ContextPatterns)
+
 	lineNum := 0
 	for scanner.Scan() {
 		line := scanner.Text()
-		allLines = append(allLines, line)
 		totalLines++
 		lineNum++
 
+		// Match the whole line, but keep little more of it than could be
+		// reported; limitBytes still sees the kept part cross the limit
+		matchesError, matchesContext := isError(line), false
+		if !matchesError {
+			matchesContext = isContext(line)
+		}
+		if limit := f.rules.MaxBytes + utf8.UTFMax; f.rules.MaxBytes > 0 && len(line) > limit {
+			line = line[:limit]
+		}
+
+		if skipped > 0 || f.budgetSpent(storedErrors, errorBytes) {
+			skipped++
+			if matchesError && distinct[line] {
+				repeats[line]++
+			}
+			continue
+		}
+		allLines = append(allLines, line)
+
 		// Check if line matches any error pattern
-		if f.matchesAnyPattern(line, f.rules.ErrorPatterns) {
+		if matchesError {
 			debug.LogPatternMatch("error patterns", line, true)
 			matchedLines = append(matchedLines, lineMatch{
 				lineNum: lineNum - 1, // 0-indexed
 				line:    line,
 				isError: true,
 			})
-		} else if f.matchesAnyPattern(line, f.rules.ContextPatterns) {
+			// Repeats of a stored error take no room once deduped
+			if !distinct[line] {
+				storedErrors++
+				errorBytes += len(line)
+			}
+			if f.rules.Dedupe {
+				distinct[line] = true
+			}
+		} else if matchesContext {
 			debug.LogPatternMatch("include patterns", line, true)
 			matchedLines = append(matchedLines, lineMatch{
 				lineNum: lineNum - 1,
@@ -100,24 +153,79 @@ func (f *OutputFilter) FilterReader(reader io.Reader) *FilteredOutput {
 		}
 	}
 
+	// Collapse repeated matches before extracting, so truncation keeps more
+	// distinct errors; totalLines still counts every line
+	if f.rules.Dedupe {
+		matchedLines, allLines = dedupeMatches(matchedLines, allLines, repeats)
+	}
+
 	// Extract matched lines with context
 	extractedLines := f.extractLinesWithContext(allLines, matchedLines)
 
+	result := &FilteredOutput{
+		Lines:      extractedLines,
+		HasErrors:  f.hasErrors(matchedLines),
+		TotalLines: totalLines,
+	}
+
 	// Apply truncation if needed
-	truncated := false
 	if f.rules.MaxLines > 0 && len(extractedLines) > f.rules.MaxLines {
 		// Re-map matched lines to their positions in extractedLines
 		remappedMatches := f.remapMatches(allLines, extractedLines, matchedLines)
-		extractedLines = f.intelligentTruncate(extractedLines, remappedMatches)
-		truncated = true
+		result.Lines = f.intelligentTruncate(extractedLines, remappedMatches, skipped)
+		result.Truncated = true
+		result.TruncatedBy = TruncatedByLines
 	}
+	limitBytes(result, f.rules.MaxBytes)
 
-	return &FilteredOutput{
-		Lines:      extractedLines,
-		HasErrors:  f.hasErrors(matchedLines),
-		Truncated:  truncated,
-		TotalLines: totalLines,
+	return result
+}
+
+// budgetSpent reports whether the stored errors already fill the output: more
+// errors than MaxLines, or without a line limit, more error bytes than
+// MaxBytes. Lines after that point can't reach the filtered output.
+func (f *OutputFilter) budgetSpent(errors, errorBytes int) bool {
+	if f.rules.MaxLines > 0 {
+		return errors > f.rules.MaxLines
 	}
+	return f.rules.MaxBytes > 0 && errorBytes > f.rules.MaxBytes
+}
+
+// limitBytes keeps the leading lines of result that fit in maxBytes, counting
+// a newline after each line. The line crossing the limit is cut at it, so a
+// single giant line is bounded too.
+func limitBytes(result *FilteredOutput, maxBytes int) {
+	if maxBytes <= 0 {
+		return
+	}
+
+	used := 0
+	for i, line := range result.Lines {
+		if used+len(line) <= maxBytes {
+			used += len(line) + 1
+			continue
+		}
+
+		kept := result.Lines[:i:i]
+		if cut := cutBytes(line, maxBytes-used); cut != "" {
+			kept = append(kept, cut)
+		}
+		result.Lines = kept
+		result.Truncated = true
+		result.TruncatedBy = TruncatedByBytes
+		return
+	}
+}
+
+// cutBytes shortens s to at most n bytes without splitting a UTF-8 sequence
+func cutBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
 }
 
 // FilterBoth filters both stdout and stderr, combining the results
@@ -169,30 +277,24 @@ func (f *OutputFilter) StreamFilter(reader io.Reader, writer io.Writer) error {
 	}()
 
 	var (
-		buffer  []string
 		lineNum int
 		mu      sync.Mutex
 	)
+	isError := f.lineMatcher(f.rules.ErrorPatterns)
+	window := newContextWindow(f.rules.ContextLines, func(line string) {
+		_, _ = fmt.Fprintln(bufWriter, line) //nolint:errcheck // Best effort output
+	})
 
 	// Process lines as they come
 	for scanner.Scan() {
 		line := scanner.Text()
 		mu.Lock()
-		buffer = append(buffer, line)
 		lineNum++
 
-		// Keep a sliding window of lines for context
-		if len(buffer) > f.rules.ContextLines*2+100 {
-			buffer = buffer[len(buffer)-f.rules.ContextLines*2-100:]
-		}
-
-		// Check if line matches patterns
-		if f.matchesAnyPattern(line, f.rules.ErrorPatterns) {
-			// Write the line with context immediately
-			contextLines := f.getContextLines(buffer, len(buffer)-1, f.rules.ContextLines)
-			for _, contextLine := range contextLines {
-				_, _ = fmt.Fprintln(bufWriter, contextLine) //nolint:errcheck // Best effort output
-			}
+		matched := isError(line)
+		window.add(lineNum, line, matched)
+		if matched || window.after == 0 {
+			// Write each completed block immediately
 			_ = bufWriter.Flush() //nolint:errcheck // Best effort flush for immediate output
 		}
 		mu.Unlock()
@@ -257,6 +359,13 @@ func (f *OutputFilter) extractLinesWithContext(allLines []string, matches []line
 		for i := 1; i <= f.rules.ContextLines && match.lineNum+i < len(allLines); i++ {
 			includeSet[match.lineNum+i] = true
 		}
+
+		// In block mode, extend the match to the end of its block
+		if f.rules.BlockMode {
+			for i := match.lineNum + 1; i < len(allLines) && strings.TrimSpace(allLines[i]) != ""; i++ {
+				includeSet[i] = true
+			}
+		}
 	}
 
 	// Extract lines in order
@@ -304,7 +413,9 @@ func (f *OutputFilter) remapMatches(allLines, extractedLines []string, originalM
 	return remapped
 }
 
-func (f *OutputFilter) intelligentTruncate(lines []string, matches []lineMatch) []string {
+// intelligentTruncate keeps up to MaxLines of lines, errors first; skipped
+// counts the lines dropped before extraction for the truncation note
+func (f *OutputFilter) intelligentTruncate(lines []string, matches []lineMatch, skipped int) []string {
 	if len(lines) <= f.rules.MaxLines {
 		return lines
 	}
@@ -345,7 +456,7 @@ func (f *OutputFilter) intelligentTruncate(lines []string, matches []lineMatch)
 	}
 
 	// Add truncation indicator
-	truncatedCount := len(lines) - len(result)
+	truncatedCount := len(lines) - len(result) + skipped
 	if truncatedCount > 0 {
 		result = append(result, fmt.Sprintf("... truncated %d lines (preserved %d error lines) ...", truncatedCount, errorCount))
 	}
@@ -353,20 +464,6 @@ func (f *OutputFilter) intelligentTruncate(lines []string, matches []lineMatch)
 	return result
 }
 
-func (f *OutputFilter) getContextLines(buffer []string, matchIndex int, contextSize int) []string {
-	start := matchIndex - contextSize
-	if start < 0 {
-		start = 0
-	}
-
-	end := matchIndex + contextSize + 1
-	if end > len(buffer) {
-		end = len(buffer)
-	}
-
-	return buffer[start:end]
-}
-
 func (f *OutputFilter) hasErrors(matches []lineMatch) bool {
 	for _, match := range matches {
 		if match.isError {
@@ -388,6 +485,16 @@ type FilterRules struct {
 	MaxLines        int
 	ContextLines    int
 	Priority        string
+	// BlockMode includes every non-blank line following a match, up to the next blank line
+	BlockMode bool
+	// CaptureGroups retains the named groups of matching error patterns per line
+	CaptureGroups bool
+	// Dedupe keeps only the first of identical matched lines, followed by
+	// its occurrence count, e.g. "(x12)"
+	Dedupe bool
+	// MaxBytes caps the filtered output in bytes; whichever of MaxLines and
+	// MaxBytes is reached first truncates it
+	MaxBytes int
 }
 
 // NewSimpleOutputFilter creates a new output filter without rules (for simple filtering)
@@ -412,5 +519,9 @@ func (o *OutputFilter) FilterWithRules(output string, rules *FilterRules) *Filte
 		maxBufferSize: o.maxBufferSize,
 	}
 
-	return filter.Filter(output)
+	result := filter.Filter(output)
+	if rules.CaptureGroups {
+		result.Captures = filter.captureGroups(result.Lines)
+	}
+	return result
 }
@@ -163,7 +163,7 @@ func BenchmarkIntelligentTruncation(b *testing.B) {
 			b.ResetTimer()
 			b.ReportAllocs()
 			for i := 0; i < b.N; i++ {
-				_ = filter.intelligentTruncate(lines, matches)
+				_ = filter.intelligentTruncate(lines, matches, 0)
 			}
 		})
 	}
@@ -62,110 +62,53 @@ func (f *OptimizedOutputFilter) FilterReaderOptimized(reader io.Reader) *Filtere
 	buf := make([]byte, 0, f.maxBufferSize)
 	scanner.Buffer(buf, f.maxBufferSize)
 
-	// Use circular buffer for context lines
-	contextBuffer := NewCircularBuffer(f.rules.ContextLines*2 + 1)
-
 	result := &FilteredOutput{
 		Lines:     make([]string, 0, 100), // Pre-allocate reasonable size
 		HasErrors: false,
 		Truncated: false,
 	}
 
-	var (
-		totalLines     int
-		capturedLines  int
-		pendingContext int
-	)
+	// Matched lines and their context count towards MaxLines and MaxBytes;
+	// once either is reached, scanning continues only to count the total lines
+	usedBytes := 0
+	window := newContextWindow(f.rules.ContextLines, func(line string) {
+		if result.Truncated {
+			return
+		}
+		if f.rules.MaxLines > 0 && len(result.Lines) >= f.rules.MaxLines {
+			result.Truncated = true
+			result.TruncatedBy = TruncatedByLines
+			return
+		}
+		if f.rules.MaxBytes > 0 && usedBytes+len(line) > f.rules.MaxBytes {
+			if cut := cutBytes(line, f.rules.MaxBytes-usedBytes); cut != "" {
+				result.Lines = append(result.Lines, cut)
+			}
+			result.Truncated = true
+			result.TruncatedBy = TruncatedByBytes
+			return
+		}
+		usedBytes += len(line) + 1
+		result.Lines = append(result.Lines, line)
+	})
 
+	var totalLines int
 	for scanner.Scan() {
 		line := scanner.Text()
 		totalLines++
 
-		// Add to circular buffer
-		contextBuffer.Add(line)
-
 		// Check if line matches patterns
 		isError := f.matchesAnyPattern(line, f.rules.ErrorPatterns)
 		isInclude := !isError && f.matchesAnyPattern(line, f.rules.ContextPatterns)
+		result.HasErrors = result.HasErrors || isError
 
-		if isError || isInclude {
-			result.HasErrors = result.HasErrors || isError
-
-			// Add context lines before the match
-			contextLines := contextBuffer.GetContext(f.rules.ContextLines)
-			for _, contextLine := range contextLines {
-				if capturedLines < f.rules.MaxLines {
-					result.Lines = append(result.Lines, contextLine)
-					capturedLines++
-				}
-			}
-
-			// Set pending context for lines after the match
-			pendingContext = f.rules.ContextLines
-		} else if pendingContext > 0 {
-			// Capture context lines after a match
-			if capturedLines < f.rules.MaxLines {
-				result.Lines = append(result.Lines, line)
-				capturedLines++
-			}
-			pendingContext--
-		}
-
-		// Check if we've hit the output limit
-		if capturedLines >= f.rules.MaxLines {
-			result.Truncated = true
-			// Continue scanning to count total lines but don't store
-		}
+		window.add(totalLines, line, isError || isInclude)
 	}
 
 	result.TotalLines = totalLines
 	return result
 }
 
-// CircularBuffer implements a fixed-size circular buffer for context lines
-type CircularBuffer struct {
-	buffer []string
-	size   int
-	head   int
-	count  int
-}
-
-// NewCircularBuffer creates a new circular buffer
-func NewCircularBuffer(size int) *CircularBuffer {
-	return &CircularBuffer{
-		buffer: make([]string, size),
-		size:   size,
-	}
-}
-
-// Add adds a line to the buffer
-func (cb *CircularBuffer) Add(line string) {
-	cb.buffer[cb.head] = line
-	cb.head = (cb.head + 1) % cb.size
-	if cb.count < cb.size {
-		cb.count++
-	}
-}
-
-// GetContext returns up to n lines before the current position
-func (cb *CircularBuffer) GetContext(n int) []string {
-	if n > cb.count {
-		n = cb.count
-	}
-
-	result := make([]string, 0, n)
-	start := (cb.head - n + cb.size) % cb.size
-
-	for i := 0; i < n; i++ {
-		idx := (start + i) % cb.size
-		if cb.buffer[idx] != "" {
-			result = append(result, cb.buffer[idx])
-		}
-	}
-
-	return result
-}
-
 // matchesAnyPattern checks if a line matches any of the given patterns
 func (f *OptimizedOutputFilter) matchesAnyPattern(line string, patterns []*config.RegexPattern) bool {
 	for _, pattern := range patterns {
@@ -121,6 +121,29 @@ func TestOutputFilter_Filter(t *testing.T) {
 	}
 }
 
+func TestOutputFilter_LiteralPatterns(t *testing.T) {
+	filter, err := NewOutputFilter(&FilterRules{
+		ErrorPatterns: []*config.RegexPattern{
+			{Pattern: "[FAIL]", Literal: true},
+			{Pattern: "*** error", Flags: "i", Literal: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewOutputFilter() error = %v", err)
+	}
+
+	result := filter.Filter("[FAIL] parser\nF build\n*** ERROR in main\nfine")
+	got := strings.Join(result.Lines, "\n")
+	if !result.HasErrors || !strings.Contains(got, "[FAIL] parser") || !strings.Contains(got, "*** ERROR in main") || strings.Contains(got, "F build") {
+		t.Errorf("expected only the lines containing the literal markers, got %q", result.Lines)
+	}
+
+	// The shared pattern set is keyed apart from the same regex pattern
+	if match := filter.lineMatcher([]*config.RegexPattern{{Pattern: "[FAIL]"}}); !match("F build") {
+		t.Error("expected the regex pattern to keep its meaning")
+	}
+}
+
 func TestOutputFilter_Operations(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -317,3 +340,48 @@ line 9`
 		})
 	}
 }
+
+func TestOutputFilter_BlockMode(t *testing.T) {
+	input := `running 3 tests
+ok   TestAlpha
+--- FAIL: TestBeta
+    beta_test.go:12: expected 2, got 3
+    beta_test.go:13: state was:
+        {count: 3}
+
+ok   TestGamma
+done`
+
+	rules := &FilterRules{
+		ErrorPatterns: []*config.RegexPattern{
+			{Pattern: "--- FAIL"},
+		},
+		BlockMode: true,
+	}
+	filter, err := NewOutputFilter(rules)
+	if err != nil {
+		t.Fatalf("NewOutputFilter() error = %v", err)
+	}
+
+	result := filter.Filter(input)
+
+	want := []string{
+		"--- FAIL: TestBeta",
+		"    beta_test.go:12: expected 2, got 3",
+		"    beta_test.go:13: state was:",
+		"        {count: 3}",
+	}
+	if strings.Join(result.Lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("block mode lines = %q, want %q", result.Lines, want)
+	}
+	if !result.HasErrors {
+		t.Error("expected block mode result to report errors")
+	}
+
+	// Without block mode only the matched line is captured
+	rules.BlockMode = false
+	result = filter.Filter(input)
+	if len(result.Lines) != 1 {
+		t.Errorf("without block mode got %d lines, want 1", len(result.Lines))
+	}
+}
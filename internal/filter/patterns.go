@@ -219,6 +219,7 @@ func (pv *PatternValidator) OptimizePattern(pattern *config.RegexPattern) (*conf
 	optimized := &config.RegexPattern{
 		Pattern: pattern.Pattern,
 		Flags:   pattern.Flags,
+		Literal: pattern.Literal,
 	}
 
 	// Check for common optimization opportunities
@@ -252,10 +253,7 @@ type PatternTestResult struct {
 // Private helper methods
 
 func (pc *PatternCache) getCacheKey(pattern *config.RegexPattern) string {
-	if pattern.Flags == "" {
-		return pattern.Pattern
-	}
-	return fmt.Sprintf("(?%s)%s", pattern.Flags, pattern.Pattern)
+	return pattern.Expression()
 }
 
 func (pc *PatternCache) recordHit() {
@@ -0,0 +1,102 @@
+package filter
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// PatternSetCache shares compiled pattern sets between identical pattern
+// slices, so components configured with the same patterns compile them once
+type PatternSetCache struct {
+	sets     map[string]*PatternSet
+	patterns *PatternCache
+	mu       sync.Mutex
+	stats    CacheStats
+}
+
+// sharedPatternSets is the process-wide pattern set cache used by the filters
+var sharedPatternSets = NewPatternSetCache()
+
+// NewPatternSetCache creates an empty pattern set cache
+func NewPatternSetCache() *PatternSetCache {
+	patterns, _ := NewPatternCache() //nolint:errcheck // NewPatternCache never fails
+	return &PatternSetCache{
+		sets:     make(map[string]*PatternSet),
+		patterns: patterns,
+	}
+}
+
+// SharedPatternSet returns the process-wide compiled set for patterns
+func SharedPatternSet(patterns []*config.RegexPattern) (*PatternSet, error) {
+	return sharedPatternSets.Get(patterns)
+}
+
+// Get returns the compiled set for patterns, compiling it on first use.
+// Slices with the same patterns and flags share one set regardless of identity.
+func (c *PatternSetCache) Get(patterns []*config.RegexPattern) (*PatternSet, error) {
+	key := c.setKey(patterns)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if set, exists := c.sets[key]; exists {
+		c.stats.Hits++
+		return set, nil
+	}
+
+	c.stats.Misses++
+	// Keep a private copy so later changes to the caller's slice cannot leak in
+	set, err := NewPatternSet(append([]*config.RegexPattern{}, patterns...), c.patterns)
+	if err != nil {
+		return nil, err
+	}
+	c.sets[key] = set
+	return set, nil
+}
+
+// Size returns the number of cached pattern sets
+func (c *PatternSetCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.sets)
+}
+
+// GetStats returns how often a cached set was reused or had to be compiled
+func (c *PatternSetCache) GetStats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{Hits: c.stats.Hits, Misses: c.stats.Misses}
+}
+
+// setKey builds a content key for a pattern slice
+func (c *PatternSetCache) setKey(patterns []*config.RegexPattern) string {
+	keys := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		if pattern != nil {
+			keys[i] = c.patterns.getCacheKey(pattern)
+		}
+	}
+	return strings.Join(keys, "\x00")
+}
+
+// lineMatcher returns a function reporting whether a line matches any of the
+// patterns. It uses the shared compiled set, falling back to per-pattern
+// matching when debugging or when a pattern fails to compile.
+func (f *OutputFilter) lineMatcher(patterns []*config.RegexPattern) func(string) bool {
+	if len(patterns) == 0 {
+		return func(string) bool { return false }
+	}
+	if !debug.IsEnabled() {
+		if set, err := SharedPatternSet(patterns); err == nil {
+			return set.MatchAny
+		}
+	}
+	return func(line string) bool {
+		return f.matchesAnyPattern(line, patterns)
+	}
+}
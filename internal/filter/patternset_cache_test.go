@@ -0,0 +1,95 @@
+//go:build unit
+
+package filter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// componentPatterns returns a fresh slice with the same content for each component
+func componentPatterns() []*config.RegexPattern {
+	return []*config.RegexPattern{
+		{Pattern: `error`, Flags: "i"},
+		{Pattern: `\d+ problems?`},
+	}
+}
+
+func TestPatternSetCache_CompilesOncePerContent(t *testing.T) {
+	cache := NewPatternSetCache()
+
+	const components = 25
+	var first *PatternSet
+	for i := 0; i < components; i++ {
+		set, err := cache.Get(componentPatterns())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if first == nil {
+			first = set
+		} else if set != first {
+			t.Fatalf("component %d got a different pattern set", i)
+		}
+	}
+
+	stats := cache.GetStats()
+	if stats.Misses != 1 || stats.Hits != components-1 {
+		t.Errorf("expected 1 compilation and %d reuses, got %d misses and %d hits", components-1, stats.Misses, stats.Hits)
+	}
+
+	// Different flags are different content
+	if _, err := cache.Get([]*config.RegexPattern{{Pattern: `error`}, {Pattern: `\d+ problems?`}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cache.Size() != 2 {
+		t.Errorf("expected 2 cached sets, got %d", cache.Size())
+	}
+}
+
+func TestPatternSetCache_InvalidPattern(t *testing.T) {
+	cache := NewPatternSetCache()
+	if _, err := cache.Get([]*config.RegexPattern{{Pattern: `[unclosed`}}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+	if cache.Size() != 0 {
+		t.Error("expected failed sets not to be cached")
+	}
+
+	// Filtering still works by skipping the invalid pattern
+	result := NewSimpleOutputFilter().FilterWithRules("an error here", &FilterRules{
+		ErrorPatterns: []*config.RegexPattern{{Pattern: `[unclosed`}, {Pattern: `error`}},
+	})
+	if !result.HasErrors {
+		t.Error("expected valid pattern to match despite the invalid one")
+	}
+}
+
+func TestFilterWithRules_SharesPatternSets(t *testing.T) {
+	// A pattern unique to this test so other tests do not affect the count
+	newRules := func() *FilterRules {
+		return &FilterRules{ErrorPatterns: []*config.RegexPattern{{Pattern: `shared-set-test-\d+`}}}
+	}
+
+	before := sharedPatternSets.Size()
+	for i := 0; i < 10; i++ {
+		result := NewSimpleOutputFilter().FilterWithRules(fmt.Sprintf("shared-set-test-%d", i), newRules())
+		if !result.HasErrors {
+			t.Fatalf("component %d: expected a match", i)
+		}
+	}
+	if added := sharedPatternSets.Size() - before; added != 1 {
+		t.Errorf("expected one shared set for 10 components, got %d", added)
+	}
+}
+
+// BenchmarkFilterSharedPatternSet filters output for many components that
+// share a pattern configuration
+func BenchmarkFilterSharedPatternSet(b *testing.B) {
+	output := "src/a.js:1:1 error no-undef\nok\n2 problems"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewSimpleOutputFilter().FilterWithRules(output, &FilterRules{ErrorPatterns: componentPatterns()})
+	}
+}
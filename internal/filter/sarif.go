@@ -0,0 +1,162 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// sarifLog is the part of a SARIF 2.1.0 log the SARIF filter reads
+type sarifLog struct {
+	Runs []struct {
+		Tool struct {
+			Driver struct {
+				Rules []struct {
+					ID                   string `json:"id"`
+					DefaultConfiguration struct {
+						Level string `json:"level"`
+					} `json:"defaultConfiguration"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []sarifResult `json:"results"`
+	} `json:"runs"`
+}
+
+// sarifResult is a single result of a SARIF run
+type sarifResult struct {
+	RuleID    string `json:"ruleId"`
+	RuleIndex *int   `json:"ruleIndex"`
+	Level     string `json:"level"`
+	Message   struct {
+		Text     string `json:"text"`
+		Markdown string `json:"markdown"`
+	} `json:"message"`
+	Locations []struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region struct {
+				StartLine   int `json:"startLine"`
+				StartColumn int `json:"startColumn"`
+			} `json:"region"`
+		} `json:"physicalLocation"`
+	} `json:"locations"`
+}
+
+// SARIFFilter is a Filter for tools reporting SARIF JSON. Each result is
+// reported as "file:line:col: ruleId: message", and the output has errors
+// when any result is at the error level.
+type SARIFFilter struct{}
+
+// NewSARIFFilter creates a SARIF filter
+func NewSARIFFilter() *SARIFFilter {
+	return &SARIFFilter{}
+}
+
+// Filter parses the SARIF log in output. Text around the log, such as
+// progress messages on stderr, is ignored. Only the MaxLines and MaxBytes
+// rules apply.
+func (f *SARIFFilter) Filter(output string, rules *FilterRules) (*FilteredOutput, error) {
+	if rules == nil {
+		return nil, fmt.Errorf("filter rules cannot be nil")
+	}
+
+	start := strings.Index(output, "{")
+	if start < 0 {
+		return nil, fmt.Errorf("invalid SARIF output: no JSON object found")
+	}
+	var log sarifLog
+	if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&log); err != nil {
+		return nil, fmt.Errorf("invalid SARIF output: %w", err)
+	}
+
+	result := &FilteredOutput{}
+	for _, run := range log.Runs {
+		for _, res := range run.Results {
+			level := res.Level
+			ruleID := res.RuleID
+			if res.RuleIndex != nil && *res.RuleIndex >= 0 && *res.RuleIndex < len(run.Tool.Driver.Rules) {
+				rule := run.Tool.Driver.Rules[*res.RuleIndex]
+				if ruleID == "" {
+					ruleID = rule.ID
+				}
+				if level == "" {
+					level = rule.DefaultConfiguration.Level
+				}
+			}
+			if level == "error" {
+				result.HasErrors = true
+			}
+			result.Lines = append(result.Lines, formatSARIFResult(res, ruleID))
+		}
+	}
+
+	result.TotalLines = len(result.Lines)
+	if rules.MaxLines > 0 && len(result.Lines) > rules.MaxLines {
+		result.Lines = result.Lines[:rules.MaxLines]
+		result.Truncated = true
+		result.TruncatedBy = TruncatedByLines
+	}
+	limitBytes(result, rules.MaxBytes)
+	return result, nil
+}
+
+// formatSARIFResult renders a result as "file:line:col: ruleId: message",
+// leaving out the parts the result does not have
+func formatSARIFResult(res sarifResult, ruleID string) string {
+	message := res.Message.Text
+	if message == "" {
+		message = res.Message.Markdown
+	}
+	message = strings.Join(strings.Fields(message), " ")
+
+	var parts []string
+	if len(res.Locations) > 0 {
+		location := res.Locations[0].PhysicalLocation
+		if file := sarifPath(location.ArtifactLocation.URI); file != "" {
+			parts = append(parts, file)
+			if location.Region.StartLine > 0 {
+				parts = append(parts, fmt.Sprint(location.Region.StartLine))
+				if location.Region.StartColumn > 0 {
+					parts = append(parts, fmt.Sprint(location.Region.StartColumn))
+				}
+			}
+		}
+	}
+
+	var segments []string
+	for _, segment := range []string{strings.Join(parts, ":"), ruleID, message} {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return strings.Join(segments, ": ")
+}
+
+// sarifPath turns an artifact URI into a file path, e.g.
+// "file:///work/src/a%20b.js" into "/work/src/a b.js"
+func sarifPath(uri string) string {
+	uri = strings.TrimPrefix(uri, "file://")
+	if path, err := url.PathUnescape(uri); err == nil {
+		return path
+	}
+	return uri
+}
+
+// ForCommand returns the filter for the output of cmdConfig: the SARIF filter
+// for SARIF output, otherwise fallback when error or include patterns are
+// configured. It returns nil when the output is reported unfiltered.
+func ForCommand(cmdConfig *config.CommandConfig, fallback Filter) Filter {
+	if cmdConfig.OutputFormat == config.OutputFormatSARIF {
+		return NewSARIFFilter()
+	}
+	if len(cmdConfig.DetectionPatterns()) == 0 && len(cmdConfig.IncludePatterns) == 0 {
+		return nil
+	}
+	return fallback
+}
@@ -0,0 +1,98 @@
+//go:build unit
+
+package filter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+const sarifOutput = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "eslint", "rules": [
+      {"id": "no-unused-vars", "defaultConfiguration": {"level": "warning"}},
+      {"id": "no-undef", "defaultConfiguration": {"level": "error"}}
+    ]}},
+    "results": [
+      {
+        "ruleId": "no-unused-vars", "ruleIndex": 0,
+        "message": {"text": "'x' is assigned a value\nbut never used."},
+        "locations": [{"physicalLocation": {
+          "artifactLocation": {"uri": "src/app.js"},
+          "region": {"startLine": 3, "startColumn": 7}
+        }}]
+      },
+      {
+        "ruleIndex": 1,
+        "message": {"text": "'y' is not defined."},
+        "locations": [{"physicalLocation": {
+          "artifactLocation": {"uri": "file:///work/src/my%20lib.js"},
+          "region": {"startLine": 10}
+        }}]
+      },
+      {"ruleId": "config", "level": "note", "message": {"text": "No files matched"}}
+    ]
+  }]
+}`
+
+func TestSARIFFilter(t *testing.T) {
+	result, err := NewSARIFFilter().Filter("Linting...\n"+sarifOutput+"\nDone", &FilterRules{})
+	if err != nil {
+		t.Fatalf("Filter() error = %v", err)
+	}
+
+	want := []string{
+		"src/app.js:3:7: no-unused-vars: 'x' is assigned a value but never used.",
+		"/work/src/my lib.js:10: no-undef: 'y' is not defined.",
+		"config: No files matched",
+	}
+	if !reflect.DeepEqual(result.Lines, want) {
+		t.Errorf("Lines = %q, want %q", result.Lines, want)
+	}
+	if !result.HasErrors {
+		t.Error("expected the rule's default error level to count as an error")
+	}
+}
+
+func TestSARIFFilter_ErrorLevel(t *testing.T) {
+	warnings := `{"runs": [{"results": [{"ruleId": "r", "level": "warning", "message": {"text": "m"}}]}]}`
+	result, err := NewSARIFFilter().Filter(warnings, &FilterRules{})
+	if err != nil {
+		t.Fatalf("Filter() error = %v", err)
+	}
+	if result.HasErrors {
+		t.Error("expected warnings alone not to be errors")
+	}
+
+	truncated, err := NewSARIFFilter().Filter(sarifOutput, &FilterRules{MaxLines: 1})
+	if err != nil {
+		t.Fatalf("Filter() error = %v", err)
+	}
+	if len(truncated.Lines) != 1 || !truncated.Truncated || truncated.TotalLines != 3 {
+		t.Errorf("expected the results to be truncated to one line, got %+v", truncated)
+	}
+
+	if _, err := NewSARIFFilter().Filter("Error: eslint crashed", &FilterRules{}); err == nil {
+		t.Error("expected output without SARIF to be rejected")
+	}
+}
+
+func TestForCommand(t *testing.T) {
+	fallback := NewRegexFilter()
+
+	if f := ForCommand(&config.CommandConfig{Command: "eslint", OutputFormat: config.OutputFormatSARIF}, fallback); f == nil {
+		t.Error("expected a SARIF filter without error patterns")
+	} else if _, ok := f.(*SARIFFilter); !ok {
+		t.Errorf("expected the SARIF filter, got %T", f)
+	}
+	if f := ForCommand(&config.CommandConfig{Command: "eslint"}, fallback); f != nil {
+		t.Errorf("expected no filter without patterns, got %T", f)
+	}
+	cmd := &config.CommandConfig{Command: "eslint", ErrorPatterns: []*config.RegexPattern{{Pattern: "error"}}}
+	if f := ForCommand(cmd, fallback); f != fallback {
+		t.Errorf("expected the fallback filter, got %T", f)
+	}
+}
@@ -0,0 +1,58 @@
+package filter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// PatternStat is how one pattern fared against a command's output
+type PatternStat struct {
+	Pattern string
+	// Matches is the number of lines the pattern matched
+	Matches int
+	// Duration is the time spent matching the pattern against every line
+	Duration time.Duration
+}
+
+// PatternStats matches each pattern against every line of output, unlike
+// filtering, which stops at the first pattern matching a line. It also
+// returns the number of lines scanned. Patterns that fail to compile are
+// left out.
+func PatternStats(output string, patterns []*config.RegexPattern) ([]PatternStat, int) {
+	lines := strings.Split(output, "\n")
+	stats := make([]PatternStat, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := pattern.Compile()
+		if err != nil {
+			continue
+		}
+		stat := PatternStat{Pattern: pattern.Pattern}
+		start := time.Now()
+		for _, line := range lines {
+			if re.MatchString(line) {
+				stat.Matches++
+			}
+		}
+		stat.Duration = time.Since(start)
+		stats = append(stats, stat)
+	}
+	return stats, len(lines)
+}
+
+// LogPatternStats logs the statistics of the error and context patterns of
+// rules against a command's output to log and to the global debug log,
+// when debugging. This scans the output again, once per pattern.
+func LogPatternStats(log *debug.Logger, command, output string, rules *FilterRules) {
+	if log == nil && !debug.IsEnabled() {
+		return
+	}
+	for _, patterns := range [][]*config.RegexPattern{rules.ErrorPatterns, rules.ContextPatterns} {
+		stats, totalLines := PatternStats(output, patterns)
+		for _, stat := range stats {
+			log.LogPatternStats(command, stat.Pattern, stat.Matches, totalLines, stat.Duration)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+//go:build unit
+
+package filter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestPatternStats(t *testing.T) {
+	output := "src/a.ts:1: error TS2322\nsrc/b.ts:4: Error TS2345\nFound 2 errors"
+	stats, totalLines := PatternStats(output, []*config.RegexPattern{
+		{Pattern: "error"},
+		{Pattern: "[invalid"},
+		{Pattern: "error", Flags: "i"},
+	})
+
+	if totalLines != 3 {
+		t.Errorf("expected 3 lines scanned, got %d", totalLines)
+	}
+	if len(stats) != 2 || stats[0].Matches != 2 || stats[1].Matches != 3 {
+		t.Errorf("expected every matching line counted per pattern, got %+v", stats)
+	}
+}
+
+func TestLogPatternStats(t *testing.T) {
+	var buf bytes.Buffer
+	log := debug.NewLogger(&buf)
+	LogPatternStats(log, "tsc", "a: error\nb: warning", &FilterRules{
+		ErrorPatterns:   []*config.RegexPattern{{Pattern: "error"}},
+		ContextPatterns: []*config.RegexPattern{{Pattern: "warning"}},
+	})
+
+	for _, want := range []string{`tsc "error" matched 1 of 2 lines`, `tsc "warning" matched 1 of 2 lines`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in the log, got:\n%s", want, buf.String())
+		}
+	}
+}
@@ -2,12 +2,17 @@
 package hook
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
 )
 
+// InputEnvVar is the environment variable holding the hook input JSON when
+// it is not piped on stdin
+const InputEnvVar = "CLAUDE_HOOK_INPUT"
+
 // HookInput represents the JSON input from Claude Code hooks
 type HookInput struct {
 	// Session ID for the Claude Code session
@@ -78,6 +83,26 @@ func (p *Parser) Parse(r io.Reader) (*HookInput, error) {
 	return &input, nil
 }
 
+// ReadInput parses hook input piped on stdin, falling back to the JSON in
+// fallback, e.g. the InputEnvVar value, when stdin is nil or empty. It returns
+// nil input when neither holds any.
+func (p *Parser) ReadInput(stdin io.Reader, fallback string) (*HookInput, error) {
+	if stdin != nil {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read hook input: %w", err)
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			return p.ParseJSON(data)
+		}
+	}
+
+	if strings.TrimSpace(fallback) == "" {
+		return nil, nil
+	}
+	return p.ParseJSON([]byte(fallback))
+}
+
 // ParseJSON parses JSON input from a byte slice
 func (p *Parser) ParseJSON(data []byte) (*HookInput, error) {
 	var input HookInput
@@ -528,3 +528,54 @@ func equalStringSlices(a, b []string) bool {
 	}
 	return true
 }
+
+func TestParser_ReadInput(t *testing.T) {
+	stdinInput := `{"session_id": "stdin", "cwd": "/project", "hook_event_name": "PostToolUse"}`
+	envInput := `{"session_id": "env", "cwd": "/project", "hook_event_name": "PostToolUse"}`
+
+	tests := []struct {
+		name        string
+		stdin       *strings.Reader
+		fallback    string
+		wantSession string
+		wantErr     bool
+	}{
+		{name: "stdin", stdin: strings.NewReader(stdinInput), fallback: envInput, wantSession: "stdin"},
+		{name: "empty stdin falls back", stdin: strings.NewReader(" \n"), fallback: envInput, wantSession: "env"},
+		{name: "no stdin falls back", fallback: envInput, wantSession: "env"},
+		{name: "no input", stdin: strings.NewReader("")},
+		{name: "invalid stdin", stdin: strings.NewReader("{not json"), fallback: envInput, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser()
+			var input *HookInput
+			var err error
+			if tt.stdin != nil {
+				input, err = parser.ReadInput(tt.stdin, tt.fallback)
+			} else {
+				input, err = parser.ReadInput(nil, tt.fallback)
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadInput() error = %v", err)
+			}
+			if tt.wantSession == "" {
+				if input != nil {
+					t.Errorf("expected no input, got %+v", input)
+				}
+				return
+			}
+			if input == nil || input.SessionID != tt.wantSession {
+				t.Errorf("expected session %q, got %+v", tt.wantSession, input)
+			}
+		})
+	}
+}
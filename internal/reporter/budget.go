@@ -0,0 +1,45 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetMaxTotalOutputBytes caps the combined output of text reports across all
+// components, suppressing the output of later components once the budget is
+// used up. Zero disables the cap. JSON reports are not capped.
+func (r *ErrorReporter) SetMaxTotalOutputBytes(maxBytes int) {
+	r.maxTotalOutputBytes = maxBytes
+}
+
+// limitTotalOutput trims output to maxBytes at a line boundary and notes the
+// suppressed remainder. Output within the budget is returned unchanged.
+func limitTotalOutput(output string, maxBytes int) string {
+	if maxBytes <= 0 || len(output) <= maxBytes {
+		return output
+	}
+	return trimOutput(output, maxBytes, maxBytes)
+}
+
+// trimOutput cuts output to the budget left under the total output limit at
+// a line boundary, followed by a note on the suppressed remainder
+func trimOutput(output string, budget, maxBytes int) string {
+	kept := output[:budget]
+	if cut := strings.LastIndex(kept, "\n"); cut >= 0 {
+		kept = kept[:cut]
+	} else {
+		kept = ""
+	}
+	kept = strings.TrimRight(kept, "\n")
+
+	note := suppressedOutputNote(len(output)-len(kept), maxBytes)
+	if kept == "" {
+		return note
+	}
+	return kept + "\n\n" + note
+}
+
+// suppressedOutputNote tells the reader that output was left out of a report
+func suppressedOutputNote(suppressed, maxBytes int) string {
+	return fmt.Sprintf("[Further output suppressed - %d bytes over the %d byte total output limit]", suppressed, maxBytes)
+}
@@ -0,0 +1,134 @@
+//go:build unit
+
+package reporter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// failingComponents returns n failing lint components with a few error lines each
+func failingComponents(n int) []executor.ComponentExecResult {
+	results := make([]executor.ComponentExecResult, 0, n)
+	for i := 0; i < n; i++ {
+		results = append(results, executor.ComponentExecResult{
+			Path:    fmt.Sprintf("packages/pkg%02d", i),
+			Command: "lint",
+			ExecResult: &executor.ExecResult{
+				ExitCode: 1,
+				Stderr:   fmt.Sprintf("pkg%02d/a.js:1:1: error one\npkg%02d/a.js:2:1: error two\n", i, i),
+			},
+		})
+	}
+	return results
+}
+
+func TestReport_MaxTotalOutputBytes(t *testing.T) {
+	const maxBytes = 500
+	results := failingComponents(50)
+
+	uncapped := NewErrorReporter().Report(results)
+	if len(uncapped.Stderr) <= maxBytes {
+		t.Fatalf("test output too small: %d bytes", len(uncapped.Stderr))
+	}
+
+	r := NewErrorReporter()
+	r.SetMaxTotalOutputBytes(maxBytes)
+	result := r.Report(results)
+
+	if result.ExitCode != 2 {
+		t.Errorf("expected exit code 2, got %d", result.ExitCode)
+	}
+	note := suppressedOutputNote(len(uncapped.Stderr), maxBytes)
+	if len(result.Stderr) > maxBytes+len(note)+2 {
+		t.Errorf("expected at most %d bytes plus the note, got %d", maxBytes, len(result.Stderr))
+	}
+	if !strings.Contains(result.Stderr, "[Further output suppressed") {
+		t.Errorf("expected a suppression note, got:\n%s", result.Stderr)
+	}
+	if !strings.HasPrefix(result.Stderr, uncapped.Stderr[:strings.Index(uncapped.Stderr, "\n")]) {
+		t.Errorf("expected the start of the report to be kept, got:\n%s", result.Stderr)
+	}
+	if strings.Contains(result.Stderr, "pkg49") {
+		t.Errorf("expected the last component to be suppressed, got:\n%s", result.Stderr)
+	}
+}
+
+func TestReport_MaxTotalOutputBytesNotReached(t *testing.T) {
+	results := failingComponents(2)
+	uncapped := NewErrorReporter().Report(results)
+
+	r := NewErrorReporter()
+	r.SetMaxTotalOutputBytes(len(uncapped.Stderr))
+	if got := r.Report(results).Stderr; got != uncapped.Stderr {
+		t.Errorf("expected output within the budget unchanged, got:\n%s", got)
+	}
+}
+
+func TestReport_MaxTotalOutputBytesIgnoresJSON(t *testing.T) {
+	r := NewErrorReporter()
+	if err := r.SetFormat(FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	r.SetMaxTotalOutputBytes(100)
+
+	result := r.Report(failingComponents(50))
+	if strings.Contains(result.Stdout+result.Stderr, "[Further output suppressed") {
+		t.Error("expected JSON reports not to be capped")
+	}
+}
+
+func TestStreamReporter_MaxTotalOutputBytes(t *testing.T) {
+	const maxBytes = 400
+	r := NewErrorReporter()
+	r.SetMaxTotalOutputBytes(maxBytes)
+
+	var out bytes.Buffer
+	stream := NewStreamReporter(r, &out)
+	for _, result := range failingComponents(50) {
+		stream.Add(result)
+	}
+
+	written := out.String()
+	if got := strings.Count(written, "[Further output suppressed"); got != 1 {
+		t.Errorf("expected a single suppression note, got %d:\n%s", got, written)
+	}
+	if len(written) > 2*maxBytes {
+		t.Errorf("expected the streamed output to stay near %d bytes, got %d", maxBytes, len(written))
+	}
+	if !strings.Contains(written, "packages/pkg00") {
+		t.Errorf("expected the first component to be reported, got:\n%s", written)
+	}
+	if strings.Contains(written, "packages/pkg49") {
+		t.Errorf("expected later components to be suppressed, got:\n%s", written)
+	}
+	if stream.Finish().ExitCode != 2 {
+		t.Error("expected suppressed components to still fail the run")
+	}
+}
+
+func TestLimitTotalOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		maxBytes int
+		want     string
+	}{
+		{"unlimited", "a\nb\nc", 0, "a\nb\nc"},
+		{"within budget", "a\nb\nc", 5, "a\nb\nc"},
+		{"cut at line boundary", "aaa\nbbb\nccc", 9, "aaa\nbbb\n\n" + suppressedOutputNote(4, 9)},
+		{"single long line", "aaaaaaaaaa", 5, suppressedOutputNote(10, 5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limitTotalOutput(tt.output, tt.maxBytes); got != tt.want {
+				t.Errorf("limitTotalOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package reporter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// redactedValue replaces sensitive values in reported command lines
+const redactedValue = "***"
+
+// sensitiveNamePattern matches flag and variable names that usually carry secrets
+var sensitiveNamePattern = regexp.MustCompile(`(?i)(token|secret|passw(or)?d|pwd|api[-_]?key|auth|credential|private[-_]?key)`)
+
+// urlCredentialsPattern matches the user:password part of a URL
+var urlCredentialsPattern = regexp.MustCompile(`://[^/@\s]+@`)
+
+// safeArgPattern matches arguments that need no shell quoting
+var safeArgPattern = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// commandLine returns the redacted, shell-quoted command line behind an
+// execution error. The executor's resolved arguments are preferred; the
+// configured ones are used when the error carries none.
+func commandLine(result executor.ComponentExecResult, execErr *executor.ExecError) string {
+	command, args := "", []string(nil)
+	switch {
+	case execErr != nil && execErr.Args != nil:
+		command, args = execErr.Command, execErr.Args
+	case result.CommandConfig != nil:
+		command, args = result.CommandConfig.Command, result.CommandConfig.Args
+	case execErr != nil && execErr.Command != result.Command:
+		command = execErr.Command
+	}
+	if command == "" {
+		return ""
+	}
+
+	return strings.Join(displayArgs(append([]string{command}, args...)), " ")
+}
+
+// displayArgs shell-quotes arguments for display, hiding the values of
+// sensitive flags and variables and any credentials embedded in URLs
+func displayArgs(args []string) []string {
+	words := make([]string, len(args))
+	hideNext := false
+	for i, arg := range args {
+		word := quoteArg(arg)
+		switch {
+		case hideNext:
+			word = redactedValue
+			hideNext = false
+		case strings.Contains(arg, "="):
+			name, _, _ := strings.Cut(arg, "=")
+			if sensitiveNamePattern.MatchString(name) {
+				word = quoteArg(name) + "=" + redactedValue
+			}
+		case strings.HasPrefix(arg, "-") && sensitiveNamePattern.MatchString(arg):
+			// Flag whose value is the next argument, e.g. --token abc
+			hideNext = true
+		}
+		words[i] = urlCredentialsPattern.ReplaceAllString(word, "://"+redactedValue+"@")
+	}
+	return words
+}
+
+// quoteArg quotes an argument for display as a POSIX shell word
+func quoteArg(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+	if safeArgPattern.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
@@ -0,0 +1,92 @@
+//go:build unit
+
+package reporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestReport_CommandNotFoundShowsCommandLine(t *testing.T) {
+	cmdConfig := &config.CommandConfig{
+		Command: "qualhook-missing-tool",
+		Args:    []string{"--max-warnings", "0", "src dir"},
+	}
+	args := append(append([]string{}, cmdConfig.Args...), "--api-key", "s3cret")
+
+	execResult, err := executor.NewCommandExecutor(time.Second).Execute(cmdConfig.Command, args, executor.ExecOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	results := []executor.ComponentExecResult{
+		{Command: "lint", CommandConfig: cmdConfig, ExecResult: execResult},
+	}
+	r := NewErrorReporter()
+
+	report := r.Report(results)
+	if report.ExitCode != 1 {
+		t.Errorf("expected exit code 1, got %d", report.ExitCode)
+	}
+	want := "Command line: qualhook-missing-tool --max-warnings 0 'src dir' --api-key ***"
+	if !strings.Contains(report.Stderr, want) {
+		t.Errorf("expected %q in report, got:\n%s", want, report.Stderr)
+	}
+	if !strings.Contains(report.Stderr, "Error: Command not found") {
+		t.Errorf("expected command not found error, got:\n%s", report.Stderr)
+	}
+	if strings.Contains(report.Stderr, "s3cret") {
+		t.Error("expected secret to be redacted")
+	}
+
+	component := r.ReportJSON(results).Components[0]
+	if component.CommandLine != "qualhook-missing-tool --max-warnings 0 'src dir' --api-key ***" {
+		t.Errorf("unexpected JSON command line: %q", component.CommandLine)
+	}
+	if component.ExecutionError == "" {
+		t.Error("expected JSON execution error")
+	}
+}
+
+func TestDisplayArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"plain", []string{"eslint", "."}, []string{"eslint", "."}},
+		{"quoted", []string{"a b", "--secret=x y"}, []string{"'a b'", "--secret=***"}},
+		{"flag with equals", []string{"--token=abc"}, []string{"--token=***"}},
+		{"flag with separate value", []string{"--password", "abc", "."}, []string{"--password", "***", "."}},
+		{"environment style", []string{"GITHUB_TOKEN=abc"}, []string{"GITHUB_TOKEN=***"}},
+		{"url credentials", []string{"https://user:pw@example.com/repo"}, []string{"https://***@example.com/repo"}},
+		{"non-sensitive equals", []string{"--max-warnings=0"}, []string{"--max-warnings=0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := displayArgs(tt.args)
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("displayArgs(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteArg(t *testing.T) {
+	tests := map[string]string{
+		"":           "''",
+		"src/app.js": "src/app.js",
+		"a b":        "'a b'",
+		"it's":       `'it'\''s'`,
+	}
+	for arg, want := range tests {
+		if got := quoteArg(arg); got != want {
+			t.Errorf("quoteArg(%q) = %q, want %q", arg, got, want)
+		}
+	}
+}
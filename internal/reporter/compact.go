@@ -0,0 +1,154 @@
+package reporter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// Output formats supported by the reporter
+const (
+	// FormatDefault groups errors by command under an LLM prompt
+	FormatDefault = "default"
+	// FormatCompact prints one line per error across all components
+	FormatCompact = "compact"
+)
+
+// locationPattern matches "file:line[:col]" and "file(line[,col])" prefixes
+// such as "src/app.js:10:5: Missing semicolon" or "src/a.ts(3,7): error TS2304".
+var locationPattern = regexp.MustCompile(`^\s*((?:[A-Za-z]:)?[^\s:()]+)(?::(\d+)(?::(\d+))?|\((\d+)(?:,(\d+))?\)):?\s*(.*)$`)
+
+// ErrorLocation is a source location extracted from an error line
+type ErrorLocation struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String formats the location as file:line[:col]
+func (l ErrorLocation) String() string {
+	if l.File == "" {
+		return ""
+	}
+	if l.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
+	}
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// ExtractLocation parses a source location from the start of an error line.
+// It returns the location, the remaining message and whether a location was found.
+func ExtractLocation(line string) (ErrorLocation, string, bool) {
+	m := locationPattern.FindStringSubmatch(line)
+	if m == nil {
+		return ErrorLocation{}, strings.TrimSpace(line), false
+	}
+
+	lineStr, colStr := m[2], m[3]
+	if lineStr == "" {
+		lineStr, colStr = m[4], m[5]
+	}
+
+	loc := ErrorLocation{File: m[1]}
+	loc.Line, _ = strconv.Atoi(lineStr) //nolint:errcheck // Regex guarantees digits
+	if colStr != "" {
+		loc.Column, _ = strconv.Atoi(colStr) //nolint:errcheck // Regex guarantees digits
+	}
+
+	return loc, strings.TrimSpace(m[6]), true
+}
+
+// errorEntry is a single reported error line of a component
+type errorEntry struct {
+	component string
+	command   string
+	location  ErrorLocation
+	message   string
+	text      string
+}
+
+// errorEntries extracts the individual errors reported by a failing component.
+// Lines without a location are usually context; they are only kept when
+// nothing in the component could be located so errors are never lost. A
+// component without output reports that it failed silently.
+func errorEntries(component executor.ComponentExecResult) []errorEntry {
+	name := componentName(component)
+
+	var located, unlocated []errorEntry
+	for _, line := range reportedLines(component) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		loc, message, ok := ExtractLocation(line)
+		entry := errorEntry{component: name, command: component.Command, location: loc, message: message, text: line}
+		if ok {
+			located = append(located, entry)
+		} else {
+			unlocated = append(unlocated, entry)
+		}
+	}
+
+	if len(located) > 0 {
+		return located
+	}
+	if len(unlocated) == 0 {
+		message := silentFailureMessage(component)
+		return []errorEntry{{component: name, command: component.Command, message: message, text: message}}
+	}
+	return unlocated
+}
+
+// formatCompact flattens errors into one line per error, sorted by component then file
+func (r *ErrorReporter) formatCompact(errorComponents []executor.ComponentExecResult) string {
+	var entries []errorEntry
+	for _, component := range errorComponents {
+		entries = append(entries, errorEntries(component)...)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.component != b.component {
+			return a.component < b.component
+		}
+		if a.location.File != b.location.File {
+			return a.location.File < b.location.File
+		}
+		if a.location.Line != b.location.Line {
+			return a.location.Line < b.location.Line
+		}
+		return a.location.Column < b.location.Column
+	})
+
+	lines := make([]string, 0, len(entries))
+	for _, e := range entries {
+		parts := []string{e.component, e.command}
+		if loc := e.location.String(); loc != "" {
+			parts = append(parts, loc)
+		}
+		if e.message != "" {
+			parts = append(parts, e.message)
+		}
+		lines = append(lines, strings.Join(parts, " "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// reportedLines returns the output lines that would be reported for a component
+func reportedLines(component executor.ComponentExecResult) []string {
+	if component.FilteredOutput != nil && len(component.FilteredOutput.Lines) > 0 {
+		return component.FilteredOutput.Lines
+	}
+	if component.ExecResult == nil {
+		return nil
+	}
+	// Fallback to raw output if no filtering applied
+	if component.ExecResult.Stderr != "" {
+		return strings.Split(component.ExecResult.Stderr, "\n")
+	}
+	return strings.Split(component.ExecResult.Stdout, "\n")
+}
@@ -0,0 +1,122 @@
+//go:build unit
+
+package reporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestExtractLocation(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    ErrorLocation
+		message string
+		found   bool
+	}{
+		{"src/app.js:10:5: Missing semicolon", ErrorLocation{"src/app.js", 10, 5}, "Missing semicolon", true},
+		{"main.go:12: undefined: x", ErrorLocation{"main.go", 12, 0}, "undefined: x", true},
+		{"src/a.ts(3,7): error TS2304: Cannot find name 'foo'.", ErrorLocation{"src/a.ts", 3, 7}, "error TS2304: Cannot find name 'foo'.", true},
+		{"Found 2 errors", ErrorLocation{}, "Found 2 errors", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			loc, message, found := ExtractLocation(tt.line)
+			if found != tt.found {
+				t.Fatalf("found = %v, want %v", found, tt.found)
+			}
+			if loc != tt.want {
+				t.Errorf("location = %+v, want %+v", loc, tt.want)
+			}
+			if message != tt.message {
+				t.Errorf("message = %q, want %q", message, tt.message)
+			}
+		})
+	}
+}
+
+func TestReport_CompactFormat(t *testing.T) {
+	reporter := NewErrorReporter()
+	if err := reporter.SetFormat(FormatCompact); err != nil {
+		t.Fatalf("SetFormat failed: %v", err)
+	}
+
+	lintConfig := &config.CommandConfig{ExitCodes: []int{1}}
+	results := []executor.ComponentExecResult{
+		{
+			Path:          "packages/frontend/**",
+			Command:       "lint",
+			ExecResult:    &executor.ExecResult{ExitCode: 1},
+			CommandConfig: lintConfig,
+			FilteredOutput: &filter.FilteredOutput{
+				Lines: []string{
+					"src/utils.js:3:1: Unexpected console statement",
+					"src/app.js:10:5: Missing semicolon",
+					"  context line without a location",
+				},
+				HasErrors: true,
+			},
+		},
+		{
+			Path:          "packages/backend/**",
+			Command:       "typecheck",
+			ExecResult:    &executor.ExecResult{ExitCode: 1},
+			CommandConfig: lintConfig,
+			FilteredOutput: &filter.FilteredOutput{
+				Lines:     []string{"src/server.ts(7,2): error TS2304: Cannot find name 'req'."},
+				HasErrors: true,
+			},
+		},
+	}
+
+	report := reporter.Report(results)
+	if report.ExitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d", report.ExitCode)
+	}
+
+	want := []string{
+		"packages/backend typecheck src/server.ts:7:2 error TS2304: Cannot find name 'req'.",
+		"packages/frontend lint src/app.js:10:5 Missing semicolon",
+		"packages/frontend lint src/utils.js:3:1 Unexpected console statement",
+	}
+	got := strings.Split(report.Stderr, "\n")
+	if len(got) != len(want) {
+		t.Fatalf("expected %d compact lines, got %d:\n%s", len(want), len(got), report.Stderr)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestReport_CompactFormatWithoutLocations(t *testing.T) {
+	reporter := NewErrorReporter()
+	if err := reporter.SetFormat(FormatCompact); err != nil {
+		t.Fatalf("SetFormat failed: %v", err)
+	}
+
+	report := reporter.Report([]executor.ComponentExecResult{
+		{
+			Path:       ".",
+			Command:    "test",
+			ExecResult: &executor.ExecResult{ExitCode: 1, Stderr: "FAIL TestSomething\n"},
+		},
+	})
+
+	if report.Stderr != ". test FAIL TestSomething" {
+		t.Errorf("unexpected compact output: %q", report.Stderr)
+	}
+}
+
+func TestSetFormat_Invalid(t *testing.T) {
+	reporter := NewErrorReporter()
+	if err := reporter.SetFormat("xml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
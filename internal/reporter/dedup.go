@@ -0,0 +1,131 @@
+package reporter
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+)
+
+// dedupEntry tracks the first occurrence of an error line and who reported it
+type dedupEntry struct {
+	component int
+	line      int
+	reporters []string
+}
+
+// dedupAcrossComponents collapses identical located error lines reported by
+// several components of the same command into a single entry on the first
+// component, annotated with every component that reported it. Relative
+// files are relative to the directory of their component, so the same
+// relative path from two components is two files. Lines without a
+// file:line location are never merged.
+func dedupAcrossComponents(components []executor.ComponentExecResult) []executor.ComponentExecResult {
+	lines := make([][]string, len(components))
+	dropped := make([]map[int]bool, len(components))
+	seen := make(map[string]*dedupEntry)
+	var entries []*dedupEntry
+
+	for ci, component := range components {
+		lines[ci] = reportedLines(component)
+		dropped[ci] = make(map[int]bool)
+		name := componentName(component)
+
+		for li, line := range lines[ci] {
+			loc, message, ok := ExtractLocation(line)
+			if !ok {
+				continue
+			}
+
+			loc.File = componentFile(component, loc.File)
+			key := component.Command + "\x00" + loc.String() + "\x00" + message
+			if entry, exists := seen[key]; exists {
+				if entry.component != ci {
+					entry.reporters = append(entry.reporters, name)
+				}
+				dropped[ci][li] = true
+				continue
+			}
+
+			entry := &dedupEntry{component: ci, line: li, reporters: []string{name}}
+			seen[key] = entry
+			entries = append(entries, entry)
+		}
+	}
+
+	// Annotate errors that several components reported
+	annotations := make([]map[int]string, len(components))
+	for _, entry := range entries {
+		if len(entry.reporters) < 2 {
+			continue
+		}
+		if annotations[entry.component] == nil {
+			annotations[entry.component] = make(map[int]string)
+		}
+		annotations[entry.component][entry.line] = fmt.Sprintf(" [reported by: %s]", strings.Join(entry.reporters, ", "))
+	}
+
+	result := make([]executor.ComponentExecResult, 0, len(components))
+	for ci, component := range components {
+		if len(dropped[ci]) == 0 && len(annotations[ci]) == 0 {
+			result = append(result, component)
+			continue
+		}
+
+		var kept []string
+		hasContent := false
+		for li, line := range lines[ci] {
+			if dropped[ci][li] {
+				continue
+			}
+			kept = append(kept, line+annotations[ci][li])
+			if strings.TrimSpace(line) != "" {
+				hasContent = true
+			}
+		}
+
+		// Every error of this component is already reported by another one
+		if !hasContent {
+			continue
+		}
+
+		deduped := component
+		deduped.FilteredOutput = &filter.FilteredOutput{
+			Lines:     kept,
+			HasErrors: true,
+		}
+		if component.FilteredOutput != nil {
+			deduped.FilteredOutput.Truncated = component.FilteredOutput.Truncated
+			deduped.FilteredOutput.TotalLines = component.FilteredOutput.TotalLines
+			deduped.FilteredOutput.TruncatedBy = component.FilteredOutput.TruncatedBy
+		}
+		result = append(result, deduped)
+	}
+
+	return result
+}
+
+// componentFile returns a file reported by a component as a path from the
+// directory qualhook runs in: relative files are joined to the directory the
+// component's command ran in
+func componentFile(component executor.ComponentExecResult, file string) string {
+	if filepath.IsAbs(file) {
+		return filepath.Clean(file)
+	}
+	dir := componentName(component)
+	if component.CommandConfig != nil && component.CommandConfig.WorkingDir != "" {
+		dir = component.CommandConfig.WorkingDir
+	}
+	return filepath.Join(dir, file)
+}
+
+// componentName returns the display name of a component
+func componentName(component executor.ComponentExecResult) string {
+	name := strings.TrimSuffix(component.Path, "/**")
+	if name == "" {
+		name = "."
+	}
+	return name
+}
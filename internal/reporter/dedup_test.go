@@ -0,0 +1,101 @@
+//go:build unit
+
+package reporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestReport_DedupAcrossComponents(t *testing.T) {
+	// Both components run one directory below the shared code
+	shared := "../shared/utils.ts:4:10: 'x' is declared but never used"
+	newResults := func() []executor.ComponentExecResult {
+		cmdConfig := &config.CommandConfig{ExitCodes: []int{1}}
+		return []executor.ComponentExecResult{
+			{
+				Path:          "frontend/**",
+				Command:       "lint",
+				ExecResult:    &executor.ExecResult{ExitCode: 1},
+				CommandConfig: cmdConfig,
+				FilteredOutput: &filter.FilteredOutput{
+					Lines:     []string{shared, "src/app.ts:1:1: Missing semicolon"},
+					HasErrors: true,
+				},
+			},
+			{
+				Path:          "backend/**",
+				Command:       "lint",
+				ExecResult:    &executor.ExecResult{ExitCode: 1},
+				CommandConfig: cmdConfig,
+				FilteredOutput: &filter.FilteredOutput{
+					Lines:     []string{shared},
+					HasErrors: true,
+				},
+			},
+		}
+	}
+
+	t.Run("without dedup the error appears twice", func(t *testing.T) {
+		report := NewErrorReporter().Report(newResults())
+		if got := strings.Count(report.Stderr, shared); got != 2 {
+			t.Errorf("expected shared error twice, got %d:\n%s", got, report.Stderr)
+		}
+	})
+
+	t.Run("with dedup the error appears once", func(t *testing.T) {
+		reporter := NewErrorReporter()
+		reporter.SetDedup(true)
+		report := reporter.Report(newResults())
+
+		if report.ExitCode != 2 {
+			t.Fatalf("expected exit code 2, got %d", report.ExitCode)
+		}
+		if got := strings.Count(report.Stderr, shared); got != 1 {
+			t.Errorf("expected shared error once, got %d:\n%s", got, report.Stderr)
+		}
+		if !strings.Contains(report.Stderr, shared+" [reported by: frontend, backend]") {
+			t.Errorf("expected reporters annotation, got:\n%s", report.Stderr)
+		}
+		if !strings.Contains(report.Stderr, "src/app.ts:1:1: Missing semicolon") {
+			t.Errorf("expected unique error to be kept, got:\n%s", report.Stderr)
+		}
+		if strings.Contains(report.Stderr, "## backend/**") {
+			t.Errorf("expected backend component with only duplicates to be dropped, got:\n%s", report.Stderr)
+		}
+	})
+
+	t.Run("same relative path in different components is not merged", func(t *testing.T) {
+		results := newResults()
+		results[1].FilteredOutput = &filter.FilteredOutput{
+			Lines:     []string{"src/app.ts:1:1: Missing semicolon"},
+			HasErrors: true,
+		}
+		reporter := NewErrorReporter()
+		reporter.SetDedup(true)
+		report := reporter.Report(results)
+
+		if got := strings.Count(report.Stderr, "src/app.ts:1:1: Missing semicolon"); got != 2 {
+			t.Errorf("expected the error of each component, got %d:\n%s", got, report.Stderr)
+		}
+		if strings.Contains(report.Stderr, "src/app.ts:1:1: Missing semicolon [reported by") {
+			t.Errorf("expected the errors not to be merged, got:\n%s", report.Stderr)
+		}
+	})
+
+	t.Run("different commands are not merged", func(t *testing.T) {
+		results := newResults()
+		results[1].Command = "typecheck"
+		reporter := NewErrorReporter()
+		reporter.SetDedup(true)
+		report := reporter.Report(results)
+
+		if got := strings.Count(report.Stderr, shared); got != 2 {
+			t.Errorf("expected shared error under both commands, got %d:\n%s", got, report.Stderr)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package reporter
+
+import "sort"
+
+// DiffEntry is an error referenced by a report diff
+type DiffEntry struct {
+	Component   string
+	Command     string
+	Text        string
+	Fingerprint string
+}
+
+// ReportDiff classifies errors between two reports by fingerprint
+type ReportDiff struct {
+	// New errors appear only in the newer report
+	New []DiffEntry
+	// Fixed errors appear only in the older report
+	Fixed []DiffEntry
+	// Persisting errors appear in both reports (as found in the newer one)
+	Persisting []DiffEntry
+}
+
+// DiffReports compares two JSON reports and classifies their errors
+func DiffReports(oldReport, newReport *JSONReport) *ReportDiff {
+	oldErrors := indexErrors(oldReport)
+	newErrors := indexErrors(newReport)
+
+	diff := &ReportDiff{}
+	for fp, entry := range newErrors {
+		if _, ok := oldErrors[fp]; ok {
+			diff.Persisting = append(diff.Persisting, entry)
+		} else {
+			diff.New = append(diff.New, entry)
+		}
+	}
+	for fp, entry := range oldErrors {
+		if _, ok := newErrors[fp]; !ok {
+			diff.Fixed = append(diff.Fixed, entry)
+		}
+	}
+
+	sortDiffEntries(diff.New)
+	sortDiffEntries(diff.Fixed)
+	sortDiffEntries(diff.Persisting)
+	return diff
+}
+
+// indexErrors maps fingerprints to errors; repeated fingerprints keep the first occurrence
+func indexErrors(report *JSONReport) map[string]DiffEntry {
+	index := make(map[string]DiffEntry)
+	if report == nil {
+		return index
+	}
+	for _, component := range report.Components {
+		path := component.Path
+		if path == "" {
+			path = "."
+		}
+		for _, e := range component.Errors {
+			if _, exists := index[e.Fingerprint]; exists {
+				continue
+			}
+			index[e.Fingerprint] = DiffEntry{
+				Component:   path,
+				Command:     component.Command,
+				Text:        e.Text,
+				Fingerprint: e.Fingerprint,
+			}
+		}
+	}
+	return index
+}
+
+// sortDiffEntries orders entries by component, command and text
+func sortDiffEntries(entries []DiffEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		if a.Command != b.Command {
+			return a.Command < b.Command
+		}
+		return a.Text < b.Text
+	})
+}
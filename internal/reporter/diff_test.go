@@ -0,0 +1,138 @@
+//go:build unit
+
+package reporter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// lintResult builds a failing lint result for the frontend component
+func lintResult(lines ...string) []executor.ComponentExecResult {
+	return []executor.ComponentExecResult{
+		{
+			Path:          "frontend/**",
+			Command:       "lint",
+			ExecResult:    &executor.ExecResult{ExitCode: 1},
+			CommandConfig: &config.CommandConfig{ExitCodes: []int{1}, Prompt: "Fix lint:"},
+			FilteredOutput: &filter.FilteredOutput{
+				Lines:      lines,
+				HasErrors:  true,
+				TotalLines: len(lines),
+			},
+		},
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	r := NewErrorReporter()
+	report := r.ReportJSON(lintResult("src/app.ts:3:7: Missing semicolon"))
+
+	if report.ExitCode != 2 || report.Passed {
+		t.Errorf("expected failing report with exit code 2, got %d (passed=%v)", report.ExitCode, report.Passed)
+	}
+	if len(report.Components) != 1 {
+		t.Fatalf("expected 1 component, got %d", len(report.Components))
+	}
+	component := report.Components[0]
+	if component.Command != "lint" || component.Path != "frontend/**" || component.ExitCode != 1 {
+		t.Errorf("unexpected component fields: %+v", component)
+	}
+	if component.Prompt != "Fix lint:" {
+		t.Errorf("expected configured prompt, got %q", component.Prompt)
+	}
+	if len(component.Errors) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(component.Errors))
+	}
+	e := component.Errors[0]
+	if e.File != "src/app.ts" || e.Line != 3 || e.Column != 7 || e.Message != "Missing semicolon" {
+		t.Errorf("unexpected error fields: %+v", e)
+	}
+	if e.Fingerprint == "" {
+		t.Error("expected a fingerprint")
+	}
+}
+
+func TestReport_JSONFormat(t *testing.T) {
+	r := NewErrorReporter()
+	if err := r.SetFormat(FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.SetCompactJSON(true)
+
+	result := r.Report(lintResult("src/app.ts:3:7: Missing semicolon"))
+	if result.ExitCode != 2 {
+		t.Errorf("expected exit code 2, got %d", result.ExitCode)
+	}
+	if strings.Contains(result.Stdout, "\n") {
+		t.Error("expected compact JSON on a single line")
+	}
+	parsed, err := ParseJSONReport([]byte(result.Stdout))
+	if err != nil {
+		t.Fatalf("expected valid JSON report: %v", err)
+	}
+	if len(parsed.Components) != 1 || len(parsed.Components[0].Errors) != 1 {
+		t.Errorf("unexpected parsed report: %+v", parsed)
+	}
+}
+
+func TestFingerprint_IgnoresLineNumbers(t *testing.T) {
+	r := NewErrorReporter()
+	before := r.ReportJSON(lintResult("src/app.ts:3:7: Missing semicolon"))
+	after := r.ReportJSON(lintResult("src/app.ts:12:7: Missing semicolon"))
+
+	if before.Components[0].Errors[0].Fingerprint != after.Components[0].Errors[0].Fingerprint {
+		t.Error("expected fingerprint to survive a line number change")
+	}
+}
+
+func TestDiffReports(t *testing.T) {
+	r := NewErrorReporter()
+	oldReport := r.ReportJSON(lintResult(
+		"src/app.ts:3:7: Missing semicolon",
+		"src/util.ts:1:1: Unused variable 'x'",
+	))
+	newReport := r.ReportJSON(lintResult(
+		"src/app.ts:5:7: Missing semicolon",
+		"src/index.ts:9:2: Unexpected any",
+	))
+
+	// Round-trip through JSON as the report command does
+	data, err := json.Marshal(oldReport)
+	if err != nil {
+		t.Fatalf("failed to marshal report: %v", err)
+	}
+	oldReport, err = ParseJSONReport(data)
+	if err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	diff := DiffReports(oldReport, newReport)
+
+	assertDiffTexts(t, "new", diff.New, "src/index.ts:9:2: Unexpected any")
+	assertDiffTexts(t, "fixed", diff.Fixed, "src/util.ts:1:1: Unused variable 'x'")
+	assertDiffTexts(t, "persisting", diff.Persisting, "src/app.ts:5:7: Missing semicolon")
+}
+
+func TestParseJSONReport_Invalid(t *testing.T) {
+	if _, err := ParseJSONReport([]byte("not json")); err == nil {
+		t.Error("expected error for invalid report")
+	}
+}
+
+func assertDiffTexts(t *testing.T, name string, entries []DiffEntry, want ...string) {
+	t.Helper()
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d %s error(s), got %+v", len(want), name, entries)
+	}
+	for i, entry := range entries {
+		if entry.Text != want[i] {
+			t.Errorf("%s[%d]: expected %q, got %q", name, i, want[i], entry.Text)
+		}
+	}
+}
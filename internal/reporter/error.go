@@ -5,24 +5,118 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bebsworthy/qualhook/internal/ansi"
 	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
 )
 
 // ErrorReporter formats and reports errors for LLM consumption
 type ErrorReporter struct {
 	// Default prompt to use if not specified in config
 	defaultPrompt string
+	// Output format (FormatDefault or FormatCompact)
+	format string
+	// dedup merges identical errors reported by several components
+	dedup bool
+	// compactJSON minifies JSON reports
+	compactJSON bool
+	// pathMask rewrites the working directory in reported output
+	pathMask *pathMask
+	// maxTotalOutputBytes caps the combined output of text reports (0: unlimited)
+	maxTotalOutputBytes int
+	// errorExitCode is the exit code of reports with quality errors
+	errorExitCode int
+	// normalizePaths reports error locations with forward slashes
+	normalizePaths bool
+	// color highlights prompt headers and the success message with ANSI colors
+	color bool
+	// summary ends failed reports of several components with a summary line
+	summary bool
 }
 
+// DefaultErrorExitCode is the exit code for quality errors, which Claude Code
+// hooks treat as feedback for the model
+const DefaultErrorExitCode = 2
+
 // NewErrorReporter creates a new error reporter
 func NewErrorReporter() *ErrorReporter {
 	return &ErrorReporter{
 		defaultPrompt: "Fix the following errors:",
+		format:        FormatDefault,
+		errorExitCode: DefaultErrorExitCode,
+	}
+}
+
+// SetErrorExitCode sets the exit code of reports with quality errors;
+// 0 restores DefaultErrorExitCode. Execution errors keep exit code 1.
+func (r *ErrorReporter) SetErrorExitCode(code int) {
+	if code == 0 {
+		code = DefaultErrorExitCode
+	}
+	r.errorExitCode = code
+}
+
+// SetFormat sets the output format used for reporting errors
+func (r *ErrorReporter) SetFormat(format string) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+	if format == "" {
+		format = FormatDefault
+	}
+	r.format = format
+	return nil
+}
+
+// SetDedup enables merging identical errors reported by several components
+func (r *ErrorReporter) SetDedup(dedup bool) {
+	r.dedup = dedup
+}
+
+// SetCompactJSON controls whether JSON reports are minified
+func (r *ErrorReporter) SetCompactJSON(compact bool) {
+	r.compactJSON = compact
+}
+
+// SetColor enables ANSI colors in text reports: red prompt headers and a
+// green success message. Without color the output is unchanged.
+func (r *ErrorReporter) SetColor(color bool) {
+	r.color = color
+}
+
+// ValidateFormat checks that format is a supported output format
+func ValidateFormat(format string) error {
+	switch format {
+	case "", FormatDefault, FormatCompact, FormatJSON, FormatTimeline, FormatJUnit:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q (use %s, %s, %s, %s or %s)", format, FormatDefault, FormatCompact, FormatJSON, FormatTimeline, FormatJUnit)
 	}
 }
 
 // Report aggregates results from multiple components and generates a report
 func (r *ErrorReporter) Report(results []executor.ComponentExecResult) *ReportResult {
+	if r.format == FormatTimeline {
+		return r.reportTimeline(results)
+	}
+	if r.format == FormatJUnit {
+		report, err := r.formatJUnit(results)
+		if err != nil {
+			return r.ReportSingleError("Report Error", err.Error())
+		}
+		return report
+	}
+
+	results = r.slashPaths(r.maskPaths(applyOutputTemplates(results)))
+
+	if r.format == FormatJSON {
+		report, err := r.formatJSON(results)
+		if err != nil {
+			return r.ReportSingleError("Report Error", err.Error())
+		}
+		return report
+	}
+
 	// Check for any execution errors first
 	if execErr := r.checkExecutionErrors(results); execErr != nil {
 		return execErr
@@ -40,19 +134,35 @@ func (r *ErrorReporter) Report(results []executor.ComponentExecResult) *ReportRe
 	if len(errorComponents) == 0 {
 		return &ReportResult{
 			ExitCode: 0,
-			Stdout:   "All quality checks passed successfully.",
+			Stdout:   ansi.Paint(r.color, "All quality checks passed successfully.", ansi.Green),
 		}
 	}
 
+	if r.dedup {
+		errorComponents = dedupAcrossComponents(errorComponents)
+	}
+
 	// Format errors for LLM
-	stderr := r.formatErrors(errorComponents)
+	var stderr string
+	if r.format == FormatCompact {
+		stderr = r.formatCompact(errorComponents)
+	} else {
+		stderr = r.formatErrors(errorComponents)
+	}
 
 	return &ReportResult{
-		ExitCode: 2, // Exit code 2 for Claude Code hook integration
-		Stderr:   stderr,
+		ExitCode: r.errorExitCode,
+		Stderr:   limitTotalOutput(stderr+skippedNotice(results), r.maxTotalOutputBytes) + r.summaryNotice(results),
 	}
 }
 
+// exitCode returns the exit code for results, following the text format rules
+func (r *ErrorReporter) exitCode(results []executor.ComponentExecResult) int {
+	textReporter := *r
+	textReporter.format = FormatDefault
+	return textReporter.Report(results).ExitCode
+}
+
 // ReportResult contains the final report output
 type ReportResult struct {
 	// Exit code (0 for success, 2 for errors that LLM should fix, 1 for other errors)
@@ -68,15 +178,7 @@ func (r *ErrorReporter) checkExecutionErrors(results []executor.ComponentExecRes
 	var criticalErrors []string
 
 	for _, result := range results {
-		if result.ExecutionError != nil {
-			// Handle specific error types
-			var execErr *executor.ExecError
-			if err, ok := result.ExecutionError.(*executor.ExecError); ok {
-				execErr = err
-			} else {
-				execErr = executor.ClassifyError(result.ExecutionError, result.Command, nil)
-			}
-
+		if execErr := executionError(result); execErr != nil {
 			msg := r.formatExecutionError(result, execErr)
 			criticalErrors = append(criticalErrors, msg)
 		}
@@ -85,13 +187,31 @@ func (r *ErrorReporter) checkExecutionErrors(results []executor.ComponentExecRes
 	if len(criticalErrors) > 0 {
 		return &ReportResult{
 			ExitCode: 1, // Exit code 1 for configuration/execution errors
-			Stderr:   fmt.Sprintf("[QUALHOOK ERROR] Execution Error\n\n%s", strings.Join(criticalErrors, "\n\n")),
+			Stderr:   fmt.Sprintf("%s\n\n%s%s", ansi.Paint(r.color, "[QUALHOOK ERROR] Execution Error", ansi.Red), strings.Join(criticalErrors, "\n\n"), skippedNotice(results)+r.summaryNotice(results)),
 		}
 	}
 
 	return nil
 }
 
+// executionError returns the error that prevented a component's command from
+// running, either reported by qualhook or by the executor when starting it
+func executionError(result executor.ComponentExecResult) *executor.ExecError {
+	err := result.ExecutionError
+	if err == nil && result.ExecResult != nil {
+		err = result.ExecResult.Error
+	}
+	if err == nil {
+		return nil
+	}
+
+	// Handle specific error types
+	if execErr, ok := err.(*executor.ExecError); ok {
+		return execErr
+	}
+	return executor.ClassifyError(err, result.Command, nil)
+}
+
 // formatExecutionError formats an execution error for output
 func (r *ErrorReporter) formatExecutionError(result executor.ComponentExecResult, execErr *executor.ExecError) string {
 	var msg strings.Builder
@@ -100,6 +220,16 @@ func (r *ErrorReporter) formatExecutionError(result executor.ComponentExecResult
 		msg.WriteString(fmt.Sprintf("Component: %s\n", result.Path))
 	}
 	msg.WriteString(fmt.Sprintf("Command: %s\n", result.Command))
+	if line := commandLine(result, execErr); line != "" {
+		msg.WriteString(fmt.Sprintf("Command line: %s\n", line))
+	}
+
+	if result.ExecResult != nil && result.ExecResult.Canceled {
+		msg.WriteString("Error: Command canceled\n")
+		msg.WriteString("Details: The run stopped before this command completed, e.g. after another command failed\n")
+		msg.WriteString("Fix: Fix the other failures and run the checks again")
+		return msg.String()
+	}
 
 	switch execErr.Type {
 	case executor.ErrorTypeCommandNotFound:
@@ -118,6 +248,10 @@ func (r *ErrorReporter) formatExecutionError(result executor.ComponentExecResult
 		msg.WriteString("Error: Working directory error\n")
 		msg.WriteString(fmt.Sprintf("Details: %s\n", execErr.Details))
 		msg.WriteString("Fix: Ensure the working directory exists and is accessible")
+	case executor.ErrorTypeCommandNotAllowed:
+		msg.WriteString("Error: Command not allowed\n")
+		msg.WriteString(fmt.Sprintf("Details: %s\n", execErr.Details))
+		msg.WriteString("Fix: Allow the command in security.allowedCommands, or remove it from security.deniedCommands, if you trust it")
 	default:
 		msg.WriteString(fmt.Sprintf("Error: %v\n", execErr.Err))
 	}
@@ -132,6 +266,11 @@ func (r *ErrorReporter) hasErrors(result executor.ComponentExecResult) bool {
 		return false
 	}
 
+	// Severity levels decide on their own once any line is classified
+	if failed, decided := severityFailure(result); decided {
+		return failed
+	}
+
 	// Check exit code
 	if result.CommandConfig != nil && len(result.CommandConfig.ExitCodes) > 0 {
 		for _, code := range result.CommandConfig.ExitCodes {
@@ -161,10 +300,11 @@ func (r *ErrorReporter) formatErrors(errorComponents []executor.ComponentExecRes
 	// Group by command type for better organization
 	commandGroups := r.groupByCommand(errorComponents)
 
-	for command, components := range commandGroups {
+	for _, command := range commandOrder(errorComponents) {
+		components := commandGroups[command]
 		// Get prompt for this command
 		prompt := r.getPrompt(command, components)
-		output.WriteString(prompt)
+		output.WriteString(ansi.Paint(r.color, prompt, ansi.Bold, ansi.Red))
 		output.WriteString("\n\n")
 
 		// Format errors for each component
@@ -177,16 +317,25 @@ func (r *ErrorReporter) formatErrors(errorComponents []executor.ComponentExecRes
 				output.WriteString(fmt.Sprintf("## %s\n\n", component.Path))
 			}
 
+			if !hasReportableOutput(component) {
+				output.WriteString(silentFailureMessage(component))
+				output.WriteString("\n")
+				continue
+			}
+
 			// Add filtered output
 			if component.FilteredOutput != nil && len(component.FilteredOutput.Lines) > 0 {
-				for _, line := range component.FilteredOutput.Lines {
-					output.WriteString(line)
-					output.WriteString("\n")
+				if classifier := newSeverityClassifier(component.CommandConfig); classifier != nil {
+					writeSeverityGroups(&output, classifier, component.FilteredOutput.Lines)
+				} else {
+					for _, line := range component.FilteredOutput.Lines {
+						output.WriteString(line)
+						output.WriteString("\n")
+					}
 				}
 
 				if component.FilteredOutput.Truncated {
-					output.WriteString(fmt.Sprintf("\n[Output truncated - %d total lines]\n",
-						component.FilteredOutput.TotalLines))
+					output.WriteString(truncationNotice(component.FilteredOutput))
 				}
 			} else if component.ExecResult != nil {
 				// Fallback to raw output if no filtering applied
@@ -222,8 +371,35 @@ func (r *ErrorReporter) groupByCommand(components []executor.ComponentExecResult
 	return groups
 }
 
+// truncationNotice tells how much output was left out, and by which limit
+func truncationNotice(output *filter.FilteredOutput) string {
+	if output.TruncatedBy != "" {
+		return fmt.Sprintf("\n[Output truncated by %s - %d total lines]\n", output.TruncatedBy, output.TotalLines)
+	}
+	return fmt.Sprintf("\n[Output truncated - %d total lines]\n", output.TotalLines)
+}
+
+// commandOrder lists the commands of components in order of first appearance,
+// so reports are laid out the same way on every run
+func commandOrder(components []executor.ComponentExecResult) []string {
+	seen := make(map[string]bool)
+	var order []string
+	for _, component := range components {
+		if !seen[component.Command] {
+			seen[component.Command] = true
+			order = append(order, component.Command)
+		}
+	}
+	return order
+}
+
 // getPrompt returns the appropriate prompt for a command
 func (r *ErrorReporter) getPrompt(command string, components []executor.ComponentExecResult) string {
+	// Prefer the prompt of the most specific error pattern that matched
+	if prompt := matchedPatternPrompt(components); prompt != "" {
+		return prompt
+	}
+
 	// Check if any component has a custom prompt
 	for _, component := range components {
 		if component.CommandConfig != nil && component.CommandConfig.Prompt != "" {
@@ -3,6 +3,7 @@
 package reporter
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -417,6 +418,21 @@ func TestFormatExecutionError(t *testing.T) {
 				"Ensure the working directory exists",
 			},
 		},
+		{
+			name: "command not allowed",
+			result: executor.ComponentExecResult{
+				Command: "lint",
+			},
+			execErr: &executor.ExecError{
+				Type:    executor.ErrorTypeCommandNotAllowed,
+				Details: "command 'curl' is in the denied command list",
+			},
+			contains: []string{
+				"Error: Command not allowed",
+				"command 'curl' is in the denied command list",
+				"security.allowedCommands",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -431,6 +447,60 @@ func TestFormatExecutionError(t *testing.T) {
 	}
 }
 
+func TestReport_CanceledCommand(t *testing.T) {
+	reporter := NewErrorReporter()
+	results := []executor.ComponentExecResult{
+		{
+			Path:       "web",
+			Command:    "lint",
+			ExecResult: &executor.ExecResult{ExitCode: -1, Canceled: true, Error: context.Canceled},
+		},
+	}
+
+	report := reporter.Report(results)
+	if report.ExitCode == 0 {
+		t.Fatal("expected a canceled command not to pass")
+	}
+	if !strings.Contains(report.Stderr, "Error: Command canceled") {
+		t.Errorf("expected canceled command in report, got:\n%s", report.Stderr)
+	}
+}
+
+func TestReport_Color(t *testing.T) {
+	failing := []executor.ComponentExecResult{
+		{
+			Command:       "lint",
+			CommandConfig: &config.CommandConfig{Prompt: "Fix the lint errors:"},
+			ExecResult:    &executor.ExecResult{ExitCode: 1, Stderr: "src/a.js:1: error"},
+		},
+	}
+	passing := []executor.ComponentExecResult{
+		{Command: "lint", ExecResult: &executor.ExecResult{ExitCode: 0}},
+	}
+
+	reporter := NewErrorReporter()
+	plain := reporter.Report(failing)
+	reporter.SetColor(true)
+	colored := reporter.Report(failing)
+	if !strings.HasPrefix(colored.Stderr, "\x1b[1m\x1b[31mFix the lint errors:\x1b[0m\n\n") {
+		t.Errorf("expected a red prompt header, got %q", colored.Stderr)
+	}
+	if strings.Contains(plain.Stderr, "\x1b[") {
+		t.Errorf("expected no color codes without color, got %q", plain.Stderr)
+	}
+	if got := reporter.Report(passing).Stdout; got != "\x1b[32mAll quality checks passed successfully.\x1b[0m" {
+		t.Errorf("expected a green success message, got %q", got)
+	}
+
+	// Machine-readable formats are never colored
+	if err := reporter.SetFormat(FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(reporter.Report(failing).Stdout, "\x1b[") {
+		t.Error("expected no color codes in JSON reports")
+	}
+}
+
 func TestGroupByCommand(t *testing.T) {
 	reporter := NewErrorReporter()
 
@@ -642,6 +712,30 @@ func TestReport_EdgeCases(t *testing.T) {
 		}
 	})
 
+	t.Run("extremely large single line bounded by maxOutputBytes", func(t *testing.T) {
+		// A 2MB minified stack trace on one line fits maxOutput
+		largeErrorLine := "ERROR: " + strings.Repeat("at a.b.c (bundle.min.js:1:12345) ", 2*1024*1024/34)
+		filtered := filter.NewSimpleOutputFilter().FilterWithRules(largeErrorLine+"\ndone", &filter.FilterRules{
+			ErrorPatterns: []*config.RegexPattern{{Pattern: "^ERROR"}},
+			MaxLines:      100,
+			MaxBytes:      4096,
+		})
+
+		report := reporter.Report([]executor.ComponentExecResult{{
+			Command:        "lint",
+			ExecResult:     &executor.ExecResult{ExitCode: 1, Stderr: largeErrorLine},
+			FilteredOutput: filtered,
+			CommandConfig:  &config.CommandConfig{ExitCodes: []int{1}},
+		}})
+
+		if !strings.Contains(report.Stderr, "[Output truncated by maxOutputBytes - 2 total lines]") {
+			t.Errorf("expected the byte limit in the truncation notice, got %q", report.Stderr[len(report.Stderr)-100:])
+		}
+		if len(report.Stderr) > 8192 {
+			t.Errorf("stderr output too large: %d bytes (expected < 8192)", len(report.Stderr))
+		}
+	})
+
 	t.Run("empty results slice", func(t *testing.T) {
 		report := reporter.Report([]executor.ComponentExecResult{})
 		if report.ExitCode != 0 {
@@ -1036,3 +1130,40 @@ func TestReport_ErrorAggregationFromMultipleSources(t *testing.T) {
 		}
 	})
 }
+
+func TestReport_ErrorExitCode(t *testing.T) {
+	failing := []executor.ComponentExecResult{{
+		Command:        "lint",
+		ExecResult:     &executor.ExecResult{ExitCode: 1},
+		FilteredOutput: &filter.FilteredOutput{Lines: []string{"main.go:3: unused"}, HasErrors: true},
+	}}
+	execFailure := []executor.ComponentExecResult{{
+		Command:    "lint",
+		ExecResult: &executor.ExecResult{ExitCode: -1, Error: errors.New("exec: \"eslint\": executable file not found in $PATH")},
+	}}
+	passing := []executor.ComponentExecResult{{Command: "lint", ExecResult: &executor.ExecResult{}}}
+
+	reporter := NewErrorReporter()
+	reporter.SetErrorExitCode(3)
+	if got := reporter.Report(failing).ExitCode; got != 3 {
+		t.Errorf("quality errors: expected exit code 3, got %d", got)
+	}
+	if got := reporter.Report(execFailure).ExitCode; got != 1 {
+		t.Errorf("execution errors: expected exit code 1, got %d", got)
+	}
+	if got := reporter.Report(passing).ExitCode; got != 0 {
+		t.Errorf("success: expected exit code 0, got %d", got)
+	}
+
+	if err := reporter.SetFormat(FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	if got := reporter.Report(failing).ExitCode; got != 3 {
+		t.Errorf("json report: expected exit code 3, got %d", got)
+	}
+
+	reporter.SetErrorExitCode(0)
+	if got := reporter.Report(failing).ExitCode; got != DefaultErrorExitCode {
+		t.Errorf("default: expected exit code %d, got %d", DefaultErrorExitCode, got)
+	}
+}
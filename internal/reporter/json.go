@@ -0,0 +1,177 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// FormatJSON emits a machine-readable JSON document
+const FormatJSON = "json"
+
+// JSONReport is the machine-readable form of a report
+type JSONReport struct {
+	// ExitCode is the exit code qualhook returns for this report
+	ExitCode int `json:"exitCode"`
+	// Passed is true when no component reported errors
+	Passed bool `json:"passed"`
+	// Components holds one entry per executed component
+	Components []JSONComponent `json:"components"`
+	// Summary tallies the components by outcome
+	Summary Summary `json:"summary"`
+}
+
+// JSONComponent describes the outcome of one component run
+type JSONComponent struct {
+	Command   string      `json:"command"`
+	Path      string      `json:"path,omitempty"`
+	ExitCode  int         `json:"exitCode"`
+	HasErrors bool        `json:"hasErrors"`
+	Prompt    string      `json:"prompt,omitempty"`
+	Errors    []JSONError `json:"errors,omitempty"`
+	// Lines are the matched output lines as they appear in the text report
+	Lines      []string `json:"lines,omitempty"`
+	Truncated  bool     `json:"truncated,omitempty"`
+	TotalLines int      `json:"totalLines,omitempty"`
+	// TruncatedBy names the limit that truncated Lines: "maxOutput" or "maxOutputBytes"
+	TruncatedBy    string `json:"truncatedBy,omitempty"`
+	ExecutionError string `json:"executionError,omitempty"`
+	// CommandLine is the redacted command line that failed to execute, or
+	// that failed without producing any output
+	CommandLine string `json:"commandLine,omitempty"`
+	// SkippedBy names the failed prerequisite that kept the command from running
+	SkippedBy string `json:"skippedBy,omitempty"`
+}
+
+// JSONError is a single reported error line
+type JSONError struct {
+	// Text is the error line as reported by the command
+	Text string `json:"text"`
+	// File, Line and Column are set when a location could be extracted
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+	// Message is the text following the location
+	Message string `json:"message,omitempty"`
+	// Severity is the configured severity level the line matched, if any
+	Severity string `json:"severity,omitempty"`
+	// Fingerprint identifies the error across runs; it ignores line and
+	// column so errors keep their identity when code moves
+	Fingerprint string `json:"fingerprint"`
+}
+
+// ReportJSON builds the machine-readable report for a set of results
+func (r *ErrorReporter) ReportJSON(results []executor.ComponentExecResult) *JSONReport {
+	results = r.maskPaths(applyOutputTemplates(results))
+	report := &JSONReport{
+		ExitCode:   r.exitCode(results),
+		Components: make([]JSONComponent, 0, len(results)),
+		Summary:    r.Summarize(results),
+	}
+	report.Passed = report.ExitCode == 0
+
+	for _, result := range results {
+		component := JSONComponent{
+			Command:   result.Command,
+			Path:      result.Path,
+			SkippedBy: result.SkippedBy,
+		}
+		if result.ExecResult != nil {
+			component.ExitCode = result.ExecResult.ExitCode
+		}
+		if execErr := executionError(result); execErr != nil {
+			component.ExecutionError = execErr.Error()
+			if result.ExecutionError != nil {
+				component.ExecutionError = result.ExecutionError.Error()
+			}
+			component.CommandLine = commandLine(result, execErr)
+		}
+		if result.FilteredOutput != nil {
+			component.Truncated = result.FilteredOutput.Truncated
+			component.TotalLines = result.FilteredOutput.TotalLines
+			component.TruncatedBy = result.FilteredOutput.TruncatedBy
+		}
+
+		if r.hasErrors(result) {
+			component.HasErrors = true
+			component.Prompt = r.getPrompt(result.Command, []executor.ComponentExecResult{result})
+			component.Lines = nonBlankLines(reportedLines(result))
+			if len(component.Lines) == 0 && component.CommandLine == "" {
+				component.CommandLine = commandLine(result, nil)
+			}
+			classifier := newSeverityClassifier(result.CommandConfig)
+			for _, entry := range errorEntries(result) {
+				component.Errors = append(component.Errors, JSONError{
+					Text:        entry.text,
+					File:        entry.location.File,
+					Line:        entry.location.Line,
+					Column:      entry.location.Column,
+					Message:     entry.message,
+					Severity:    classifier.severity(entry.text),
+					Fingerprint: fingerprint(entry),
+				})
+			}
+		}
+
+		report.Components = append(report.Components, component)
+	}
+
+	return report
+}
+
+// formatJSON renders the JSON report, minified when compact is set
+func (r *ErrorReporter) formatJSON(results []executor.ComponentExecResult) (*ReportResult, error) {
+	report := r.ReportJSON(results)
+
+	var data []byte
+	var err error
+	if r.compactJSON {
+		data, err = json.Marshal(report)
+	} else {
+		data, err = json.MarshalIndent(report, "", "  ")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	return &ReportResult{
+		ExitCode: report.ExitCode,
+		Stdout:   string(data),
+	}, nil
+}
+
+// nonBlankLines drops empty lines, such as the trailing one of raw output
+func nonBlankLines(lines []string) []string {
+	var kept []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			kept = append(kept, line)
+		}
+	}
+	return kept
+}
+
+// ParseJSONReport decodes a report previously written in the JSON format
+func ParseJSONReport(data []byte) (*JSONReport, error) {
+	var report JSONReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("invalid JSON report: %w", err)
+	}
+	return &report, nil
+}
+
+// fingerprint computes a stable identifier for an error entry
+func fingerprint(entry errorEntry) string {
+	key := entry.command + "\x00" + entry.component + "\x00" + entry.location.File + "\x00"
+	if entry.location.File != "" {
+		key += entry.message
+	} else {
+		key += strings.TrimSpace(entry.text)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
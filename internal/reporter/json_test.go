@@ -0,0 +1,120 @@
+//go:build unit
+
+package reporter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func jsonTestResults() []executor.ComponentExecResult {
+	return []executor.ComponentExecResult{
+		{
+			Path:    "frontend/**",
+			Command: "lint",
+			CommandConfig: &config.CommandConfig{
+				Command:   "eslint",
+				Prompt:    "Fix the ESLint errors:",
+				ExitCodes: []int{1},
+			},
+			ExecResult: &executor.ExecResult{ExitCode: 1},
+			FilteredOutput: &filter.FilteredOutput{
+				Lines:      []string{"src/app.js:10:5: Missing semicolon", "src/app.js:12:1: Unexpected console"},
+				HasErrors:  true,
+				Truncated:  true,
+				TotalLines: 40,
+			},
+		},
+		{
+			Path:           "backend/**",
+			Command:        "lint",
+			CommandConfig:  &config.CommandConfig{Command: "golangci-lint", ExitCodes: []int{1}},
+			ExecResult:     &executor.ExecResult{ExitCode: 0},
+			FilteredOutput: &filter.FilteredOutput{},
+		},
+	}
+}
+
+func TestReportJSON_FieldMapping(t *testing.T) {
+	reporter := NewErrorReporter()
+	report := reporter.ReportJSON(jsonTestResults())
+
+	if text := reporter.Report(jsonTestResults()); report.ExitCode != text.ExitCode {
+		t.Errorf("expected JSON exit code %d to match the text report, got %d", text.ExitCode, report.ExitCode)
+	}
+	if report.Passed {
+		t.Error("expected report not to pass")
+	}
+	if len(report.Components) != 2 {
+		t.Fatalf("expected 2 components, got %d", len(report.Components))
+	}
+
+	failed := report.Components[0]
+	if failed.Command != "lint" || failed.Path != "frontend/**" || failed.ExitCode != 1 {
+		t.Errorf("unexpected component identity: %+v", failed)
+	}
+	if !failed.HasErrors || failed.Prompt != "Fix the ESLint errors:" {
+		t.Errorf("expected errors with the configured prompt, got %+v", failed)
+	}
+	if !failed.Truncated || failed.TotalLines != 40 {
+		t.Errorf("expected truncation info, got truncated=%v totalLines=%d", failed.Truncated, failed.TotalLines)
+	}
+	if len(failed.Lines) != 2 || failed.Lines[0] != "src/app.js:10:5: Missing semicolon" {
+		t.Errorf("expected matched lines, got %v", failed.Lines)
+	}
+	if len(failed.Errors) != 2 || failed.Errors[0].File != "src/app.js" || failed.Errors[0].Line != 10 ||
+		failed.Errors[0].Column != 5 || failed.Errors[0].Message != "Missing semicolon" {
+		t.Errorf("unexpected errors: %+v", failed.Errors)
+	}
+
+	passed := report.Components[1]
+	if passed.HasErrors || passed.Prompt != "" || len(passed.Errors) != 0 || len(passed.Lines) != 0 {
+		t.Errorf("expected passing component without errors, got %+v", passed)
+	}
+}
+
+func TestReport_JSONFormatDocument(t *testing.T) {
+	reporter := NewErrorReporter()
+	if err := reporter.SetFormat(FormatJSON); err != nil {
+		t.Fatalf("SetFormat() error = %v", err)
+	}
+
+	result := reporter.Report(jsonTestResults())
+	if result.ExitCode != 2 || result.Stderr != "" {
+		t.Errorf("expected exit code 2 and JSON on stdout, got %d with stderr %q", result.ExitCode, result.Stderr)
+	}
+
+	parsed, err := ParseJSONReport([]byte(result.Stdout))
+	if err != nil {
+		t.Fatalf("ParseJSONReport() error = %v", err)
+	}
+	if parsed.ExitCode != result.ExitCode || len(parsed.Components) != 2 {
+		t.Errorf("unexpected parsed report: %+v", parsed)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(result.Stdout), &raw); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, key := range []string{"exitCode", "passed", "components"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("expected key %q in JSON report", key)
+		}
+	}
+}
+
+func TestReport_DefaultFormatUnchanged(t *testing.T) {
+	result := NewErrorReporter().Report(jsonTestResults())
+
+	if strings.Contains(result.Stderr, "{") || result.Stdout != "" {
+		t.Errorf("expected the text report, got stdout %q stderr %q", result.Stdout, result.Stderr)
+	}
+	if !strings.HasPrefix(result.Stderr, "Fix the ESLint errors:") {
+		t.Errorf("expected the text report to start with the prompt, got:\n%s", result.Stderr)
+	}
+}
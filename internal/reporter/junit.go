@@ -0,0 +1,125 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// FormatJUnit emits JUnit XML for CI systems: one test suite per command,
+// with a test case per component
+const FormatJUnit = "junit"
+
+// JUnitTestSuites is the root element of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite holds the components a command ran for
+type JUnitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase is one component run; a failure carries the reported
+// errors, an error the reason the command could not run and skipped the
+// failed prerequisite that kept it from running
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitProblem `xml:"failure,omitempty"`
+	Error     *JUnitProblem `xml:"error,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+}
+
+// JUnitSkipped marks a test case that did not run
+type JUnitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnitProblem is the failure or error of a test case
+type JUnitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ReportJUnit builds the JUnit report for a set of results, with suites in
+// order of first appearance of their command
+func (r *ErrorReporter) ReportJUnit(results []executor.ComponentExecResult) *JUnitTestSuites {
+	results = r.slashPaths(r.maskPaths(applyOutputTemplates(results)))
+	report := &JUnitTestSuites{Name: "qualhook"}
+
+	groups := r.groupByCommand(results)
+	var total time.Duration
+	for _, command := range commandOrder(results) {
+		suite := JUnitTestSuite{Name: command}
+		var suiteTime time.Duration
+		for _, result := range groups[command] {
+			testCase := JUnitTestCase{
+				Name:      componentName(result),
+				ClassName: "qualhook." + command,
+				Time:      junitSeconds(result.Duration),
+			}
+			if result.SkippedBy != "" {
+				testCase.Skipped = &JUnitSkipped{Message: skippedMessage(result)}
+				suite.Skipped++
+			} else if execErr := executionError(result); execErr != nil {
+				testCase.Error = &JUnitProblem{Message: execErr.Error(), Type: r.outcome(result), Text: r.formatExecutionError(result, execErr)}
+				suite.Errors++
+			} else if r.hasErrors(result) {
+				lines := nonBlankLines(reportedLines(result))
+				message := fmt.Sprintf("%s failed with %d error line(s)", command, len(lines))
+				if len(lines) == 0 {
+					message = silentFailureMessage(result)
+				}
+				testCase.Failure = &JUnitProblem{Message: message, Type: r.outcome(result), Text: strings.Join(lines, "\n")}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, testCase)
+			suiteTime += result.Duration
+		}
+		suite.Tests = len(suite.Cases)
+		suite.Time = junitSeconds(suiteTime)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		total += suiteTime
+		report.Suites = append(report.Suites, suite)
+	}
+	report.Time = junitSeconds(total)
+	return report
+}
+
+// formatJUnit renders the JUnit report, with the exit code of the text report
+func (r *ErrorReporter) formatJUnit(results []executor.ComponentExecResult) (*ReportResult, error) {
+	data, err := xml.MarshalIndent(r.ReportJUnit(results), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return &ReportResult{
+		ExitCode: r.exitCode(results),
+		Stdout:   xml.Header + string(data),
+	}, nil
+}
+
+// junitSeconds formats a duration as JUnit seconds
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
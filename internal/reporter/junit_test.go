@@ -0,0 +1,74 @@
+//go:build unit
+
+package reporter
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestReport_JUnit(t *testing.T) {
+	results := append(timelineResults(time.Now())[:2],
+		executor.ComponentExecResult{
+			Path: "api/**", Command: "test", CommandConfig: &config.CommandConfig{Command: "jest"},
+			ExecResult: &executor.ExecResult{}, Duration: 500 * time.Millisecond,
+		},
+		executor.ComponentExecResult{
+			Path: "web/**", Command: "test", ExecutionError: errors.New("failed to start: no such file"),
+		},
+	)
+
+	r := NewErrorReporter()
+	if err := r.SetFormat(FormatJUnit); err != nil {
+		t.Fatalf("SetFormat() error = %v", err)
+	}
+	report := r.Report(results)
+	if report.ExitCode != 1 || !strings.HasPrefix(report.Stdout, xml.Header) {
+		t.Fatalf("expected a JUnit document with the text report exit code, got %d:\n%s", report.ExitCode, report.Stdout)
+	}
+
+	var suites JUnitTestSuites
+	if err := xml.Unmarshal([]byte(report.Stdout), &suites); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if suites.Tests != 4 || suites.Failures != 1 || suites.Errors != 1 || len(suites.Suites) != 2 {
+		t.Fatalf("unexpected counts %+v", suites)
+	}
+
+	lint := suites.Suites[0]
+	if lint.Name != "lint" || lint.Tests != 2 || lint.Failures != 1 || lint.Time != "4.200" {
+		t.Errorf("unexpected lint suite %+v", lint)
+	}
+	if lint.Cases[0].Name != "web" || lint.Cases[0].Failure != nil {
+		t.Errorf("expected web to pass, got %+v", lint.Cases[0])
+	}
+	if failure := lint.Cases[1].Failure; failure == nil || failure.Text != "api/x.js:3:1: bad" || failure.Type != "failed" {
+		t.Errorf("expected the filtered errors in the api failure, got %+v", failure)
+	}
+
+	test := suites.Suites[1]
+	if test.Errors != 1 || test.Cases[1].Error == nil || !strings.Contains(test.Cases[1].Error.Message, "no such file") {
+		t.Errorf("expected the execution error as a test case error, got %+v", test)
+	}
+}
+
+func TestReport_JUnitPassed(t *testing.T) {
+	r := NewErrorReporter()
+	if err := r.SetFormat(FormatJUnit); err != nil {
+		t.Fatal(err)
+	}
+	report := r.Report([]executor.ComponentExecResult{{
+		Command: "lint", CommandConfig: &config.CommandConfig{Command: "eslint"},
+		ExecResult: &executor.ExecResult{}, FilteredOutput: &filter.FilteredOutput{},
+	}})
+	if report.ExitCode != 0 || !strings.Contains(report.Stdout, `<testsuites name="qualhook" tests="1" failures="0" errors="0"`) {
+		t.Errorf("expected a passing suite, got %d:\n%s", report.ExitCode, report.Stdout)
+	}
+}
@@ -0,0 +1,74 @@
+package reporter
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// PathMaskRelative masks the working directory by making paths under it relative
+const PathMaskRelative = "relative"
+
+// SetPathMask rewrites the dir prefix of paths in all reported output, matched
+// lines and raw output alike. With PathMaskRelative paths become relative to
+// dir; any other mask replaces dir, e.g. "$WORKSPACE". An empty mask disables it.
+func (r *ErrorReporter) SetPathMask(dir, mask string) {
+	r.pathMask = nil
+	dir = filepath.Clean(dir)
+	if mask == "" || dir == "" || dir == "." || dir == string(filepath.Separator) {
+		return
+	}
+	r.pathMask = &pathMask{
+		// The directory must end at a path boundary, so /work does not match /workspace
+		pattern: regexp.MustCompile(regexp.QuoteMeta(dir) + `(/|\\|[^A-Za-z0-9._\-]|$)`),
+		mask:    mask,
+	}
+}
+
+// pathMask rewrites a directory prefix in reported output
+type pathMask struct {
+	pattern *regexp.Regexp
+	mask    string
+}
+
+// apply rewrites the masked directory in s
+func (m *pathMask) apply(s string) string {
+	return m.pattern.ReplaceAllStringFunc(s, func(match string) string {
+		boundary := m.pattern.FindStringSubmatch(match)[1]
+		if m.mask != PathMaskRelative {
+			return m.mask + boundary
+		}
+		if boundary == "/" || boundary == `\` {
+			return ""
+		}
+		return "." + boundary
+	})
+}
+
+// maskPaths applies the path mask to the output of results. Results are
+// copied; the originals are left untouched.
+func (r *ErrorReporter) maskPaths(results []executor.ComponentExecResult) []executor.ComponentExecResult {
+	if r.pathMask == nil || len(results) == 0 {
+		return results
+	}
+
+	masked := append([]executor.ComponentExecResult(nil), results...)
+	for i, result := range masked {
+		if result.ExecResult != nil {
+			execResult := *result.ExecResult
+			execResult.Stdout = r.pathMask.apply(execResult.Stdout)
+			execResult.Stderr = r.pathMask.apply(execResult.Stderr)
+			masked[i].ExecResult = &execResult
+		}
+		if result.FilteredOutput != nil {
+			output := *result.FilteredOutput
+			output.Lines = make([]string, len(result.FilteredOutput.Lines))
+			for li, line := range result.FilteredOutput.Lines {
+				output.Lines[li] = r.pathMask.apply(line)
+			}
+			masked[i].FilteredOutput = &output
+		}
+	}
+	return masked
+}
@@ -0,0 +1,76 @@
+//go:build unit
+
+package reporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestPathMask_Apply(t *testing.T) {
+	tests := []struct {
+		name, mask, line, want string
+	}{
+		{"relative file", PathMaskRelative, "/home/runner/work/app/src/a.go:3:1: bad", "src/a.go:3:1: bad"},
+		{"relative dir itself", PathMaskRelative, "cd /home/runner/work/app failed", "cd . failed"},
+		{"relative at end", PathMaskRelative, "in /home/runner/work/app", "in ."},
+		{"placeholder", "$WORKSPACE", "/home/runner/work/app/src/a.go: bad", "$WORKSPACE/src/a.go: bad"},
+		{"sibling directory kept", PathMaskRelative, "/home/runner/work/app2/a.go: bad", "/home/runner/work/app2/a.go: bad"},
+		{"several paths", PathMaskRelative, "/home/runner/work/app/a.go vs /home/runner/work/app/b.go", "a.go vs b.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewErrorReporter()
+			r.SetPathMask("/home/runner/work/app", tt.mask)
+			if got := r.pathMask.apply(tt.line); got != tt.want {
+				t.Errorf("apply(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_MasksWorkingDirectory(t *testing.T) {
+	workDir := "/home/runner/work/app"
+	cmdConfig := &config.CommandConfig{Command: "eslint", ExitCodes: []int{1}}
+	filtered := []string{workDir + "/src/a.js:1:1: error no-unused-vars"}
+	results := []executor.ComponentExecResult{
+		{
+			Command:        "lint",
+			CommandConfig:  cmdConfig,
+			ExecResult:     &executor.ExecResult{ExitCode: 1, Stdout: filtered[0]},
+			FilteredOutput: &filter.FilteredOutput{Lines: filtered, HasErrors: true},
+		},
+		{
+			// Unfiltered output is reported raw and masked as well
+			Command:       "typecheck",
+			CommandConfig: &config.CommandConfig{Command: "tsc"},
+			ExecResult:    &executor.ExecResult{ExitCode: 2, Stderr: workDir + "/src/b.ts(4,2): error TS2345\n"},
+		},
+	}
+
+	r := NewErrorReporter()
+	r.SetPathMask(workDir, PathMaskRelative)
+	report := r.Report(results)
+
+	if strings.Contains(report.Stderr, workDir) {
+		t.Errorf("report still contains the working directory:\n%s", report.Stderr)
+	}
+	for _, want := range []string{"src/a.js:1:1: error no-unused-vars", "src/b.ts(4,2): error TS2345"} {
+		if !strings.Contains(report.Stderr, want) {
+			t.Errorf("report missing relativized line %q:\n%s", want, report.Stderr)
+		}
+	}
+	if results[0].FilteredOutput.Lines[0] != filtered[0] || !strings.HasPrefix(results[1].ExecResult.Stderr, workDir) {
+		t.Error("masking modified the original results")
+	}
+
+	jsonReport := r.ReportJSON(results)
+	if got := jsonReport.Components[0].Errors[0]; got.File != "src/a.js" || strings.Contains(got.Text, workDir) {
+		t.Errorf("JSON report still contains the working directory: %+v", got)
+	}
+}
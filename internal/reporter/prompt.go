@@ -0,0 +1,39 @@
+package reporter
+
+import (
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// matchedPatternPrompt returns the prompt of the most specific error pattern
+// that matched the reported lines of the components. A pattern matching fewer
+// lines is more specific; ties go to the pattern configured first.
+func matchedPatternPrompt(components []executor.ComponentExecResult) string {
+	prompt := ""
+	best := 0
+	for _, component := range components {
+		if component.CommandConfig == nil {
+			continue
+		}
+		lines := reportedLines(component)
+		for _, pattern := range component.CommandConfig.DetectionPatterns() {
+			if pattern == nil || pattern.Prompt == "" {
+				continue
+			}
+			re, err := pattern.Compile()
+			if err != nil {
+				continue
+			}
+
+			matches := 0
+			for _, line := range lines {
+				if re.MatchString(line) {
+					matches++
+				}
+			}
+			if matches > 0 && (best == 0 || matches < best) {
+				prompt, best = pattern.Prompt, matches
+			}
+		}
+	}
+	return prompt
+}
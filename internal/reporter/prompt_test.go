@@ -0,0 +1,87 @@
+//go:build unit
+
+package reporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestReport_PatternPrompt(t *testing.T) {
+	cmdConfig := &config.CommandConfig{
+		Command:   "tsc",
+		Prompt:    "Fix the problems below:",
+		ExitCodes: []int{1},
+		ErrorPatterns: []*config.RegexPattern{
+			{Pattern: `error TS\d+`, Prompt: "Fix the type errors below:"},
+			{Pattern: `error TS2307`, Prompt: "Install or correct the missing modules below:"},
+			{Pattern: `warning`},
+		},
+	}
+	newResult := func(lines ...string) []executor.ComponentExecResult {
+		return []executor.ComponentExecResult{
+			{
+				Command:        "typecheck",
+				CommandConfig:  cmdConfig,
+				ExecResult:     &executor.ExecResult{ExitCode: 1},
+				FilteredOutput: &filter.FilteredOutput{Lines: lines, HasErrors: true},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{
+			name:  "specific pattern prompt wins over command prompt",
+			lines: []string{"src/a.ts(1,1): error TS2322: Type mismatch"},
+			want:  "Fix the type errors below:",
+		},
+		{
+			name: "most specific matched pattern wins",
+			lines: []string{
+				"src/a.ts(1,1): error TS2322: Type mismatch",
+				"src/b.ts(2,1): error TS2307: Cannot find module 'x'",
+			},
+			want: "Install or correct the missing modules below:",
+		},
+		{
+			name:  "falls back to command prompt",
+			lines: []string{"src/a.ts: warning unused"},
+			want:  "Fix the problems below:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewErrorReporter().Report(newResult(tt.lines...))
+			if !strings.HasPrefix(report.Stderr, tt.want) {
+				t.Errorf("expected prompt %q, got:\n%s", tt.want, report.Stderr)
+			}
+		})
+	}
+
+	t.Run("falls back to default prompt", func(t *testing.T) {
+		results := []executor.ComponentExecResult{
+			{
+				Command: "typecheck",
+				CommandConfig: &config.CommandConfig{
+					ExitCodes:     []int{1},
+					ErrorPatterns: []*config.RegexPattern{{Pattern: `error`, Prompt: "Never used"}},
+				},
+				ExecResult:     &executor.ExecResult{ExitCode: 1},
+				FilteredOutput: &filter.FilteredOutput{Lines: []string{"unrelated failure"}, HasErrors: true},
+			},
+		}
+		report := NewErrorReporter().Report(results)
+		if !strings.HasPrefix(report.Stderr, "Fix the type errors below:") {
+			t.Errorf("expected default typecheck prompt, got:\n%s", report.Stderr)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package reporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// severityClassifier assigns reported lines to the configured severity levels
+type severityClassifier struct {
+	levels   []*config.SeverityConfig
+	patterns [][]*regexp.Regexp
+	// failRank is the index of the least severe level that fails
+	failRank int
+}
+
+// newSeverityClassifier returns a classifier for a command, or nil when the
+// command defines no severities
+func newSeverityClassifier(cmdConfig *config.CommandConfig) *severityClassifier {
+	if cmdConfig == nil || len(cmdConfig.Severities) == 0 {
+		return nil
+	}
+
+	c := &severityClassifier{
+		levels:   cmdConfig.Severities,
+		patterns: make([][]*regexp.Regexp, len(cmdConfig.Severities)),
+		failRank: len(cmdConfig.Severities) - 1,
+	}
+	for i, level := range cmdConfig.Severities {
+		if level.Name == cmdConfig.FailOn {
+			c.failRank = i
+		}
+		for _, pattern := range level.Patterns {
+			// Patterns are validated when the configuration is loaded
+			if re, err := pattern.Compile(); err == nil {
+				c.patterns[i] = append(c.patterns[i], re)
+			}
+		}
+	}
+	return c
+}
+
+// rank returns the index of the first level matching line, or -1
+func (c *severityClassifier) rank(line string) int {
+	for i, patterns := range c.patterns {
+		for _, re := range patterns {
+			if re.MatchString(line) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// severity returns the name of the level matching line, or ""
+func (c *severityClassifier) severity(line string) string {
+	if c == nil {
+		return ""
+	}
+	if i := c.rank(line); i >= 0 {
+		return c.levels[i].Name
+	}
+	return ""
+}
+
+// fails reports whether any line is at or above the failOn level, and
+// whether any line could be classified at all
+func (c *severityClassifier) fails(lines []string) (failed, classified bool) {
+	for _, line := range lines {
+		i := c.rank(line)
+		if i < 0 {
+			continue
+		}
+		classified = true
+		if i <= c.failRank {
+			return true, true
+		}
+	}
+	return false, classified
+}
+
+// writeSeverityGroups writes lines grouped by severity, most severe first,
+// with a count per level. Lines matching no level are listed last.
+func writeSeverityGroups(output *strings.Builder, c *severityClassifier, lines []string) {
+	groups := make([][]string, len(c.levels)+1)
+	for _, line := range lines {
+		i := c.rank(line)
+		if i < 0 {
+			i = len(c.levels)
+		}
+		groups[i] = append(groups[i], line)
+	}
+
+	for i, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		name := "other"
+		if i < len(c.levels) {
+			name = c.levels[i].Name
+		}
+		output.WriteString(fmt.Sprintf("### %s (%d)\n", name, len(group)))
+		for _, line := range group {
+			output.WriteString(line)
+			output.WriteString("\n")
+		}
+	}
+}
+
+// severityFailure applies the failOn threshold to a component. The second
+// result is false when the component defines no severities or none of its
+// lines matched one, in which case the regular error detection applies.
+func severityFailure(result executor.ComponentExecResult) (failed, decided bool) {
+	c := newSeverityClassifier(result.CommandConfig)
+	if c == nil {
+		return false, false
+	}
+	return c.fails(reportedLines(result))
+}
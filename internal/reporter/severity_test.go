@@ -0,0 +1,95 @@
+//go:build unit
+
+package reporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// severityCommand defines error, warning and info levels failing on warning
+func severityCommand() *config.CommandConfig {
+	return &config.CommandConfig{
+		Command:   "lint",
+		ExitCodes: []int{1},
+		Severities: []*config.SeverityConfig{
+			{Name: "error", Patterns: []*config.RegexPattern{{Pattern: `\berror\b`}}},
+			{Name: "warning", Patterns: []*config.RegexPattern{{Pattern: `\bwarning\b`}}},
+			{Name: "info", Patterns: []*config.RegexPattern{{Pattern: `\binfo\b`}}},
+		},
+		FailOn: "warning",
+	}
+}
+
+// severityResult filters output with the command's detection patterns
+func severityResult(cmdConfig *config.CommandConfig, exitCode int, output string) executor.ComponentExecResult {
+	filtered := filter.NewSimpleOutputFilter().FilterWithRules(output, &filter.FilterRules{
+		ErrorPatterns: cmdConfig.DetectionPatterns(),
+		MaxLines:      100,
+	})
+	return executor.ComponentExecResult{
+		Command:        "lint",
+		CommandConfig:  cmdConfig,
+		ExecResult:     &executor.ExecResult{ExitCode: exitCode, Stdout: output},
+		FilteredOutput: filtered,
+	}
+}
+
+func TestReport_SeverityThreshold(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		exitCode int
+		wantExit int
+	}{
+		{"error fails", "a.js:1:1 error no-undef\nb.js:2:1 info prefer-const", 1, 2},
+		{"warning fails", "a.js:1:1 warning unused", 0, 2},
+		{"info alone passes despite exit code", "a.js:1:1 info prefer-const\nb.js:3:1 info style", 1, 0},
+		{"unclassified output falls back to exit codes", "crashed", 1, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := severityResult(severityCommand(), tt.exitCode, tt.output)
+			report := NewErrorReporter().Report([]executor.ComponentExecResult{result})
+			if report.ExitCode != tt.wantExit {
+				t.Errorf("expected exit code %d, got %d\n%s", tt.wantExit, report.ExitCode, report.Stderr)
+			}
+		})
+	}
+}
+
+func TestReport_SeverityGroups(t *testing.T) {
+	output := strings.Join([]string{
+		"b.js:2:1 info prefer-const",
+		"a.js:1:1 error no-undef",
+		"c.js:5:1 warning unused",
+		"d.js:7:1 error no-redeclare",
+	}, "\n")
+	result := severityResult(severityCommand(), 1, output)
+
+	report := NewErrorReporter().Report([]executor.ComponentExecResult{result})
+	for _, want := range []string{"### error (2)", "### warning (1)", "### info (1)"} {
+		if !strings.Contains(report.Stderr, want) {
+			t.Errorf("expected %q in report, got:\n%s", want, report.Stderr)
+		}
+	}
+	errorIdx := strings.Index(report.Stderr, "### error")
+	infoIdx := strings.Index(report.Stderr, "### info")
+	if errorIdx < 0 || infoIdx < errorIdx {
+		t.Errorf("expected severities ordered most severe first, got:\n%s", report.Stderr)
+	}
+
+	jsonReport := NewErrorReporter().ReportJSON([]executor.ComponentExecResult{result})
+	severities := map[string]int{}
+	for _, e := range jsonReport.Components[0].Errors {
+		severities[e.Severity]++
+	}
+	if severities["error"] != 2 || severities["warning"] != 1 || severities["info"] != 1 {
+		t.Errorf("unexpected JSON severities: %v", severities)
+	}
+}
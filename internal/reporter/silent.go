@@ -0,0 +1,33 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// hasReportableOutput reports whether a component has any non-blank output to report
+func hasReportableOutput(component executor.ComponentExecResult) bool {
+	for _, line := range reportedLines(component) {
+		if strings.TrimSpace(line) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// silentFailureMessage explains a failing component that produced no output,
+// e.g. after a crash, with the command line to reproduce it
+func silentFailureMessage(component executor.ComponentExecResult) string {
+	exitCode := 0
+	if component.ExecResult != nil {
+		exitCode = component.ExecResult.ExitCode
+	}
+
+	msg := fmt.Sprintf("Command failed with exit code %d and produced no output", exitCode)
+	if line := commandLine(component, nil); line != "" {
+		msg += fmt.Sprintf(" (command line: %s)", line)
+	}
+	return msg
+}
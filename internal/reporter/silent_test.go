@@ -0,0 +1,76 @@
+//go:build unit
+
+package reporter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// silentFailure is a component whose command crashed without any output
+func silentFailure() executor.ComponentExecResult {
+	return executor.ComponentExecResult{
+		Command:        "test",
+		CommandConfig:  &config.CommandConfig{Command: "sh", Args: []string{"-c", "exit 1"}},
+		ExecResult:     &executor.ExecResult{ExitCode: 1, Stdout: "\n"},
+		FilteredOutput: &filter.FilteredOutput{},
+	}
+}
+
+func TestReport_SilentFailure(t *testing.T) {
+	want := "Command failed with exit code 1 and produced no output (command line: sh -c 'exit 1')"
+
+	for _, format := range []string{FormatDefault, FormatCompact} {
+		t.Run(format, func(t *testing.T) {
+			r := NewErrorReporter()
+			if err := r.SetFormat(format); err != nil {
+				t.Fatal(err)
+			}
+			result := r.Report([]executor.ComponentExecResult{silentFailure()})
+			if result.ExitCode != 2 {
+				t.Errorf("expected exit code 2, got %d", result.ExitCode)
+			}
+			if !strings.Contains(result.Stderr, want) {
+				t.Errorf("expected %q in the report, got:\n%s", want, result.Stderr)
+			}
+		})
+	}
+}
+
+func TestReport_SilentFailureJSON(t *testing.T) {
+	r := NewErrorReporter()
+	if err := r.SetFormat(FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	result := r.Report([]executor.ComponentExecResult{silentFailure()})
+
+	var report JSONReport
+	if err := json.Unmarshal([]byte(result.Stdout), &report); err != nil {
+		t.Fatalf("invalid JSON report: %v", err)
+	}
+	component := report.Components[0]
+	if component.CommandLine != "sh -c 'exit 1'" {
+		t.Errorf("expected the command line, got %q", component.CommandLine)
+	}
+	if len(component.Errors) != 1 || !strings.Contains(component.Errors[0].Message, "produced no output") {
+		t.Errorf("expected a silent failure error, got %+v", component.Errors)
+	}
+}
+
+func TestReport_FailureWithOutputNotSilent(t *testing.T) {
+	component := silentFailure()
+	component.ExecResult.Stderr = "segmentation fault"
+
+	result := NewErrorReporter().Report([]executor.ComponentExecResult{component})
+	if strings.Contains(result.Stderr, "produced no output") {
+		t.Errorf("expected the command output instead of the silent failure message, got:\n%s", result.Stderr)
+	}
+	if !strings.Contains(result.Stderr, "segmentation fault") {
+		t.Errorf("expected the command output, got:\n%s", result.Stderr)
+	}
+}
@@ -0,0 +1,26 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// skippedMessage explains why a command did not run
+func skippedMessage(result executor.ComponentExecResult) string {
+	return fmt.Sprintf("Skipped %s: prerequisite %s failed", result.Command, result.SkippedBy)
+}
+
+// skippedNotice lists the commands skipped after a prerequisite failed, to
+// follow the errors of a failed report; it is empty when none were
+func skippedNotice(results []executor.ComponentExecResult) string {
+	var notice strings.Builder
+	for _, result := range results {
+		if result.SkippedBy != "" {
+			notice.WriteString("\n\n")
+			notice.WriteString(skippedMessage(result))
+		}
+	}
+	return notice.String()
+}
@@ -0,0 +1,65 @@
+//go:build unit
+
+package reporter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// prerequisiteFailure is a failed typecheck that kept test from running
+func prerequisiteFailure() []executor.ComponentExecResult {
+	return []executor.ComponentExecResult{
+		{Command: "typecheck", ExecResult: &executor.ExecResult{ExitCode: 1, Stdout: "src/app.ts:3: type error\n"}},
+		{Command: "test", SkippedBy: "typecheck"},
+	}
+}
+
+func TestReport_Skipped(t *testing.T) {
+	want := "Skipped test: prerequisite typecheck failed"
+
+	for _, format := range []string{FormatDefault, FormatCompact} {
+		t.Run(format, func(t *testing.T) {
+			r := NewErrorReporter()
+			if err := r.SetFormat(format); err != nil {
+				t.Fatal(err)
+			}
+			result := r.Report(prerequisiteFailure())
+			if result.ExitCode != 2 {
+				t.Errorf("expected exit code 2, got %d", result.ExitCode)
+			}
+			if !strings.Contains(result.Stderr, "type error") || !strings.Contains(result.Stderr, want) {
+				t.Errorf("expected the prerequisite errors and %q, got:\n%s", want, result.Stderr)
+			}
+		})
+	}
+
+	t.Run("execution error", func(t *testing.T) {
+		results := prerequisiteFailure()
+		results[0] = executor.ComponentExecResult{Command: "typecheck", ExecutionError: errors.New("boom")}
+		result := NewErrorReporter().Report(results)
+		if result.ExitCode != 1 || !strings.Contains(result.Stderr, want) {
+			t.Errorf("expected exit code 1 and %q, got %d:\n%s", want, result.ExitCode, result.Stderr)
+		}
+	})
+
+	t.Run("structured formats", func(t *testing.T) {
+		r := NewErrorReporter()
+		jsonReport := r.ReportJSON(prerequisiteFailure())
+		if got := jsonReport.Components[1].SkippedBy; got != "typecheck" {
+			t.Errorf("expected skippedBy typecheck in the JSON report, got %q", got)
+		}
+
+		junit := r.ReportJUnit(prerequisiteFailure())
+		testSuite := junit.Suites[1]
+		if testSuite.Skipped != 1 || testSuite.Cases[0].Skipped == nil || testSuite.Cases[0].Skipped.Message != want {
+			t.Errorf("expected a skipped test case, got %+v", testSuite)
+		}
+		if r.outcome(prerequisiteFailure()[1]) != "skipped" {
+			t.Errorf("expected the skipped outcome")
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package reporter
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// SetNormalizePaths rewrites the file of error locations in reported output
+// to use forward slashes, so "src\app.js:3: error" on Windows is grouped and
+// reported like "src/app.js:3: error". Elsewhere a backslash is a valid file
+// name character and paths are left unchanged.
+func (r *ErrorReporter) SetNormalizePaths(normalize bool) {
+	r.normalizePaths = normalize
+}
+
+// slashLocation rewrites the file of the error location at the start of line
+// to use forward slashes
+func slashLocation(line string) string {
+	m := locationPattern.FindStringSubmatchIndex(line)
+	if m == nil {
+		return line
+	}
+	start, end := m[2], m[3]
+	return line[:start] + filepath.ToSlash(line[start:end]) + line[end:]
+}
+
+// slashLocations rewrites the error locations of every line of s
+func slashLocations(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = slashLocation(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// slashPaths normalizes the error locations in the output of results.
+// Results are copied; the originals are left untouched.
+func (r *ErrorReporter) slashPaths(results []executor.ComponentExecResult) []executor.ComponentExecResult {
+	if !r.normalizePaths || filepath.Separator == '/' || len(results) == 0 {
+		return results
+	}
+
+	normalized := append([]executor.ComponentExecResult(nil), results...)
+	for i, result := range normalized {
+		if result.ExecResult != nil {
+			execResult := *result.ExecResult
+			execResult.Stdout = slashLocations(execResult.Stdout)
+			execResult.Stderr = slashLocations(execResult.Stderr)
+			normalized[i].ExecResult = &execResult
+		}
+		if result.FilteredOutput != nil {
+			output := *result.FilteredOutput
+			output.Lines = make([]string, len(result.FilteredOutput.Lines))
+			for li, line := range result.FilteredOutput.Lines {
+				output.Lines[li] = slashLocation(line)
+			}
+			normalized[i].FilteredOutput = &output
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,68 @@
+//go:build unit
+
+package reporter
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// componentLintResult is a failing lint run of a component reporting lines
+func componentLintResult(path string, lines ...string) executor.ComponentExecResult {
+	return executor.ComponentExecResult{
+		Path:           path,
+		Command:        "lint",
+		CommandConfig:  &config.CommandConfig{Command: "eslint", ExitCodes: []int{1}},
+		ExecResult:     &executor.ExecResult{ExitCode: 1, Stdout: strings.Join(lines, "\n")},
+		FilteredOutput: &filter.FilteredOutput{Lines: lines, HasErrors: true},
+	}
+}
+
+func TestReport_NormalizesBackslashPathsOnWindows(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("backslashes only separate paths on Windows")
+	}
+
+	results := []executor.ComponentExecResult{
+		componentLintResult("web", `src\app.js:3:1: error no-unused-vars`),
+		componentLintResult("api", `src/app.js:3:1: error no-unused-vars`),
+	}
+
+	r := NewErrorReporter()
+	r.SetDedup(true)
+	r.SetNormalizePaths(true)
+	if err := r.SetFormat(FormatCompact); err != nil {
+		t.Fatal(err)
+	}
+	report := r.Report(results)
+
+	if strings.Contains(report.Stderr, `src\app.js`) {
+		t.Errorf("expected backslash paths to be normalized, got:\n%s", report.Stderr)
+	}
+	if got := strings.Count(report.Stderr, "src/app.js:3:1"); got != 1 {
+		t.Errorf("expected the error to be grouped once, got %d in:\n%s", got, report.Stderr)
+	}
+
+	if got := slashLocation(`C:\work\app\src\a.ts(4,2): error TS2345 in lib\b.ts`); got != `C:/work/app/src/a.ts(4,2): error TS2345 in lib\b.ts` {
+		t.Errorf("expected only the location to be normalized, got %q", got)
+	}
+}
+
+func TestReport_KeepsBackslashesOutsideWindows(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("backslashes separate paths on Windows")
+	}
+
+	r := NewErrorReporter()
+	r.SetNormalizePaths(true)
+	report := r.Report([]executor.ComponentExecResult{componentLintResult("web", `src\app.js:3:1: error no-unused-vars`)})
+
+	if !strings.Contains(report.Stderr, `src\app.js:3:1`) {
+		t.Errorf("expected the file name to be kept, got:\n%s", report.Stderr)
+	}
+}
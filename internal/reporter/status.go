@@ -0,0 +1,62 @@
+package reporter
+
+import (
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// Status is a small machine-readable summary of a run for CI gating
+type Status struct {
+	// Passed is true when every component passed
+	Passed bool `json:"passed"`
+	// ExitCode is the exit code qualhook returns for the run
+	ExitCode int `json:"exitCode"`
+	// ErrorCount is the total number of reported errors
+	ErrorCount int `json:"errorCount"`
+	// Commands holds the outcome of each executed component
+	Commands []CommandStatus `json:"commands"`
+}
+
+// CommandStatus is the outcome of one command for one component
+type CommandStatus struct {
+	Command    string `json:"command"`
+	Path       string `json:"path,omitempty"`
+	Passed     bool   `json:"passed"`
+	ExitCode   int    `json:"exitCode"`
+	ErrorCount int    `json:"errorCount"`
+}
+
+// BuildStatus summarizes results independently of the report format
+func (r *ErrorReporter) BuildStatus(results []executor.ComponentExecResult) *Status {
+	exitCode := r.exitCode(results)
+
+	status := &Status{
+		Passed:   exitCode == 0,
+		ExitCode: exitCode,
+		Commands: make([]CommandStatus, 0, len(results)),
+	}
+
+	for _, result := range results {
+		commandStatus := CommandStatus{
+			Command: result.Command,
+			Path:    result.Path,
+			Passed:  true,
+		}
+		if result.ExecResult != nil {
+			commandStatus.ExitCode = result.ExecResult.ExitCode
+		}
+
+		switch {
+		case executionError(result) != nil:
+			commandStatus.Passed = false
+			commandStatus.ErrorCount = 1
+		case r.hasErrors(result):
+			commandStatus.Passed = false
+			commandStatus.ErrorCount = len(errorEntries(result))
+		}
+
+		status.ErrorCount += commandStatus.ErrorCount
+		status.Commands = append(status.Commands, commandStatus)
+	}
+
+	return status
+}
@@ -0,0 +1,72 @@
+//go:build unit
+
+package reporter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestBuildStatus_MixedRun(t *testing.T) {
+	cmdConfig := &config.CommandConfig{ExitCodes: []int{1}}
+	results := []executor.ComponentExecResult{
+		{
+			Path:           "frontend/**",
+			Command:        "lint",
+			CommandConfig:  cmdConfig,
+			ExecResult:     &executor.ExecResult{ExitCode: 0},
+			FilteredOutput: &filter.FilteredOutput{},
+		},
+		{
+			Path:          "backend/**",
+			Command:       "lint",
+			CommandConfig: cmdConfig,
+			ExecResult:    &executor.ExecResult{ExitCode: 1},
+			FilteredOutput: &filter.FilteredOutput{
+				Lines:     []string{"main.go:1:1: unused import", "main.go:9:2: undefined: x"},
+				HasErrors: true,
+			},
+		},
+	}
+
+	status := NewErrorReporter().BuildStatus(results)
+	if status.Passed || status.ExitCode != 2 {
+		t.Errorf("expected failing status with exit code 2, got passed=%v exit=%d", status.Passed, status.ExitCode)
+	}
+	if status.ErrorCount != 2 {
+		t.Errorf("expected 2 errors in total, got %d", status.ErrorCount)
+	}
+	if len(status.Commands) != 2 {
+		t.Fatalf("expected 2 command outcomes, got %d", len(status.Commands))
+	}
+	if c := status.Commands[0]; !c.Passed || c.ErrorCount != 0 || c.Path != "frontend/**" {
+		t.Errorf("unexpected passing outcome: %+v", c)
+	}
+	if c := status.Commands[1]; c.Passed || c.ErrorCount != 2 || c.ExitCode != 1 {
+		t.Errorf("unexpected failing outcome: %+v", c)
+	}
+
+	// The status is independent of the report format
+	r := NewErrorReporter()
+	if err := r.SetFormat(FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := r.BuildStatus(results); got.ExitCode != 2 || got.ErrorCount != 2 {
+		t.Errorf("expected same status with JSON format, got %+v", got)
+	}
+}
+
+func TestBuildStatus_ExecutionError(t *testing.T) {
+	results := []executor.ComponentExecResult{
+		{Command: "test", ExecutionError: errors.New("boom")},
+	}
+
+	status := NewErrorReporter().BuildStatus(results)
+	if status.Passed || status.ExitCode != 1 || status.Commands[0].Passed {
+		t.Errorf("expected execution error to fail the run, got %+v", status)
+	}
+}
@@ -0,0 +1,96 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// StreamReporter emits each failing component's report as soon as the
+// component completes, followed by a summary once all components are done.
+// It is safe for concurrent use.
+type StreamReporter struct {
+	reporter *ErrorReporter
+	w        io.Writer
+
+	mu      sync.Mutex
+	results []executor.ComponentExecResult
+	failed  []string
+	// written counts the bytes of component reports written so far
+	written int
+	// suppressed is set once the total output limit cut off a report
+	suppressed bool
+}
+
+// NewStreamReporter creates a stream reporter writing component reports to w
+func NewStreamReporter(reporter *ErrorReporter, w io.Writer) *StreamReporter {
+	return &StreamReporter{
+		reporter: reporter,
+		w:        w,
+	}
+}
+
+// Add records a completed component and writes its report immediately if it failed
+func (s *StreamReporter) Add(result executor.ComponentExecResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.results = append(s.results, result)
+
+	report := s.reporter.Report([]executor.ComponentExecResult{result})
+	if report.ExitCode == 0 {
+		return
+	}
+
+	s.failed = append(s.failed, fmt.Sprintf("%s (%s)", componentName(result), result.Command))
+
+	var out strings.Builder
+	if result.Path != "" {
+		out.WriteString(fmt.Sprintf("## %s\n\n", result.Path))
+	}
+	out.WriteString(report.Stderr)
+	out.WriteString("\n\n")
+	_, _ = io.WriteString(s.w, s.limit(out.String())) //nolint:errcheck // Best effort streaming output
+}
+
+// limit trims a component report to the remaining total output budget. Once
+// the budget is used up, later reports are suppressed after a single note.
+func (s *StreamReporter) limit(output string) string {
+	maxBytes := s.reporter.maxTotalOutputBytes
+	if maxBytes <= 0 {
+		return output
+	}
+	if s.suppressed {
+		return ""
+	}
+
+	remaining := maxBytes - s.written
+	if len(output) > remaining {
+		s.suppressed = true
+		if remaining <= 0 {
+			return suppressedOutputNote(len(output), maxBytes) + "\n\n"
+		}
+		output = trimOutput(output, remaining, maxBytes) + "\n\n"
+	}
+	s.written += len(output)
+	return output
+}
+
+// Finish returns the final report for all added components. Errors were
+// already written by Add, so on failure Stderr only holds a summary.
+func (s *StreamReporter) Finish() *ReportResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	final := s.reporter.Report(s.results)
+	if final.ExitCode == 0 {
+		return final
+	}
+
+	final.Stderr = fmt.Sprintf("[QUALHOOK] %d of %d component(s) reported errors: %s",
+		len(s.failed), len(s.results), strings.Join(s.failed, ", "))
+	return final
+}
@@ -0,0 +1,84 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// Summary tallies the components of a run by outcome
+type Summary struct {
+	// Components is the number of components run
+	Components int `json:"components"`
+	// Failed counts the components that reported errors or could not run
+	Failed int `json:"failed"`
+	// Skipped counts the components skipped after a prerequisite failed
+	Skipped int `json:"skipped,omitempty"`
+	// FailedByCommand counts the failed components of each command
+	FailedByCommand map[string]int `json:"failedByCommand,omitempty"`
+
+	// commands lists the failed commands in order of first appearance
+	commands []string
+}
+
+// Summarize tallies the outcome of each component in results
+func (r *ErrorReporter) Summarize(results []executor.ComponentExecResult) Summary {
+	summary := Summary{Components: len(results)}
+	for _, result := range results {
+		switch {
+		case result.SkippedBy != "":
+			summary.Skipped++
+		case executionError(result) != nil || r.hasErrors(result):
+			summary.Failed++
+			if summary.FailedByCommand == nil {
+				summary.FailedByCommand = make(map[string]int)
+			}
+			if summary.FailedByCommand[result.Command] == 0 {
+				summary.commands = append(summary.commands, result.Command)
+			}
+			summary.FailedByCommand[result.Command]++
+		}
+	}
+	return summary
+}
+
+// String formats the summary as "3 of 15 components failed (lint: 2, test: 1)"
+func (s Summary) String() string {
+	noun := "components"
+	if s.Components == 1 {
+		noun = "component"
+	}
+	if s.Failed == 0 && s.Skipped == 0 {
+		return fmt.Sprintf("All %d %s passed", s.Components, noun)
+	}
+
+	line := fmt.Sprintf("%d of %d %s failed", s.Failed, s.Components, noun)
+	if len(s.commands) > 0 {
+		counts := make([]string, len(s.commands))
+		for i, command := range s.commands {
+			counts[i] = fmt.Sprintf("%s: %d", command, s.FailedByCommand[command])
+		}
+		line += " (" + strings.Join(counts, ", ") + ")"
+	}
+	if s.Skipped > 0 {
+		line += fmt.Sprintf(", %d skipped", s.Skipped)
+	}
+	return line
+}
+
+// SetSummary controls whether failed text reports of several components end
+// with a summary line
+func (r *ErrorReporter) SetSummary(summary bool) {
+	r.summary = summary
+}
+
+// summaryNotice returns the summary line that ends a failed text report of
+// several components; it is empty when disabled and in compact reports,
+// which hold one line per error
+func (r *ErrorReporter) summaryNotice(results []executor.ComponentExecResult) string {
+	if !r.summary || r.format == FormatCompact || len(results) < 2 {
+		return ""
+	}
+	return "\n\n" + r.Summarize(results).String()
+}
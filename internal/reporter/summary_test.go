@@ -0,0 +1,87 @@
+//go:build unit
+
+package reporter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// summaryResults holds two failed lint components, a failed test component,
+// a passing one and one skipped after its prerequisite failed
+func summaryResults() []executor.ComponentExecResult {
+	failing := &executor.ExecResult{ExitCode: 1, Stderr: "error"}
+	return []executor.ComponentExecResult{
+		{Command: "lint", Path: "web", ExecResult: failing},
+		{Command: "test", Path: "web", ExecResult: failing},
+		{Command: "lint", Path: "api", ExecResult: failing},
+		{Command: "lint", Path: "docs", ExecResult: &executor.ExecResult{ExitCode: 0}},
+		{Command: "e2e", SkippedBy: "test"},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	summary := NewErrorReporter().Summarize(summaryResults())
+	if summary.Components != 5 || summary.Failed != 3 || summary.Skipped != 1 {
+		t.Errorf("Summarize() = %+v, want 3 of 5 failed and 1 skipped", summary)
+	}
+	if got, want := summary.String(), "3 of 5 components failed (lint: 2, test: 1), 1 skipped"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	passed := NewErrorReporter().Summarize(summaryResults()[3:4])
+	if got := passed.String(); got != "All 1 component passed" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestReport_Summary(t *testing.T) {
+	reporter := NewErrorReporter()
+	if strings.Contains(reporter.Report(summaryResults()).Stderr, "components failed") {
+		t.Error("expected no summary unless enabled")
+	}
+
+	reporter.SetSummary(true)
+	report := reporter.Report(summaryResults())
+	if !strings.HasSuffix(report.Stderr, "\n\n3 of 5 components failed (lint: 2, test: 1), 1 skipped") {
+		t.Errorf("expected the report to end with the summary, got:\n%s", report.Stderr)
+	}
+
+	// A single component needs no tally
+	if single := reporter.Report(summaryResults()[:1]); strings.Contains(single.Stderr, "components failed") {
+		t.Errorf("expected no summary for one component, got:\n%s", single.Stderr)
+	}
+
+	if err := reporter.SetFormat(FormatCompact); err != nil {
+		t.Fatal(err)
+	}
+	if compact := reporter.Report(summaryResults()); strings.Contains(compact.Stderr, "components failed") {
+		t.Errorf("expected no summary in compact reports, got:\n%s", compact.Stderr)
+	}
+}
+
+func TestReportJSON_Summary(t *testing.T) {
+	reporter := NewErrorReporter()
+	if err := reporter.SetFormat(FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	var report struct {
+		Summary struct {
+			Components      int            `json:"components"`
+			Failed          int            `json:"failed"`
+			Skipped         int            `json:"skipped"`
+			FailedByCommand map[string]int `json:"failedByCommand"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(reporter.Report(summaryResults()).Stdout), &report); err != nil {
+		t.Fatalf("invalid JSON report: %v", err)
+	}
+	summary := report.Summary
+	if summary.Components != 5 || summary.Failed != 3 || summary.Skipped != 1 || summary.FailedByCommand["lint"] != 2 || summary.FailedByCommand["test"] != 1 {
+		t.Errorf("unexpected JSON summary: %+v", summary)
+	}
+}
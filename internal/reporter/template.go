@@ -0,0 +1,61 @@
+package reporter
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// applyOutputTemplates rewrites the matched lines of components whose command
+// sets an outputTemplate, using the named groups captured by the filter.
+// Lines without captures are kept as they are. Results are copied; the
+// originals are left untouched.
+func applyOutputTemplates(results []executor.ComponentExecResult) []executor.ComponentExecResult {
+	var rewritten []executor.ComponentExecResult
+	for i, result := range results {
+		output := result.FilteredOutput
+		if output == nil || len(output.Captures) == 0 || result.CommandConfig == nil || result.CommandConfig.OutputTemplate == "" {
+			continue
+		}
+
+		tmpl, err := template.New("output").Option("missingkey=zero").Parse(result.CommandConfig.OutputTemplate)
+		if err != nil {
+			// Templates are validated when the configuration is loaded
+			debug.LogError(err, "parsing output template")
+			continue
+		}
+
+		if rewritten == nil {
+			rewritten = append([]executor.ComponentExecResult(nil), results...)
+		}
+		templated := *output
+		templated.Lines = make([]string, len(output.Lines))
+		templated.Captures = nil
+		for li, line := range output.Lines {
+			templated.Lines[li] = renderLine(tmpl, line, output.Captures, li)
+		}
+		rewritten[i].FilteredOutput = &templated
+	}
+
+	if rewritten == nil {
+		return results
+	}
+	return rewritten
+}
+
+// renderLine executes the template with the groups captured from a line,
+// keeping the line unchanged when it has none or rendering fails
+func renderLine(tmpl *template.Template, line string, captures []map[string]string, index int) string {
+	if index >= len(captures) || captures[index] == nil {
+		return line
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, captures[index]); err != nil {
+		debug.LogError(err, "rendering output template")
+		return line
+	}
+	return out.String()
+}
@@ -0,0 +1,79 @@
+//go:build unit
+
+package reporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestReport_OutputTemplate(t *testing.T) {
+	newResults := func(tmpl string) []executor.ComponentExecResult {
+		return []executor.ComponentExecResult{
+			{
+				Command: "lint",
+				CommandConfig: &config.CommandConfig{
+					Command:        "eslint",
+					ExitCodes:      []int{1},
+					OutputTemplate: tmpl,
+				},
+				ExecResult: &executor.ExecResult{ExitCode: 1},
+				FilteredOutput: &filter.FilteredOutput{
+					Lines: []string{
+						"src/app.js:10:5: no-unused-vars: 'x' is never used",
+						"  context line",
+					},
+					Captures: []map[string]string{
+						{"file": "src/app.js", "line": "10", "code": "no-unused-vars", "message": "'x' is never used"},
+						nil,
+					},
+					HasErrors: true,
+				},
+			},
+		}
+	}
+
+	t.Run("rewrites captured lines", func(t *testing.T) {
+		results := newResults("{{.file}}:{{.line}} [{{.code}}] {{.message}}")
+		report := NewErrorReporter().Report(results)
+
+		if !strings.Contains(report.Stderr, "src/app.js:10 [no-unused-vars] 'x' is never used") {
+			t.Errorf("expected rewritten line, got:\n%s", report.Stderr)
+		}
+		if !strings.Contains(report.Stderr, "  context line") {
+			t.Errorf("expected line without captures to be kept, got:\n%s", report.Stderr)
+		}
+		if results[0].FilteredOutput.Lines[0] != "src/app.js:10:5: no-unused-vars: 'x' is never used" {
+			t.Error("expected the original results to be left untouched")
+		}
+	})
+
+	t.Run("missing groups render empty", func(t *testing.T) {
+		report := NewErrorReporter().Report(newResults("{{.file}}{{.severity}}: {{.message}}"))
+		if !strings.Contains(report.Stderr, "src/app.js: 'x' is never used") {
+			t.Errorf("expected missing group to render empty, got:\n%s", report.Stderr)
+		}
+	})
+
+	t.Run("no template keeps lines unchanged", func(t *testing.T) {
+		report := NewErrorReporter().Report(newResults(""))
+		if !strings.Contains(report.Stderr, "src/app.js:10:5: no-unused-vars: 'x' is never used") {
+			t.Errorf("expected original line, got:\n%s", report.Stderr)
+		}
+	})
+
+	t.Run("compact format parses the rewritten location", func(t *testing.T) {
+		reporter := NewErrorReporter()
+		if err := reporter.SetFormat(FormatCompact); err != nil {
+			t.Fatal(err)
+		}
+		report := reporter.Report(newResults("{{.file}}:{{.line}}: {{.message}} ({{.code}})"))
+		if !strings.Contains(report.Stderr, ". lint src/app.js:10 'x' is never used (no-unused-vars)") {
+			t.Errorf("unexpected compact output:\n%s", report.Stderr)
+		}
+	})
+}
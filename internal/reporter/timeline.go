@@ -0,0 +1,123 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// FormatTimeline lists when each component started and finished, followed
+// by the default report
+const FormatTimeline = "timeline"
+
+// TimelineEvent is a component starting or finishing
+type TimelineEvent struct {
+	// At is the time of the event
+	At time.Time
+	// Finished is false for a start event
+	Finished bool
+	// Component is the component name and Command the command it ran
+	Component string
+	Command   string
+	// Duration and Outcome are set for finish events
+	Duration time.Duration
+	Outcome  string
+}
+
+// Timeline returns the start and finish events of results in chronological
+// order; results without timing are left out
+func (r *ErrorReporter) Timeline(results []executor.ComponentExecResult) []TimelineEvent {
+	var events []TimelineEvent
+	for _, result := range results {
+		if result.StartedAt.IsZero() {
+			continue
+		}
+		name := componentName(result)
+		events = append(events,
+			TimelineEvent{At: result.StartedAt, Component: name, Command: result.Command},
+			TimelineEvent{
+				At:        result.StartedAt.Add(result.Duration),
+				Finished:  true,
+				Component: name,
+				Command:   result.Command,
+				Duration:  result.Duration,
+				Outcome:   r.outcome(result),
+			})
+	}
+
+	// Starts come before finishes at the same instant
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].At.Equal(events[j].At) {
+			return events[i].At.Before(events[j].At)
+		}
+		return !events[i].Finished && events[j].Finished
+	})
+	return events
+}
+
+// outcome describes how a component run ended
+func (r *ErrorReporter) outcome(result executor.ComponentExecResult) string {
+	switch {
+	case result.SkippedBy != "":
+		return "skipped"
+	case result.ExecResult != nil && result.ExecResult.Canceled:
+		return "canceled"
+	case result.ExecResult != nil && result.ExecResult.TimedOut:
+		return "timed out"
+	case executionError(result) != nil:
+		return "execution error"
+	case r.hasErrors(result):
+		return "failed"
+	default:
+		return "passed"
+	}
+}
+
+// formatTimeline renders the timeline of results, with times relative to
+// the first start
+func (r *ErrorReporter) formatTimeline(results []executor.ComponentExecResult) string {
+	events := r.Timeline(results)
+	if len(events) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("Timeline:\n")
+	start := events[0].At
+	for _, event := range events {
+		offset := formatTimelineDuration(event.At.Sub(start))
+		if event.Finished {
+			b.WriteString(fmt.Sprintf("  +%s  finished  %s (%s)  %s in %s\n",
+				offset, event.Component, event.Command, event.Outcome, formatTimelineDuration(event.Duration)))
+		} else {
+			b.WriteString(fmt.Sprintf("  +%s  started   %s (%s)\n", offset, event.Component, event.Command))
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatTimelineDuration formats d in seconds with millisecond precision
+func formatTimelineDuration(d time.Duration) string {
+	return fmt.Sprintf("%.3fs", d.Seconds())
+}
+
+// reportTimeline writes the timeline to stdout and the default report of
+// results as usual
+func (r *ErrorReporter) reportTimeline(results []executor.ComponentExecResult) *ReportResult {
+	textReporter := *r
+	textReporter.format = FormatDefault
+	report := textReporter.Report(results)
+
+	timeline := r.formatTimeline(results)
+	switch {
+	case timeline == "":
+	case report.Stdout == "":
+		report.Stdout = timeline
+	default:
+		report.Stdout = timeline + "\n\n" + report.Stdout
+	}
+	return report
+}
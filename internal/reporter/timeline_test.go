@@ -0,0 +1,109 @@
+//go:build unit
+
+package reporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// timelineResults are three components started together that finish in
+// the order api, docs, web
+func timelineResults(start time.Time) []executor.ComponentExecResult {
+	cmdConfig := &config.CommandConfig{Command: "eslint", ExitCodes: []int{1}}
+	return []executor.ComponentExecResult{
+		{
+			Path: "web/**", Command: "lint", CommandConfig: cmdConfig,
+			ExecResult: &executor.ExecResult{}, StartedAt: start, Duration: 3 * time.Second,
+		},
+		{
+			Path: "api/**", Command: "lint", CommandConfig: cmdConfig,
+			ExecResult:     &executor.ExecResult{ExitCode: 1},
+			FilteredOutput: &filter.FilteredOutput{Lines: []string{"api/x.js:3:1: bad"}, HasErrors: true},
+			StartedAt:      start.Add(10 * time.Millisecond), Duration: 1200 * time.Millisecond,
+		},
+		{
+			Path: "docs/**", Command: "lint", CommandConfig: cmdConfig,
+			ExecResult: &executor.ExecResult{ExitCode: -1, TimedOut: true},
+			StartedAt:  start.Add(20 * time.Millisecond), Duration: 2 * time.Second,
+		},
+	}
+}
+
+func TestTimeline_CompletionOrder(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	events := NewErrorReporter().Timeline(timelineResults(start))
+
+	var finished []string
+	for _, event := range events {
+		if event.Finished {
+			finished = append(finished, event.Component+" "+event.Outcome+" "+event.Duration.String())
+		}
+	}
+	want := []string{"api failed 1.2s", "docs timed out 2s", "web passed 3s"}
+	if strings.Join(finished, ", ") != strings.Join(want, ", ") {
+		t.Errorf("finish events = %v, want %v", finished, want)
+	}
+
+	for i := 1; i < len(events); i++ {
+		if events[i].At.Before(events[i-1].At) {
+			t.Fatalf("events out of order at %d: %+v", i, events)
+		}
+	}
+	if events[0].Finished || events[0].Component != "web" {
+		t.Errorf("expected the web start first, got %+v", events[0])
+	}
+}
+
+func TestReport_TimelineFormat(t *testing.T) {
+	reporter := NewErrorReporter()
+	if err := reporter.SetFormat(FormatTimeline); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	report := reporter.Report(timelineResults(start))
+	if report.ExitCode != DefaultErrorExitCode {
+		t.Errorf("expected exit code %d, got %d", DefaultErrorExitCode, report.ExitCode)
+	}
+
+	want := `Timeline:
+  +0.000s  started   web (lint)
+  +0.010s  started   api (lint)
+  +0.020s  started   docs (lint)
+  +1.210s  finished  api (lint)  failed in 1.200s
+  +2.020s  finished  docs (lint)  timed out in 2.000s
+  +3.000s  finished  web (lint)  passed in 3.000s`
+	if report.Stdout != want {
+		t.Errorf("timeline =\n%s\nwant\n%s", report.Stdout, want)
+	}
+	if !strings.Contains(report.Stderr, "api/x.js:3:1: bad") {
+		t.Errorf("expected the default report on stderr, got:\n%s", report.Stderr)
+	}
+}
+
+func TestReport_TimelinePassing(t *testing.T) {
+	reporter := NewErrorReporter()
+	if err := reporter.SetFormat(FormatTimeline); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	report := reporter.Report([]executor.ComponentExecResult{
+		{Command: "test", ExecResult: &executor.ExecResult{}, StartedAt: start, Duration: 500 * time.Millisecond},
+	})
+	if report.ExitCode != 0 {
+		t.Errorf("expected exit code 0, got %d", report.ExitCode)
+	}
+	if !strings.HasPrefix(report.Stdout, "Timeline:\n") || !strings.HasSuffix(report.Stdout, "All quality checks passed successfully.") {
+		t.Errorf("expected the timeline followed by the success message, got:\n%s", report.Stdout)
+	}
+	if !strings.Contains(report.Stdout, "finished  . (test)  passed in 0.500s") {
+		t.Errorf("expected the finish event, got:\n%s", report.Stdout)
+	}
+}
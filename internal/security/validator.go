@@ -2,6 +2,7 @@
 package security
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,10 +11,16 @@ import (
 	"time"
 )
 
+// ErrCommandNotAllowed marks commands rejected by the allowed or denied
+// command lists
+var ErrCommandNotAllowed = errors.New("not allowed by the security configuration")
+
 // SecurityValidator provides comprehensive security validation
 type SecurityValidator struct {
 	// Command whitelist - empty means all commands allowed
 	allowedCommands map[string]bool
+	// Command blacklist, checked before the whitelist
+	deniedCommands map[string]bool
 	// Maximum allowed timeout
 	maxTimeout time.Duration
 	// Maximum regex pattern length
@@ -52,10 +59,15 @@ func (v *SecurityValidator) ValidateCommand(command string, args []string) error
 	// Extract base command name
 	baseCommand := filepath.Base(command)
 
+	// Check against blacklist if configured
+	if v.deniedCommands[command] || v.deniedCommands[baseCommand] {
+		return fmt.Errorf("command '%s' is in the denied command list: %w", command, ErrCommandNotAllowed)
+	}
+
 	// Check against whitelist if configured
 	if len(v.allowedCommands) > 0 {
 		if !v.allowedCommands[command] && !v.allowedCommands[baseCommand] {
-			return fmt.Errorf("command '%s' is not in the allowed command list", command)
+			return fmt.Errorf("command '%s' is not in the allowed command list: %w", command, ErrCommandNotAllowed)
 		}
 	}
 
@@ -419,6 +431,15 @@ func (v *SecurityValidator) SetAllowedCommands(commands []string) {
 	}
 }
 
+// SetDeniedCommands updates the list of denied commands, matched like the
+// allowed commands against the command or its base name
+func (v *SecurityValidator) SetDeniedCommands(commands []string) {
+	v.deniedCommands = make(map[string]bool)
+	for _, cmd := range commands {
+		v.deniedCommands[cmd] = true
+	}
+}
+
 // SetMaxTimeout updates the maximum allowed timeout
 func (v *SecurityValidator) SetMaxTimeout(timeout time.Duration) {
 	v.maxTimeout = timeout
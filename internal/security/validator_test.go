@@ -142,6 +142,22 @@ func TestValidateCommandWithWhitelist(t *testing.T) {
 	}
 }
 
+func TestValidateCommandWithBlacklist(t *testing.T) {
+	v := NewSecurityValidator()
+	v.SetAllowedCommands([]string{"npm", "curl"})
+	v.SetDeniedCommands([]string{"curl"})
+
+	if err := v.ValidateCommand("npm", nil); err != nil {
+		t.Errorf("expected npm to be allowed, got %v", err)
+	}
+	for _, command := range []string{"curl", "/usr/bin/curl"} {
+		err := v.ValidateCommand(command, nil)
+		if err == nil || !strings.Contains(err.Error(), "denied command list") {
+			t.Errorf("expected %s to be denied even though allowed, got %v", command, err)
+		}
+	}
+}
+
 func TestValidatePath(t *testing.T) {
 	v := NewSecurityValidator()
 
@@ -0,0 +1,91 @@
+package selftest
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// CheckCommands runs every configured root command and reports whether it is
+// healthy, i.e. exits with its expectedExitCode (0 unless configured)
+func (r *Runner) CheckCommands(cfg *config.Config) *Report {
+	debug.LogSection("Doctor")
+
+	names := make([]string, 0, len(cfg.Commands))
+	for name := range cfg.Commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := &Report{}
+	for _, name := range names {
+		check := r.CheckCommand(name, cfg.Commands[name])
+		debug.Log("Check %q passed=%v: %s", check.Name, check.Passed, check.Details)
+		report.Checks = append(report.Checks, check)
+	}
+
+	return report
+}
+
+// ApplySecurity restricts the commands CheckCommand runs to the security
+// settings of a configuration, with working directories relative to root
+func (r *Runner) ApplySecurity(root string, settings *config.SecurityConfig) error {
+	return r.executor.ApplySecurity(root, settings)
+}
+
+// CheckCommand runs a single configured command like a normal run would, in
+// its working directory with its env, and compares its exit code with the
+// expected exit code of a healthy run
+func (r *Runner) CheckCommand(name string, cmd *config.CommandConfig) Check {
+	check := Check{Name: name}
+	if cmd == nil {
+		check.Details = "command is not configured"
+		return check
+	}
+
+	if err := r.validator.ValidateCommand(cmd.Command, cmd.Args); err != nil {
+		check.Details = err.Error()
+		return check
+	}
+
+	env, err := executor.CommandEnvironment(cmd, true)
+	if err != nil {
+		check.Details = err.Error()
+		return check
+	}
+	options := executor.ExecOptions{
+		WorkingDir:  cmd.WorkingDir,
+		Environment: env,
+		InheritEnv:  true,
+		PathPrepend: cmd.PathPrepend,
+	}
+	if cmd.Timeout > 0 {
+		options.Timeout = time.Duration(cmd.Timeout) * time.Millisecond
+	}
+
+	var backend executor.Backend = r.executor
+	if sandbox := cmd.Sandbox; sandbox != nil {
+		backend = executor.NewContainerExecutor(r.executor, sandbox.Runtime, sandbox.Image, sandbox.Writable)
+	}
+
+	result, err := backend.Execute(cmd.Command, cmd.Args, options)
+	switch {
+	case err != nil:
+		check.Details = err.Error()
+	case result.TimedOut:
+		check.Details = "command timed out"
+	case result.Error != nil:
+		check.Details = result.Error.Error()
+	case result.ExitCode != cmd.ExpectedExitCode:
+		check.Details = fmt.Sprintf("expected exit code %d, got %d", cmd.ExpectedExitCode, result.ExitCode)
+	default:
+		check.Passed = true
+		check.Details = fmt.Sprintf("exit code %d", result.ExitCode)
+	}
+
+	return check
+}
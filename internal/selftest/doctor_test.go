@@ -0,0 +1,142 @@
+//go:build unit
+
+package selftest
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestCheckCommand_ExpectedExitCode(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("sh is not available on Windows")
+	}
+
+	tests := []struct {
+		name     string
+		exitCode string
+		expected int
+		healthy  bool
+	}{
+		{"zero exit is healthy by default", "0", 0, true},
+		{"non-zero exit is unhealthy by default", "1", 0, false},
+		{"matching expected exit code is healthy", "1", 1, true},
+		{"zero exit is unhealthy when another code is expected", "0", 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &config.CommandConfig{
+				Command:          "sh",
+				Args:             []string{"-c", "exit " + tt.exitCode},
+				ExpectedExitCode: tt.expected,
+			}
+
+			check := NewRunner().CheckCommand("format", cmd)
+			if check.Passed != tt.healthy {
+				t.Errorf("Passed = %v, want %v (%s)", check.Passed, tt.healthy, check.Details)
+			}
+			if !tt.healthy && !strings.Contains(check.Details, "expected exit code") {
+				t.Errorf("expected details to explain the exit code mismatch, got %q", check.Details)
+			}
+		})
+	}
+}
+
+func TestCheckCommands(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("sh is not available on Windows")
+	}
+
+	cfg := &config.Config{
+		Version: "1.0",
+		Commands: map[string]*config.CommandConfig{
+			"lint":   {Command: "sh", Args: []string{"-c", "exit 2"}},
+			"format": {Command: "sh", Args: []string{"-c", "exit 1"}, ExpectedExitCode: 1},
+		},
+	}
+
+	report := NewRunner().CheckCommands(cfg)
+
+	if len(report.Checks) != 2 {
+		t.Fatalf("expected 2 checks, got %d", len(report.Checks))
+	}
+	if report.Checks[0].Name != "format" || !report.Checks[0].Passed {
+		t.Errorf("expected format to be healthy, got %+v", report.Checks[0])
+	}
+	if report.Checks[1].Name != "lint" || report.Checks[1].Passed {
+		t.Errorf("expected lint to be unhealthy, got %+v", report.Checks[1])
+	}
+	if report.Passed() {
+		t.Error("expected report to fail")
+	}
+}
+
+func TestCheckCommand_RejectsUnsafeCommand(t *testing.T) {
+	check := NewRunner().CheckCommand("lint", &config.CommandConfig{Command: "echo; id"})
+	if check.Passed {
+		t.Error("expected unsafe command to be reported unhealthy")
+	}
+}
+
+func TestCheckCommand_CommandSettings(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("sh is not available on Windows")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "marker"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	runner := NewRunner()
+	if err := runner.ApplySecurity(dir, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cmd  *config.CommandConfig
+	}{
+		{"working directory", &config.CommandConfig{Command: "test", Args: []string{"-f", "marker"}, WorkingDir: dir}},
+		{"env", &config.CommandConfig{
+			Command:    "sh",
+			Args:       []string{"-c", `test "$QUALHOOK_MODE" = ci`},
+			WorkingDir: dir,
+			Env:        map[string]string{"QUALHOOK_MODE": "ci"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if check := runner.CheckCommand("test", tt.cmd); !check.Passed {
+				t.Errorf("expected the command to run with its %s, got %q", tt.name, check.Details)
+			}
+		})
+	}
+}
+
+func TestCheckCommand_DeniedCommand(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("touch is not available on Windows")
+	}
+
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran")
+	runner := NewRunner()
+	if err := runner.ApplySecurity(dir, &config.SecurityConfig{DeniedCommands: []string{"touch"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	check := runner.CheckCommand("lint", &config.CommandConfig{Command: "touch", Args: []string{marker}})
+	if check.Passed || !strings.Contains(check.Details, "not allowed") {
+		t.Errorf("expected the denied command to be reported as not allowed, got %+v", check)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Error("expected the denied command not to run")
+	}
+}
@@ -0,0 +1,223 @@
+// Package selftest provides environment diagnostics for qualhook.
+package selftest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/security"
+)
+
+const windowsOS = "windows"
+
+// Check is the outcome of a single self-test check
+type Check struct {
+	// Name identifies the check
+	Name string
+	// Passed indicates whether the check succeeded
+	Passed bool
+	// Details describes what was observed
+	Details string
+}
+
+// Capabilities describes features of the environment qualhook runs in
+type Capabilities struct {
+	OS              string
+	Arch            string
+	Shell           string
+	ShellAvailable  bool
+	SymlinkSupport  bool
+	TempDirWritable bool
+}
+
+// Report aggregates the results of a self-test run
+type Report struct {
+	Checks       []Check
+	Capabilities Capabilities
+}
+
+// Passed returns true if every check in the report passed
+func (r *Report) Passed() bool {
+	for _, check := range r.Checks {
+		if !check.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Runner runs safe diagnostic commands through the real executor
+type Runner struct {
+	executor  *executor.CommandExecutor
+	validator *security.SecurityValidator
+}
+
+// NewRunner creates a new self-test runner
+func NewRunner() *Runner {
+	return &Runner{
+		executor:  executor.NewCommandExecutor(10 * time.Second),
+		validator: security.NewSecurityValidator(),
+	}
+}
+
+// Run executes all checks and returns a report
+func (r *Runner) Run() *Report {
+	debug.LogSection("Self Test")
+
+	report := &Report{
+		Capabilities: DetectCapabilities(),
+	}
+
+	report.Checks = append(report.Checks,
+		r.checkEcho(),
+		r.checkExitCode("exit code 0", trueCommand(), trueArgs(), false),
+		r.checkExitCode("exit code non-zero", falseCommand(), falseArgs(), true),
+		r.checkRejectsInjection(),
+		r.checkRejectsReDoS(),
+	)
+
+	for _, check := range report.Checks {
+		debug.Log("Check %q passed=%v: %s", check.Name, check.Passed, check.Details)
+	}
+
+	return report
+}
+
+// checkEcho verifies that stdout is captured from a simple command
+func (r *Runner) checkEcho() Check {
+	const marker = "qualhook-selftest"
+	check := Check{Name: "echo output capture"}
+
+	result, err := r.executor.Execute("echo", []string{marker}, executor.ExecOptions{InheritEnv: true})
+	switch {
+	case err != nil:
+		check.Details = err.Error()
+	case result.Error != nil:
+		check.Details = result.Error.Error()
+	case !strings.Contains(result.Stdout, marker):
+		check.Details = "unexpected output: " + strings.TrimSpace(result.Stdout)
+	default:
+		check.Passed = true
+		check.Details = "stdout captured"
+	}
+
+	return check
+}
+
+// checkExitCode verifies that exit codes are propagated from the command
+func (r *Runner) checkExitCode(name, command string, args []string, wantFailure bool) Check {
+	check := Check{Name: name}
+
+	result, err := r.executor.Execute(command, args, executor.ExecOptions{InheritEnv: true})
+	switch {
+	case err != nil:
+		check.Details = err.Error()
+	case result.Error != nil:
+		check.Details = result.Error.Error()
+	case wantFailure && result.ExitCode == 0:
+		check.Details = "expected non-zero exit code, got 0"
+	case !wantFailure && result.ExitCode != 0:
+		check.Details = fmt.Sprintf("expected exit code 0, got %d", result.ExitCode)
+	default:
+		check.Passed = true
+		check.Details = fmt.Sprintf("exit code %d", result.ExitCode)
+	}
+
+	return check
+}
+
+// checkRejectsInjection verifies that shell metacharacters are refused
+func (r *Runner) checkRejectsInjection() Check {
+	check := Check{Name: "security: shell injection rejected"}
+	if err := r.validator.ValidateCommand("echo; id", nil); err != nil {
+		check.Passed = true
+		check.Details = "command rejected"
+		return check
+	}
+	check.Details = "command with shell metacharacters was accepted"
+	return check
+}
+
+// checkRejectsReDoS verifies that catastrophic regex patterns are refused
+func (r *Runner) checkRejectsReDoS() Check {
+	check := Check{Name: "security: ReDoS pattern rejected"}
+	if err := r.validator.ValidateRegexPattern("(a+)+"); err != nil {
+		check.Passed = true
+		check.Details = "pattern rejected"
+		return check
+	}
+	check.Details = "nested quantifier pattern was accepted"
+	return check
+}
+
+// DetectCapabilities inspects the environment for features qualhook relies on
+func DetectCapabilities() Capabilities {
+	caps := Capabilities{
+		OS:   runtime.GOOS,
+		Arch: runtime.GOARCH,
+	}
+
+	shell := "sh"
+	if runtime.GOOS == windowsOS {
+		shell = "cmd"
+	}
+	if path, err := exec.LookPath(shell); err == nil {
+		caps.Shell = path
+		caps.ShellAvailable = true
+	}
+
+	dir, err := os.MkdirTemp("", "qualhook-selftest-")
+	if err != nil {
+		return caps
+	}
+	defer func() { _ = os.RemoveAll(dir) }() //nolint:errcheck // Best effort cleanup
+
+	target := filepath.Join(dir, "target")
+	if err := os.WriteFile(target, []byte("ok"), 0600); err != nil {
+		return caps
+	}
+	caps.TempDirWritable = true
+
+	if err := os.Symlink(target, filepath.Join(dir, "link")); err == nil {
+		caps.SymlinkSupport = true
+	}
+
+	return caps
+}
+
+// Platform-specific command helpers
+
+func trueCommand() string {
+	if runtime.GOOS == windowsOS {
+		return "cmd"
+	}
+	return "true"
+}
+
+func trueArgs() []string {
+	if runtime.GOOS == windowsOS {
+		return []string{"/c", "exit 0"}
+	}
+	return nil
+}
+
+func falseCommand() string {
+	if runtime.GOOS == windowsOS {
+		return "cmd"
+	}
+	return "false"
+}
+
+func falseArgs() []string {
+	if runtime.GOOS == windowsOS {
+		return []string{"/c", "exit 1"}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+//go:build unit
+
+package selftest
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestRun_AllChecksPass(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("echo is a shell builtin on Windows")
+	}
+
+	report := NewRunner().Run()
+
+	if len(report.Checks) == 0 {
+		t.Fatal("expected checks to be run")
+	}
+	for _, check := range report.Checks {
+		if !check.Passed {
+			t.Errorf("check %q failed: %s", check.Name, check.Details)
+		}
+	}
+	if !report.Passed() {
+		t.Error("expected report to pass")
+	}
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	caps := DetectCapabilities()
+
+	if caps.OS != runtime.GOOS {
+		t.Errorf("OS = %q, want %q", caps.OS, runtime.GOOS)
+	}
+	if caps.Arch != runtime.GOARCH {
+		t.Errorf("Arch = %q, want %q", caps.Arch, runtime.GOARCH)
+	}
+	if !caps.TempDirWritable {
+		t.Error("expected temp directory to be writable")
+	}
+	if runtime.GOOS != windowsOS {
+		if !caps.ShellAvailable || caps.Shell == "" {
+			t.Error("expected sh to be available on Unix")
+		}
+		if !caps.SymlinkSupport {
+			t.Error("expected symlink support on Unix")
+		}
+	}
+}
+
+func TestReport_Passed(t *testing.T) {
+	report := &Report{Checks: []Check{{Name: "a", Passed: true}, {Name: "b", Passed: true}}}
+	if !report.Passed() {
+		t.Error("expected report with passing checks to pass")
+	}
+
+	report.Checks = append(report.Checks, Check{Name: "c", Passed: false})
+	if report.Passed() {
+		t.Error("expected report with a failing check to fail")
+	}
+}
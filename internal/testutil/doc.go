@@ -1,6 +1,6 @@
 // Package testutil provides common test utilities and helpers for the qualhook test suite.
 //
-// The package includes three main components:
+// The package includes these main components:
 //
 // ConfigBuilder: A fluent interface for building test configurations
 //   - Create configurations with NewConfigBuilder()
@@ -20,6 +20,11 @@
 //   - RunCommand() executes commands with proper error handling
 //   - Platform detection and skip helpers
 //
+// Recordings: Real command output captured once and replayed in tests
+//   - RecordCommandOutput() saves stdout, stderr and exit code to a fixture
+//   - LoadRecordedOutput() reads a saved fixture back
+//   - ReplayExecutor serves recordings as an executor.Backend
+//
 // Example usage:
 //
 //	// Create a test configuration
@@ -0,0 +1,125 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+)
+
+// RecordedOutput is the captured result of running a command once
+type RecordedOutput struct {
+	Command  string   `json:"command"`
+	Args     []string `json:"args,omitempty"`
+	Stdout   string   `json:"stdout"`
+	Stderr   string   `json:"stderr"`
+	ExitCode int      `json:"exitCode"`
+}
+
+// RecordCommandOutput runs a command once and saves its stdout, stderr and
+// exit code as a JSON fixture at path, creating parent directories as needed.
+func RecordCommandOutput(t testing.TB, path string, command string, args ...string) *RecordedOutput {
+	t.Helper()
+
+	stdout, stderr, exitCode := RunCommand(t, TestCommand{Command: command, Args: args})
+	recording := &RecordedOutput{
+		Command:  command,
+		Args:     args,
+		Stdout:   stdout,
+		Stderr:   stderr,
+		ExitCode: exitCode,
+	}
+
+	data, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal recording: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		t.Fatalf("Failed to create recording directory: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write recording %s: %v", path, err)
+	}
+
+	return recording
+}
+
+// LoadRecordedOutput reads a fixture previously saved by RecordCommandOutput
+func LoadRecordedOutput(t testing.TB, path string) *RecordedOutput {
+	t.Helper()
+
+	data, err := os.ReadFile(path) // #nosec G304 - paths are controlled by tests
+	if err != nil {
+		t.Fatalf("Failed to read recording %s: %v", path, err)
+	}
+
+	var recording RecordedOutput
+	if err := json.Unmarshal(data, &recording); err != nil {
+		t.Fatalf("Failed to parse recording %s: %v", path, err)
+	}
+	return &recording
+}
+
+// ReplayExecutor serves recorded command output instead of running commands,
+// so filters can be tested against real tool output without the tools installed.
+type ReplayExecutor struct {
+	mu         sync.Mutex
+	recordings map[string]*RecordedOutput
+	calls      []string
+}
+
+// Ensure ReplayExecutor can stand in for a real executor
+var _ executor.Backend = (*ReplayExecutor)(nil)
+
+// NewReplayExecutor creates a replay executor serving the given recordings
+func NewReplayExecutor(recordings ...*RecordedOutput) *ReplayExecutor {
+	r := &ReplayExecutor{recordings: make(map[string]*RecordedOutput)}
+	for _, recording := range recordings {
+		r.Add(recording)
+	}
+	return r
+}
+
+// Add registers a recording, replacing any recording of the same command line
+func (r *ReplayExecutor) Add(recording *RecordedOutput) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recordings[replayKey(recording.Command, recording.Args)] = recording
+}
+
+// Execute returns the recorded result for the command line. It fails for
+// command lines that were never recorded.
+func (r *ReplayExecutor) Execute(command string, args []string, _ executor.ExecOptions) (*executor.ExecResult, error) {
+	key := replayKey(command, args)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, key)
+
+	recording, ok := r.recordings[key]
+	if !ok {
+		return nil, fmt.Errorf("no recording for %q", key)
+	}
+	return &executor.ExecResult{
+		Stdout:   recording.Stdout,
+		Stderr:   recording.Stderr,
+		ExitCode: recording.ExitCode,
+	}, nil
+}
+
+// Calls returns the command lines executed so far, in order
+func (r *ReplayExecutor) Calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+// replayKey identifies a recording by its command line
+func replayKey(command string, args []string) string {
+	return strings.Join(append([]string{command}, args...), " ")
+}
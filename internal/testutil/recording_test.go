@@ -0,0 +1,51 @@
+//go:build unit
+
+package testutil
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestRecordAndReplayCommandOutput(t *testing.T) {
+	SkipOnWindows(t, "echo is a shell builtin on Windows")
+
+	path := filepath.Join(t.TempDir(), "recordings", "echo.json")
+	recorded := RecordCommandOutput(t, path, "echo", "src/app.js:3:1: error: Missing semicolon")
+	if recorded.ExitCode != 0 || recorded.Stdout == "" {
+		t.Fatalf("unexpected recording: %+v", recorded)
+	}
+
+	replay := NewReplayExecutor(LoadRecordedOutput(t, path))
+	var backend executor.Backend = replay
+	result, err := backend.Execute("echo", []string{"src/app.js:3:1: error: Missing semicolon"}, executor.ExecOptions{})
+	if err != nil {
+		t.Fatalf("unexpected replay error: %v", err)
+	}
+	if result.Stdout != recorded.Stdout || result.ExitCode != recorded.ExitCode {
+		t.Errorf("replayed result %+v does not match recording %+v", result, recorded)
+	}
+
+	filtered := filter.NewSimpleOutputFilter().FilterWithRules(result.Stdout, &filter.FilterRules{
+		ErrorPatterns: []*config.RegexPattern{{Pattern: `error:`}},
+		MaxLines:      10,
+	})
+	if !filtered.HasErrors || len(filtered.Lines) != 1 || filtered.Lines[0] != "src/app.js:3:1: error: Missing semicolon" {
+		t.Errorf("unexpected filtered output: %+v", filtered)
+	}
+}
+
+func TestReplayExecutor_UnknownCommand(t *testing.T) {
+	replay := NewReplayExecutor(&RecordedOutput{Command: "eslint", Args: []string{"."}, Stdout: "ok"})
+
+	if _, err := replay.Execute("eslint", []string{"src"}, executor.ExecOptions{}); err == nil {
+		t.Error("expected an error for a command line that was not recorded")
+	}
+	if calls := replay.Calls(); len(calls) != 1 || calls[0] != "eslint src" {
+		t.Errorf("unexpected calls: %v", calls)
+	}
+}
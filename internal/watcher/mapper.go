@@ -54,6 +54,7 @@ func (m *FileMapper) MapFilesToComponents(files []string) ([]ComponentGroup, err
 	// First, determine which path config each file belongs to
 	fileToPath := make(map[string]*config.PathConfig)
 	fileToPathPattern := make(map[string]string)
+	excluded := make(map[string]bool)
 
 	for _, file := range files {
 		// Clean and normalize the file path
@@ -75,6 +76,13 @@ func (m *FileMapper) MapFilesToComponents(files []string) ([]ComponentGroup, err
 			}
 		}
 
+		// Excludes apply after matching: an excluded file belongs to no
+		// component, not to a less specific one
+		if bestMatch != nil && matchesExclude(cleanFile, bestMatch.Exclude) {
+			excluded[cleanFile] = true
+			continue
+		}
+
 		if bestMatch != nil {
 			fileToPath[cleanFile] = bestMatch
 			fileToPathPattern[cleanFile] = bestPattern
@@ -97,7 +105,7 @@ func (m *FileMapper) MapFilesToComponents(files []string) ([]ComponentGroup, err
 	rootFiles := []string{}
 	for _, file := range files {
 		cleanFile := filepath.Clean(file)
-		if _, hasPath := fileToPath[cleanFile]; !hasPath {
+		if _, hasPath := fileToPath[cleanFile]; !hasPath && !excluded[cleanFile] {
 			rootFiles = append(rootFiles, file)
 		}
 	}
@@ -117,8 +125,8 @@ func (m *FileMapper) MapFilesToComponents(files []string) ([]ComponentGroup, err
 		})
 	}
 
-	// Add root component if there are files
-	if len(rootFiles) > 0 {
+	// Add root component if there are files, unless unmatched files are skipped
+	if len(rootFiles) > 0 && m.rootConfig.RootFallback != config.RootFallbackSkip {
 		groups = append(groups, ComponentGroup{
 			Path:   ".",
 			Files:  rootFiles,
@@ -151,6 +159,18 @@ func (m *FileMapper) matchesPath(filePath, pattern string) (bool, int) {
 	return true, specificity
 }
 
+// matchesExclude reports whether a file matches any of the exclude globs of a
+// path configuration
+func matchesExclude(filePath string, excludes []string) bool {
+	filePath = filepath.ToSlash(filepath.Clean(filePath))
+	for _, exclude := range excludes {
+		if matched, err := doublestar.Match(filepath.ToSlash(exclude), filePath); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
 // calculateSpecificity calculates how specific a pattern is
 // More specific patterns have higher values
 func calculateSpecificity(pattern string) int {
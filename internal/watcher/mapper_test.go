@@ -199,6 +199,141 @@ func TestFileMapper_MapFilesToComponents(t *testing.T) {
 	}
 }
 
+func TestFileMapper_RootFallback(t *testing.T) {
+	newConfig := func(fallback string) *config.Config {
+		return &config.Config{
+			Version:      "1.0",
+			RootFallback: fallback,
+			Commands: map[string]*config.CommandConfig{
+				"lint": {Command: "npm", Args: []string{"run", "lint"}},
+			},
+			Paths: []*config.PathConfig{
+				{
+					Path: "frontend/**",
+					Commands: map[string]*config.CommandConfig{
+						"lint": {Command: "npm", Args: []string{"run", "lint", "--prefix", "frontend"}},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		fallback  string
+		files     []string
+		wantPaths []string
+	}{
+		{
+			name:      "default runs root for unmatched files",
+			fallback:  "",
+			files:     []string{"scripts/build.js"},
+			wantPaths: []string{"."},
+		},
+		{
+			name:      "run runs root for unmatched files",
+			fallback:  config.RootFallbackRun,
+			files:     []string{"scripts/build.js"},
+			wantPaths: []string{"."},
+		},
+		{
+			name:      "skip ignores unmatched files",
+			fallback:  config.RootFallbackSkip,
+			files:     []string{"scripts/build.js"},
+			wantPaths: nil,
+		},
+		{
+			name:      "skip still runs matched paths",
+			fallback:  config.RootFallbackSkip,
+			files:     []string{"frontend/app.js", "scripts/build.js"},
+			wantPaths: []string{"frontend/**"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapper := NewFileMapper(newConfig(tt.fallback))
+			groups, err := mapper.MapFilesToComponents(tt.files)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var paths []string
+			for _, group := range groups {
+				paths = append(paths, group.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("component paths = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestFileMapper_Exclude(t *testing.T) {
+	cfg := &config.Config{
+		Version: "1.0",
+		Commands: map[string]*config.CommandConfig{
+			"lint": {Command: "eslint"},
+		},
+		Paths: []*config.PathConfig{
+			{
+				Path:     "src/**",
+				Exclude:  []string{"src/**/*.generated.ts", "src/gen/**"},
+				Commands: map[string]*config.CommandConfig{"lint": {Command: "eslint", Args: []string{"src"}}},
+			},
+			{
+				Path:     "src/api/**",
+				Commands: map[string]*config.CommandConfig{"lint": {Command: "eslint", Args: []string{"src/api"}}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		files     []string
+		wantFiles map[string][]string
+	}{
+		{
+			name:      "excluded file is dropped",
+			files:     []string{"src/app.ts", "src/models.generated.ts", "src/gen/client.ts"},
+			wantFiles: map[string][]string{"src/**": {"src/app.ts"}},
+		},
+		{
+			name:      "only excluded files run nothing",
+			files:     []string{"src/gen/client.ts"},
+			wantFiles: map[string][]string{},
+		},
+		{
+			// The more specific path wins before excludes apply, and it has none
+			name:      "most specific path wins before excludes",
+			files:     []string{"src/api/types.generated.ts"},
+			wantFiles: map[string][]string{"src/api/**": {"src/api/types.generated.ts"}},
+		},
+		{
+			name:      "unmatched files still run the root",
+			files:     []string{"README.md"},
+			wantFiles: map[string][]string{".": {"README.md"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := NewFileMapper(cfg).MapFilesToComponents(tt.files)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := map[string][]string{}
+			for _, group := range groups {
+				got[group.Path] = group.Files
+			}
+			if !reflect.DeepEqual(got, tt.wantFiles) {
+				t.Errorf("component files = %v, want %v", got, tt.wantFiles)
+			}
+		})
+	}
+}
+
 func TestFileMapper_matchesPath(t *testing.T) {
 	mapper := &FileMapper{}
 
@@ -0,0 +1,169 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/fsnotify/fsnotify"
+)
+
+// errObserverClosed is returned by Next once the observer is closed
+var errObserverClosed = errors.New("file observer closed")
+
+// Observer reports the files changed in a set of watched directories, in
+// batches once changes settle
+type Observer struct {
+	root     string
+	debounce time.Duration
+	watcher  *fsnotify.Watcher
+	// rules are the .gitignore rules of the watched directories
+	rules []ignoreRule
+}
+
+// NewObserver watches dirs, slash-separated paths relative to root such as
+// those returned by WatchDirs. A batch of changes is reported once no file
+// changed for the debounce period.
+func NewObserver(root string, dirs []string, debounce time.Duration) (*Observer, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start file watcher: %w", err)
+	}
+
+	o := &Observer{root: root, debounce: debounce, watcher: w}
+	for _, dir := range dirs {
+		if err := o.add(dir); err != nil {
+			_ = w.Close() //nolint:errcheck // Already failing
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// Close stops watching
+func (o *Observer) Close() error {
+	return o.watcher.Close()
+}
+
+// Next waits for files to change and returns them, relative to the root and
+// sorted, once no other change happened for the debounce period. Files
+// ignored by .gitignore are left out. It returns the context error when ctx
+// is done first.
+func (o *Observer) Next(ctx context.Context) ([]string, error) {
+	changed := make(map[string]bool)
+	var settled <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err, ok := <-o.watcher.Errors:
+			if !ok {
+				return nil, errObserverClosed
+			}
+			debug.LogError(err, "watching files")
+		case event, ok := <-o.watcher.Events:
+			if !ok {
+				return nil, errObserverClosed
+			}
+			if o.record(event, changed) {
+				settled = time.After(o.debounce)
+			}
+		case <-settled:
+			files := make([]string, 0, len(changed))
+			for file := range changed {
+				files = append(files, file)
+			}
+			sort.Strings(files)
+			return files, nil
+		}
+	}
+}
+
+// record adds the files an event changed, relative to the root, to changed
+// and reports whether there were any. New directories are watched too, and
+// the files already in them count as changed.
+func (o *Observer) record(event fsnotify.Event, changed map[string]bool) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	rel, err := filepath.Rel(o.root, event.Name)
+	if err != nil {
+		return false
+	}
+	slashRel := filepath.ToSlash(rel)
+	if slashRel == ".git" || strings.HasPrefix(slashRel, ".git/") {
+		return false
+	}
+
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			return o.addNew(slashRel, changed)
+		}
+	}
+
+	if isIgnored(o.rules, slashRel, false) {
+		return false
+	}
+	changed[rel] = true
+	return true
+}
+
+// addNew watches a new directory and its subdirectories, adding the files
+// they already hold to changed, as they may have been written before the
+// directory was watched
+func (o *Observer) addNew(dir string, changed map[string]bool) bool {
+	found := false
+	err := filepath.WalkDir(filepath.Join(o.root, filepath.FromSlash(dir)), func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(o.root, p)
+		if err != nil {
+			return err
+		}
+		slashRel := filepath.ToSlash(rel)
+		if isIgnored(o.rules, slashRel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return o.add(slashRel)
+		}
+		changed[rel] = true
+		found = true
+		return nil
+	})
+	if err != nil {
+		debug.LogError(err, "watching new directory")
+	}
+	return found
+}
+
+// add watches dir, relative to the root, and reads its .gitignore
+func (o *Observer) add(dir string) error {
+	path := filepath.Join(o.root, filepath.FromSlash(dir))
+	if err := o.watcher.Add(path); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", path, err)
+	}
+	debug.Log("Watching directory: %s", path)
+
+	base := dir
+	if base == "." {
+		base = ""
+	}
+	rules, err := parseGitignore(filepath.Join(path, ".gitignore"), base)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", filepath.Join(path, ".gitignore"), err)
+	}
+	o.rules = append(o.rules, rules...)
+	return nil
+}
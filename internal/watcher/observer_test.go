@@ -0,0 +1,81 @@
+//go:build unit
+
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// nextChanges waits for the next batch of changes, failing after a timeout
+func nextChanges(t *testing.T, o *Observer) []string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	files, err := o.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	return files
+}
+
+func TestObserver(t *testing.T) {
+	root := t.TempDir()
+	writeScopeFixture(t, root, map[string]string{
+		".gitignore":   "*.log\n",
+		"src/app.js":   "",
+		"src/util.js":  "",
+		"src/lint.log": "",
+	})
+
+	o, err := NewObserver(root, []string{".", "src"}, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewObserver() error = %v", err)
+	}
+	defer func() { _ = o.Close() }()
+
+	// Rapid changes are reported together, ignored files are not
+	for _, name := range []string{"src/app.js", "src/util.js", "src/app.js", "src/lint.log"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte("changed"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{filepath.Join("src", "app.js"), filepath.Join("src", "util.js")}
+	if got := nextChanges(t, o); !reflect.DeepEqual(got, want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+
+	// New directories are watched as they appear
+	if err := os.Mkdir(filepath.Join(root, "lib"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := os.WriteFile(filepath.Join(root, "lib", "new.js"), []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := nextChanges(t, o); !reflect.DeepEqual(got, []string{filepath.Join("lib", "new.js")}) {
+		t.Errorf("Next() = %v, want the file in the new directory", got)
+	}
+}
+
+func TestObserver_ContextDone(t *testing.T) {
+	o, err := NewObserver(t.TempDir(), []string{"."}, time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewObserver() error = %v", err)
+	}
+	defer func() { _ = o.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := o.Next(ctx); err != context.Canceled {
+		t.Errorf("Next() error = %v, want %v", err, context.Canceled)
+	}
+
+	if _, err := NewObserver(t.TempDir(), []string{"missing"}, time.Millisecond); err == nil {
+		t.Error("expected a missing directory to fail")
+	}
+}
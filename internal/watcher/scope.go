@@ -0,0 +1,173 @@
+package watcher
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// ignoreRule is a single parsed .gitignore entry
+type ignoreRule struct {
+	// base is the directory containing the .gitignore, relative to the scan root
+	base     string
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// matches reports whether the rule applies to relPath (slash-separated, relative to the scan root)
+func (r ignoreRule) matches(relPath string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+
+	if r.base != "" {
+		if !strings.HasPrefix(relPath, r.base+"/") {
+			return false
+		}
+		relPath = strings.TrimPrefix(relPath, r.base+"/")
+	}
+
+	if r.anchored {
+		matched, err := doublestar.Match(r.pattern, relPath)
+		return err == nil && matched
+	}
+
+	matched, err := doublestar.Match(r.pattern, path.Base(relPath))
+	return err == nil && matched
+}
+
+// parseGitignore reads ignore rules from a .gitignore file located in base
+func parseGitignore(file, base string) ([]ignoreRule, error) {
+	f, err := os.Open(file) // #nosec G304 - path is built from the scanned tree
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }() //nolint:errcheck // Best effort close on read-only file
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		// A slash anywhere but the end anchors the pattern to the .gitignore directory
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+
+	return rules, scanner.Err()
+}
+
+// isIgnored applies gitignore rules in order; the last matching rule wins
+func isIgnored(rules []ignoreRule, relPath string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.matches(relPath, isDir) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// ScopeFiles lists the files under root that a path pattern would cover.
+// Files ignored by .gitignore (including nested ones), the .git directory and
+// anything matching one of the exclude globs are left out.
+// Returned paths are slash-separated, relative to root and sorted.
+func ScopeFiles(root, pattern string, excludes []string) ([]string, error) {
+	pattern = filepath.ToSlash(pattern)
+	if !doublestar.ValidatePattern(pattern) {
+		return nil, fmt.Errorf("invalid path pattern: %s", pattern)
+	}
+	for _, exclude := range excludes {
+		if !doublestar.ValidatePattern(filepath.ToSlash(exclude)) {
+			return nil, fmt.Errorf("invalid exclude pattern: %s", exclude)
+		}
+	}
+
+	var rules []ignoreRule
+	var files []string
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel != "." {
+				if d.Name() == ".git" || isIgnored(rules, rel, true) || matchesAny(excludes, rel) {
+					return filepath.SkipDir
+				}
+			}
+
+			base := rel
+			if base == "." {
+				base = ""
+			}
+			dirRules, err := parseGitignore(filepath.Join(p, ".gitignore"), base)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to read %s: %w", filepath.Join(p, ".gitignore"), err)
+			}
+			rules = append(rules, dirRules...)
+			return nil
+		}
+
+		if isIgnored(rules, rel, false) || matchesAny(excludes, rel) {
+			return nil
+		}
+
+		if matched, err := doublestar.Match(pattern, rel); err == nil && matched {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// matchesAny reports whether relPath matches any of the glob patterns
+func matchesAny(patterns []string, relPath string) bool {
+	for _, pattern := range patterns {
+		if matched, err := doublestar.Match(filepath.ToSlash(pattern), relPath); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+//go:build unit
+
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeScopeFixture(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestScopeFiles(t *testing.T) {
+	root := t.TempDir()
+	writeScopeFixture(t, root, map[string]string{
+		".gitignore":                         "node_modules/\n*.log\n/dist\n",
+		".git/config":                        "",
+		"frontend/src/app.js":                "",
+		"frontend/src/app.test.js":           "",
+		"frontend/src/debug.log":             "",
+		"frontend/node_modules/lib/index.js": "",
+		"frontend/.gitignore":                "generated/\n!keep.log\n",
+		"frontend/generated/api.js":          "",
+		"frontend/keep.log":                  "",
+		"backend/main.go":                    "",
+		"dist/bundle.js":                     "",
+		"frontend/dist/bundle.js":            "",
+	})
+
+	tests := []struct {
+		name     string
+		pattern  string
+		excludes []string
+		want     []string
+	}{
+		{
+			name:    "component pattern respects gitignore",
+			pattern: "frontend/**",
+			want: []string{
+				"frontend/.gitignore",
+				"frontend/dist/bundle.js",
+				"frontend/keep.log",
+				"frontend/src/app.js",
+				"frontend/src/app.test.js",
+			},
+		},
+		{
+			name:     "excludes are applied",
+			pattern:  "frontend/src/**/*.js",
+			excludes: []string{"**/*.test.js"},
+			want:     []string{"frontend/src/app.js"},
+		},
+		{
+			name:    "anchored ignore only applies at root",
+			pattern: "**/bundle.js",
+			want:    []string{"frontend/dist/bundle.js"},
+		},
+		{
+			name:    "no matches",
+			pattern: "docs/**",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScopeFiles(root, tt.pattern, tt.excludes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ScopeFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeFiles_InvalidPattern(t *testing.T) {
+	if _, err := ScopeFiles(t.TempDir(), "[", nil); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
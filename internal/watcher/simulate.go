@@ -0,0 +1,86 @@
+package watcher
+
+import (
+	"path/filepath"
+)
+
+// PathCoverage records how a path configuration fared against sample files
+type PathCoverage struct {
+	// Path is the configured path pattern
+	Path string
+	// Files are the sample files this path configuration was selected for
+	Files []string
+	// Shadowed are sample files the pattern matched but a more specific
+	// path configuration won
+	Shadowed []string
+	// Excluded are sample files this path configuration was selected for
+	// but its exclude globs left out
+	Excluded []string
+}
+
+// Fired reports whether the path configuration was selected for any file
+func (p PathCoverage) Fired() bool {
+	return len(p.Files) > 0
+}
+
+// Coverage is the result of mapping sample files against every path configuration
+type Coverage struct {
+	// Paths holds one entry per path configuration, in configuration order
+	Paths []PathCoverage
+	// RootFiles are sample files no path configuration matched
+	RootFiles []string
+}
+
+// Unfired returns the path patterns that were never selected for a sample file
+func (c *Coverage) Unfired() []PathCoverage {
+	var unfired []PathCoverage
+	for _, path := range c.Paths {
+		if !path.Fired() {
+			unfired = append(unfired, path)
+		}
+	}
+	return unfired
+}
+
+// Simulate maps sample files to path configurations, recording which
+// configurations fire and which are never selected for any sample
+func (m *FileMapper) Simulate(files []string) *Coverage {
+	coverage := &Coverage{Paths: make([]PathCoverage, len(m.rootConfig.Paths))}
+	for i, pathConfig := range m.rootConfig.Paths {
+		coverage.Paths[i].Path = pathConfig.Path
+	}
+
+	for _, file := range files {
+		cleanFile := filepath.Clean(file)
+
+		// Select the most specific match the same way MapFilesToComponents does
+		best, bestSpecificity := -1, -1
+		var matched []int
+		for i, pathConfig := range m.rootConfig.Paths {
+			if match, specificity := m.matchesPath(cleanFile, pathConfig.Path); match {
+				matched = append(matched, i)
+				if specificity > bestSpecificity {
+					best, bestSpecificity = i, specificity
+				}
+			}
+		}
+
+		if best < 0 {
+			coverage.RootFiles = append(coverage.RootFiles, file)
+			continue
+		}
+		if matchesExclude(cleanFile, m.rootConfig.Paths[best].Exclude) {
+			coverage.Paths[best].Excluded = append(coverage.Paths[best].Excluded, file)
+			continue
+		}
+		for _, i := range matched {
+			if i == best {
+				coverage.Paths[i].Files = append(coverage.Paths[i].Files, file)
+			} else {
+				coverage.Paths[i].Shadowed = append(coverage.Paths[i].Shadowed, file)
+			}
+		}
+	}
+
+	return coverage
+}
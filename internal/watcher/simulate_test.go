@@ -0,0 +1,74 @@
+//go:build unit
+
+package watcher
+
+import (
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestFileMapper_Simulate(t *testing.T) {
+	cfg := &config.Config{
+		Version: "1.0",
+		Paths: []*config.PathConfig{
+			{Path: "frontend/**"},
+			{Path: "frontend/src/**"},
+			{Path: "backend/**/*.go"},
+			{Path: "docs/**"},
+		},
+	}
+
+	coverage := NewFileMapper(cfg).Simulate([]string{
+		"frontend/src/app.js",
+		"frontend/index.html",
+		"backend/cmd/main.go",
+		"README.md",
+	})
+
+	byPath := make(map[string]PathCoverage)
+	for _, path := range coverage.Paths {
+		byPath[path.Path] = path
+	}
+
+	if got := byPath["frontend/src/**"].Files; len(got) != 1 || got[0] != "frontend/src/app.js" {
+		t.Errorf("expected frontend/src/** to fire for app.js, got %v", got)
+	}
+	if got := byPath["frontend/**"].Shadowed; len(got) != 1 || got[0] != "frontend/src/app.js" {
+		t.Errorf("expected app.js to be shadowed for frontend/**, got %v", got)
+	}
+	if got := byPath["frontend/**"].Files; len(got) != 1 || got[0] != "frontend/index.html" {
+		t.Errorf("expected frontend/** to fire for index.html, got %v", got)
+	}
+	if !byPath["backend/**/*.go"].Fired() {
+		t.Error("expected backend/**/*.go to fire")
+	}
+	if len(coverage.RootFiles) != 1 || coverage.RootFiles[0] != "README.md" {
+		t.Errorf("expected README.md to fall back to root, got %v", coverage.RootFiles)
+	}
+
+	unfired := coverage.Unfired()
+	if len(unfired) != 1 || unfired[0].Path != "docs/**" {
+		t.Errorf("expected only docs/** to be unfired, got %+v", unfired)
+	}
+}
+
+func TestFileMapper_SimulateExclude(t *testing.T) {
+	cfg := &config.Config{
+		Version: "1.0",
+		Paths:   []*config.PathConfig{{Path: "src/**", Exclude: []string{"src/gen/**"}}},
+	}
+
+	coverage := NewFileMapper(cfg).Simulate([]string{"src/app.ts", "src/gen/client.ts"})
+
+	path := coverage.Paths[0]
+	if len(path.Files) != 1 || path.Files[0] != "src/app.ts" {
+		t.Errorf("expected src/** to fire for app.ts only, got %v", path.Files)
+	}
+	if len(path.Excluded) != 1 || path.Excluded[0] != "src/gen/client.ts" {
+		t.Errorf("expected client.ts to be excluded, got %v", path.Excluded)
+	}
+	if len(coverage.RootFiles) != 0 {
+		t.Errorf("excluded files must not fall back to root, got %v", coverage.RootFiles)
+	}
+}
@@ -0,0 +1,91 @@
+package watcher
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// MapFilesForCommand maps files to component groups like MapFilesToComponents,
+// adding the components whose command declares triggers matching any of the
+// files, even when none of the files is in the component's path
+func (m *FileMapper) MapFilesForCommand(files []string, commandName string) ([]ComponentGroup, error) {
+	groups, err := m.MapFilesToComponents(files)
+	if err != nil || len(files) == 0 {
+		return groups, err
+	}
+
+	if triggered := matchTriggers(m.rootConfig.Commands[commandName], files); len(triggered) > 0 {
+		groups = addTriggeredFiles(groups, ".", triggered, func() map[string]*config.CommandConfig {
+			return m.rootConfig.Commands
+		})
+	}
+
+	// Path components only fire on their own triggers, not on inherited ones
+	for _, pathConfig := range m.rootConfig.Paths {
+		if triggered := matchTriggers(pathConfig.Commands[commandName], files); len(triggered) > 0 {
+			pathConfig := pathConfig
+			groups = addTriggeredFiles(groups, pathConfig.Path, triggered, func() map[string]*config.CommandConfig {
+				return m.mergeConfigs(pathConfig)
+			})
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Path < groups[j].Path
+	})
+	return groups, nil
+}
+
+// matchTriggers returns the files matching any trigger of a command
+func matchTriggers(cmd *config.CommandConfig, files []string) []string {
+	if cmd == nil || len(cmd.Triggers) == 0 {
+		return nil
+	}
+
+	var triggered []string
+	for _, file := range files {
+		cleanFile := filepath.ToSlash(filepath.Clean(file))
+		for _, trigger := range cmd.Triggers {
+			if matched, err := doublestar.Match(filepath.ToSlash(trigger), cleanFile); err == nil && matched {
+				triggered = append(triggered, file)
+				break
+			}
+		}
+	}
+	return triggered
+}
+
+// addTriggeredFiles adds triggering files to the group of a component,
+// creating the group when the component was not otherwise mapped
+func addTriggeredFiles(groups []ComponentGroup, path string, files []string, commands func() map[string]*config.CommandConfig) []ComponentGroup {
+	for i := range groups {
+		if groups[i].Path != path {
+			continue
+		}
+		for _, file := range files {
+			if !containsFile(groups[i].Files, file) {
+				groups[i].Files = append(groups[i].Files, file)
+			}
+		}
+		return groups
+	}
+
+	return append(groups, ComponentGroup{
+		Path:   path,
+		Files:  files,
+		Config: commands(),
+	})
+}
+
+// containsFile reports whether files contains file
+func containsFile(files []string, file string) bool {
+	for _, f := range files {
+		if f == file {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+//go:build unit
+
+package watcher
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func triggersTestConfig() *config.Config {
+	return &config.Config{
+		Version: "1.0",
+		Commands: map[string]*config.CommandConfig{
+			"lockcheck": {Command: "npm", Args: []string{"ci", "--dry-run"}, Triggers: []string{"**/package.json"}},
+			"lint":      {Command: "npm", Args: []string{"run", "lint"}},
+		},
+		Paths: []*config.PathConfig{
+			{
+				Path: "packages/frontend/**",
+				Commands: map[string]*config.CommandConfig{
+					"lint": {Command: "eslint", Triggers: []string{".eslintrc.json"}},
+				},
+			},
+		},
+		RootFallback: config.RootFallbackSkip,
+	}
+}
+
+func groupPaths(groups []ComponentGroup) []string {
+	paths := make([]string, 0, len(groups))
+	for _, group := range groups {
+		paths = append(paths, group.Path)
+	}
+	return paths
+}
+
+func TestFileMapper_MapFilesForCommand_TriggersRoot(t *testing.T) {
+	mapper := NewFileMapper(triggersTestConfig())
+
+	groups, err := mapper.MapFilesForCommand([]string{"packages/frontend/package.json"}, "lockcheck")
+	if err != nil {
+		t.Fatalf("MapFilesForCommand() error = %v", err)
+	}
+
+	if paths := groupPaths(groups); !reflect.DeepEqual(paths, []string{".", "packages/frontend/**"}) {
+		t.Fatalf("expected the root to be triggered besides the owning component, got %v", paths)
+	}
+	root := groups[0]
+	if !reflect.DeepEqual(root.Files, []string{"packages/frontend/package.json"}) {
+		t.Errorf("expected the triggering file in the root group, got %v", root.Files)
+	}
+	if root.Config["lockcheck"] == nil || root.Config["lockcheck"].Command != "npm" {
+		t.Errorf("expected the root configuration, got %v", root.Config)
+	}
+}
+
+func TestFileMapper_MapFilesForCommand_TriggersOnlyTheirCommand(t *testing.T) {
+	mapper := NewFileMapper(triggersTestConfig())
+
+	groups, err := mapper.MapFilesForCommand([]string{"packages/frontend/package.json"}, "lint")
+	if err != nil {
+		t.Fatalf("MapFilesForCommand() error = %v", err)
+	}
+	if paths := groupPaths(groups); !reflect.DeepEqual(paths, []string{"packages/frontend/**"}) {
+		t.Errorf("expected only the owning component, got %v", paths)
+	}
+}
+
+func TestFileMapper_MapFilesForCommand_TriggersPathComponent(t *testing.T) {
+	mapper := NewFileMapper(triggersTestConfig())
+
+	groups, err := mapper.MapFilesForCommand([]string{".eslintrc.json"}, "lint")
+	if err != nil {
+		t.Fatalf("MapFilesForCommand() error = %v", err)
+	}
+
+	if paths := groupPaths(groups); !reflect.DeepEqual(paths, []string{"packages/frontend/**"}) {
+		t.Fatalf("expected the frontend component to be triggered, got %v", paths)
+	}
+	if groups[0].Config["lint"].Command != "eslint" {
+		t.Errorf("expected the merged component configuration, got %v", groups[0].Config["lint"])
+	}
+}
+
+func TestFileMapper_MapFilesForCommand_MergesIntoExistingGroup(t *testing.T) {
+	cfg := triggersTestConfig()
+	cfg.RootFallback = ""
+	mapper := NewFileMapper(cfg)
+
+	groups, err := mapper.MapFilesForCommand([]string{"README.md", "package.json"}, "lockcheck")
+	if err != nil {
+		t.Fatalf("MapFilesForCommand() error = %v", err)
+	}
+
+	if len(groups) != 1 || groups[0].Path != "." {
+		t.Fatalf("expected a single root group, got %v", groupPaths(groups))
+	}
+	if !reflect.DeepEqual(groups[0].Files, []string{"README.md", "package.json"}) {
+		t.Errorf("expected each file once, got %v", groups[0].Files)
+	}
+}
@@ -0,0 +1,130 @@
+package watcher
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// WatchDirs returns the directories under root that a file watcher should
+// register, as slash-separated paths relative to root, sorted.
+//
+// When watchPaths is empty, the scope defaults to the root directory itself
+// (non-recursively, for root-level files) plus every directory under the
+// static prefix of each configured path pattern. Otherwise each watch path is
+// a glob and matching directories are registered together with their
+// subdirectories. Directories ignored by .gitignore, the .git directory and
+// anything matching an exclude glob are never registered.
+func WatchDirs(root string, cfg *config.Config, watchPaths, excludes []string) ([]string, error) {
+	globs := make([]string, 0, len(watchPaths))
+	for _, p := range watchPaths {
+		p = strings.TrimSuffix(filepath.ToSlash(p), "/")
+		if !doublestar.ValidatePattern(p) {
+			return nil, fmt.Errorf("invalid watch path: %s", p)
+		}
+		globs = append(globs, p)
+	}
+
+	includeRoot := false
+	if len(globs) == 0 {
+		includeRoot = true
+		if cfg != nil {
+			for _, pathCfg := range cfg.Paths {
+				globs = append(globs, staticPrefix(pathCfg.Path))
+			}
+		}
+	}
+
+	var rules []ignoreRule
+	dirs := make(map[string]bool)
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if rel != "." && (d.Name() == ".git" || isIgnored(rules, rel, true) || matchesAny(excludes, rel)) {
+			return filepath.SkipDir
+		}
+
+		base := rel
+		if base == "." {
+			base = ""
+		}
+		dirRules, err := parseGitignore(filepath.Join(p, ".gitignore"), base)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", filepath.Join(p, ".gitignore"), err)
+		}
+		rules = append(rules, dirRules...)
+
+		if (rel == "." && includeRoot) || inWatchScope(globs, rel) {
+			dirs[rel] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		result = append(result, dir)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// inWatchScope reports whether dir matches a glob or lies beneath a matching directory
+func inWatchScope(globs []string, dir string) bool {
+	for _, glob := range globs {
+		if glob == "." {
+			return true
+		}
+		for candidate := dir; candidate != "."; candidate = parentDir(candidate) {
+			if matched, err := doublestar.Match(glob, candidate); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parentDir returns the parent of a slash-separated relative path, or "."
+func parentDir(dir string) string {
+	if i := strings.LastIndex(dir, "/"); i >= 0 {
+		return dir[:i]
+	}
+	return "."
+}
+
+// staticPrefix returns the directory part of a path pattern before its first
+// wildcard, e.g. "packages/frontend" for "packages/frontend/**/*.ts".
+// Patterns starting with a wildcard cover the whole tree and yield ".".
+func staticPrefix(pattern string) string {
+	var segments []string
+	for _, segment := range strings.Split(filepath.ToSlash(pattern), "/") {
+		if segment == "" || strings.ContainsAny(segment, "*?[{") {
+			break
+		}
+		segments = append(segments, segment)
+	}
+	if len(segments) == 0 {
+		return "."
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,101 @@
+//go:build unit
+
+package watcher
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestWatchDirs(t *testing.T) {
+	root := t.TempDir()
+	writeScopeFixture(t, root, map[string]string{
+		".gitignore":                          "dist/\n",
+		"README.md":                           "",
+		".git/HEAD":                           "",
+		"node_modules/react/index.js":         "",
+		"packages/frontend/src/app.js":        "",
+		"packages/frontend/dist/bundle.js":    "",
+		"packages/frontend/.gitignore":        "generated/\n",
+		"packages/frontend/generated/api.js":  "",
+		"packages/backend/cmd/server/main.go": "",
+		"packages/shared/index.js":            "",
+		"docs/guide.md":                       "",
+	})
+
+	cfg := &config.Config{
+		Version: "1.0",
+		Paths: []*config.PathConfig{
+			{Path: "packages/frontend/**"},
+			{Path: "packages/backend/**/*.go"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		cfg        *config.Config
+		watchPaths []string
+		excludes   []string
+		want       []string
+	}{
+		{
+			name: "defaults to root plus configured path prefixes",
+			cfg:  cfg,
+			want: []string{
+				".",
+				"packages/backend",
+				"packages/backend/cmd",
+				"packages/backend/cmd/server",
+				"packages/frontend",
+				"packages/frontend/src",
+			},
+		},
+		{
+			name:       "explicit watch paths replace the default",
+			cfg:        cfg,
+			watchPaths: []string{"packages/*/src", "docs"},
+			want:       []string{"docs", "packages/frontend/src"},
+		},
+		{
+			name:     "excludes prune directories",
+			cfg:      cfg,
+			excludes: []string{"packages/backend/cmd"},
+			want:     []string{".", "packages/backend", "packages/frontend", "packages/frontend/src"},
+		},
+		{
+			name: "no paths configured watches only root",
+			cfg:  &config.Config{Version: "1.0"},
+			want: []string{"."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WatchDirs(root, tt.cfg, tt.watchPaths, tt.excludes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WatchDirs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaticPrefix(t *testing.T) {
+	tests := map[string]string{
+		"packages/frontend/**":    "packages/frontend",
+		"packages/*/src/**":       "packages",
+		"backend/**/*.go":         "backend",
+		"**/*.ts":                 ".",
+		"apps/web":                "apps/web",
+		"lib/{core,util}/**/*.js": "lib",
+	}
+	for pattern, want := range tests {
+		if got := staticPrefix(pattern); got != want {
+			t.Errorf("staticPrefix(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+}
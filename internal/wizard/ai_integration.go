@@ -4,6 +4,7 @@ package wizard
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -95,6 +96,9 @@ func (a *AIIntegration) getAISuggestion(ctx context.Context, commandType string,
 	projectInfo := ai.ProjectContext{
 		ProjectType: "", // Will be detected by AI
 	}
+	if wd, err := os.Getwd(); err == nil {
+		projectInfo.WorkingDir = wd
+	}
 
 	// Generate suggestion
 	fmt.Printf("\nGenerating %s command suggestion using %s...\n", commandType, selectedTool)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ type ConfigWizard struct {
 	projectDetector *detector.ProjectDetector
 	defaults        *config.DefaultConfigs
 	aiIntegration   *AIIntegration
+	compact         bool
 }
 
 // NewConfigWizard creates a new configuration wizard
@@ -42,6 +44,11 @@ func NewConfigWizard() (*ConfigWizard, error) {
 	}, nil
 }
 
+// SetCompact controls whether the configuration is saved as minified JSON
+func (w *ConfigWizard) SetCompact(compact bool) {
+	w.compact = compact
+}
+
 // Run runs the interactive configuration wizard
 func (w *ConfigWizard) Run(outputPath string, force bool) error {
 	debug.LogSection("Configuration Wizard")
@@ -128,13 +135,19 @@ func (w *ConfigWizard) createFromDefault(projectType string) (*pkgconfig.Config,
 	var pType config.ProjectType
 	switch projectType {
 	case "nodejs":
-		pType = config.ProjectTypeNodeJS
+		return w.createNodeJSFromDefault()
 	case "go":
 		pType = config.ProjectTypeGo
 	case "python":
 		pType = config.ProjectTypePython
 	case "rust":
 		pType = config.ProjectTypeRust
+	case "java":
+		pType = config.ProjectTypeJava
+	case "gradle":
+		pType = config.ProjectTypeGradle
+	case "php":
+		pType = config.ProjectTypePHP
 	default:
 		return nil, fmt.Errorf("no default configuration for project type: %s", projectType)
 	}
@@ -142,6 +155,22 @@ func (w *ConfigWizard) createFromDefault(projectType string) (*pkgconfig.Config,
 	return w.defaults.GetConfig(pType)
 }
 
+// createNodeJSFromDefault creates a Node.js configuration using the package
+// manager detected from the lockfile in the current directory
+func (w *ConfigWizard) createNodeJSFromDefault() (*pkgconfig.Config, error) {
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	packageManager := w.projectDetector.DetectPackageManager(projectDir)
+	if packageManager != "" {
+		fmt.Printf("📦 Using %s (detected from lockfile)\n", packageManager)
+	}
+
+	return w.defaults.GetNodeJSConfig(packageManager)
+}
+
 // createWithAIAssistance creates a configuration using AI assistance
 func (w *ConfigWizard) createWithAIAssistance() (*pkgconfig.Config, error) {
 	fmt.Println("\n🤖 Using AI assistance to generate configuration...")
@@ -289,46 +318,10 @@ func (w *ConfigWizard) configureMonorepoPaths(cfg *pkgconfig.Config, info *detec
 			}
 
 			if configure {
-				pathConfig := &pkgconfig.PathConfig{
-					Path:     workspace + "/**",
-					Commands: make(map[string]*pkgconfig.CommandConfig),
-				}
-
-				// Select commands to override
-				var commandNames []string
-				for name := range cfg.Commands {
-					commandNames = append(commandNames, name)
-				}
-
-				selectedCommands := []string{}
-				selectPrompt := &survey.MultiSelect{
-					Message: "Select commands to override for this workspace:",
-					Options: commandNames,
-				}
-				if err := survey.AskOne(selectPrompt, &selectedCommands); err != nil {
+				pathConfig, err := w.configurePathCommands(cfg, workspace+"/**")
+				if err != nil {
 					return nil, err
 				}
-
-				// Configure each selected command
-				for _, cmdName := range selectedCommands {
-					command := ""
-					commandPrompt := &survey.Input{
-						Message: fmt.Sprintf("Override command for '%s':", cmdName),
-					}
-					if err := survey.AskOne(commandPrompt, &command, survey.WithValidator(survey.Required)); err != nil {
-						return nil, err
-					}
-
-					pathConfig.Commands[cmdName] = &pkgconfig.CommandConfig{
-						Command:   command,
-						ExitCodes: []int{1},
-						ErrorPatterns: []*pkgconfig.RegexPattern{
-							{Pattern: "error", Flags: "i"},
-						},
-						MaxOutput: 100,
-					}
-				}
-
 				if len(pathConfig.Commands) > 0 {
 					cfg.Paths = append(cfg.Paths, pathConfig)
 				}
@@ -339,6 +332,52 @@ func (w *ConfigWizard) configureMonorepoPaths(cfg *pkgconfig.Config, info *detec
 	return cfg, nil
 }
 
+// configurePathCommands prompts for the commands a path pattern overrides
+func (w *ConfigWizard) configurePathCommands(cfg *pkgconfig.Config, pattern string) (*pkgconfig.PathConfig, error) {
+	pathConfig := &pkgconfig.PathConfig{
+		Path:     pattern,
+		Commands: make(map[string]*pkgconfig.CommandConfig),
+	}
+
+	// Select commands to override
+	var commandNames []string
+	for name := range cfg.Commands {
+		commandNames = append(commandNames, name)
+	}
+	sort.Strings(commandNames)
+
+	selectedCommands := []string{}
+	selectPrompt := &survey.MultiSelect{
+		Message: "Select commands to override for this workspace:",
+		Options: commandNames,
+	}
+	if err := survey.AskOne(selectPrompt, &selectedCommands); err != nil {
+		return nil, err
+	}
+
+	// Configure each selected command
+	for _, cmdName := range selectedCommands {
+		command := ""
+		commandPrompt := &survey.Input{
+			Message: fmt.Sprintf("Override command for '%s':", cmdName),
+		}
+		if err := survey.AskOne(commandPrompt, &command, survey.WithValidator(survey.Required)); err != nil {
+			return nil, err
+		}
+
+		pathConfig.Commands[cmdName] = &pkgconfig.CommandConfig{
+			Command:   command,
+			ExitCodes: []int{1},
+			ErrorPatterns: []*pkgconfig.RegexPattern{
+				{Pattern: "error", Flags: "i"},
+			},
+			MaxOutput: 100,
+		}
+	}
+
+	return pathConfig, nil
+}
+
 // determineOutputPath determines the output path for configuration
 func (w *ConfigWizard) determineOutputPath(outputPath string) (string, error) {
 	if outputPath != "" {
@@ -349,6 +388,10 @@ func (w *ConfigWizard) determineOutputPath(outputPath string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to get current directory: %w", err)
 	}
+	// Keep the format of an existing configuration file
+	if existing, found := config.FindConfigFile(cwd); found {
+		return existing, nil
+	}
 	return filepath.Join(cwd, config.ConfigFileName), nil
 }
 
@@ -383,39 +426,13 @@ func (w *ConfigWizard) detectProject() ([]detector.ProjectType, *detector.Monore
 		return nil, nil, fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	detectedTypes, err := w.projectDetector.Detect(projectDir)
-	if err != nil {
-		debug.LogError(err, "detecting project type")
-	}
-
-	monorepoInfo, err := w.projectDetector.DetectMonorepo(projectDir)
-	if err != nil {
-		debug.LogError(err, "detecting monorepo")
-	}
-
+	detectedTypes, monorepoInfo := w.detectDir(projectDir)
 	return detectedTypes, monorepoInfo, nil
 }
 
 // displayDetectionResults displays project detection results
 func (w *ConfigWizard) displayDetectionResults(detectedTypes []detector.ProjectType, monorepoInfo *detector.MonorepoInfo) {
-	if len(detectedTypes) > 0 {
-		fmt.Println("📦 Detected project types:")
-		for _, dt := range detectedTypes {
-			fmt.Printf("   • %s (confidence: %.0f%%)\n", dt.Name, dt.Confidence*100)
-		}
-		fmt.Println()
-	}
-
-	if monorepoInfo.IsMonorepo {
-		fmt.Printf("🏢 Monorepo detected: %s\n", monorepoInfo.Type)
-		if len(monorepoInfo.Workspaces) > 0 {
-			fmt.Println("   Workspaces found:")
-			for _, ws := range monorepoInfo.Workspaces {
-				fmt.Printf("   • %s\n", ws)
-			}
-		}
-		fmt.Println()
-	}
+	WriteDetectionReport(os.Stdout, newDetectionReport(detectedTypes, monorepoInfo))
 }
 
 // createConfiguration creates configuration based on detected project types
@@ -505,7 +522,25 @@ func (w *ConfigWizard) handleMonorepoConfig(cfg *pkgconfig.Config, monorepoInfo
 
 // validateAndSave validates and saves configuration
 func (w *ConfigWizard) validateAndSave(cfg *pkgconfig.Config, outputPath string) error {
-	// Validate configuration
+	if err := w.confirmValid(cfg); err != nil {
+		return err
+	}
+
+	data, err := w.marshal(cfg, outputPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write configuration file: %w", err)
+	}
+
+	return nil
+}
+
+// confirmValid validates the configuration, asking whether to save anyway
+// when it is invalid
+func (w *ConfigWizard) confirmValid(cfg *pkgconfig.Config) error {
 	validator := config.NewValidator()
 	if err := validator.Validate(cfg); err != nil {
 		fmt.Printf("\n⚠️  Configuration validation warning: %v\n", err)
@@ -522,18 +557,22 @@ func (w *ConfigWizard) validateAndSave(cfg *pkgconfig.Config, outputPath string)
 			return fmt.Errorf("configuration validation failed")
 		}
 	}
+	return nil
+}
 
-	// Save configuration
-	data, err := pkgconfig.SaveConfig(cfg)
-	if err != nil {
-		return fmt.Errorf("failed to serialize configuration: %w", err)
+// marshal serializes the configuration in the format of outputPath
+func (w *ConfigWizard) marshal(cfg *pkgconfig.Config, outputPath string) ([]byte, error) {
+	save := pkgconfig.SaveConfig
+	if config.IsYAMLFile(outputPath) {
+		save = pkgconfig.SaveConfigYAML
+	} else if w.compact {
+		save = pkgconfig.SaveConfigCompact
 	}
-
-	if err := os.WriteFile(outputPath, data, 0600); err != nil {
-		return fmt.Errorf("failed to write configuration file: %w", err)
+	data, err := save(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize configuration: %w", err)
 	}
-
-	return nil
+	return data, nil
 }
 
 // printSuccess prints success message
@@ -701,6 +740,19 @@ func (w *ConfigWizard) configureCustomCommands(cfg *pkgconfig.Config) error {
 		return nil
 	}
 
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	if err := w.importPackageScripts(cfg, projectDir); err != nil {
+		return err
+	}
+
+	return w.promptCustomCommands(cfg)
+}
+
+// promptCustomCommands adds custom commands until an empty name is entered
+func (w *ConfigWizard) promptCustomCommands(cfg *pkgconfig.Config) error {
 	for {
 		cmdName := ""
 		namePrompt := &survey.Input{
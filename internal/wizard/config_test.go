@@ -111,3 +111,41 @@ func TestValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateFromDefault_PHP(t *testing.T) {
+	t.Parallel()
+	wizard, err := NewConfigWizard()
+	if err != nil {
+		t.Fatalf("NewConfigWizard() failed: %v", err)
+	}
+
+	cfg, err := wizard.createFromDefault("php")
+	if err != nil {
+		t.Fatalf("createFromDefault(\"php\") failed: %v", err)
+	}
+	for name, command := range map[string]string{"format": "php-cs-fixer", "lint": "phpcs", "typecheck": "phpstan", "test": "phpunit"} {
+		if cmd := cfg.Commands[name]; cmd == nil || cmd.Command != command {
+			t.Errorf("expected %s to run %s, got %+v", name, command, cmd)
+		}
+	}
+}
+
+func TestCreateFromDefault_JVM(t *testing.T) {
+	t.Parallel()
+	wizard, err := NewConfigWizard()
+	if err != nil {
+		t.Fatalf("NewConfigWizard() failed: %v", err)
+	}
+
+	for projectType, command := range map[string]string{"java": "mvn", "gradle": "gradle"} {
+		cfg, err := wizard.createFromDefault(projectType)
+		if err != nil {
+			t.Fatalf("createFromDefault(%q) failed: %v", projectType, err)
+		}
+		for _, name := range []string{"format", "lint", "typecheck", "test"} {
+			if cmd := cfg.Commands[name]; cmd == nil || cmd.Command != command {
+				t.Errorf("createFromDefault(%q): expected %s to run %s, got %+v", projectType, name, command, cmd)
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package wizard
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bebsworthy/qualhook/internal/debug"
+	"github.com/bebsworthy/qualhook/internal/detector"
+)
+
+// DetectionReport is the result of the wizard's detection phase
+type DetectionReport struct {
+	// Types lists the detected project types, most confident first
+	Types []DetectedType `json:"types"`
+	// Monorepo reports whether the project is a monorepo
+	Monorepo bool `json:"monorepo"`
+	// MonorepoType names the monorepo tool, e.g. "pnpm" or "go-workspace"
+	MonorepoType string `json:"monorepoType,omitempty"`
+	// Workspaces lists the monorepo workspaces
+	Workspaces []DetectedWorkspace `json:"workspaces"`
+}
+
+// DetectedType is a detected project type
+type DetectedType struct {
+	Name       string   `json:"name"`
+	Confidence float64  `json:"confidence"`
+	Markers    []string `json:"markers,omitempty"`
+}
+
+// DetectedWorkspace is a monorepo workspace and its detected project types
+type DetectedWorkspace struct {
+	Path  string         `json:"path"`
+	Types []DetectedType `json:"types"`
+}
+
+// Detect runs the wizard's project and monorepo detection on dir without
+// prompting or writing configuration
+func (w *ConfigWizard) Detect(dir string) *DetectionReport {
+	detectedTypes, monorepoInfo := w.detectDir(dir)
+	return newDetectionReport(detectedTypes, monorepoInfo)
+}
+
+// detectDir detects the project types and monorepo layout of dir. Detection
+// errors are logged and reported as nothing detected.
+func (w *ConfigWizard) detectDir(dir string) ([]detector.ProjectType, *detector.MonorepoInfo) {
+	detectedTypes, err := w.projectDetector.Detect(dir)
+	if err != nil {
+		debug.LogError(err, "detecting project type")
+	}
+
+	monorepoInfo, err := w.projectDetector.DetectMonorepo(dir)
+	if err != nil {
+		debug.LogError(err, "detecting monorepo")
+	}
+	if monorepoInfo == nil {
+		monorepoInfo = &detector.MonorepoInfo{Path: dir}
+	}
+
+	return detectedTypes, monorepoInfo
+}
+
+// newDetectionReport converts detector results to a detection report
+func newDetectionReport(detectedTypes []detector.ProjectType, monorepoInfo *detector.MonorepoInfo) *DetectionReport {
+	report := &DetectionReport{
+		Types:        detectedTypeList(detectedTypes),
+		Monorepo:     monorepoInfo.IsMonorepo,
+		MonorepoType: monorepoInfo.Type,
+		Workspaces:   []DetectedWorkspace{},
+	}
+	for _, ws := range monorepoInfo.Workspaces {
+		report.Workspaces = append(report.Workspaces, DetectedWorkspace{
+			Path:  ws,
+			Types: detectedTypeList(monorepoInfo.SubProjects[ws]),
+		})
+	}
+	return report
+}
+
+// detectedTypeList converts detected project types for a report
+func detectedTypeList(types []detector.ProjectType) []DetectedType {
+	list := make([]DetectedType, 0, len(types))
+	for _, pt := range types {
+		list = append(list, DetectedType{Name: pt.Name, Confidence: pt.Confidence, Markers: pt.Markers})
+	}
+	return list
+}
+
+// WriteDetectionReport writes a detection report in the wizard's text format
+func WriteDetectionReport(out io.Writer, report *DetectionReport) {
+	if len(report.Types) > 0 {
+		fmt.Fprintln(out, "📦 Detected project types:") //nolint:errcheck // Best effort output
+		for _, dt := range report.Types {
+			fmt.Fprintf(out, "   • %s (confidence: %.0f%%)\n", dt.Name, dt.Confidence*100) //nolint:errcheck // Best effort output
+		}
+		fmt.Fprintln(out) //nolint:errcheck // Best effort output
+	}
+
+	if report.Monorepo {
+		fmt.Fprintf(out, "🏢 Monorepo detected: %s\n", report.MonorepoType) //nolint:errcheck // Best effort output
+		if len(report.Workspaces) > 0 {
+			fmt.Fprintln(out, "   Workspaces found:") //nolint:errcheck // Best effort output
+			for _, ws := range report.Workspaces {
+				fmt.Fprintf(out, "   • %s\n", ws.Path) //nolint:errcheck // Best effort output
+			}
+		}
+		fmt.Fprintln(out) //nolint:errcheck // Best effort output
+	}
+}
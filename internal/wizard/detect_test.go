@@ -0,0 +1,81 @@
+//go:build unit
+
+package wizard
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeMonorepoFixture creates a pnpm monorepo with a Node.js and a Go workspace
+func writeMonorepoFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json":               `{"name": "root", "private": true}`,
+		"pnpm-workspace.yaml":        "packages:\n  - packages/*\n",
+		"packages/web/package.json":  `{"name": "web"}`,
+		"packages/api/go.mod":        "module example.com/api\n\ngo 1.23\n",
+		"packages/api/cmd/main.go":   "package main\n",
+		"packages/web/src/index.tsx": "export {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+	wizard, err := NewConfigWizard()
+	if err != nil {
+		t.Fatalf("NewConfigWizard() failed: %v", err)
+	}
+
+	report := wizard.Detect(writeMonorepoFixture(t))
+
+	if len(report.Types) == 0 || report.Types[0].Name != "nodejs" || report.Types[0].Confidence <= 0 {
+		t.Errorf("expected nodejs with a confidence, got %+v", report.Types)
+	}
+	if !report.Monorepo || report.MonorepoType != "pnpm" {
+		t.Errorf("expected a pnpm monorepo, got monorepo=%v type=%q", report.Monorepo, report.MonorepoType)
+	}
+
+	workspaces := map[string]string{}
+	for _, ws := range report.Workspaces {
+		if len(ws.Types) > 0 {
+			workspaces[ws.Path] = ws.Types[0].Name
+		}
+	}
+	if workspaces[filepath.Join("packages", "web")] != "nodejs" || workspaces[filepath.Join("packages", "api")] != "go" {
+		t.Errorf("unexpected workspaces: %+v", report.Workspaces)
+	}
+
+	var out bytes.Buffer
+	WriteDetectionReport(&out, report)
+	if !strings.Contains(out.String(), "🏢 Monorepo detected: pnpm") || !strings.Contains(out.String(), "• nodejs") {
+		t.Errorf("unexpected text report:\n%s", out.String())
+	}
+}
+
+func TestDetect_EmptyDir(t *testing.T) {
+	t.Parallel()
+	wizard, err := NewConfigWizard()
+	if err != nil {
+		t.Fatalf("NewConfigWizard() failed: %v", err)
+	}
+
+	report := wizard.Detect(t.TempDir())
+	if len(report.Types) != 0 || report.Monorepo || len(report.Workspaces) != 0 {
+		t.Errorf("expected nothing detected, got %+v", report)
+	}
+}
@@ -0,0 +1,382 @@
+package wizard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/bebsworthy/qualhook/internal/config"
+	"github.com/bebsworthy/qualhook/internal/debug"
+	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Actions of the configuration editor
+const (
+	editModifyCommands = "Modify commands"
+	editAddCommands    = "Add commands"
+	editRemoveCommands = "Remove commands"
+	editAddPath        = "Add a path"
+	editRemovePaths    = "Remove paths"
+	editSave           = "Save and exit"
+	editDiscard        = "Exit without saving"
+)
+
+// Edit interactively changes the configuration file at configPath and saves
+// it back. Only the file itself is edited, not the configurations it
+// extends, and fields qualhook does not know are kept as they were.
+func (w *ConfigWizard) Edit(configPath string) error {
+	debug.LogSection("Configuration Editor")
+
+	original, err := os.ReadFile(configPath) // #nosec G304 - user-selected configuration file
+	if err != nil {
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+	parse := pkgconfig.ParseConfig
+	if config.IsYAMLFile(configPath) {
+		parse = pkgconfig.ParseConfigYAML
+	}
+	cfg, err := parse(original)
+	if err != nil {
+		return err
+	}
+	if cfg.Commands == nil {
+		cfg.Commands = make(map[string]*pkgconfig.CommandConfig)
+	}
+
+	fmt.Printf("🛠  Editing %s\n", configPath)
+	for {
+		w.printEditSummary(cfg)
+
+		action := ""
+		actionPrompt := &survey.Select{
+			Message: "What would you like to change?",
+			Options: []string{editModifyCommands, editAddCommands, editRemoveCommands, editAddPath, editRemovePaths, editSave, editDiscard},
+		}
+		if err := survey.AskOne(actionPrompt, &action); err != nil {
+			return err
+		}
+
+		switch action {
+		case editModifyCommands:
+			cfg, err = w.customizeConfiguration(cfg)
+		case editAddCommands:
+			err = w.promptCustomCommands(cfg)
+		case editRemoveCommands:
+			err = w.removeCommands(cfg)
+		case editAddPath:
+			err = w.addPath(cfg)
+		case editRemovePaths:
+			err = w.removePaths(cfg)
+		case editSave:
+			if err := w.saveEdited(cfg, original, configPath); err != nil {
+				return err
+			}
+			fmt.Printf("\n✅ Configuration saved to: %s\n", configPath)
+			return nil
+		case editDiscard:
+			fmt.Println("Configuration left unchanged.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// printEditSummary lists the commands and paths being edited
+func (w *ConfigWizard) printEditSummary(cfg *pkgconfig.Config) {
+	fmt.Println("\nCommands:")
+	for _, name := range sortedCommandNames(cfg.Commands) {
+		cmd := cfg.Commands[name]
+		fmt.Printf("  • %s: %s %v\n", name, cmd.Command, cmd.Args)
+	}
+	if len(cfg.Paths) > 0 {
+		fmt.Println("Paths:")
+		for _, path := range cfg.Paths {
+			fmt.Printf("  • %s: %d overrides\n", path.Path, len(path.Commands))
+		}
+	}
+}
+
+// removeCommands prompts for root commands to remove
+func (w *ConfigWizard) removeCommands(cfg *pkgconfig.Config) error {
+	selected := []string{}
+	prompt := &survey.MultiSelect{
+		Message: "Select commands to remove:",
+		Options: sortedCommandNames(cfg.Commands),
+	}
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return err
+	}
+	for _, name := range selected {
+		delete(cfg.Commands, name)
+	}
+	return nil
+}
+
+// addPath prompts for a path pattern and the commands it overrides
+func (w *ConfigWizard) addPath(cfg *pkgconfig.Config) error {
+	pattern := ""
+	patternPrompt := &survey.Input{
+		Message: "Path pattern, e.g. frontend/**:",
+	}
+	if err := survey.AskOne(patternPrompt, &pattern, survey.WithValidator(survey.Required)); err != nil {
+		return err
+	}
+
+	pathConfig, err := w.configurePathCommands(cfg, pattern)
+	if err != nil {
+		return err
+	}
+	if len(pathConfig.Commands) > 0 {
+		cfg.Paths = append(cfg.Paths, pathConfig)
+	}
+	return nil
+}
+
+// removePaths prompts for path configurations to remove
+func (w *ConfigWizard) removePaths(cfg *pkgconfig.Config) error {
+	if len(cfg.Paths) == 0 {
+		fmt.Println("No paths are configured.")
+		return nil
+	}
+
+	patterns := make([]string, len(cfg.Paths))
+	for i, path := range cfg.Paths {
+		patterns[i] = path.Path
+	}
+	selected := []int{}
+	prompt := &survey.MultiSelect{
+		Message: "Select paths to remove:",
+		Options: patterns,
+	}
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return err
+	}
+
+	remove := make(map[int]bool, len(selected))
+	for _, i := range selected {
+		remove[i] = true
+	}
+	kept := make([]*pkgconfig.PathConfig, 0, len(cfg.Paths))
+	for i, path := range cfg.Paths {
+		if !remove[i] {
+			kept = append(kept, path)
+		}
+	}
+	cfg.Paths = kept
+	return nil
+}
+
+// saveEdited validates the edited configuration and writes it to
+// configPath, with the unknown fields of the original file restored
+func (w *ConfigWizard) saveEdited(cfg *pkgconfig.Config, original []byte, configPath string) error {
+	if err := w.confirmValid(cfg); err != nil {
+		return err
+	}
+	data, err := w.marshal(cfg, configPath)
+	if err != nil {
+		return err
+	}
+	if data, err = restoreUnknownFields(original, data, config.IsYAMLFile(configPath), w.compact); err != nil {
+		return fmt.Errorf("failed to serialize configuration: %w", err)
+	}
+
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write configuration file: %w", err)
+	}
+	return nil
+}
+
+// restoreUnknownFields adds the fields of the original configuration that
+// qualhook does not know, and so dropped when decoding it, to the edited
+// one. JSON is valid YAML, so both formats are handled as YAML nodes, which
+// keep the order of fields.
+func restoreUnknownFields(original, edited []byte, yamlFormat, compact bool) ([]byte, error) {
+	// What qualhook keeps of the original: the fields it lost are unknown
+	parse := pkgconfig.ParseConfig
+	if yamlFormat {
+		parse = pkgconfig.ParseConfigYAML
+	}
+	cfg, err := parse(original)
+	if err != nil {
+		return nil, err
+	}
+	known, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var originalNode, knownNode, editedNode yaml.Node
+	for _, doc := range []struct {
+		data []byte
+		node *yaml.Node
+	}{{original, &originalNode}, {known, &knownNode}, {edited, &editedNode}} {
+		if err := yaml.Unmarshal(doc.data, doc.node); err != nil {
+			return nil, err
+		}
+	}
+	if len(originalNode.Content) == 0 || len(editedNode.Content) == 0 {
+		return edited, nil
+	}
+	restoreUnknown(originalNode.Content[0], knownNode.Content[0], editedNode.Content[0])
+
+	if yamlFormat {
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&editedNode); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var buf bytes.Buffer
+	if err := writeNodeJSON(&buf, &editedNode); err != nil {
+		return nil, err
+	}
+	if compact {
+		return buf.Bytes(), nil
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return indented.Bytes(), nil
+}
+
+// restoreUnknown adds the mapping fields of original missing from both known
+// and edited to edited. Fields the edit removed are in known and stay
+// removed. Sequence items are matched by their "path" field, such as path
+// configurations, or else by position when the sequences kept their length.
+func restoreUnknown(original, known, edited *yaml.Node) {
+	if known == nil || edited == nil || original.Kind != edited.Kind {
+		return
+	}
+
+	switch original.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(original.Content); i += 2 {
+			key, value := original.Content[i], original.Content[i+1]
+			knownValue := mappingValue(known, key.Value)
+			editedValue := mappingValue(edited, key.Value)
+			switch {
+			case knownValue == nil && editedValue == nil:
+				edited.Content = append(edited.Content, key, value)
+			case knownValue != nil:
+				restoreUnknown(value, knownValue, editedValue)
+			}
+		}
+	case yaml.SequenceNode:
+		if len(original.Content) != len(known.Content) {
+			return
+		}
+		for i, item := range original.Content {
+			restoreUnknown(item, known.Content[i], sequenceItem(edited, item, i, len(original.Content)))
+		}
+	}
+}
+
+// mappingValue returns the value of key in a mapping node, if any
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sequenceItem returns the item of the edited sequence matching the original
+// item at index i of a sequence of length n
+func sequenceItem(edited, item *yaml.Node, i, n int) *yaml.Node {
+	if path := mappingValue(item, "path"); path != nil {
+		for _, candidate := range edited.Content {
+			if p := mappingValue(candidate, "path"); p != nil && p.Value == path.Value {
+				return candidate
+			}
+		}
+		return nil
+	}
+	if len(edited.Content) != n {
+		return nil
+	}
+	return edited.Content[i]
+}
+
+// writeNodeJSON writes a node decoded from JSON as compact JSON, keeping the
+// order of fields
+func writeNodeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			if err := writeNodeJSON(buf, child); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeNodeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, child := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeNodeJSON(buf, child); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case yaml.AliasNode:
+		return writeNodeJSON(buf, node.Alias)
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!str":
+			value, err := json.Marshal(node.Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(value)
+		case "!!null":
+			buf.WriteString("null")
+		default:
+			buf.WriteString(node.Value)
+		}
+	}
+	return nil
+}
+
+// sortedCommandNames returns the names of commands in order
+func sortedCommandNames(commands map[string]*pkgconfig.CommandConfig) []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,83 @@
+//go:build unit
+
+package wizard
+
+import (
+	"strings"
+	"testing"
+
+	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func TestRestoreUnknownFields(t *testing.T) {
+	original := `{
+  "version": "1.0",
+  "x-team": {"owner": "web"},
+  "commands": {
+    "lint": {"command": "eslint", "prompt": "Fix the lint errors:", "x-note": "keep me"},
+    "test": {"command": "jest", "x-note": "removed with the command"}
+  },
+  "paths": [
+    {"path": "docs/**", "x-owner": "docs", "commands": {"lint": {"command": "markdownlint"}}},
+    {"path": "web/**", "x-owner": "web", "commands": {"lint": {"command": "eslint"}}}
+  ]
+}`
+	cfg, err := pkgconfig.ParseConfig([]byte(original))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Edit: remove a command and a path, change another command
+	delete(cfg.Commands, "test")
+	cfg.Paths = cfg.Paths[1:]
+	cfg.Commands["lint"].Args = []string{"."}
+	edited, err := pkgconfig.SaveConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := restoreUnknownFields([]byte(original), edited, false, false)
+	if err != nil {
+		t.Fatalf("restoreUnknownFields() error = %v", err)
+	}
+	got := string(data)
+	for _, want := range []string{`"x-team": {`, `"x-note": "keep me"`, `"x-owner": "web"`, `"prompt": "Fix the lint errors:"`, `"args": [`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %s in the saved configuration:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"removed with the command", `"x-owner": "docs"`} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("expected %s to be removed:\n%s", unwanted, got)
+		}
+	}
+	// Known fields keep their order, with unknown ones after them
+	if strings.Index(got, `"version"`) > strings.Index(got, `"commands"`) || strings.Index(got, `"commands"`) > strings.Index(got, `"x-team"`) {
+		t.Errorf("expected the field order of the saved configuration, got:\n%s", got)
+	}
+	if _, err := pkgconfig.LoadConfig(data); err != nil {
+		t.Errorf("expected a valid configuration, got %v", err)
+	}
+}
+
+func TestRestoreUnknownFields_YAML(t *testing.T) {
+	original := "version: \"1.0\"\nx-team: web\ncommands:\n  lint:\n    command: eslint\n"
+	cfg, err := pkgconfig.ParseConfigYAML([]byte(original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Commands["lint"].Command = "biome"
+	edited, err := pkgconfig.SaveConfigYAML(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := restoreUnknownFields([]byte(original), edited, true, false)
+	if err != nil {
+		t.Fatalf("restoreUnknownFields() error = %v", err)
+	}
+	got := string(data)
+	if !strings.Contains(got, "x-team: web") || !strings.Contains(got, "command: biome") {
+		t.Errorf("expected the edit with the unknown field kept, got:\n%s", got)
+	}
+}
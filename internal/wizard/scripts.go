@@ -0,0 +1,93 @@
+package wizard
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/bebsworthy/qualhook/internal/detector"
+	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// readPackageScripts returns the sorted script names of the package.json in
+// dir. A missing package.json has no scripts.
+func readPackageScripts(dir string) ([]string, error) {
+	// #nosec G304 - package.json of the project being configured
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package.json: %w", err)
+	}
+
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("invalid package.json: %w", err)
+	}
+
+	scripts := make([]string, 0, len(pkg.Scripts))
+	for name := range pkg.Scripts {
+		scripts = append(scripts, name)
+	}
+	sort.Strings(scripts)
+	return scripts, nil
+}
+
+// scriptCommand creates the configuration running a package.json script with
+// the project's package manager
+func scriptCommand(packageManager, script string) *pkgconfig.CommandConfig {
+	if packageManager == "" {
+		packageManager = detector.PackageManagerNPM
+	}
+	return &pkgconfig.CommandConfig{
+		Command:   packageManager,
+		Args:      []string{"run", script},
+		ExitCodes: []int{1},
+		ErrorPatterns: []*pkgconfig.RegexPattern{
+			{Pattern: "error", Flags: "i"},
+		},
+		MaxOutput: 100,
+	}
+}
+
+// importPackageScripts offers the package.json scripts of dir that are not
+// configured yet and adds the selected ones as custom commands. A missing or
+// invalid package.json only skips the import.
+func (w *ConfigWizard) importPackageScripts(cfg *pkgconfig.Config, dir string) error {
+	scripts, err := readPackageScripts(dir)
+	if err != nil {
+		fmt.Printf("\n⚠️  Skipping package.json scripts: %v\n", err)
+		return nil
+	}
+
+	var options []string
+	for _, script := range scripts {
+		if _, exists := cfg.Commands[script]; !exists {
+			options = append(options, script)
+		}
+	}
+	if len(options) == 0 {
+		return nil
+	}
+
+	var selected []string
+	selectPrompt := &survey.MultiSelect{
+		Message: "Import package.json scripts as custom commands:",
+		Options: options,
+	}
+	if err := survey.AskOne(selectPrompt, &selected); err != nil {
+		return err
+	}
+
+	packageManager := w.projectDetector.DetectPackageManager(dir)
+	for _, script := range selected {
+		cfg.Commands[script] = scriptCommand(packageManager, script)
+	}
+	return nil
+}
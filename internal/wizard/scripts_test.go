@@ -0,0 +1,87 @@
+//go:build unit
+
+package wizard
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/internal/detector"
+	pkgconfig "github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func writePackageJSON(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write package.json: %v", err)
+	}
+}
+
+func TestReadPackageScripts(t *testing.T) {
+	dir := t.TempDir()
+	writePackageJSON(t, dir, `{"name": "app", "scripts": {"test": "jest", "lint:css": "stylelint .", "build": "tsc"}}`)
+
+	scripts, err := readPackageScripts(dir)
+	if err != nil {
+		t.Fatalf("readPackageScripts() error = %v", err)
+	}
+	if want := []string{"build", "lint:css", "test"}; !reflect.DeepEqual(scripts, want) {
+		t.Errorf("readPackageScripts() = %v, want %v", scripts, want)
+	}
+}
+
+func TestReadPackageScripts_MissingOrInvalid(t *testing.T) {
+	scripts, err := readPackageScripts(t.TempDir())
+	if err != nil || len(scripts) != 0 {
+		t.Errorf("expected no scripts without package.json, got %v, %v", scripts, err)
+	}
+
+	dir := t.TempDir()
+	writePackageJSON(t, dir, `{"scripts": `)
+	if _, err := readPackageScripts(dir); err == nil {
+		t.Error("expected an error for an invalid package.json")
+	}
+}
+
+func TestScriptCommand(t *testing.T) {
+	cmd := scriptCommand("", "lint:css")
+	if cmd.Command != detector.PackageManagerNPM || !reflect.DeepEqual(cmd.Args, []string{"run", "lint:css"}) {
+		t.Errorf("scriptCommand() = %s %v, want npm run lint:css", cmd.Command, cmd.Args)
+	}
+	if err := cmd.Validate(); err != nil {
+		t.Errorf("scriptCommand() is invalid: %v", err)
+	}
+
+	if cmd := scriptCommand(detector.PackageManagerYarn, "e2e"); cmd.Command != "yarn" {
+		t.Errorf("expected the detected package manager, got %q", cmd.Command)
+	}
+}
+
+func TestImportPackageScripts_NothingToOffer(t *testing.T) {
+	wizard, err := NewConfigWizard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &pkgconfig.Config{Commands: map[string]*pkgconfig.CommandConfig{
+		"test": {Command: "npm", Args: []string{"test"}},
+	}}
+
+	// Invalid package.json files and already configured scripts are skipped
+	// without prompting
+	invalid := t.TempDir()
+	writePackageJSON(t, invalid, `not json`)
+	if err := wizard.importPackageScripts(cfg, invalid); err != nil {
+		t.Errorf("importPackageScripts() with invalid package.json error = %v", err)
+	}
+
+	configured := t.TempDir()
+	writePackageJSON(t, configured, `{"scripts": {"test": "jest"}}`)
+	if err := wizard.importPackageScripts(cfg, configured); err != nil {
+		t.Errorf("importPackageScripts() error = %v", err)
+	}
+	if len(cfg.Commands) != 1 {
+		t.Errorf("expected no commands to be added, got %v", cfg.Commands)
+	}
+}
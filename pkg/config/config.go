@@ -6,16 +6,149 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"text/template"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
 // Config represents the main configuration structure for qualhook
 type Config struct {
-	Version     string                    `json:"version"`
+	Version string `json:"version"`
+	// Extends names a base configuration file, relative to this file, whose
+	// commands and paths this configuration inherits
+	Extends     string                    `json:"extends,omitempty"`
 	ProjectType string                    `json:"projectType,omitempty"`
 	Commands    map[string]*CommandConfig `json:"commands"`
 	Paths       []*PathConfig             `json:"paths,omitempty"`
+	// RootFallback controls files matching no path config: "run" (default) or "skip"
+	RootFallback string `json:"rootFallback,omitempty"`
+	// AI holds settings for AI-assisted configuration
+	AI *AIConfig `json:"ai,omitempty"`
+	// Profiles holds named command overrides selected at load time, e.g. "ci"
+	Profiles map[string]*Profile `json:"profiles,omitempty"`
+	// StandardCommands lists commands, e.g. "build", offered as subcommands
+	// like the built-in format, lint, typecheck and test
+	StandardCommands []string `json:"standardCommands,omitempty"`
+	// Security restricts where configured commands may run
+	Security *SecurityConfig `json:"security,omitempty"`
+	// ErrorExitCode is the exit code for quality errors (default 2); execution
+	// and configuration errors always exit with 1
+	ErrorExitCode int `json:"errorExitCode,omitempty"`
+	// DefaultCommand names the command run by a bare qualhook invocation,
+	// which otherwise shows help
+	DefaultCommand string `json:"defaultCommand,omitempty"`
+	// NormalizePaths reports error locations with forward slashes on Windows,
+	// so they group and match path patterns like on other systems
+	NormalizePaths bool `json:"normalizePaths,omitempty"`
+	// MaxTotalRetries caps the retries of all commands in a run together
+	// (0: only the per-command retries limit them)
+	MaxTotalRetries int `json:"maxTotalRetries,omitempty"`
+	// MaxParallel is the number of components run at once, unless --jobs
+	// is given (0: the number of CPUs)
+	MaxParallel int `json:"maxParallel,omitempty"`
+}
+
+// SecurityConfig defines restrictions enforced for every command
+type SecurityConfig struct {
+	// AllowedWorkingDirs are globs, relative to the project root unless
+	// absolute, that command working directories must match. Empty allows
+	// the project root and its subdirectories.
+	AllowedWorkingDirs []string `json:"allowedWorkingDirs,omitempty"`
+	// AllowedCommands, when not empty, are the only executables commands may
+	// run, by name or path
+	AllowedCommands []string `json:"allowedCommands,omitempty"`
+	// DeniedCommands are executables commands may never run, by name or path
+	DeniedCommands []string `json:"deniedCommands,omitempty"`
 }
 
+// Clone creates a deep copy of the SecurityConfig
+func (s *SecurityConfig) Clone() *SecurityConfig {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	if s.AllowedWorkingDirs != nil {
+		clone.AllowedWorkingDirs = append([]string(nil), s.AllowedWorkingDirs...)
+	}
+	if s.AllowedCommands != nil {
+		clone.AllowedCommands = append([]string(nil), s.AllowedCommands...)
+	}
+	if s.DeniedCommands != nil {
+		clone.DeniedCommands = append([]string(nil), s.DeniedCommands...)
+	}
+	return &clone
+}
+
+// Validate performs validation on the SecurityConfig
+func (s *SecurityConfig) Validate() error {
+	for i, pattern := range s.AllowedWorkingDirs {
+		if pattern == "" {
+			return fmt.Errorf("allowedWorkingDirs %d: pattern must not be empty", i)
+		}
+		if !doublestar.ValidatePattern(pattern) {
+			return fmt.Errorf("allowedWorkingDirs %d: invalid glob pattern %q", i, pattern)
+		}
+	}
+	for i, command := range s.AllowedCommands {
+		if strings.TrimSpace(command) == "" {
+			return fmt.Errorf("allowedCommands %d: command must not be empty", i)
+		}
+	}
+	for i, command := range s.DeniedCommands {
+		if strings.TrimSpace(command) == "" {
+			return fmt.Errorf("deniedCommands %d: command must not be empty", i)
+		}
+	}
+	return nil
+}
+
+// AIConfig defines settings for AI tool invocations
+type AIConfig struct {
+	// Timeout for the AI tool in milliseconds, separate from command timeouts
+	Timeout int `json:"timeout,omitempty"`
+	// MaxConcurrent limits simultaneous AI tool invocations (default 1)
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+	// MaxContextBytes limits the project files embedded in AI prompts (default 16384)
+	MaxContextBytes int `json:"maxContextBytes,omitempty"`
+	// APIKeyEnv names the environment variable holding the AI tool's API key
+	APIKeyEnv string `json:"apiKeyEnv,omitempty"`
+	// APIKeyFile names a file, relative to the configuration file, holding
+	// the AI tool's API key
+	APIKeyFile string `json:"apiKeyFile,omitempty"`
+	// Tools registers AI CLIs by name, or changes how claude and gemini are invoked
+	Tools map[string]*AIToolConfig `json:"tools,omitempty"`
+}
+
+// AIToolConfig defines how an AI CLI is invoked
+type AIToolConfig struct {
+	// Command is the executable of the tool
+	Command string `json:"command"`
+	// Args are the tool arguments; "{prompt}" is replaced by the prompt, and
+	// without it the prompt is written to the tool's stdin
+	Args []string `json:"args,omitempty"`
+}
+
+// ValidateErrorExitCode checks an exit code for quality errors: 0 selects
+// the default, otherwise it must be a failing exit code other than 1, which
+// reports execution errors
+func ValidateErrorExitCode(code int) error {
+	if code == 0 {
+		return nil
+	}
+	if code < 2 || code > 255 {
+		return fmt.Errorf("must be between 2 and 255, got %d", code)
+	}
+	return nil
+}
+
+// Root fallback behaviors for files that match no path configuration
+const (
+	// RootFallbackRun runs the root commands for unmatched files
+	RootFallbackRun = "run"
+	// RootFallbackSkip ignores unmatched files
+	RootFallbackSkip = "skip"
+)
+
 // CommandConfig defines configuration for a single command
 type CommandConfig struct {
 	Command         string          `json:"command"`
@@ -27,6 +160,95 @@ type CommandConfig struct {
 	ContextLines    int             `json:"contextLines,omitempty"`
 	MaxOutput       int             `json:"maxOutput,omitempty"`
 	IncludePatterns []*RegexPattern `json:"includePatterns,omitempty"`
+	// MaxOutputBytes caps the reported output in bytes, for tools printing
+	// huge lines such as minified stack traces; maxOutput still applies
+	MaxOutputBytes int `json:"maxOutputBytes,omitempty"`
+	// BlockMode extends each matched line through the following non-blank lines
+	BlockMode bool `json:"blockMode,omitempty"`
+	// Dedupe collapses repeated matched lines into one with an occurrence count
+	Dedupe bool `json:"dedupe,omitempty"`
+	// Sandbox runs the command inside a container instead of on the host
+	Sandbox *SandboxConfig `json:"sandbox,omitempty"`
+	// BinaryOutput controls how non-text command output is reported
+	BinaryOutput string `json:"binaryOutput,omitempty"`
+	// OutputFormat is the format of the command output: "sarif" is parsed as
+	// SARIF JSON; anything else, "text" by default, is filtered with the error
+	// patterns. Other values, such as the name of a linter formatter, are
+	// accepted as text for existing configurations.
+	OutputFormat string `json:"outputFormat,omitempty"`
+	// Severities classifies reported lines, ordered from most to least severe
+	Severities []*SeverityConfig `json:"severities,omitempty"`
+	// FailOn is the least severe level that still fails the command (default: all levels)
+	FailOn string `json:"failOn,omitempty"`
+	// OutputTemplate rewrites matched lines using the named groups of error
+	// patterns, e.g. "{{.file}}:{{.line}} {{.message}}" (text/template syntax)
+	OutputTemplate string `json:"outputTemplate,omitempty"`
+	// VerifyWith lists commands to run after the command succeeds, e.g. lint after format
+	VerifyWith []string `json:"verifyWith,omitempty"`
+	// ContinueOnError lets the commands that follow in a verifyWith sequence
+	// run even when this command fails; by default a failure stops them
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+	// DependsOn lists commands that must pass before this one runs, e.g.
+	// typecheck before test; a failed prerequisite skips the command
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Tags name groups the command belongs to, e.g. "fast", for run --tag
+	Tags []string `json:"tags,omitempty"`
+	// ExpectedExitCode is the exit code of a healthy run, used only by diagnostic commands
+	ExpectedExitCode int `json:"expectedExitCode,omitempty"`
+	// Retries re-runs a command that exits with an error code up to this many more times
+	Retries int `json:"retries,omitempty"`
+	// RetryDelay is the wait before the first retry, doubled for each further retry
+	RetryDelay int `json:"retryDelay,omitempty"` // milliseconds
+	// Triggers are globs of files that run the command when edited, even
+	// outside the path the command is configured for
+	Triggers []string `json:"triggers,omitempty"`
+	// PathPrepend lists directories searched before PATH, relative to the
+	// working directory, e.g. "node_modules/.bin"
+	PathPrepend []string `json:"pathPrepend,omitempty"`
+	// WorkingDir is the directory the command runs in instead of the one of
+	// its component, relative to the configuration file; loading makes it
+	// absolute
+	WorkingDir string `json:"workingDir,omitempty"`
+	// Env sets environment variables for the command, overriding inherited
+	// ones; values may reference the parent environment as ${VAR}
+	Env map[string]string `json:"env,omitempty"`
+	// ErrorPatternsFile names a JSON, YAML or TOML list of error patterns,
+	// relative to the configuration file, added to ErrorPatterns when loading
+	ErrorPatternsFile string `json:"errorPatternsFile,omitempty"`
+}
+
+// SeverityConfig defines a named severity level and the lines that belong to it
+type SeverityConfig struct {
+	Name     string          `json:"name"`
+	Patterns []*RegexPattern `json:"patterns"`
+}
+
+// Binary output policies for commands that emit non-text data
+const (
+	// BinaryOutputSkip replaces binary output with a short note (the default)
+	BinaryOutputSkip = "skip"
+	// BinaryOutputHexdump reports a hexdump of the start of the output
+	BinaryOutputHexdump = "hexdump"
+	// BinaryOutputRaw passes binary output through unchanged
+	BinaryOutputRaw = "raw"
+)
+
+// Command output formats
+const (
+	// OutputFormatText is plain text output filtered with the error patterns
+	OutputFormatText = "text"
+	// OutputFormatSARIF is a SARIF JSON log of the tool results
+	OutputFormatSARIF = "sarif"
+)
+
+// SandboxConfig defines container execution settings for a command
+type SandboxConfig struct {
+	// Runtime is the container CLI to use ("docker" or "podman", default "docker")
+	Runtime string `json:"runtime,omitempty"`
+	// Image is the container image to run the command in
+	Image string `json:"image"`
+	// Writable mounts the project read-write; by default it is mounted read-only
+	Writable bool `json:"writable,omitempty"`
 }
 
 // PathConfig defines path-specific configuration for monorepo support
@@ -34,12 +256,20 @@ type PathConfig struct {
 	Path     string                    `json:"path"`
 	Extends  string                    `json:"extends,omitempty"`
 	Commands map[string]*CommandConfig `json:"commands"`
+	// Exclude lists globs of files that match Path but do not belong to the
+	// component, e.g. generated code
+	Exclude []string `json:"exclude,omitempty"`
 }
 
 // RegexPattern represents a regex pattern with optional flags
 type RegexPattern struct {
 	Pattern string `json:"pattern"`
 	Flags   string `json:"flags,omitempty"`
+	// Literal matches Pattern as a plain substring instead of a regular
+	// expression, so metacharacters need no escaping; the "i" flag still applies
+	Literal bool `json:"literal,omitempty"`
+	// Prompt replaces the command prompt when this error pattern matched
+	Prompt string `json:"prompt,omitempty"`
 }
 
 // Validate performs validation on the Config
@@ -57,6 +287,17 @@ func (c *Config) Validate() error {
 		if err := cmd.Validate(); err != nil {
 			return fmt.Errorf("command %q: %w", name, err)
 		}
+		if err := c.validateVerifyWith(name, cmd); err != nil {
+			return fmt.Errorf("command %q: %w", name, err)
+		}
+		if err := c.validateDependsOn(name, cmd); err != nil {
+			return fmt.Errorf("command %q: %w", name, err)
+		}
+	}
+	for name := range c.Commands {
+		if _, err := c.Prerequisites(name); err != nil {
+			return fmt.Errorf("command %q: %w", name, err)
+		}
 	}
 
 	// Validate paths
@@ -66,9 +307,180 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	switch c.RootFallback {
+	case "", RootFallbackRun, RootFallbackSkip:
+	default:
+		return fmt.Errorf("rootFallback must be %q or %q, got %q", RootFallbackRun, RootFallbackSkip, c.RootFallback)
+	}
+
+	if err := ValidateErrorExitCode(c.ErrorExitCode); err != nil {
+		return fmt.Errorf("errorExitCode: %w", err)
+	}
+
+	if c.MaxTotalRetries < 0 {
+		return fmt.Errorf("maxTotalRetries must be non-negative")
+	}
+
+	if c.AI != nil && c.AI.Timeout < 0 {
+		return fmt.Errorf("ai: timeout must be non-negative")
+	}
+
+	if c.AI != nil && c.AI.MaxConcurrent < 0 {
+		return fmt.Errorf("ai: maxConcurrent must be non-negative")
+	}
+
+	if c.AI != nil && c.AI.MaxContextBytes < 0 {
+		return fmt.Errorf("ai: maxContextBytes must be non-negative")
+	}
+
+	if c.AI != nil && c.AI.APIKeyEnv != "" && c.AI.APIKeyFile != "" {
+		return fmt.Errorf("ai: set only one of apiKeyEnv and apiKeyFile")
+	}
+
+	if c.AI != nil {
+		for name, tool := range c.AI.Tools {
+			if name == "" {
+				return fmt.Errorf("ai: tools: tool name must not be empty")
+			}
+			if tool == nil || tool.Command == "" {
+				return fmt.Errorf("ai: tools: %s: command is required", name)
+			}
+		}
+	}
+
+	if c.Security != nil {
+		if err := c.Security.Validate(); err != nil {
+			return fmt.Errorf("security: %w", err)
+		}
+	}
+
+	if err := validateStandardCommands(c.StandardCommands); err != nil {
+		return err
+	}
+
+	if err := c.validateDefaultCommand(); err != nil {
+		return err
+	}
+
+	for name, profile := range c.Profiles {
+		if name == "" {
+			return fmt.Errorf("profile names must not be empty")
+		}
+		if profile == nil {
+			return fmt.Errorf("profile %q: commands are required", name)
+		}
+		for cmdName, override := range profile.Commands {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(override, &fields); err != nil {
+				return fmt.Errorf("profile %q: command %q must be an object", name, cmdName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateStandardCommands checks that standard command names are usable as
+// subcommand names and listed once
+func validateStandardCommands(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t") {
+			return fmt.Errorf("standardCommands: invalid command name %q", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("standardCommands: %q is listed more than once", name)
+		}
+		seen[name] = true
+	}
 	return nil
 }
 
+// validateDefaultCommand checks that the default command is configured at the
+// root or for a path
+func (c *Config) validateDefaultCommand() error {
+	if c.DefaultCommand == "" {
+		return nil
+	}
+	if _, ok := c.Commands[c.DefaultCommand]; ok {
+		return nil
+	}
+	for _, path := range c.Paths {
+		if _, ok := path.Commands[c.DefaultCommand]; ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("defaultCommand %q is not a configured command", c.DefaultCommand)
+}
+
+// validateVerifyWith checks that verification commands name other root commands
+func (c *Config) validateVerifyWith(name string, cmd *CommandConfig) error {
+	for _, verifyName := range cmd.VerifyWith {
+		if verifyName == name {
+			return fmt.Errorf("verifyWith cannot include the command itself")
+		}
+		if _, ok := c.Commands[verifyName]; !ok {
+			return fmt.Errorf("verifyWith command %q is not configured", verifyName)
+		}
+	}
+	return nil
+}
+
+// validateDependsOn checks that prerequisites name other root commands
+func (c *Config) validateDependsOn(name string, cmd *CommandConfig) error {
+	for _, dependency := range cmd.DependsOn {
+		if dependency == name {
+			return fmt.Errorf("dependsOn cannot include the command itself")
+		}
+		if _, ok := c.Commands[dependency]; !ok {
+			return fmt.Errorf("dependsOn command %q is not configured", dependency)
+		}
+	}
+	return nil
+}
+
+// Prerequisites returns the commands name depends on, directly or through
+// other prerequisites, ordered so that each follows its own prerequisites.
+// It fails when the dependsOn lists form a cycle.
+func (c *Config) Prerequisites(name string) ([]string, error) {
+	var order []string
+	done := make(map[string]bool)
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		for i, seen := range path {
+			if seen == name {
+				cycle := append(append([]string{}, path[i:]...), name)
+				return fmt.Errorf("dependsOn cycle: %s", strings.Join(cycle, " -> "))
+			}
+		}
+		if done[name] {
+			return nil
+		}
+		cmd, ok := c.Commands[name]
+		if !ok {
+			return nil
+		}
+		path = append(path, name)
+		for _, dependency := range cmd.DependsOn {
+			if err := visit(dependency, path); err != nil {
+				return err
+			}
+		}
+		done[name] = true
+		order = append(order, name)
+		return nil
+	}
+
+	if _, ok := c.Commands[name]; !ok {
+		return nil, nil
+	}
+	if err := visit(name, nil); err != nil {
+		return nil, err
+	}
+	// The command itself comes last
+	return order[:len(order)-1], nil
+}
+
 // Validate performs validation on the CommandConfig
 func (c *CommandConfig) Validate() error {
 	if c.Command == "" {
@@ -97,10 +509,134 @@ func (c *CommandConfig) Validate() error {
 		return fmt.Errorf("max output must be non-negative")
 	}
 
+	if c.MaxOutputBytes < 0 {
+		return fmt.Errorf("max output bytes must be non-negative")
+	}
+
 	if c.Timeout < 0 {
 		return fmt.Errorf("timeout must be non-negative")
 	}
 
+	if c.Retries < 0 {
+		return fmt.Errorf("retries must be non-negative")
+	}
+
+	if c.RetryDelay < 0 {
+		return fmt.Errorf("retry delay must be non-negative")
+	}
+
+	for i, trigger := range c.Triggers {
+		if !doublestar.ValidatePattern(trigger) {
+			return fmt.Errorf("trigger %d: invalid glob pattern %q", i, trigger)
+		}
+	}
+
+	for i, tag := range c.Tags {
+		if tag == "" || strings.ContainsAny(tag, " \t") {
+			return fmt.Errorf("tag %d: invalid tag %q", i, tag)
+		}
+	}
+
+	for i, dir := range c.PathPrepend {
+		if strings.TrimSpace(dir) == "" {
+			return fmt.Errorf("pathPrepend %d: directory must not be empty", i)
+		}
+	}
+
+	if c.WorkingDir != "" && strings.TrimSpace(c.WorkingDir) == "" {
+		return fmt.Errorf("workingDir must not be blank")
+	}
+
+	for key := range c.Env {
+		if key == "" || strings.ContainsAny(key, "= \t\x00") {
+			return fmt.Errorf("env: invalid variable name %q", key)
+		}
+	}
+
+	if c.OutputTemplate != "" {
+		if _, err := template.New("output").Parse(c.OutputTemplate); err != nil {
+			return fmt.Errorf("invalid output template: %w", err)
+		}
+	}
+
+	if c.ExpectedExitCode < 0 || c.ExpectedExitCode > 255 {
+		return fmt.Errorf("expected exit code must be between 0 and 255")
+	}
+
+	switch c.BinaryOutput {
+	case "", BinaryOutputSkip, BinaryOutputHexdump, BinaryOutputRaw:
+	default:
+		return fmt.Errorf("binaryOutput must be %q, %q or %q, got %q",
+			BinaryOutputSkip, BinaryOutputHexdump, BinaryOutputRaw, c.BinaryOutput)
+	}
+
+	if c.Sandbox != nil {
+		if err := c.Sandbox.Validate(); err != nil {
+			return fmt.Errorf("sandbox: %w", err)
+		}
+	}
+
+	if err := c.validateSeverities(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSeverities checks severity levels and the failOn threshold
+func (c *CommandConfig) validateSeverities() error {
+	names := make(map[string]bool, len(c.Severities))
+	for i, severity := range c.Severities {
+		if severity == nil || severity.Name == "" {
+			return fmt.Errorf("severity %d: name is required", i)
+		}
+		if names[severity.Name] {
+			return fmt.Errorf("severity %q is defined more than once", severity.Name)
+		}
+		names[severity.Name] = true
+
+		if len(severity.Patterns) == 0 {
+			return fmt.Errorf("severity %q: at least one pattern is required", severity.Name)
+		}
+		for j, pattern := range severity.Patterns {
+			if err := pattern.Validate(); err != nil {
+				return fmt.Errorf("severity %q pattern %d: %w", severity.Name, j, err)
+			}
+		}
+	}
+
+	if c.FailOn != "" && !names[c.FailOn] {
+		return fmt.Errorf("failOn %q does not name a configured severity", c.FailOn)
+	}
+
+	return nil
+}
+
+// DetectionPatterns returns the patterns that mark lines for reporting: the
+// error patterns followed by every severity pattern
+func (c *CommandConfig) DetectionPatterns() []*RegexPattern {
+	if len(c.Severities) == 0 {
+		return c.ErrorPatterns
+	}
+	patterns := append([]*RegexPattern{}, c.ErrorPatterns...)
+	for _, severity := range c.Severities {
+		patterns = append(patterns, severity.Patterns...)
+	}
+	return patterns
+}
+
+// Validate performs validation on the SandboxConfig
+func (s *SandboxConfig) Validate() error {
+	if s.Image == "" {
+		return fmt.Errorf("image is required")
+	}
+
+	switch s.Runtime {
+	case "", "docker", "podman":
+	default:
+		return fmt.Errorf("unsupported runtime %q (use docker or podman)", s.Runtime)
+	}
+
 	return nil
 }
 
@@ -110,6 +646,12 @@ func (p *PathConfig) Validate() error {
 		return fmt.Errorf("path is required")
 	}
 
+	for i, exclude := range p.Exclude {
+		if !doublestar.ValidatePattern(exclude) {
+			return fmt.Errorf("exclude %d: invalid glob pattern %q", i, exclude)
+		}
+	}
+
 	for name, cmd := range p.Commands {
 		if err := cmd.Validate(); err != nil {
 			return fmt.Errorf("command %q: %w", name, err)
@@ -145,50 +687,77 @@ func (r *RegexPattern) Validate() error {
 
 // validateRegex checks if the regex pattern is valid
 func (r *RegexPattern) validateRegex() error {
-	pattern := r.Pattern
-
-	// Add flags to pattern if specified
-	if r.Flags != "" {
-		pattern = "(?" + r.Flags + ")" + pattern
-	}
-
-	_, err := regexp.Compile(pattern)
+	_, err := regexp.Compile(r.Expression())
 	return err
 }
 
-// Compile returns a compiled regular expression
-func (r *RegexPattern) Compile() (*regexp.Regexp, error) {
+// Expression returns the regular expression the pattern compiles to: the
+// pattern, quoted when literal, preceded by its flags
+func (r *RegexPattern) Expression() string {
 	pattern := r.Pattern
+	if r.Literal {
+		pattern = regexp.QuoteMeta(pattern)
+	}
 
 	// Add flags to pattern if specified
 	if r.Flags != "" {
 		pattern = "(?" + r.Flags + ")" + pattern
 	}
+	return pattern
+}
 
-	return regexp.Compile(pattern)
+// Compile returns a compiled regular expression
+func (r *RegexPattern) Compile() (*regexp.Regexp, error) {
+	return regexp.Compile(r.Expression())
 }
 
 // LoadConfig loads a configuration from JSON data
 func LoadConfig(data []byte) (*Config, error) {
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config: %w", err)
+	config, err := ParseConfig(data)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	return config, nil
+}
+
+// ParseConfig decodes a configuration from JSON data without validating it,
+// e.g. for configurations that are only complete once merged with a base
+func ParseConfig(data []byte) (*Config, error) {
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
 	return &config, nil
 }
 
-// SaveConfig serializes a configuration to JSON
+// SaveConfig serializes a configuration to pretty-printed JSON
 func SaveConfig(config *Config) ([]byte, error) {
+	return marshalConfig(config, false)
+}
+
+// SaveConfigCompact serializes a configuration to minified single-line JSON
+func SaveConfigCompact(config *Config) ([]byte, error) {
+	return marshalConfig(config, true)
+}
+
+// marshalConfig validates and serializes a configuration
+func marshalConfig(config *Config, compact bool) ([]byte, error) {
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	data, err := json.MarshalIndent(config, "", "  ")
+	var data []byte
+	var err error
+	if compact {
+		data, err = json.Marshal(config)
+	} else {
+		data, err = json.MarshalIndent(config, "", "  ")
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -203,11 +772,66 @@ func (c *CommandConfig) Clone() *CommandConfig {
 	}
 
 	clone := &CommandConfig{
-		Command:      c.Command,
-		Prompt:       c.Prompt,
-		Timeout:      c.Timeout,
-		ContextLines: c.ContextLines,
-		MaxOutput:    c.MaxOutput,
+		Command:           c.Command,
+		Prompt:            c.Prompt,
+		Timeout:           c.Timeout,
+		ContextLines:      c.ContextLines,
+		MaxOutput:         c.MaxOutput,
+		MaxOutputBytes:    c.MaxOutputBytes,
+		BlockMode:         c.BlockMode,
+		Dedupe:            c.Dedupe,
+		BinaryOutput:      c.BinaryOutput,
+		OutputFormat:      c.OutputFormat,
+		FailOn:            c.FailOn,
+		OutputTemplate:    c.OutputTemplate,
+		ContinueOnError:   c.ContinueOnError,
+		ExpectedExitCode:  c.ExpectedExitCode,
+		Retries:           c.Retries,
+		RetryDelay:        c.RetryDelay,
+		WorkingDir:        c.WorkingDir,
+		ErrorPatternsFile: c.ErrorPatternsFile,
+	}
+
+	if c.Severities != nil {
+		clone.Severities = make([]*SeverityConfig, len(c.Severities))
+		for i, s := range c.Severities {
+			if s == nil {
+				continue
+			}
+			severity := &SeverityConfig{Name: s.Name}
+			if s.Patterns != nil {
+				severity.Patterns = make([]*RegexPattern, len(s.Patterns))
+				for j, p := range s.Patterns {
+					if p != nil {
+						pattern := *p
+						severity.Patterns[j] = &pattern
+					}
+				}
+			}
+			clone.Severities[i] = severity
+		}
+	}
+
+	if c.Sandbox != nil {
+		sandbox := *c.Sandbox
+		clone.Sandbox = &sandbox
+	}
+
+	if c.Triggers != nil {
+		clone.Triggers = make([]string, len(c.Triggers))
+		copy(clone.Triggers, c.Triggers)
+	}
+
+	if c.PathPrepend != nil {
+		clone.PathPrepend = make([]string, len(c.PathPrepend))
+		copy(clone.PathPrepend, c.PathPrepend)
+	}
+
+	if c.Env != nil {
+		clone.Env = make(map[string]string, len(c.Env))
+		for key, value := range c.Env {
+			clone.Env[key] = value
+		}
 	}
 
 	if c.Args != nil {
@@ -220,6 +844,21 @@ func (c *CommandConfig) Clone() *CommandConfig {
 		copy(clone.ExitCodes, c.ExitCodes)
 	}
 
+	if c.VerifyWith != nil {
+		clone.VerifyWith = make([]string, len(c.VerifyWith))
+		copy(clone.VerifyWith, c.VerifyWith)
+	}
+
+	if c.DependsOn != nil {
+		clone.DependsOn = make([]string, len(c.DependsOn))
+		copy(clone.DependsOn, c.DependsOn)
+	}
+
+	if c.Tags != nil {
+		clone.Tags = make([]string, len(c.Tags))
+		copy(clone.Tags, c.Tags)
+	}
+
 	if c.ErrorPatterns != nil {
 		clone.ErrorPatterns = make([]*RegexPattern, len(c.ErrorPatterns))
 		for i, p := range c.ErrorPatterns {
@@ -227,6 +866,8 @@ func (c *CommandConfig) Clone() *CommandConfig {
 				clone.ErrorPatterns[i] = &RegexPattern{
 					Pattern: p.Pattern,
 					Flags:   p.Flags,
+					Literal: p.Literal,
+					Prompt:  p.Prompt,
 				}
 			}
 		}
@@ -239,6 +880,7 @@ func (c *CommandConfig) Clone() *CommandConfig {
 				clone.IncludePatterns[i] = &RegexPattern{
 					Pattern: p.Pattern,
 					Flags:   p.Flags,
+					Literal: p.Literal,
 				}
 			}
 		}
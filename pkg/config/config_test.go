@@ -131,6 +131,79 @@ func TestConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "path config 0: path is required",
 		},
+		{
+			name: "invalid root fallback",
+			buildFunc: func() *Config {
+				cfg := newTestConfigBuilder().
+					withCommand("lint", &CommandConfig{Command: "npm"}).
+					build()
+				cfg.RootFallback = "ignore"
+				return cfg
+			},
+			wantErr: true,
+			errMsg:  "rootFallback must be",
+		},
+		{
+			name: "verifyWith names a configured command",
+			buildFunc: func() *Config {
+				return newTestConfigBuilder().
+					withCommand("format", &CommandConfig{Command: "prettier", VerifyWith: []string{"lint"}}).
+					withCommand("lint", &CommandConfig{Command: "eslint"}).
+					build()
+			},
+			wantErr: false,
+		},
+		{
+			name: "verifyWith names an unknown command",
+			buildFunc: func() *Config {
+				return newTestConfigBuilder().
+					withCommand("format", &CommandConfig{Command: "prettier", VerifyWith: []string{"lint"}}).
+					build()
+			},
+			wantErr: true,
+			errMsg:  "verifyWith command \"lint\" is not configured",
+		},
+		{
+			name: "verifyWith includes the command itself",
+			buildFunc: func() *Config {
+				return newTestConfigBuilder().
+					withCommand("format", &CommandConfig{Command: "prettier", VerifyWith: []string{"format"}}).
+					build()
+			},
+			wantErr: true,
+			errMsg:  "verifyWith cannot include the command itself",
+		},
+		{
+			name: "dependsOn names an unknown command",
+			buildFunc: func() *Config {
+				return newTestConfigBuilder().
+					withCommand("test", &CommandConfig{Command: "jest", DependsOn: []string{"typecheck"}}).
+					build()
+			},
+			wantErr: true,
+			errMsg:  "dependsOn command \"typecheck\" is not configured",
+		},
+		{
+			name: "dependsOn includes the command itself",
+			buildFunc: func() *Config {
+				return newTestConfigBuilder().
+					withCommand("test", &CommandConfig{Command: "jest", DependsOn: []string{"test"}}).
+					build()
+			},
+			wantErr: true,
+			errMsg:  "dependsOn cannot include the command itself",
+		},
+		{
+			name: "dependsOn cycle",
+			buildFunc: func() *Config {
+				return newTestConfigBuilder().
+					withCommand("test", &CommandConfig{Command: "jest", DependsOn: []string{"typecheck"}}).
+					withCommand("typecheck", &CommandConfig{Command: "tsc", DependsOn: []string{"test"}}).
+					build()
+			},
+			wantErr: true,
+			errMsg:  "dependsOn cycle:",
+		},
 	}
 
 	for _, tt := range tests {
@@ -177,6 +250,61 @@ func TestCommandConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "timeout must be non-negative",
 		},
+		{
+			name: "negative retries",
+			config: &CommandConfig{
+				Command: "npm",
+				Retries: -1,
+			},
+			wantErr: true,
+			errMsg:  "retries must be non-negative",
+		},
+		{
+			name: "tag with whitespace",
+			config: &CommandConfig{
+				Command: "npm",
+				Tags:    []string{"fast", "not fast"},
+			},
+			wantErr: true,
+			errMsg:  `tag 1: invalid tag "not fast"`,
+		},
+		{
+			name: "negative retry delay",
+			config: &CommandConfig{
+				Command:    "npm",
+				Retries:    2,
+				RetryDelay: -1,
+			},
+			wantErr: true,
+			errMsg:  "retry delay must be non-negative",
+		},
+		{
+			name: "invalid trigger",
+			config: &CommandConfig{
+				Command:  "npm",
+				Triggers: []string{"**/package.json", "[invalid"},
+			},
+			wantErr: true,
+			errMsg:  "trigger 1",
+		},
+		{
+			name: "empty path prepend directory",
+			config: &CommandConfig{
+				Command:     "eslint",
+				PathPrepend: []string{"node_modules/.bin", " "},
+			},
+			wantErr: true,
+			errMsg:  "pathPrepend 1",
+		},
+		{
+			name: "invalid env variable name",
+			config: &CommandConfig{
+				Command: "jest",
+				Env:     map[string]string{"NODE_ENV=test": "1"},
+			},
+			wantErr: true,
+			errMsg:  "env: invalid variable name",
+		},
 		{
 			name: "invalid error pattern",
 			config: &CommandConfig{
@@ -197,6 +325,127 @@ func TestCommandConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "context lines must be non-negative",
 		},
+		{
+			name: "valid output template",
+			config: &CommandConfig{
+				Command:        "npm",
+				OutputTemplate: "{{.file}}:{{.line}} {{.message}}",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid output template",
+			config: &CommandConfig{
+				Command:        "npm",
+				OutputTemplate: "{{.file",
+			},
+			wantErr: true,
+			errMsg:  "invalid output template",
+		},
+		{
+			name: "sandbox without image",
+			config: &CommandConfig{
+				Command: "npm",
+				Sandbox: &SandboxConfig{Runtime: "docker"},
+			},
+			wantErr: true,
+			errMsg:  "sandbox: image is required",
+		},
+		{
+			name: "sandbox with unsupported runtime",
+			config: &CommandConfig{
+				Command: "npm",
+				Sandbox: &SandboxConfig{Runtime: "lxc", Image: "node:20"},
+			},
+			wantErr: true,
+			errMsg:  "unsupported runtime",
+		},
+		{
+			name: "valid sandbox",
+			config: &CommandConfig{
+				Command: "npm",
+				Sandbox: &SandboxConfig{Runtime: "podman", Image: "node:20"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported binary output policy",
+			config: &CommandConfig{
+				Command:      "npm",
+				BinaryOutput: "base64",
+			},
+			wantErr: true,
+			errMsg:  "binaryOutput must be",
+		},
+		{
+			name: "other output formats are text",
+			config: &CommandConfig{
+				Command:      "eslint",
+				OutputFormat: "stylish",
+			},
+			wantErr: false,
+		},
+		{
+			name: "sarif output format",
+			config: &CommandConfig{
+				Command:      "eslint",
+				OutputFormat: OutputFormatSARIF,
+			},
+			wantErr: false,
+		},
+		{
+			name: "failOn names unknown severity",
+			config: &CommandConfig{
+				Command: "eslint",
+				Severities: []*SeverityConfig{
+					{Name: "error", Patterns: []*RegexPattern{{Pattern: "error"}}},
+				},
+				FailOn: "warning",
+			},
+			wantErr: true,
+			errMsg:  "does not name a configured severity",
+		},
+		{
+			name: "duplicate severity",
+			config: &CommandConfig{
+				Command: "eslint",
+				Severities: []*SeverityConfig{
+					{Name: "error", Patterns: []*RegexPattern{{Pattern: "error"}}},
+					{Name: "error", Patterns: []*RegexPattern{{Pattern: "fatal"}}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "defined more than once",
+		},
+		{
+			name: "severity without patterns",
+			config: &CommandConfig{
+				Command:    "eslint",
+				Severities: []*SeverityConfig{{Name: "hint"}},
+			},
+			wantErr: true,
+			errMsg:  "at least one pattern is required",
+		},
+		{
+			name: "valid severities",
+			config: &CommandConfig{
+				Command: "eslint",
+				Severities: []*SeverityConfig{
+					{Name: "error", Patterns: []*RegexPattern{{Pattern: "error"}}},
+					{Name: "hint", Patterns: []*RegexPattern{{Pattern: "hint"}}},
+				},
+				FailOn: "error",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid binary output policy",
+			config: &CommandConfig{
+				Command:      "npm",
+				BinaryOutput: BinaryOutputHexdump,
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid with all fields",
 			config: &CommandConfig{
@@ -266,6 +515,18 @@ func TestPathConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "command \"lint\": command is required",
 		},
+		{
+			name: "invalid exclude glob",
+			config: &PathConfig{
+				Path:    "src/**",
+				Exclude: []string{"src/[gen/**"},
+				Commands: map[string]*CommandConfig{
+					"lint": {Command: "npm"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "exclude 0: invalid glob pattern",
+		},
 		{
 			name: "valid with extends",
 			config: &PathConfig{
@@ -383,6 +644,23 @@ func TestRegexPattern_Compile(t *testing.T) {
 	}
 }
 
+func TestRegexPattern_Literal(t *testing.T) {
+	pattern := &RegexPattern{Pattern: "[ERROR] (a+)+", Flags: "i", Literal: true}
+	if err := pattern.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	re, err := pattern.Compile()
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if !re.MatchString("build: [error] (A+)+ in main.c") {
+		t.Error("expected a case-insensitive substring match")
+	}
+	if re.MatchString("E (aa)") {
+		t.Error("expected the pattern not to be interpreted as a regular expression")
+	}
+}
+
 func TestLoadConfig(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -510,6 +788,55 @@ func TestSaveConfig(t *testing.T) {
 	}
 }
 
+func TestSaveConfigCompact(t *testing.T) {
+	cfg := newTestConfigBuilder().
+		withSimpleCommand("lint", "npm", "run", "lint").
+		withPath(&PathConfig{
+			Path:     "frontend/**",
+			Commands: map[string]*CommandConfig{"lint": {Command: "npm", Args: []string{"run", "lint"}}},
+		}).
+		build()
+	cfg.Commands["lint"].ErrorPatterns = []*RegexPattern{{Pattern: "error", Flags: "i"}}
+
+	pretty, err := SaveConfig(cfg)
+	if err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	compact, err := SaveConfigCompact(cfg)
+	if err != nil {
+		t.Fatalf("SaveConfigCompact() error = %v", err)
+	}
+
+	if strings.Contains(string(compact), "\n") {
+		t.Errorf("expected compact output on a single line, got %q", compact)
+	}
+	if !strings.Contains(string(pretty), "\n  ") {
+		t.Errorf("expected pretty output to be indented, got %q", pretty)
+	}
+	if len(compact) >= len(pretty) {
+		t.Errorf("expected compact output (%d bytes) to be smaller than pretty (%d bytes)", len(compact), len(pretty))
+	}
+
+	fromPretty, err := LoadConfig(pretty)
+	if err != nil {
+		t.Fatalf("failed to load pretty config: %v", err)
+	}
+	fromCompact, err := LoadConfig(compact)
+	if err != nil {
+		t.Fatalf("failed to load compact config: %v", err)
+	}
+	if !reflect.DeepEqual(fromPretty, fromCompact) {
+		t.Errorf("compact and pretty outputs load differently:\npretty:  %+v\ncompact: %+v", fromPretty, fromCompact)
+	}
+	if !reflect.DeepEqual(fromCompact, cfg) {
+		t.Errorf("compact round trip changed the config:\ngot:  %+v\nwant: %+v", fromCompact, cfg)
+	}
+
+	if _, err := SaveConfigCompact(&Config{}); err == nil {
+		t.Error("expected SaveConfigCompact to reject an invalid config")
+	}
+}
+
 func TestCommandConfig_Clone(t *testing.T) {
 	original := &CommandConfig{
 		Command:   "npm",
@@ -525,6 +852,11 @@ func TestCommandConfig_Clone(t *testing.T) {
 		MaxOutput:    100,
 		Prompt:       "Fix errors:",
 		Timeout:      60000,
+		Retries:      2,
+		RetryDelay:   500,
+		Triggers:     []string{"**/package.json"},
+		PathPrepend:  []string{"node_modules/.bin"},
+		Env:          map[string]string{"NODE_ENV": "test"},
 	}
 
 	clone := original.Clone()
@@ -548,6 +880,9 @@ func TestCommandConfig_Clone(t *testing.T) {
 	if clone.MaxOutput != original.MaxOutput {
 		t.Error("MaxOutput not cloned correctly")
 	}
+	if clone.Retries != original.Retries || clone.RetryDelay != original.RetryDelay {
+		t.Error("Retries not cloned correctly")
+	}
 
 	// Verify deep copy - modifying clone should not affect original
 	clone.Args[0] = "test"
@@ -555,6 +890,21 @@ func TestCommandConfig_Clone(t *testing.T) {
 		t.Error("Args not deep copied")
 	}
 
+	clone.Triggers[0] = "modified"
+	if original.Triggers[0] == "modified" {
+		t.Error("Triggers not deep copied")
+	}
+
+	clone.PathPrepend[0] = "modified"
+	if original.PathPrepend[0] == "modified" {
+		t.Error("PathPrepend not deep copied")
+	}
+
+	clone.Env["NODE_ENV"] = "modified"
+	if original.Env["NODE_ENV"] == "modified" {
+		t.Error("Env not deep copied")
+	}
+
 	clone.ExitCodes[0] = 99
 	if original.ExitCodes[0] == 99 {
 		t.Error("ExitCodes not deep copied")
@@ -647,3 +997,202 @@ func TestConfig_ComplexValidation(t *testing.T) {
 		t.Error("Paths count mismatch after roundtrip")
 	}
 }
+
+func TestConfig_ValidateStandardCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		wantErr string
+	}{
+		{name: "valid", names: []string{"build", "vet"}},
+		{name: "empty name", names: []string{""}, wantErr: "invalid command name"},
+		{name: "flag-like name", names: []string{"--build"}, wantErr: "invalid command name"},
+		{name: "name with spaces", names: []string{"go build"}, wantErr: "invalid command name"},
+		{name: "duplicate", names: []string{"build", "build"}, wantErr: "listed more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Version:          "1.0",
+				Commands:         map[string]*CommandConfig{"build": {Command: "go"}},
+				StandardCommands: tt.names,
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateSecurity(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		allowed  []string
+		denied   []string
+		wantErr  string
+	}{
+		{name: "valid", patterns: []string{"packages/**", "/opt/shared"}, allowed: []string{"eslint"}, denied: []string{"curl"}},
+		{name: "empty pattern", patterns: []string{""}, wantErr: "allowedWorkingDirs 0"},
+		{name: "invalid glob", patterns: []string{"packages/**", "src/[a"}, wantErr: "allowedWorkingDirs 1"},
+		{name: "empty allowed command", allowed: []string{"eslint", " "}, wantErr: "allowedCommands 1"},
+		{name: "empty denied command", denied: []string{""}, wantErr: "deniedCommands 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Version:  "1.0",
+				Commands: map[string]*CommandConfig{"lint": {Command: "eslint"}},
+				Security: &SecurityConfig{AllowedWorkingDirs: tt.patterns, AllowedCommands: tt.allowed, DeniedCommands: tt.denied},
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAIAPIKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		ai      *AIConfig
+		wantErr bool
+	}{
+		{name: "env", ai: &AIConfig{APIKeyEnv: "ANTHROPIC_API_KEY"}},
+		{name: "file", ai: &AIConfig{APIKeyFile: ".secrets/anthropic"}},
+		{name: "both", ai: &AIConfig{APIKeyEnv: "ANTHROPIC_API_KEY", APIKeyFile: ".secrets/anthropic"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Version:  "1.0",
+				Commands: map[string]*CommandConfig{"lint": {Command: "eslint"}},
+				AI:       tt.ai,
+			}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAITools(t *testing.T) {
+	tests := []struct {
+		name    string
+		ai      *AIConfig
+		wantErr bool
+	}{
+		{name: "custom tool", ai: &AIConfig{Tools: map[string]*AIToolConfig{"ollama": {Command: "ollama", Args: []string{"run", "llama3"}}}}},
+		{name: "missing command", ai: &AIConfig{Tools: map[string]*AIToolConfig{"ollama": {Args: []string{"run"}}}}, wantErr: true},
+		{name: "nil tool", ai: &AIConfig{Tools: map[string]*AIToolConfig{"ollama": nil}}, wantErr: true},
+		{name: "empty name", ai: &AIConfig{Tools: map[string]*AIToolConfig{"": {Command: "ollama"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Version:  "1.0",
+				Commands: map[string]*CommandConfig{"lint": {Command: "eslint"}},
+				AI:       tt.ai,
+			}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateErrorExitCode(t *testing.T) {
+	for code, wantErr := range map[int]bool{0: false, 2: false, 3: false, 255: false, 1: true, -1: true, 256: true} {
+		cfg := &Config{
+			Version:       "1.0",
+			Commands:      map[string]*CommandConfig{"lint": {Command: "eslint"}},
+			ErrorExitCode: code,
+		}
+		if err := cfg.Validate(); (err != nil) != wantErr {
+			t.Errorf("errorExitCode %d: Validate() error = %v, wantErr %v", code, err, wantErr)
+		}
+	}
+}
+
+func TestConfig_ValidateMaxTotalRetries(t *testing.T) {
+	for retries, wantErr := range map[int]bool{0: false, 5: false, -1: true} {
+		cfg := &Config{
+			Version:         "1.0",
+			Commands:        map[string]*CommandConfig{"test": {Command: "go", Retries: 2}},
+			MaxTotalRetries: retries,
+		}
+		if err := cfg.Validate(); (err != nil) != wantErr {
+			t.Errorf("maxTotalRetries %d: Validate() error = %v, wantErr %v", retries, err, wantErr)
+		}
+	}
+}
+
+func TestConfig_ValidateDefaultCommand(t *testing.T) {
+	tests := []struct {
+		name           string
+		defaultCommand string
+		wantErr        bool
+	}{
+		{name: "unset"},
+		{name: "root command", defaultCommand: "lint"},
+		{name: "path command", defaultCommand: "test"},
+		{name: "unknown command", defaultCommand: "check", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Version:        "1.0",
+				Commands:       map[string]*CommandConfig{"lint": {Command: "eslint"}},
+				Paths:          []*PathConfig{{Path: "web/**", Commands: map[string]*CommandConfig{"test": {Command: "jest"}}}},
+				DefaultCommand: tt.defaultCommand,
+			}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Prerequisites(t *testing.T) {
+	cfg := newTestConfigBuilder().
+		withCommand("test", &CommandConfig{Command: "jest", DependsOn: []string{"typecheck", "build"}}).
+		withCommand("typecheck", &CommandConfig{Command: "tsc", DependsOn: []string{"build"}}).
+		withCommand("build", &CommandConfig{Command: "make"}).
+		withCommand("lint", &CommandConfig{Command: "eslint"}).
+		build()
+
+	order, err := cfg.Prerequisites("test")
+	if err != nil {
+		t.Fatalf("Prerequisites() error = %v", err)
+	}
+	if got := strings.Join(order, ","); got != "build,typecheck" {
+		t.Errorf("Prerequisites(test) = %v, want [build typecheck]", order)
+	}
+	if order, _ := cfg.Prerequisites("lint"); len(order) != 0 {
+		t.Errorf("Prerequisites(lint) = %v, want none", order)
+	}
+
+	cfg.Commands["build"].DependsOn = []string{"test"}
+	if _, err := cfg.Prerequisites("test"); err == nil || !strings.Contains(err.Error(), "dependsOn cycle: test -> typecheck -> build -> test") {
+		t.Errorf("Prerequisites() error = %v, want the cycle", err)
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Profile holds environment-specific command overrides, e.g. a stricter "ci"
+// profile or a faster "dev" profile
+type Profile struct {
+	// Commands maps command names to partial command configurations. Only the
+	// fields they set override the base command.
+	Commands map[string]json.RawMessage `json:"commands"`
+}
+
+// ApplyProfile merges the named profile over the configured commands. Each
+// profile command overrides the fields it sets in the root command and in
+// path commands of the same name; commands missing from the root are added.
+func (c *Config) ApplyProfile(name string) error {
+	profile, ok := c.Profiles[name]
+	if !ok || profile == nil {
+		return fmt.Errorf("unknown profile %q (%s)", name, c.describeProfiles())
+	}
+
+	if c.Commands == nil {
+		c.Commands = make(map[string]*CommandConfig)
+	}
+	for cmdName, override := range profile.Commands {
+		merged, err := overrideCommand(c.Commands[cmdName], override)
+		if err != nil {
+			return fmt.Errorf("profile %q: command %q: %w", name, cmdName, err)
+		}
+		c.Commands[cmdName] = merged
+
+		for _, path := range c.Paths {
+			cmd, exists := path.Commands[cmdName]
+			if !exists {
+				continue
+			}
+			if path.Commands[cmdName], err = overrideCommand(cmd, override); err != nil {
+				return fmt.Errorf("profile %q: path %q: command %q: %w", name, path.Path, cmdName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// describeProfiles lists the defined profile names for error messages
+func (c *Config) describeProfiles() string {
+	if len(c.Profiles) == 0 {
+		return "no profiles are defined"
+	}
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "available: " + strings.Join(names, ", ")
+}
+
+// overrideCommand returns a copy of base with the fields set in override
+// replaced. Fields are replaced as a whole, so an overridden list is not
+// merged with the base list.
+func overrideCommand(base *CommandConfig, override json.RawMessage) (*CommandConfig, error) {
+	var overrideFields map[string]json.RawMessage
+	if err := json.Unmarshal(override, &overrideFields); err != nil {
+		return nil, fmt.Errorf("invalid override: %w", err)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if base != nil {
+		data, err := json.Marshal(base)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode command: %w", err)
+		}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("failed to encode command: %w", err)
+		}
+	}
+	for field, value := range overrideFields {
+		fields[field] = value
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode command: %w", err)
+	}
+	var merged CommandConfig
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, fmt.Errorf("invalid override: %w", err)
+	}
+	return &merged, nil
+}
+
+// MergeProfiles returns the profiles of base with those of override added,
+// replacing base profiles of the same name
+func MergeProfiles(base, override map[string]*Profile) map[string]*Profile {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]*Profile, len(base)+len(override))
+	for name, profile := range base {
+		merged[name] = profile.Clone()
+	}
+	for name, profile := range override {
+		merged[name] = profile.Clone()
+	}
+	return merged
+}
+
+// Clone creates a deep copy of the Profile
+func (p *Profile) Clone() *Profile {
+	if p == nil {
+		return nil
+	}
+	clone := &Profile{}
+	if p.Commands != nil {
+		clone.Commands = make(map[string]json.RawMessage, len(p.Commands))
+		for name, override := range p.Commands {
+			clone.Commands[name] = append(json.RawMessage(nil), override...)
+		}
+	}
+	return clone
+}
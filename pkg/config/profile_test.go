@@ -0,0 +1,81 @@
+//go:build unit
+
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestConfig_ApplyProfile(t *testing.T) {
+	cfg := &Config{
+		Version: "1.0",
+		Commands: map[string]*CommandConfig{
+			"lint": {Command: "eslint", Args: []string{"."}, Prompt: "Fix lint:", Timeout: 1000},
+		},
+		Paths: []*PathConfig{{
+			Path:     "web/**",
+			Commands: map[string]*CommandConfig{"lint": {Command: "npm", Args: []string{"run", "lint"}}},
+		}},
+		Profiles: map[string]*Profile{
+			"ci": {Commands: map[string]json.RawMessage{
+				"lint":  json.RawMessage(`{"args": ["--max-warnings=0", "."], "timeout": 5000}`),
+				"audit": json.RawMessage(`{"command": "npm", "args": ["audit"]}`),
+			}},
+		},
+	}
+	base := cfg.Commands["lint"]
+
+	if err := cfg.ApplyProfile("ci"); err != nil {
+		t.Fatalf("ApplyProfile() error = %v", err)
+	}
+
+	lint := cfg.Commands["lint"]
+	if lint.Command != "eslint" || lint.Prompt != "Fix lint:" {
+		t.Errorf("fields missing from the profile were not kept: %+v", lint)
+	}
+	if len(lint.Args) != 2 || lint.Args[0] != "--max-warnings=0" || lint.Timeout != 5000 {
+		t.Errorf("profile fields not applied: %+v", lint)
+	}
+	if len(base.Args) != 1 {
+		t.Errorf("base command was modified: %+v", base)
+	}
+	if audit := cfg.Commands["audit"]; audit == nil || audit.Command != "npm" {
+		t.Errorf("profile command not added: %+v", audit)
+	}
+	if pathLint := cfg.Paths[0].Commands["lint"]; pathLint.Command != "npm" || pathLint.Timeout != 5000 {
+		t.Errorf("profile not applied to the path command: %+v", pathLint)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestConfig_ApplyProfileErrors(t *testing.T) {
+	cfg := &Config{Version: "1.0", Commands: map[string]*CommandConfig{"lint": {Command: "eslint"}}}
+	if err := cfg.ApplyProfile("ci"); err == nil || !strings.Contains(err.Error(), "no profiles are defined") {
+		t.Errorf("ApplyProfile() error = %v, want no profiles error", err)
+	}
+
+	cfg.Profiles = map[string]*Profile{
+		"dev": {Commands: map[string]json.RawMessage{"lint": json.RawMessage(`{"timeout": "fast"}`)}},
+	}
+	if err := cfg.ApplyProfile("ci"); err == nil || !strings.Contains(err.Error(), "available: dev") {
+		t.Errorf("ApplyProfile() error = %v, want available profiles", err)
+	}
+	if err := cfg.ApplyProfile("dev"); err == nil || !strings.Contains(err.Error(), `profile "dev": command "lint"`) {
+		t.Errorf("ApplyProfile() error = %v, want invalid override error", err)
+	}
+}
+
+func TestConfig_ValidateProfiles(t *testing.T) {
+	cfg := &Config{
+		Version:  "1.0",
+		Commands: map[string]*CommandConfig{"lint": {Command: "eslint"}},
+		Profiles: map[string]*Profile{"ci": {Commands: map[string]json.RawMessage{"lint": json.RawMessage(`["--strict"]`)}}},
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "must be an object") {
+		t.Errorf("Validate() error = %v, want non-object override error", err)
+	}
+}
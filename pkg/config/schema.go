@@ -0,0 +1,182 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// builtinCommands are the commands qualhook offers as subcommands out of the box
+var builtinCommands = []string{"format", "lint", "typecheck", "test"}
+
+// schemaHints adds keywords to the generated schema of a field, keyed by
+// the Go type name and JSON field name
+var schemaHints = map[string]map[string]any{
+	"Config.version":         {"enum": []string{"1.0"}},
+	"Config.rootFallback":    {"enum": []string{RootFallbackRun, RootFallbackSkip}},
+	"Config.defaultCommand":  {"examples": builtinCommands},
+	"Config.errorExitCode":   {"minimum": 2, "maximum": 255},
+	"Config.maxTotalRetries": {"minimum": 0},
+	"Config.standardCommands": {
+		"uniqueItems": true,
+		"items":       map[string]any{"type": "string", "examples": []string{"build", "vet"}},
+	},
+	"CommandConfig.timeout":      {"minimum": 0, "description": "Timeout in milliseconds"},
+	"CommandConfig.retryDelay":   {"minimum": 0, "description": "Delay before the first retry in milliseconds"},
+	"CommandConfig.retries":      {"minimum": 0},
+	"CommandConfig.binaryOutput": {"enum": []string{BinaryOutputSkip, BinaryOutputHexdump, BinaryOutputRaw}},
+	"CommandConfig.outputFormat": {"examples": []string{OutputFormatText, OutputFormatSARIF}},
+	"CommandConfig.verifyWith":   {"items": map[string]any{"type": "string", "examples": builtinCommands}},
+	"CommandConfig.dependsOn":    {"items": map[string]any{"type": "string", "examples": builtinCommands}},
+	"RegexPattern.flags":         {"pattern": "^[imsU]*$"},
+	"SandboxConfig.runtime":      {"enum": []string{"docker", "podman"}},
+	"SecurityConfig.allowedCommands": {
+		"items": map[string]any{"type": "string", "minLength": 1},
+	},
+	"SecurityConfig.deniedCommands": {
+		"items": map[string]any{"type": "string", "minLength": 1},
+	},
+}
+
+// JSONSchema returns a JSON Schema (draft-07) of the configuration file,
+// generated from the Config struct, for editor completion and validation.
+// Command maps suggest the built-in command names. A configuration may set
+// "$schema" to the schema's location.
+func JSONSchema() map[string]any {
+	g := &schemaGenerator{definitions: make(map[string]any)}
+	root := g.object(reflect.TypeOf(Config{}), false)
+	root["$schema"] = "http://json-schema.org/draft-07/schema#"
+	root["title"] = "qualhook configuration"
+	properties := root["properties"].(map[string]any)
+	properties["$schema"] = map[string]any{"type": "string", "description": "Location of this JSON Schema"}
+	root["definitions"] = g.definitions
+	return root
+}
+
+// MarshalJSONSchema returns the indented JSON of JSONSchema
+func MarshalJSONSchema() ([]byte, error) {
+	return json.MarshalIndent(JSONSchema(), "", "  ")
+}
+
+// schemaGenerator builds the schema of a type, with nested structs as
+// definitions
+type schemaGenerator struct {
+	definitions map[string]any
+}
+
+// object returns the schema of a struct. Fields without omitempty are
+// required, except in the root configuration, whose fields may come from a
+// configuration it extends.
+func (g *schemaGenerator) object(t reflect.Type, requireFields bool) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty := jsonField(field)
+		if name == "" {
+			continue
+		}
+
+		schema := g.schema(field.Type)
+		if field.Type == reflect.TypeOf(map[string]*CommandConfig{}) {
+			schema = g.commandMap()
+		}
+		for key, value := range schemaHints[t.Name()+"."+name] {
+			schema[key] = value
+		}
+		properties[name] = schema
+
+		// Maps may be left out even when they have no omitempty
+		if requireFields && !omitEmpty && field.Type.Kind() != reflect.Map {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// schema returns the schema of a field type
+func (g *schemaGenerator) schema(t reflect.Type) map[string]any {
+	if t == reflect.TypeOf(json.RawMessage{}) {
+		return map[string]any{"type": "object"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schema(t.Elem())
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		return g.definition(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+// definition adds the schema of a struct to the definitions once and
+// returns a reference to it
+func (g *schemaGenerator) definition(t reflect.Type) map[string]any {
+	name := definitionName(t.Name())
+	if _, ok := g.definitions[name]; !ok {
+		g.definitions[name] = nil // Guards against recursive types
+		g.definitions[name] = g.object(t, true)
+	}
+	return map[string]any{"$ref": "#/definitions/" + name}
+}
+
+// commandMap returns the schema of a map of commands, suggesting the
+// built-in command names
+func (g *schemaGenerator) commandMap() map[string]any {
+	command := g.definition(reflect.TypeOf(CommandConfig{}))
+	builtin := make(map[string]any, len(builtinCommands))
+	for _, name := range builtinCommands {
+		builtin[name] = command
+	}
+	return map[string]any{"type": "object", "properties": builtin, "additionalProperties": command}
+}
+
+// jsonField returns the JSON name of a struct field and whether it is
+// omitted when empty; the name is empty for fields not in JSON
+func jsonField(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(options, "omitempty")
+}
+
+// definitionName turns a type name such as CommandConfig into commandConfig,
+// or AIConfig into aiConfig
+func definitionName(typeName string) string {
+	runes := []rune(typeName)
+	for i := range runes {
+		// Keep the first letter of the next word of an acronym upper case
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
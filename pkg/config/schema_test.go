@@ -0,0 +1,68 @@
+//go:build unit
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	data, err := MarshalJSONSchema()
+	if err != nil {
+		t.Fatalf("MarshalJSONSchema() error = %v", err)
+	}
+	var schema struct {
+		Properties  map[string]map[string]any `json:"properties"`
+		Definitions map[string]struct {
+			Properties map[string]map[string]any `json:"properties"`
+			Required   []string                  `json:"required"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("invalid schema JSON: %v", err)
+	}
+
+	// Every configuration field is described
+	command := schema.Definitions["commandConfig"]
+	commandType := reflect.TypeOf(CommandConfig{})
+	for i := 0; i < commandType.NumField(); i++ {
+		if name, _ := jsonField(commandType.Field(i)); command.Properties[name] == nil {
+			t.Errorf("expected command field %q in the schema", name)
+		}
+	}
+	if !reflect.DeepEqual(command.Required, []string{"command"}) {
+		t.Errorf("expected command to be required, got %v", command.Required)
+	}
+	if got := command.Properties["timeout"]["type"]; got != "integer" {
+		t.Errorf("expected an integer timeout, got %v", got)
+	}
+
+	commands := schema.Properties["commands"]
+	builtin, _ := commands["properties"].(map[string]any)
+	if len(builtin) != 4 || builtin["typecheck"] == nil || commands["additionalProperties"] == nil {
+		t.Errorf("expected the built-in command names as hints, got %v", commands)
+	}
+	if enum := schema.Properties["rootFallback"]["enum"]; !reflect.DeepEqual(enum, []any{"run", "skip"}) {
+		t.Errorf("expected rootFallback values, got %v", enum)
+	}
+	if schema.Properties["$schema"] == nil {
+		t.Error(`expected "$schema" to be allowed`)
+	}
+	for _, name := range []string{"pathConfig", "regexPattern", "aiConfig", "aiToolConfig", "securityConfig"} {
+		if _, ok := schema.Definitions[name]; !ok {
+			t.Errorf("expected definition %q", name)
+		}
+	}
+}
+
+func TestLoadConfig_SchemaReference(t *testing.T) {
+	cfg, err := LoadConfig([]byte(`{"$schema": "./qualhook.schema.json", "version": "1.0", "commands": {"lint": {"command": "eslint"}}}`))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Commands["lint"] == nil {
+		t.Error("expected the configuration to load with a $schema reference")
+	}
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadConfigYAML loads a configuration from YAML data. The YAML is converted
+// to JSON first so the same field names and validation apply to both formats.
+func LoadConfigYAML(data []byte) (*Config, error) {
+	config, err := ParseConfigYAML(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return config, nil
+}
+
+// ParseConfigYAML decodes a configuration from YAML data without validating it
+func ParseConfigYAML(data []byte) (*Config, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if doc == nil {
+		doc = map[string]interface{}{}
+	}
+
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	return ParseConfig(jsonData)
+}
+
+// SaveConfigYAML serializes a configuration to YAML using the JSON field names
+func SaveConfigYAML(config *Config) ([]byte, error) {
+	jsonData, err := marshalConfig(config, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML; decoding it into a node keeps the field order
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonData, &node); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	useBlockStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// useBlockStyle resets the JSON flow and quoting styles to idiomatic YAML
+func useBlockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		useBlockStyle(child)
+	}
+}
@@ -0,0 +1,107 @@
+//go:build unit
+
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigYAML(t *testing.T) {
+	data := []byte(`version: "1.0"
+commands:
+  lint:
+    command: npm
+    args: [run, lint]
+    errorPatterns:
+      - pattern: "error"
+        flags: i
+    exitCodes: [1]
+    timeout: 60000
+paths:
+  - path: "frontend/**"
+    commands:
+      lint:
+        command: eslint
+`)
+
+	cfg, err := LoadConfigYAML(data)
+	if err != nil {
+		t.Fatalf("LoadConfigYAML() error = %v", err)
+	}
+
+	lint := cfg.Commands["lint"]
+	if lint == nil {
+		t.Fatal("expected lint command")
+	}
+	if lint.Command != "npm" || !reflect.DeepEqual(lint.Args, []string{"run", "lint"}) {
+		t.Errorf("unexpected lint command: %s %v", lint.Command, lint.Args)
+	}
+	if len(lint.ErrorPatterns) != 1 || lint.ErrorPatterns[0].Flags != "i" {
+		t.Errorf("unexpected error patterns: %+v", lint.ErrorPatterns)
+	}
+	if !reflect.DeepEqual(lint.ExitCodes, []int{1}) || lint.Timeout != 60000 {
+		t.Errorf("unexpected exit codes %v or timeout %d", lint.ExitCodes, lint.Timeout)
+	}
+	if len(cfg.Paths) != 1 || cfg.Paths[0].Commands["lint"].Command != "eslint" {
+		t.Errorf("unexpected paths: %+v", cfg.Paths)
+	}
+}
+
+func TestLoadConfigYAML_ValidatesLikeJSON(t *testing.T) {
+	jsonErr := func() error {
+		_, err := LoadConfig([]byte(`{"version": "1.0", "commands": {"lint": {"command": ""}}}`))
+		return err
+	}()
+	_, yamlErr := LoadConfigYAML([]byte("version: \"1.0\"\ncommands:\n  lint:\n    command: \"\"\n"))
+
+	if jsonErr == nil || yamlErr == nil {
+		t.Fatalf("expected both formats to fail validation, got json=%v yaml=%v", jsonErr, yamlErr)
+	}
+	if jsonErr.Error() != yamlErr.Error() {
+		t.Errorf("validation errors differ:\njson: %v\nyaml: %v", jsonErr, yamlErr)
+	}
+}
+
+func TestLoadConfigYAML_InvalidYAML(t *testing.T) {
+	_, err := LoadConfigYAML([]byte("version: [1.0\n"))
+	if err == nil || !strings.Contains(err.Error(), "failed to parse config") {
+		t.Errorf("expected parse error, got %v", err)
+	}
+}
+
+func TestSaveConfigYAML_RoundTrip(t *testing.T) {
+	original := newTestConfigBuilder().
+		withCommand("lint", &CommandConfig{
+			Command:       "npm",
+			Args:          []string{"run", "lint"},
+			ErrorPatterns: []*RegexPattern{{Pattern: `error:\s+`, Flags: "i"}},
+			ExitCodes:     []int{1, 2},
+			Prompt:        "Fix: the errors",
+		}).
+		build()
+
+	data, err := SaveConfigYAML(original)
+	if err != nil {
+		t.Fatalf("SaveConfigYAML() error = %v", err)
+	}
+
+	text := string(data)
+	for _, want := range []string{"errorPatterns:", "exitCodes:", "version: \"1.0\""} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected YAML to contain %q, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "{") {
+		t.Errorf("expected block style YAML, got:\n%s", text)
+	}
+
+	loaded, err := LoadConfigYAML(data)
+	if err != nil {
+		t.Fatalf("LoadConfigYAML() error = %v", err)
+	}
+	if !reflect.DeepEqual(original.Commands, loaded.Commands) {
+		t.Errorf("round trip mismatch:\noriginal: %+v\nloaded: %+v", original.Commands["lint"], loaded.Commands["lint"])
+	}
+}
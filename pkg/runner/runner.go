@@ -0,0 +1,360 @@
+// Package runner provides a Go API for running qualhook checks without the CLI.
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	internalconfig "github.com/bebsworthy/qualhook/internal/config"
+	"github.com/bebsworthy/qualhook/internal/executor"
+	"github.com/bebsworthy/qualhook/internal/filter"
+	"github.com/bebsworthy/qualhook/internal/reporter"
+	"github.com/bebsworthy/qualhook/internal/watcher"
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+// defaultCommandTimeout applies to commands without a configured timeout
+const defaultCommandTimeout = 2 * time.Minute
+
+// defaultRetryDelay is the wait before the first retry of commands without a
+// configured retryDelay
+const defaultRetryDelay = time.Second
+
+// Result is the report of a run: the exit code qualhook would exit with and
+// the output it would print
+type Result = reporter.ReportResult
+
+// RunOptions configures a run
+type RunOptions struct {
+	// WorkingDir is the directory commands run in and the configuration is
+	// loaded from (default: the current directory)
+	WorkingDir string
+	// EditedFiles limits the run to the components owning these files,
+	// relative to WorkingDir. Without edited files the command runs once.
+	EditedFiles []string
+	// ExtraArgs are appended to the configured arguments of the command
+	ExtraArgs []string
+	// Format is the report format: "default", "compact" or "json"
+	Format string
+	// Dedup merges identical errors reported by several components
+	Dedup bool
+	// Profile names the configuration profile merged over the commands. It
+	// applies when the configuration is loaded from the working directory.
+	Profile string
+}
+
+// Run runs a configured command the way the qualhook CLI does and returns its
+// report. A nil cfg is loaded from the working directory. Failing checks are
+// reported through the result's exit code; the error is only set when the
+// run could not happen, e.g. for an unknown command.
+func Run(cfg *config.Config, command string, opts RunOptions) (*Result, error) {
+	workingDir, err := resolveWorkingDir(opts.WorkingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, projectRoot, err := resolveConfig(cfg, workingDir, opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	cmdConfig, exists := cfg.Commands[command]
+	if !exists {
+		return nil, fmt.Errorf("command %q not found in configuration", command)
+	}
+
+	errorReporter := reporter.NewErrorReporter()
+	if err := errorReporter.SetFormat(opts.Format); err != nil {
+		return nil, err
+	}
+	errorReporter.SetDedup(opts.Dedup)
+	errorReporter.SetNormalizePaths(cfg.NormalizePaths)
+
+	r := &run{
+		cfg:         cfg,
+		workingDir:  workingDir,
+		projectRoot: projectRoot,
+		editedFiles: relativeFiles(opts.EditedFiles, workingDir),
+	}
+	if cfg.MaxTotalRetries > 0 {
+		r.retryBudget = executor.NewRetryBudget(cfg.MaxTotalRetries)
+	}
+
+	prerequisiteResults, failedBy, err := r.runPrerequisites(command)
+	if err != nil {
+		return nil, err
+	}
+	if failedBy != "" {
+		skipped := executor.ComponentExecResult{Command: command, CommandConfig: cmdConfig, SkippedBy: failedBy}
+		return errorReporter.Report(append(prerequisiteResults, skipped)), nil
+	}
+
+	results, err := r.runCommand(command, opts.ExtraArgs)
+	if err != nil {
+		return nil, err
+	}
+	results = append(prerequisiteResults, results...)
+
+	// Verification commands run only once the fix passed; a failed command
+	// stops the sequence unless it sets continueOnError
+	previous, stepResults := cmdConfig, results
+	for _, verifyName := range cmdConfig.VerifyWith {
+		if !previous.ContinueOnError && reporter.NewErrorReporter().Report(stepResults).ExitCode != 0 {
+			break
+		}
+		verifyResults, err := r.runCommand(verifyName, nil)
+		if err != nil {
+			return nil, fmt.Errorf("verification command %q: %w", verifyName, err)
+		}
+		results = append(results, verifyResults...)
+		previous, stepResults = cfg.Commands[verifyName], verifyResults
+	}
+
+	return errorReporter.Report(results), nil
+}
+
+// resolveWorkingDir returns the absolute working directory of a run
+func resolveWorkingDir(dir string) (string, error) {
+	if dir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get working directory: %w", err)
+		}
+		return cwd, nil
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid working directory: %w", err)
+	}
+	return absDir, nil
+}
+
+// resolveConfig validates a given configuration, or loads the configuration
+// of the working directory when none is given. It also returns the project
+// root of the run, see internalconfig.ProjectRoot.
+func resolveConfig(cfg *config.Config, workingDir, profile string) (*config.Config, string, error) {
+	if cfg == nil {
+		loader := &internalconfig.Loader{SearchPaths: []string{workingDir}, Profile: profile}
+		loaded, err := loader.LoadForMonorepo(workingDir)
+		if err != nil {
+			return nil, "", err
+		}
+		path, err := loader.FindPath()
+		if err != nil {
+			return nil, "", err
+		}
+		return loaded, internalconfig.ProjectRoot(workingDir, path), nil
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, "", fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, internalconfig.ProjectRoot(workingDir, ""), nil
+}
+
+// relativeFiles makes edited files under the working directory relative to
+// it, as path patterns are matched against relative paths
+func relativeFiles(files []string, workingDir string) []string {
+	relative := make([]string, 0, len(files))
+	for _, file := range files {
+		if filepath.IsAbs(file) {
+			if rel, err := filepath.Rel(workingDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+		relative = append(relative, file)
+	}
+	return relative
+}
+
+// run holds the state shared by the commands of a run
+type run struct {
+	cfg        *config.Config
+	workingDir string
+	// projectRoot is the directory the allowed working directories of the
+	// security settings are relative to
+	projectRoot string
+	editedFiles []string
+	// retryBudget caps the command retries of the run; nil is unlimited
+	retryBudget *executor.RetryBudget
+}
+
+// runPrerequisites runs the dependsOn commands of commandName, each after its
+// own prerequisites, skipping those that depend on a failed one. It also
+// returns the failed prerequisite that keeps commandName from running.
+func (r *run) runPrerequisites(commandName string) ([]executor.ComponentExecResult, string, error) {
+	order, err := r.cfg.Prerequisites(commandName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var results []executor.ComponentExecResult
+	failed := make(map[string]string)
+	failedBy := func(name string) string {
+		for _, dependency := range r.cfg.Commands[name].DependsOn {
+			if by, ok := failed[dependency]; ok {
+				return by
+			}
+		}
+		return ""
+	}
+	for _, name := range order {
+		if by := failedBy(name); by != "" {
+			failed[name] = by
+			results = append(results, executor.ComponentExecResult{Command: name, CommandConfig: r.cfg.Commands[name], SkippedBy: by})
+			continue
+		}
+		prerequisiteResults, err := r.runCommand(name, nil)
+		if err != nil {
+			return nil, "", fmt.Errorf("prerequisite %q: %w", name, err)
+		}
+		results = append(results, prerequisiteResults...)
+		if reporter.NewErrorReporter().Report(prerequisiteResults).ExitCode != 0 {
+			failed[name] = name
+		}
+	}
+	return results, failedBy(commandName), nil
+}
+
+// runCommand runs the named command for the components owning the edited
+// files, or once for the root configuration when there are none
+func (r *run) runCommand(commandName string, extraArgs []string) ([]executor.ComponentExecResult, error) {
+	if len(r.editedFiles) == 0 {
+		cmdConfig, exists := r.cfg.Commands[commandName]
+		if !exists {
+			return nil, fmt.Errorf("command %q not found in configuration", commandName)
+		}
+		return []executor.ComponentExecResult{r.runComponent("", nil, commandName, cmdConfig, extraArgs)}, nil
+	}
+
+	groups, err := watcher.NewFileMapper(r.cfg).MapFilesForCommand(r.editedFiles, commandName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map files to components: %w", err)
+	}
+
+	var results []executor.ComponentExecResult
+	for _, group := range groups {
+		cmdConfig := group.Config[commandName]
+		if cmdConfig == nil {
+			continue
+		}
+		results = append(results, r.runComponent(group.Path, group.Files, commandName, cmdConfig, extraArgs))
+	}
+	return results, nil
+}
+
+// runComponent runs a command for a component and filters its output.
+// Execution failures are returned as a result carrying the error.
+func (r *run) runComponent(path string, files []string, commandName string, cmdConfig *config.CommandConfig, extraArgs []string) executor.ComponentExecResult {
+	result := executor.ComponentExecResult{
+		Path:          path,
+		Command:       commandName,
+		Files:         files,
+		CommandConfig: cmdConfig,
+	}
+
+	execResult, err := r.execute(cmdConfig, extraArgs)
+	if err != nil {
+		result.CommandConfig = nil
+		result.ExecutionError = err
+		return result
+	}
+
+	result.ExecResult = execResult
+	result.FilteredOutput = filterOutput(cmdConfig, execResult)
+	return result
+}
+
+// execute runs a command with its configured timeout, sandbox and retries
+func (r *run) execute(cmdConfig *config.CommandConfig, extraArgs []string) (*executor.ExecResult, error) {
+	args := make([]string, 0, len(cmdConfig.Args)+len(extraArgs))
+	args = append(args, cmdConfig.Args...)
+	args = append(args, extraArgs...)
+
+	hostExecutor := executor.NewCommandExecutor(defaultCommandTimeout)
+	if err := hostExecutor.ApplySecurity(r.projectRoot, r.cfg.Security); err != nil {
+		return nil, err
+	}
+	var backend executor.Backend = hostExecutor
+	if sandbox := cmdConfig.Sandbox; sandbox != nil {
+		backend = executor.NewContainerExecutor(hostExecutor, sandbox.Runtime, sandbox.Image, sandbox.Writable)
+	}
+	if cmdConfig.Retries > 0 {
+		delay := defaultRetryDelay
+		if cmdConfig.RetryDelay > 0 {
+			delay = time.Duration(cmdConfig.RetryDelay) * time.Millisecond
+		}
+		retryExecutor := executor.NewRetryExecutor(backend, cmdConfig.Retries, delay)
+		retryExecutor.SetBudget(r.retryBudget)
+		backend = retryExecutor
+	}
+
+	timeout := defaultCommandTimeout
+	if cmdConfig.Timeout > 0 {
+		timeout = time.Duration(cmdConfig.Timeout) * time.Millisecond
+	}
+
+	workingDir := r.workingDir
+	if dir := cmdConfig.WorkingDir; dir != "" {
+		// Loaded configurations have it absolute already
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(r.workingDir, dir)
+		}
+		workingDir = dir
+	}
+
+	env, err := executor.CommandEnvironment(cmdConfig, true)
+	if err != nil {
+		return nil, err
+	}
+	result, err := backend.Execute(cmdConfig.Command, args, executor.ExecOptions{
+		WorkingDir:  workingDir,
+		Environment: env,
+		InheritEnv:  true,
+		Timeout:     timeout,
+		PathPrepend: cmdConfig.PathPrepend,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Stdout = filter.SanitizeBinary(result.Stdout, cmdConfig.BinaryOutput)
+	result.Stderr = filter.SanitizeBinary(result.Stderr, cmdConfig.BinaryOutput)
+	return result, nil
+}
+
+// filterOutput applies the configured error and include patterns to the
+// combined command output, returning nil when none are configured
+func filterOutput(cmdConfig *config.CommandConfig, result *executor.ExecResult) *filter.FilteredOutput {
+	outputFilter := filter.ForCommand(cmdConfig, filter.NewRegexFilter())
+	if outputFilter == nil {
+		return nil
+	}
+
+	combinedOutput := result.Stdout
+	if result.Stderr != "" {
+		if combinedOutput != "" {
+			combinedOutput += "\n"
+		}
+		combinedOutput += result.Stderr
+	}
+
+	filteredOutput, err := outputFilter.Filter(combinedOutput, &filter.FilterRules{
+		ErrorPatterns:   cmdConfig.DetectionPatterns(),
+		ContextPatterns: cmdConfig.IncludePatterns,
+		MaxLines:        cmdConfig.MaxOutput,
+		MaxBytes:        cmdConfig.MaxOutputBytes,
+		ContextLines:    cmdConfig.ContextLines,
+		BlockMode:       cmdConfig.BlockMode,
+		CaptureGroups:   cmdConfig.OutputTemplate != "",
+	})
+	if err != nil {
+		// Report the raw output, e.g. when a SARIF log cannot be parsed
+		return nil
+	}
+	return filteredOutput
+}
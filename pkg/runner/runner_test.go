@@ -0,0 +1,276 @@
+//go:build unit
+
+package runner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bebsworthy/qualhook/pkg/config"
+)
+
+func testConfig(commands map[string]*config.CommandConfig) *config.Config {
+	return &config.Config{Version: "1.0", Commands: commands}
+}
+
+func TestRun_Passing(t *testing.T) {
+	cfg := testConfig(map[string]*config.CommandConfig{
+		"lint": {Command: "echo", Args: []string{"all good"}},
+	})
+
+	result, err := Run(cfg, "lint", RunOptions{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.ExitCode != 0 {
+		t.Errorf("expected exit code 0, got %d: %s", result.ExitCode, result.Stderr)
+	}
+}
+
+func TestRun_FilteredErrors(t *testing.T) {
+	cfg := testConfig(map[string]*config.CommandConfig{
+		"lint": {
+			Command:       "echo",
+			Args:          []string{"src/a.go:1: error: unused variable"},
+			Prompt:        "Fix the lint errors:",
+			ErrorPatterns: []*config.RegexPattern{{Pattern: "error:"}},
+		},
+	})
+
+	result, err := Run(cfg, "lint", RunOptions{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.ExitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d", result.ExitCode)
+	}
+	if !strings.Contains(result.Stderr, "Fix the lint errors:") || !strings.Contains(result.Stderr, "unused variable") {
+		t.Errorf("expected the filtered errors, got:\n%s", result.Stderr)
+	}
+}
+
+func TestRun_JSONFormat(t *testing.T) {
+	cfg := testConfig(map[string]*config.CommandConfig{
+		"test": {Command: "false"},
+	})
+
+	result, err := Run(cfg, "test", RunOptions{Format: "json"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.ExitCode == 0 {
+		t.Error("expected a failing exit code")
+	}
+
+	var report map[string]interface{}
+	if err := json.Unmarshal([]byte(result.Stdout+result.Stderr), &report); err != nil {
+		t.Errorf("expected a JSON report, got %q: %v", result.Stdout+result.Stderr, err)
+	}
+}
+
+func TestRun_EditedFiles(t *testing.T) {
+	dir := t.TempDir()
+	cfg := testConfig(map[string]*config.CommandConfig{
+		"lint": {Command: "echo", Args: []string{"root"}},
+	})
+	cfg.Paths = []*config.PathConfig{{
+		Path: "frontend/**",
+		Commands: map[string]*config.CommandConfig{
+			"lint": {Command: "false"},
+		},
+	}}
+
+	result, err := Run(cfg, "lint", RunOptions{WorkingDir: dir, EditedFiles: []string{"README.md"}})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.ExitCode != 0 {
+		t.Errorf("expected the root command to pass, got %d: %s", result.ExitCode, result.Stderr)
+	}
+
+	result, err = Run(cfg, "lint", RunOptions{
+		WorkingDir:  dir,
+		EditedFiles: []string{filepath.Join(dir, "frontend", "app.js")},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.ExitCode == 0 {
+		t.Error("expected the frontend command to fail")
+	}
+}
+
+func TestRun_SilentFailure(t *testing.T) {
+	cfg := testConfig(map[string]*config.CommandConfig{
+		"test": {Command: "sh", Args: []string{"-c", "exit 1"}},
+	})
+
+	result, err := Run(cfg, "test", RunOptions{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.ExitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d", result.ExitCode)
+	}
+	want := "Command failed with exit code 1 and produced no output (command line: sh -c 'exit 1')"
+	if !strings.Contains(result.Stderr, want) {
+		t.Errorf("expected %q, got:\n%s", want, result.Stderr)
+	}
+}
+
+func TestRun_VerifyWith(t *testing.T) {
+	cfg := testConfig(map[string]*config.CommandConfig{
+		"format": {Command: "echo", Args: []string{"formatted"}, VerifyWith: []string{"lint"}},
+		"lint":   {Command: "false"},
+	})
+
+	result, err := Run(cfg, "format", RunOptions{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.ExitCode == 0 {
+		t.Error("expected the verification failure to be reported")
+	}
+}
+
+func TestRun_VerifyWithContinueOnError(t *testing.T) {
+	for _, continueOnError := range []bool{false, true} {
+		cfg := testConfig(map[string]*config.CommandConfig{
+			"check":     {Command: "true", VerifyWith: []string{"typecheck", "test"}},
+			"typecheck": {Command: "false", ContinueOnError: continueOnError},
+			"test":      {Command: "echo", Args: []string{"tests ran"}, ExitCodes: []int{0}, ErrorPatterns: []*config.RegexPattern{{Pattern: "tests ran"}}},
+		})
+
+		result, err := Run(cfg, "check", RunOptions{})
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if got := strings.Contains(result.Stderr, "tests ran"); got != continueOnError {
+			t.Errorf("continueOnError=%v: expected test to run: %v, got:\n%s", continueOnError, continueOnError, result.Stderr)
+		}
+	}
+}
+
+func TestRun_DependsOn(t *testing.T) {
+	cfg := testConfig(map[string]*config.CommandConfig{
+		"typecheck": {Command: "false"},
+		"test":      {Command: "echo", Args: []string{"tests ran"}, DependsOn: []string{"typecheck"}},
+	})
+
+	result, err := Run(cfg, "test", RunOptions{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.ExitCode == 0 || !strings.Contains(result.Stderr, "Skipped test: prerequisite typecheck failed") {
+		t.Errorf("expected test to be skipped after typecheck failed, got exit code %d:\n%s", result.ExitCode, result.Stderr)
+	}
+}
+
+func TestRun_MaxTotalRetries(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "flaky.sh")
+	if err := os.WriteFile(script, []byte("echo attempt >> attempts\nexit 1\n"), 0600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	flaky := func() *config.CommandConfig {
+		return &config.CommandConfig{
+			Command:         "sh",
+			Args:            []string{script},
+			Retries:         2,
+			RetryDelay:      1,
+			ContinueOnError: true,
+		}
+	}
+	cfg := testConfig(map[string]*config.CommandConfig{
+		"check": {Command: "true", VerifyWith: []string{"a", "b", "c"}},
+		"a":     flaky(),
+		"b":     flaky(),
+		"c":     flaky(),
+	})
+	cfg.MaxTotalRetries = 3
+
+	if _, err := Run(cfg, "check", RunOptions{WorkingDir: dir}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "attempts"))
+	if err != nil {
+		t.Fatalf("failed to read attempts: %v", err)
+	}
+	// Three first attempts and the three retries the run allows, not six
+	if got := strings.Count(string(data), "attempt"); got != 6 {
+		t.Errorf("expected 6 attempts, got %d", got)
+	}
+}
+
+func TestRun_LoadsConfigFromWorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	data := `{"version": "1.0", "commands": {"lint": {"command": "echo", "args": ["ok"]}}}`
+	if err := os.WriteFile(filepath.Join(dir, ".qualhook.json"), []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	result, err := Run(nil, "lint", RunOptions{WorkingDir: dir})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.ExitCode != 0 {
+		t.Errorf("expected exit code 0, got %d: %s", result.ExitCode, result.Stderr)
+	}
+}
+
+func TestRun_CommandEnvironment(t *testing.T) {
+	cfg := testConfig(map[string]*config.CommandConfig{
+		"test": {
+			Command:       "sh",
+			Args:          []string{"-c", "echo \"error: mode=$QUALHOOK_MODE\""},
+			Env:           map[string]string{"QUALHOOK_MODE": "ci"},
+			ErrorPatterns: []*config.RegexPattern{{Pattern: "error:"}},
+		},
+	})
+
+	result, err := Run(cfg, "test", RunOptions{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !strings.Contains(result.Stderr, "error: mode=ci") {
+		t.Errorf("expected the configured env to be passed, got exit code %d: %s", result.ExitCode, result.Stderr)
+	}
+}
+
+func TestRun_AllowedWorkingDirs(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	cfg := testConfig(map[string]*config.CommandConfig{
+		"lint": {Command: "echo", Args: []string{"escaped"}, WorkingDir: outside},
+	})
+
+	// The working directory is checked against the project root even
+	// without security settings
+	result, err := Run(cfg, "lint", RunOptions{WorkingDir: dir})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.ExitCode != 1 || !strings.Contains(result.Stderr, "outside the project root") {
+		t.Errorf("expected the working directory to be rejected, got exit code %d: %s", result.ExitCode, result.Stderr)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	cfg := testConfig(map[string]*config.CommandConfig{
+		"lint": {Command: "echo"},
+	})
+
+	if _, err := Run(cfg, "missing", RunOptions{}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected an unknown command error, got %v", err)
+	}
+	if _, err := Run(cfg, "lint", RunOptions{Format: "xml"}); err == nil {
+		t.Error("expected an unsupported format error")
+	}
+	if _, err := Run(&config.Config{}, "lint", RunOptions{}); err == nil || !strings.Contains(err.Error(), "invalid config") {
+		t.Errorf("expected an invalid config error, got %v", err)
+	}
+}
@@ -7,18 +7,23 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/bebsworthy/qualhook/test/benchmarks"
 )
 
 func main() {
 	var (
-		action         = flag.String("action", "report", "Action to perform: report, track, analyze-flakiness, coverage")
+		action         = flag.String("action", "report", "Action to perform: report, track, startup-track, analyze-flakiness, coverage")
 		outputDir      = flag.String("output", "test/benchmarks/reports", "Output directory for reports")
 		coverageDir    = flag.String("coverage-dir", "test/benchmarks/coverage", "Directory containing coverage files")
 		trackingFile   = flag.String("tracking-file", "", "Test tracking data file")
 		flakeThreshold = flag.Float64("flake-threshold", 0.1, "Flakiness threshold (0.0-1.0)")
 		format         = flag.String("format", "text", "Output format: text, json, html")
+		binary         = flag.String("binary", "qualhook", "Binary to measure for startup tracking")
+		iterations     = flag.Int("iterations", 10, "Invocations to average per startup timing")
+		regression     = flag.Float64("regression-threshold", benchmarks.DefaultStartupRegressionThreshold,
+			"Relative startup slowdown flagged as a regression (0.2 = 20%)")
 	)
 
 	flag.Parse()
@@ -33,6 +38,11 @@ func main() {
 		fmt.Println("Test tracking should be integrated into your test runner")
 		fmt.Println("See the documentation for examples")
 
+	case "startup-track":
+		if err := trackStartup(*trackingFile, *binary, *iterations, *regression); err != nil {
+			log.Fatal(err)
+		}
+
 	case "analyze-flakiness":
 		if err := analyzeFlakinessOnly(*trackingFile, *flakeThreshold); err != nil {
 			log.Fatal(err)
@@ -106,6 +116,50 @@ func generateReport(outputDir, coverageDir, trackingFile, format string) error {
 	return nil
 }
 
+// startupInvocations are the CLI invocations measured by startup tracking
+var startupInvocations = map[string][]string{
+	"help":    {"--help"},
+	"version": {"--version"},
+}
+
+func trackStartup(trackingFile, binary string, iterations int, threshold float64) error {
+	if trackingFile == "" {
+		return fmt.Errorf("tracking file required for startup tracking")
+	}
+
+	tracker := benchmarks.NewTracker()
+	if _, err := os.Stat(trackingFile); err == nil {
+		if err := tracker.LoadResults(trackingFile); err != nil {
+			return fmt.Errorf("failed to load tracking file: %w", err)
+		}
+	}
+
+	run := tracker.StartRun(fmt.Sprintf("startup-%d", time.Now().Unix()))
+	for _, name := range []string{"help", "version"} {
+		sample, err := benchmarks.MeasureStartup(name, binary, startupInvocations[name], iterations)
+		if err != nil {
+			return err
+		}
+		tracker.RecordStartup(sample)
+		fmt.Printf("%s: %s\n", name, sample.Duration)
+	}
+	tracker.FinishRun(run)
+
+	if err := tracker.SaveResults(trackingFile); err != nil {
+		return fmt.Errorf("failed to save tracking file: %w", err)
+	}
+
+	regressions := tracker.DetectStartupRegressions(threshold)
+	if len(regressions) > 0 {
+		fmt.Println("\nStartup Regressions:")
+		benchmarks.WriteStartupRegressions(os.Stdout, regressions)
+		return fmt.Errorf("startup regressed for %d invocation(s)", len(regressions))
+	}
+
+	fmt.Println("\nNo startup regressions detected")
+	return nil
+}
+
 func analyzeFlakinessOnly(trackingFile string, threshold float64) error {
 	if trackingFile == "" {
 		return fmt.Errorf("tracking file required for flakiness analysis")
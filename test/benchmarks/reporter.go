@@ -36,14 +36,21 @@ func (r *Reporter) GenerateSummary() TestSuiteSummary {
 	flakinessReport := r.flakeDetector.AnalyzeFlakiness()
 	coverageSummary := r.coverageAnalyzer.GetSummaryReport()
 
-	return TestSuiteSummary{
-		GeneratedAt:     time.Now(),
-		TestStats:       stats,
-		FlakinessReport: flakinessReport,
-		CoverageSummary: coverageSummary,
-		HealthScore:     r.calculateHealthScore(stats, flakinessReport, coverageSummary),
-		Recommendations: r.generateRecommendations(stats, flakinessReport, coverageSummary),
+	summary := TestSuiteSummary{
+		GeneratedAt:        time.Now(),
+		TestStats:          stats,
+		FlakinessReport:    flakinessReport,
+		CoverageSummary:    coverageSummary,
+		StartupRegressions: r.tracker.DetectStartupRegressions(DefaultStartupRegressionThreshold),
+		HealthScore:        r.calculateHealthScore(stats, flakinessReport, coverageSummary),
+		Recommendations:    r.generateRecommendations(stats, flakinessReport, coverageSummary),
 	}
+	if len(summary.StartupRegressions) > 0 {
+		summary.Recommendations = append(summary.Recommendations,
+			fmt.Sprintf("CLI startup regressed for %d invocation(s). Check recent changes to initialization code.",
+				len(summary.StartupRegressions)))
+	}
+	return summary
 }
 
 // fprintf is a helper that ignores the error from fmt.Fprintf
@@ -125,6 +132,14 @@ func (r *Reporter) WriteTextReport(w io.Writer, summary TestSuiteSummary) error
 	}
 	fprintln(w)
 
+	// Startup Regressions
+	if len(summary.StartupRegressions) > 0 {
+		fprintf(w, "Startup Regressions\n")
+		fprintf(w, "===================\n")
+		WriteStartupRegressions(w, summary.StartupRegressions)
+		fprintln(w)
+	}
+
 	// Recommendations
 	if len(summary.Recommendations) > 0 {
 		fprintf(w, "Recommendations\n")
@@ -344,6 +359,16 @@ type TestSuiteSummary struct {
 	TestStats       TestStats       `json:"test_stats"`
 	FlakinessReport FlakinessReport `json:"flakiness_report"`
 	CoverageSummary CoverageSummary `json:"coverage_summary"`
-	HealthScore     float64         `json:"health_score"`
-	Recommendations []string        `json:"recommendations"`
+	// StartupRegressions lists startup timings slower than their recorded baseline
+	StartupRegressions []StartupRegression `json:"startup_regressions,omitempty"`
+	HealthScore        float64             `json:"health_score"`
+	Recommendations    []string            `json:"recommendations"`
+}
+
+// WriteStartupRegressions writes one line per startup regression
+func WriteStartupRegressions(w io.Writer, regressions []StartupRegression) {
+	for _, regression := range regressions {
+		fprintf(w, "- %s: %s (baseline: %s, +%.1f%%)\n",
+			regression.Name, regression.Current, regression.Baseline, regression.Increase*100)
+	}
 }
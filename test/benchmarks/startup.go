@@ -0,0 +1,129 @@
+package benchmarks
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// DefaultStartupRegressionThreshold flags startup timings more than 20% slower
+// than the baseline
+const DefaultStartupRegressionThreshold = 0.2
+
+// StartupSample is an average CLI startup timing
+type StartupSample struct {
+	// Name identifies the measured invocation, e.g. "help"
+	Name       string        `json:"name"`
+	Args       []string      `json:"args,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	Iterations int           `json:"iterations"`
+	RecordedAt time.Time     `json:"recorded_at"`
+}
+
+// StartupRegression is a startup timing slower than its baseline beyond the threshold
+type StartupRegression struct {
+	Name     string        `json:"name"`
+	Baseline time.Duration `json:"baseline"`
+	Current  time.Duration `json:"current"`
+	// Increase is the relative slowdown, e.g. 0.5 for 50% slower
+	Increase float64 `json:"increase"`
+}
+
+// MeasureStartup runs binary with args iterations times and returns the
+// average wall-clock time of an invocation
+func MeasureStartup(name, binary string, args []string, iterations int) (StartupSample, error) {
+	if iterations <= 0 {
+		iterations = 1
+	}
+
+	// Warm up so the first measurement does not pay for cold caches
+	if err := exec.Command(binary, args...).Run(); err != nil { // #nosec G204 - benchmark binary provided by caller
+		return StartupSample{}, fmt.Errorf("failed to run %s: %w", binary, err)
+	}
+
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		if err := exec.Command(binary, args...).Run(); err != nil { // #nosec G204 - benchmark binary provided by caller
+			return StartupSample{}, fmt.Errorf("failed to run %s: %w", binary, err)
+		}
+	}
+
+	return StartupSample{
+		Name:       name,
+		Args:       args,
+		Duration:   time.Since(start) / time.Duration(iterations),
+		Iterations: iterations,
+		RecordedAt: time.Now(),
+	}, nil
+}
+
+// RecordStartup records a startup timing in the tracker's history
+func (t *Tracker) RecordStartup(sample StartupSample) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if sample.RecordedAt.IsZero() {
+		sample.RecordedAt = time.Now()
+	}
+	t.startup = append(t.startup, sample)
+}
+
+// StartupHistory returns the recorded startup timings of an invocation, oldest first
+func (t *Tracker) StartupHistory(name string) []StartupSample {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var history []StartupSample
+	for _, sample := range t.startup {
+		if sample.Name == name {
+			history = append(history, sample)
+		}
+	}
+	return history
+}
+
+// DetectStartupRegressions compares the latest startup timing of every
+// invocation with its baseline, the average of the earlier timings, and
+// returns those slower than the baseline by more than threshold
+func (t *Tracker) DetectStartupRegressions(threshold float64) []StartupRegression {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var names []string
+	byName := make(map[string][]StartupSample)
+	for _, sample := range t.startup {
+		if _, seen := byName[sample.Name]; !seen {
+			names = append(names, sample.Name)
+		}
+		byName[sample.Name] = append(byName[sample.Name], sample)
+	}
+
+	var regressions []StartupRegression
+	for _, name := range names {
+		history := byName[name]
+		if len(history) < 2 {
+			continue
+		}
+
+		var total time.Duration
+		for _, sample := range history[:len(history)-1] {
+			total += sample.Duration
+		}
+		baseline := total / time.Duration(len(history)-1)
+		current := history[len(history)-1].Duration
+		if baseline <= 0 {
+			continue
+		}
+
+		increase := float64(current-baseline) / float64(baseline)
+		if increase > threshold {
+			regressions = append(regressions, StartupRegression{
+				Name:     name,
+				Baseline: baseline,
+				Current:  current,
+				Increase: increase,
+			})
+		}
+	}
+
+	return regressions
+}
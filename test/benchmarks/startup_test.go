@@ -0,0 +1,68 @@
+//go:build unit
+
+package benchmarks_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bebsworthy/qualhook/test/benchmarks"
+)
+
+func TestTracker_DetectStartupRegressions(t *testing.T) {
+	tracker := benchmarks.NewTracker()
+	tracker.RecordStartup(benchmarks.StartupSample{Name: "help", Duration: 10 * time.Millisecond})
+	tracker.RecordStartup(benchmarks.StartupSample{Name: "help", Duration: 15 * time.Millisecond})
+
+	regressions := tracker.DetectStartupRegressions(0.2)
+	if len(regressions) != 1 {
+		t.Fatalf("expected 1 regression, got %d", len(regressions))
+	}
+	regression := regressions[0]
+	if regression.Name != "help" || regression.Baseline != 10*time.Millisecond || regression.Current != 15*time.Millisecond {
+		t.Errorf("unexpected regression: %+v", regression)
+	}
+	if regression.Increase < 0.49 || regression.Increase > 0.51 {
+		t.Errorf("expected a 50%% increase, got %.2f", regression.Increase)
+	}
+}
+
+func TestTracker_DetectStartupRegressionsWithinThreshold(t *testing.T) {
+	tracker := benchmarks.NewTracker()
+	tracker.RecordStartup(benchmarks.StartupSample{Name: "help", Duration: 10 * time.Millisecond})
+	tracker.RecordStartup(benchmarks.StartupSample{Name: "help", Duration: 11 * time.Millisecond})
+	tracker.RecordStartup(benchmarks.StartupSample{Name: "version", Duration: 10 * time.Millisecond})
+
+	if regressions := tracker.DetectStartupRegressions(0.2); len(regressions) != 0 {
+		t.Errorf("expected no regressions, got %+v", regressions)
+	}
+}
+
+func TestTracker_StartupHistoryPersists(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "startup.json")
+
+	for _, duration := range []time.Duration{10 * time.Millisecond, 20 * time.Millisecond} {
+		tracker := benchmarks.NewTracker()
+		if err := tracker.LoadResults(file); err != nil && duration != 10*time.Millisecond {
+			t.Fatalf("failed to load history: %v", err)
+		}
+		run := tracker.StartRun("startup")
+		tracker.RecordStartup(benchmarks.StartupSample{Name: "help", Duration: duration})
+		tracker.FinishRun(run)
+		if err := tracker.SaveResults(file); err != nil {
+			t.Fatalf("failed to save history: %v", err)
+		}
+	}
+
+	tracker := benchmarks.NewTracker()
+	if err := tracker.LoadResults(file); err != nil {
+		t.Fatalf("failed to load history: %v", err)
+	}
+	if history := tracker.StartupHistory("help"); len(history) != 2 {
+		t.Fatalf("expected 2 recorded samples, got %d", len(history))
+	}
+	if regressions := tracker.DetectStartupRegressions(benchmarks.DefaultStartupRegressionThreshold); len(regressions) != 1 {
+		t.Errorf("expected 1 regression, got %d", len(regressions))
+	}
+}
@@ -32,12 +32,19 @@ type TestRun struct {
 	EndTime   time.Time     `json:"end_time"`
 	Duration  time.Duration `json:"duration"`
 	Results   []TestResult  `json:"results"`
+	// Startup holds the CLI startup timings recorded during the run
+	Startup []StartupSample `json:"startup,omitempty"`
+
+	// Offsets of the run's first result and startup sample in the tracker
+	resultsStart int
+	startupStart int
 }
 
 // Tracker tracks test execution metrics
 type Tracker struct {
 	mu      sync.RWMutex
 	results []TestResult
+	startup []StartupSample
 	runs    []TestRun
 }
 
@@ -58,10 +65,15 @@ func (t *Tracker) RecordTest(result TestResult) {
 
 // StartRun starts tracking a new test run
 func (t *Tracker) StartRun(id string) *TestRun {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
 	run := &TestRun{
-		ID:        id,
-		StartTime: time.Now(),
-		Results:   make([]TestResult, 0),
+		ID:           id,
+		StartTime:    time.Now(),
+		Results:      make([]TestResult, 0),
+		resultsStart: len(t.results),
+		startupStart: len(t.startup),
 	}
 	return run
 }
@@ -73,7 +85,12 @@ func (t *Tracker) FinishRun(run *TestRun) {
 
 	run.EndTime = time.Now()
 	run.Duration = run.EndTime.Sub(run.StartTime)
-	run.Results = t.results
+	// Only keep what was recorded during the run, so loaded history is not
+	// saved again with every new run
+	run.Results = append(make([]TestResult, 0), t.results[run.resultsStart:]...)
+	if len(t.startup) > run.startupStart {
+		run.Startup = append([]StartupSample(nil), t.startup[run.startupStart:]...)
+	}
 
 	t.runs = append(t.runs, *run)
 }
@@ -198,8 +215,10 @@ func (t *Tracker) LoadResults(filename string) error {
 
 	// Rebuild results from runs
 	t.results = make([]TestResult, 0)
+	t.startup = nil
 	for _, run := range runs {
 		t.results = append(t.results, run.Results...)
+		t.startup = append(t.startup, run.Startup...)
 	}
 
 	return nil
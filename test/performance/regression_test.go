@@ -585,6 +585,44 @@ func TestConfigLoadingPerformance(t *testing.T) {
 			t.Logf("%s: %.2fms average (baseline: %.2fms)", tc.name, avgMs, tc.baselineMs)
 		})
 	}
+
+	// The config cache skips parsing and validation of unchanged files,
+	// which matters most for YAML configs
+	t.Run("ComplexConfigCached", func(t *testing.T) {
+		yamlData, err := pkgconfig.SaveConfigYAML(complexConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		yamlPath := filepath.Join(tmpDir, "complex.yaml")
+		if err := os.WriteFile(yamlPath, yamlData, 0600); err != nil {
+			t.Fatal(err)
+		}
+		settled := time.Now().Add(-time.Hour)
+		if err := os.Chtimes(yamlPath, settled, settled); err != nil {
+			t.Fatal(err)
+		}
+
+		measure := func(loader *config.Loader) float64 {
+			if _, err := loader.LoadFromPath(yamlPath); err != nil {
+				t.Fatal(err)
+			}
+			iterations := 100
+			start := time.Now()
+			for i := 0; i < iterations; i++ {
+				if _, err := loader.LoadFromPath(yamlPath); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return float64(time.Since(start).Nanoseconds()) / float64(iterations) / 1e6
+		}
+
+		uncachedMs := measure(&config.Loader{})
+		cachedMs := measure(&config.Loader{CacheDir: t.TempDir()})
+		if cachedMs > uncachedMs {
+			t.Errorf("Cached config loading is slower than parsing: %.3fms (uncached: %.3fms)", cachedMs, uncachedMs)
+		}
+		t.Logf("ComplexConfigCached: %.3fms average (uncached: %.3fms)", cachedMs, uncachedMs)
+	})
 }
 
 // TestProjectDetectionPerformance tests project detection performance